@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
 	"github.com/jtsunne/epm-go/internal/tui"
 )
 
@@ -91,6 +92,11 @@ func main() {
 		showVersion       = flag.Bool("version", false, "print version and exit")
 		userFlag          = flag.String("user", "", "Elasticsearch username (overrides URI credentials and ES_USER env var)")
 		passFlag          = flag.String("password", "", "Elasticsearch password (overrides URI credentials and ES_PASSWORD env var)")
+		snapshotMaxAge    = flag.Duration("snapshot-max-age", 24*time.Hour, "warn when the newest successful snapshot in a repository is older than this (0 disables)")
+		fieldLimitMargin  = flag.Float64("field-limit-margin", 10, "flag indices whose mapped field count is within this percentage of index.mapping.total_fields.limit (0-100)")
+		fdThreshold       = flag.Float64("fd-threshold", 80, "warn when a node has more than this percentage of its file descriptor limit open (0-100, 0 disables)")
+		deletedThreshold  = flag.Float64("deleted-docs-threshold", 25, "flag indices of 1 GB or more whose deleted documents exceed this percentage (0-100, 0 disables)")
+		zoneAttr          = flag.String("zone-attr", "", "node attribute that identifies a node's zone or rack (default: first of zone, availability_zone, az, rack, rack_id)")
 	)
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "epm %s — Elasticsearch Performance Monitor\n\n", version)
@@ -123,6 +129,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *snapshotMaxAge < 0 {
+		fmt.Fprintf(os.Stderr, "error: --snapshot-max-age must not be negative (got %s)\n", *snapshotMaxAge)
+		os.Exit(1)
+	}
+
+	if *fieldLimitMargin < 0 || *fieldLimitMargin > 100 {
+		fmt.Fprintf(os.Stderr, "error: --field-limit-margin must be between 0 and 100 (got %g)\n", *fieldLimitMargin)
+		os.Exit(1)
+	}
+
+	if *fdThreshold < 0 || *fdThreshold > 100 {
+		fmt.Fprintf(os.Stderr, "error: --fd-threshold must be between 0 and 100 (got %g)\n", *fdThreshold)
+		os.Exit(1)
+	}
+
+	if *deletedThreshold < 0 || *deletedThreshold > 100 {
+		fmt.Fprintf(os.Stderr, "error: --deleted-docs-threshold must be between 0 and 100 (got %g)\n", *deletedThreshold)
+		os.Exit(1)
+	}
+
 	args := flag.Args()
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "error: elasticsearch URI is required")
@@ -170,9 +196,9 @@ func main() {
 		fmt.Fprintln(os.Stderr, "note: connecting to https:// — if the cluster uses a self-signed certificate, add --insecure")
 	}
 
-	// Mirror the fetchCmd context timeout: interval-500ms, capped at 10s.
+	// Mirror the poller's fetch context timeout: interval-500ms, capped at 10s.
 	// The 10s cap ensures the HTTP transport also releases promptly on quit,
-	// consistent with the context cancellation guarantee in tui.fetchTimeout.
+	// consistent with the context cancellation guarantee in engine.FetchTimeout.
 	requestTimeout := *interval - 500*time.Millisecond
 	if requestTimeout > 10*time.Second {
 		requestTimeout = 10 * time.Second
@@ -192,7 +218,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	recConfig := engine.DefaultRecommendationConfig()
+	recConfig.SnapshotMaxAge = *snapshotMaxAge
+	recConfig.FieldLimitMargin = *fieldLimitMargin
+	recConfig.FDThreshold = *fdThreshold
+	recConfig.DeletedDocsThreshold = *deletedThreshold
+	recConfig.ZoneAttribute = strings.TrimSpace(*zoneAttr)
+
 	app := tui.NewApp(c, *interval)
+	app.SetRecommendationConfig(recConfig)
 	p := tea.NewProgram(app, tea.WithAltScreen())
 	finalModel, err := p.Run()
 	if err != nil {
@@ -8,7 +8,6 @@ require (
 	github.com/charmbracelet/lipgloss v1.1.0
 	github.com/mattn/go-runewidth v0.0.19
 	github.com/stretchr/testify v1.11.1
-	golang.org/x/sync v0.19.0
 )
 
 require (
@@ -18,6 +18,31 @@ type ESClient interface {
 	GetIndices(ctx context.Context) ([]IndexInfo, error)
 	GetIndexStats(ctx context.Context) (*IndexStatsResponse, error)
 	GetAllocation(ctx context.Context) ([]AllocationInfo, error)
+	GetPendingTasks(ctx context.Context) ([]PendingTask, error)
+	GetTasks(ctx context.Context) ([]TaskInfo, error)
+	CancelTask(ctx context.Context, taskID string) error
+	GetHotThreads(ctx context.Context, nodeID string) (string, error)
+	GetShards(ctx context.Context) ([]ShardInfo, error)
+	GetAllocationExplain(ctx context.Context, index string, shard int, primary bool) (*AllocationExplain, error)
+	GetRecovery(ctx context.Context) ([]RecoveryInfo, error)
+	GetSnapshotRepositories(ctx context.Context) ([]SnapshotRepository, error)
+	GetSnapshots(ctx context.Context, repo string) ([]SnapshotInfo, error)
+	GetLifecycleExplain(ctx context.Context) (map[string]LifecycleExplain, error)
+	GetDataStreams(ctx context.Context) ([]DataStream, error)
+	GetAliases(ctx context.Context) ([]AliasInfo, error)
+	GetNodeAttributes(ctx context.Context) ([]NodeAttribute, error)
+	GetIngestStats(ctx context.Context) (*IngestStatsResponse, error)
+	GetRemoteInfo(ctx context.Context) (map[string]RemoteInfo, error)
+	GetCCRStats(ctx context.Context) (*CCRStatsResponse, error)
+	GetIndexingPressure(ctx context.Context) (*IndexingPressureResponse, error)
+	GetNodeVersions(ctx context.Context) (map[string]NodeVersion, error)
+	GetTemplates(ctx context.Context) ([]IndexTemplate, error)
+	SimulateIndex(ctx context.Context, name string) (*SimulatedIndex, error)
+	GetMappingStats(ctx context.Context) (map[string]MappingStats, error)
+	GetIndexMapping(ctx context.Context, index string) (map[string]any, error)
+	GetClusterSettings(ctx context.Context) (*ClusterSettings, error)
+	GetDiskWatermarks(ctx context.Context) (*DiskWatermarks, error)
+	UpdateClusterSettings(ctx context.Context, persistent, transient map[string]any) error
 	DeleteIndex(ctx context.Context, names []string) error
 	GetIndexSettings(ctx context.Context, name string) (*IndexSettingsValues, error)
 	UpdateIndexSettings(ctx context.Context, names []string, settings map[string]any) error
@@ -105,8 +105,11 @@ func TestGetNodeStats(t *testing.T) {
 					"indexing": {"index_total": 1000, "index_time_in_millis": 500},
 					"search":   {"query_total": 2000, "query_time_in_millis": 800}
 				},
-				"os":  {"cpu": {"percent": 45}},
-				"jvm": {"mem": {"heap_used_in_bytes": 536870912, "heap_max_in_bytes": 1073741824}},
+				"os":  {"cpu": {"percent": 45, "load_average": {"1m": 1.5, "5m": 1.2, "15m": 0.9}},
+				        "mem": {"total_in_bytes": 17179869184, "free_in_bytes": 1073741824, "used_in_bytes": 16106127360},
+				        "swap": {"total_in_bytes": 2147483648, "free_in_bytes": 1610612736, "used_in_bytes": 536870912}},
+				"process": {"open_file_descriptors": 1200, "max_file_descriptors": 65535},
+				"jvm": {"uptime_in_millis": 3600000, "mem": {"heap_used_in_bytes": 536870912, "heap_max_in_bytes": 1073741824}},
 				"fs":  {"total": {"total_in_bytes": 10737418240, "available_in_bytes": 5368709120}}
 			}
 		}
@@ -154,9 +157,21 @@ func TestGetNodeStats(t *testing.T) {
 	if node.OS == nil || node.OS.CPU.Percent != 45 {
 		t.Errorf("OS.CPU.Percent unexpected")
 	}
+	if node.OS == nil || node.OS.CPU.LoadAverage["5m"] != 1.2 {
+		t.Errorf("OS.CPU.LoadAverage unexpected")
+	}
+	if node.OS == nil || node.OS.Mem.UsedInBytes != 16106127360 || node.OS.Swap.UsedInBytes != 536870912 {
+		t.Errorf("OS.Mem/OS.Swap unexpected")
+	}
+	if node.Process == nil || node.Process.OpenFileDescriptors != 1200 || node.Process.MaxFileDescriptors != 65535 {
+		t.Errorf("Process file descriptors unexpected")
+	}
 	if node.JVM == nil || node.JVM.Mem.HeapUsedInBytes != 536870912 {
 		t.Errorf("JVM.Mem.HeapUsedInBytes unexpected")
 	}
+	if node.JVM == nil || node.JVM.UptimeInMillis != 3600000 {
+		t.Errorf("JVM.UptimeInMillis unexpected")
+	}
 	if node.FS == nil || node.FS.Total.TotalInBytes != 10737418240 {
 		t.Errorf("FS.Total.TotalInBytes unexpected")
 	}
@@ -164,7 +179,7 @@ func TestGetNodeStats(t *testing.T) {
 
 func TestGetIndices(t *testing.T) {
 	fixture := `[
-		{"index":"my-index","pri":"1","rep":"1","pri.store.size":"1gb","store.size":"2gb","docs.count":"5000"}
+		{"index":"my-index","pri":"1","rep":"1","pri.store.size":"1gb","store.size":"2gb","docs.count":"5000","docs.deleted":"1200"}
 	]`
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -174,6 +189,9 @@ func TestGetIndices(t *testing.T) {
 		if !strings.Contains(r.URL.RawQuery, "format=json") {
 			t.Errorf("format=json missing from query: %q", r.URL.RawQuery)
 		}
+		if !strings.Contains(r.URL.RawQuery, "docs.deleted") {
+			t.Errorf("docs.deleted missing from query: %q", r.URL.RawQuery)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(fixture))
 	}))
@@ -206,6 +224,9 @@ func TestGetIndices(t *testing.T) {
 	if idx.DocsCount != "5000" {
 		t.Errorf("DocsCount = %q, want %q", idx.DocsCount, "5000")
 	}
+	if idx.DocsDeleted != "1200" {
+		t.Errorf("DocsDeleted = %q, want %q", idx.DocsDeleted, "1200")
+	}
 }
 
 func TestGetIndexStats(t *testing.T) {
@@ -488,401 +509,1468 @@ func TestGetAllocation(t *testing.T) {
 	}
 }
 
-func TestDeleteIndex_Success(t *testing.T) {
-	var gotMethod, gotPath string
+func TestGetPendingTasks(t *testing.T) {
+	fixture := `{"tasks":[
+		{"insert_order":101,"priority":"URGENT","source":"create-index [foo_9], cause [api]","executing":true,"time_in_queue_millis":86,"time_in_queue":"86ms"},
+		{"insert_order":46,"priority":"HIGH","source":"shard-started","executing":false,"time_in_queue_millis":842,"time_in_queue":"842ms"}
+	]}`
+
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotMethod = r.Method
-		gotPath = r.URL.Path
+		if r.URL.Path != "/_cluster/pending_tasks" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.DeleteIndex(context.Background(), []string{"my-index"})
+	tasks, err := c.GetPendingTasks(context.Background())
 	if err != nil {
-		t.Fatalf("DeleteIndex: %v", err)
+		t.Fatalf("GetPendingTasks: %v", err)
 	}
-	if gotMethod != http.MethodDelete {
-		t.Errorf("method = %q, want DELETE", gotMethod)
+	if len(tasks) != 2 {
+		t.Fatalf("len(tasks) = %d, want 2", len(tasks))
 	}
-	if gotPath != "/my-index" {
-		t.Errorf("path = %q, want /my-index", gotPath)
+	if tasks[0].Priority != "URGENT" || !tasks[0].Executing || tasks[0].TimeInQueueMillis != 86 {
+		t.Errorf("tasks[0] = %+v, want URGENT executing 86ms", tasks[0])
+	}
+	if tasks[1].InsertOrder != 46 || tasks[1].Source != "shard-started" {
+		t.Errorf("tasks[1] = %+v, want insert_order 46 source shard-started", tasks[1])
 	}
 }
 
-func TestDeleteIndex_BatchPath(t *testing.T) {
-	var gotPath string
+func TestGetPendingTasks_EmptyQueue(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotPath = r.URL.Path
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tasks":[]}`))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.DeleteIndex(context.Background(), []string{"index-a", "index-b", "index-c"})
+	tasks, err := c.GetPendingTasks(context.Background())
 	if err != nil {
-		t.Fatalf("DeleteIndex: %v", err)
+		t.Fatalf("GetPendingTasks: %v", err)
 	}
-	if gotPath != "/index-a,index-b,index-c" {
-		t.Errorf("path = %q, want /index-a,index-b,index-c", gotPath)
+	if tasks == nil || len(tasks) != 0 {
+		t.Errorf("tasks = %#v, want empty non-nil slice", tasks)
 	}
 }
 
-func TestDeleteIndex_NotFound(t *testing.T) {
+func TestGetRecovery(t *testing.T) {
+	fixture := `[
+		{"index":"logs","shard":"0","time":"65000","type":"peer","stage":"index","source_node":"es-1","target_node":"es-2","files_percent":"40.0%","bytes_recovered":"250000","bytes_total":"1000000","bytes_percent":"25.0%"}
+	]`
+
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
-		_, _ = w.Write([]byte(`{"error":"index not found"}`))
+		if r.URL.Path != "/_cat/recovery" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "active_only=true") {
+			t.Errorf("active_only missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.DeleteIndex(context.Background(), []string{"missing-index"})
-	if err == nil {
-		t.Fatal("expected error on 404, got nil")
+	recs, err := c.GetRecovery(context.Background())
+	if err != nil {
+		t.Fatalf("GetRecovery: %v", err)
 	}
-	if !strings.Contains(err.Error(), "404") {
-		t.Errorf("error %q does not contain 404", err.Error())
+	if len(recs) != 1 {
+		t.Fatalf("len(recs) = %d, want 1", len(recs))
+	}
+	r := recs[0]
+	if r.Type != "peer" || r.SourceNode != "es-1" || r.TargetNode != "es-2" || r.BytesPercent != "25.0%" {
+		t.Errorf("recs[0] = %+v", r)
 	}
 }
 
-func TestDeleteIndex_EmptyNames(t *testing.T) {
-	// A server that records whether it received any request.
-	received := false
+func TestGetRecovery_NoneActive(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		received = true
-		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.DeleteIndex(context.Background(), []string{})
-	if err == nil {
-		t.Fatal("expected error on empty names slice, got nil")
+	recs, err := c.GetRecovery(context.Background())
+	if err != nil {
+		t.Fatalf("GetRecovery: %v", err)
 	}
-	if received {
-		t.Error("DeleteIndex with empty names must not send any HTTP request")
+	if recs == nil || len(recs) != 0 {
+		t.Errorf("recs = %#v, want empty non-nil slice", recs)
 	}
 }
 
-func TestGetIndexSettings(t *testing.T) {
-	fixture := `{
-		"my-index": {
-			"settings": {
-				"index": {
-					"number_of_replicas": "1",
-					"refresh_interval": "5s",
-					"routing": {
-						"allocation": {
-							"include": {"_name": "node-1,node-2", "_ip": "10.0.0.1"},
-							"exclude": {"_name": "", "_ip": ""},
-							"require": {"_name": "", "_ip": ""},
-							"total_shards_per_node": "-1"
-						}
-					},
-					"mapping": {
-						"total_fields": {"limit": "2000"}
-					},
-					"blocks": {
-						"read_only_allow_delete": "false"
-					}
-				}
-			}
-		}
-	}`
-
-	var gotPath, gotQuery string
+func TestGetSnapshotRepositories(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotPath = r.URL.Path
-		gotQuery = r.URL.RawQuery
+		if r.URL.Path != "/_snapshot" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(fixture))
+		_, _ = w.Write([]byte(`{"s3-offsite":{"type":"s3","settings":{"bucket":"b"}},"nightly":{"type":"fs","settings":{"location":"/mnt"}}}`))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	vals, err := c.GetIndexSettings(context.Background(), "my-index")
+	repos, err := c.GetSnapshotRepositories(context.Background())
 	if err != nil {
-		t.Fatalf("GetIndexSettings: %v", err)
-	}
-
-	if gotPath != "/my-index/_settings" {
-		t.Errorf("path = %q, want /my-index/_settings", gotPath)
+		t.Fatalf("GetSnapshotRepositories: %v", err)
 	}
-	if !strings.Contains(gotQuery, "filter_path") {
-		t.Errorf("filter_path missing from query: %q", gotQuery)
+	if len(repos) != 2 {
+		t.Fatalf("len(repos) = %d, want 2", len(repos))
 	}
-	if vals.NumberOfReplicas != "1" {
-		t.Errorf("NumberOfReplicas = %q, want %q", vals.NumberOfReplicas, "1")
+	if repos[0].Name != "nightly" || repos[0].Type != "fs" || repos[1].Name != "s3-offsite" {
+		t.Errorf("repos = %+v, want sorted by name", repos)
 	}
-	if vals.RefreshInterval != "5s" {
-		t.Errorf("RefreshInterval = %q, want %q", vals.RefreshInterval, "5s")
+}
+
+func TestGetSnapshots(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cat/snapshots/my repo" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "s=start_epoch") {
+			t.Errorf("sort missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"snap-1","status":"PARTIAL","start_epoch":"1700000000","end_epoch":"1700000060","duration":"60000","indices":"4","successful_shards":"8","failed_shards":"2","total_shards":"10"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	snaps, err := c.GetSnapshots(context.Background(), "my repo")
+	if err != nil {
+		t.Fatalf("GetSnapshots: %v", err)
 	}
-	if vals.Routing.Allocation.Include.Name != "node-1,node-2" {
-		t.Errorf("Routing.Allocation.Include.Name = %q, want %q", vals.Routing.Allocation.Include.Name, "node-1,node-2")
+	if len(snaps) != 1 || snaps[0].Status != "PARTIAL" || snaps[0].FailedShards != "2" {
+		t.Errorf("snaps = %+v", snaps)
 	}
-	if vals.Routing.Allocation.TotalShardsPerNode != "-1" {
-		t.Errorf("TotalShardsPerNode = %q, want %q", vals.Routing.Allocation.TotalShardsPerNode, "-1")
+}
+
+func TestGetSnapshots_EmptyRepo(t *testing.T) {
+	c := newTestClient(t, "http://localhost:9200")
+	if _, err := c.GetSnapshots(context.Background(), ""); err == nil {
+		t.Error("expected error for empty repository")
 	}
-	if vals.Mapping.TotalFields.Limit != "2000" {
-		t.Errorf("Mapping.TotalFields.Limit = %q, want %q", vals.Mapping.TotalFields.Limit, "2000")
+}
+
+func TestGetAliases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cat/aliases" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "is_write_index") {
+			t.Errorf("is_write_index missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"alias":"logs","index":"logs-000001","is_write_index":"false"},{"alias":"logs","index":"logs-000002","is_write_index":"true"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	aliases, err := c.GetAliases(context.Background())
+	if err != nil {
+		t.Fatalf("GetAliases: %v", err)
 	}
-	if vals.Blocks.ReadOnlyAllowDelete != "false" {
-		t.Errorf("Blocks.ReadOnlyAllowDelete = %q, want %q", vals.Blocks.ReadOnlyAllowDelete, "false")
+	if len(aliases) != 2 || aliases[1].Index != "logs-000002" || aliases[1].IsWriteIndex != "true" {
+		t.Errorf("aliases = %+v", aliases)
 	}
 }
 
-func TestGetIndexSettings_EmptyName(t *testing.T) {
-	received := false
+func TestGetDataStreams(t *testing.T) {
+	fixture := `{"data_streams":[{"name":"logs-app","timestamp_field":{"name":"@timestamp"},"indices":[
+		{"index_name":".ds-logs-app-2024.01.01-000001","index_uuid":"a"},
+		{"index_name":".ds-logs-app-2024.01.02-000002","index_uuid":"b"}],
+		"generation":2,"status":"YELLOW","template":"logs","ilm_policy":"logs","hidden":false}]}`
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		received = true
-		w.WriteHeader(http.StatusOK)
+		if r.URL.Path != "/_data_stream" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "expand_wildcards=all") {
+			t.Errorf("expand_wildcards missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	_, err := c.GetIndexSettings(context.Background(), "")
-	if err == nil {
-		t.Fatal("expected error for empty name, got nil")
+	streams, err := c.GetDataStreams(context.Background())
+	if err != nil {
+		t.Fatalf("GetDataStreams: %v", err)
 	}
-	if received {
-		t.Error("GetIndexSettings with empty name must not send any HTTP request")
+	if len(streams) != 1 {
+		t.Fatalf("len = %d, want 1", len(streams))
+	}
+	ds := streams[0]
+	if ds.Name != "logs-app" || ds.Generation != 2 || ds.Status != "YELLOW" || ds.Template != "logs" || ds.ILMPolicy != "logs" {
+		t.Errorf("stream = %+v", ds)
+	}
+	if len(ds.Indices) != 2 || ds.Indices[1].IndexName != ".ds-logs-app-2024.01.02-000002" {
+		t.Errorf("indices = %+v", ds.Indices)
 	}
 }
 
-func TestGetIndexSettings_EmptyResponse(t *testing.T) {
+func TestGetDataStreams_Empty(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{}`))
+		_, _ = w.Write([]byte(`{"data_streams":[]}`))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	_, err := c.GetIndexSettings(context.Background(), "missing-index")
-	if err == nil {
-		t.Fatal("expected error for empty response, got nil")
+	streams, err := c.GetDataStreams(context.Background())
+	if err != nil {
+		t.Fatalf("GetDataStreams: %v", err)
+	}
+	if streams == nil || len(streams) != 0 {
+		t.Errorf("streams = %#v, want empty non-nil slice", streams)
 	}
 }
 
-func TestUpdateIndexSettings_Success(t *testing.T) {
-	var gotMethod, gotPath, gotContentType string
-	var gotBody []byte
+func TestGetLifecycleExplain_ILM(t *testing.T) {
+	fixture := `{"indices":{
+		"logs-000001":{"index":"logs-000001","managed":true,"policy":"logs","phase":"warm","action":"shrink","step":"ERROR","lifecycle_date_millis":1700000000000,"failed_step":"shrink","step_info":{"type":"illegal_argument_exception","reason":"no node can hold all shards"}},
+		"logs-000002":{"index":"logs-000002","managed":true,"policy":"logs","phase":"hot","action":"rollover","step":"check-rollover-ready","lifecycle_date_millis":1700000100000},
+		"plain":{"index":"plain","managed":false}
+	}}`
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotMethod = r.Method
-		gotPath = r.URL.Path
-		gotContentType = r.Header.Get("Content-Type")
-		var err error
-		gotBody, err = io.ReadAll(r.Body)
-		if err != nil {
-			t.Errorf("read body: %v", err)
+		if r.URL.Path != "/_all/_ilm/explain" {
+			t.Errorf("unexpected path %q", r.URL.Path)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	settings := map[string]any{
-		"index.number_of_replicas": "2",
-		"index.refresh_interval":   "30s",
-	}
-	err := c.UpdateIndexSettings(context.Background(), []string{"my-index"}, settings)
+	got, err := c.GetLifecycleExplain(context.Background())
 	if err != nil {
-		t.Fatalf("UpdateIndexSettings: %v", err)
-	}
-
-	if gotMethod != http.MethodPut {
-		t.Errorf("method = %q, want PUT", gotMethod)
-	}
-	if gotPath != "/my-index/_settings" {
-		t.Errorf("path = %q, want /my-index/_settings", gotPath)
+		t.Fatalf("GetLifecycleExplain: %v", err)
 	}
-	if !strings.Contains(gotContentType, "application/json") {
-		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
 	}
-
-	var parsed map[string]any
-	if err := json.Unmarshal(gotBody, &parsed); err != nil {
-		t.Fatalf("unmarshal sent body: %v", err)
+	failed := got["logs-000001"]
+	if !failed.Managed || !failed.Failed || failed.FailedStep != "shrink" || failed.Phase != "warm" {
+		t.Errorf("logs-000001 = %+v", failed)
 	}
-	index, ok := parsed["index"].(map[string]any)
-	if !ok {
-		t.Fatalf("body missing index key, got: %v", parsed)
+	if failed.StepError != "illegal_argument_exception: no node can hold all shards" {
+		t.Errorf("StepError = %q", failed.StepError)
 	}
-	if index["number_of_replicas"] != "2" {
-		t.Errorf("number_of_replicas = %v, want 2", index["number_of_replicas"])
+	if ok := got["logs-000002"]; ok.Failed || ok.LifecycleDateMillis != 1700000100000 {
+		t.Errorf("logs-000002 = %+v", ok)
 	}
-	if index["refresh_interval"] != "30s" {
-		t.Errorf("refresh_interval = %v, want 30s", index["refresh_interval"])
+	if got["plain"].Managed {
+		t.Error("plain should be unmanaged")
 	}
 }
 
-func TestUpdateIndexSettings_BatchPath(t *testing.T) {
-	var gotPath string
+func TestGetLifecycleExplain_ISMFallback(t *testing.T) {
+	fixture := `{
+		"logs-1":{"index":"logs-1","policy_id":"hot-delete","index_creation_date":1700000000000,"state":{"name":"hot"},"action":{"name":"rollover","failed":true},"step":{"name":"attempt_rollover","step_status":"failed"},"info":{"message":"Missing rollover_alias"}},
+		"plain":{"index.plugins.index_state_management.policy_id":null},
+		"total_managed_indices":1
+	}`
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		gotPath = r.URL.Path
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		if r.URL.Path != "/_plugins/_ism/explain/*" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.UpdateIndexSettings(context.Background(), []string{"idx-a", "idx-b"}, map[string]any{
-		"index.number_of_replicas": "1",
-	})
+	got, err := c.GetLifecycleExplain(context.Background())
 	if err != nil {
-		t.Fatalf("UpdateIndexSettings: %v", err)
+		t.Fatalf("GetLifecycleExplain: %v", err)
 	}
-	if gotPath != "/idx-a,idx-b/_settings" {
-		t.Errorf("path = %q, want /idx-a,idx-b/_settings", gotPath)
+	le := got["logs-1"]
+	if !le.Managed || le.Policy != "hot-delete" || le.Phase != "hot" || le.Action != "rollover" {
+		t.Errorf("logs-1 = %+v", le)
+	}
+	if !le.Failed || le.StepError != "Missing rollover_alias" || le.FailedStep != "attempt_rollover" {
+		t.Errorf("logs-1 failure = %+v", le)
+	}
+	if got["plain"].Managed {
+		t.Error("plain should be unmanaged")
+	}
+	if _, ok := got["total_managed_indices"]; ok {
+		t.Error("total_managed_indices should not be treated as an index")
 	}
 }
 
-func TestUpdateIndexSettings_NoOp(t *testing.T) {
-	received := false
+func TestGetLifecycleExplain_BothFail(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		received = true
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusForbidden)
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.UpdateIndexSettings(context.Background(), []string{"my-index"}, map[string]any{})
-	if err != nil {
-		t.Fatalf("UpdateIndexSettings with empty map: %v", err)
-	}
-	if received {
-		t.Error("UpdateIndexSettings with empty settings must not send any HTTP request")
+	if _, err := c.GetLifecycleExplain(context.Background()); err == nil {
+		t.Error("expected error when neither ILM nor ISM is available")
 	}
 }
 
-func TestUpdateIndexSettings_EmptyNames(t *testing.T) {
-	received := false
+func TestGetTasks(t *testing.T) {
+	fixture := `{"tasks":{
+		"nodeB:42":{"node":"nodeB","id":42,"type":"transport","action":"indices:data/write/reindex","description":"reindex from [a] to [b]","start_time_in_millis":1700000000000,"running_time_in_nanos":90000000000,"cancellable":true,"cancelled":false,
+			"children":[{"node":"nodeA","id":7,"type":"transport","action":"indices:data/write/bulk","running_time_in_nanos":1000,"cancellable":false}]},
+		"nodeA:3":{"node":"nodeA","id":3,"type":"transport","action":"cluster:monitor/tasks/lists","running_time_in_nanos":500000,"cancellable":false}
+	}}`
+
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		received = true
-		w.WriteHeader(http.StatusOK)
+		if r.URL.Path != "/_tasks" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "detailed") || !strings.Contains(r.URL.RawQuery, "group_by=parents") {
+			t.Errorf("detailed/group_by=parents missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
 	}))
 	defer srv.Close()
 
 	c := newTestClient(t, srv.URL)
-	err := c.UpdateIndexSettings(context.Background(), []string{}, map[string]any{"index.number_of_replicas": "1"})
-	if err == nil {
-		t.Fatal("expected error on empty names, got nil")
+	tasks, err := c.GetTasks(context.Background())
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
 	}
-	if received {
-		t.Error("UpdateIndexSettings with empty names must not send any HTTP request")
+	if len(tasks) != 2 {
+		t.Fatalf("len(tasks) = %d, want 2", len(tasks))
+	}
+	// Sorted by task ID: "nodeA:3" < "nodeB:42".
+	if tasks[0].TaskID() != "nodeA:3" {
+		t.Errorf("tasks[0].TaskID() = %q, want %q", tasks[0].TaskID(), "nodeA:3")
+	}
+	reindex := tasks[1]
+	if reindex.Action != "indices:data/write/reindex" || !reindex.Cancellable {
+		t.Errorf("reindex task = %+v", reindex)
+	}
+	if reindex.RunningTimeInNanos != 90000000000 {
+		t.Errorf("RunningTimeInNanos = %d, want 90000000000", reindex.RunningTimeInNanos)
+	}
+	if len(reindex.Children) != 1 || reindex.Children[0].TaskID() != "nodeA:7" {
+		t.Errorf("Children = %+v, want one child nodeA:7", reindex.Children)
 	}
 }
 
-func TestBuildNestedMap(t *testing.T) {
-	tests := []struct {
+func TestGetHotThreads(t *testing.T) {
+	const report = "::: {es-1}{abc}\n   Hot threads at ...\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_nodes/abc/hot_threads" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "ignore_idle_threads=true") {
+			t.Errorf("ignore_idle_threads missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(report))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	text, err := c.GetHotThreads(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("GetHotThreads: %v", err)
+	}
+	if text != report {
+		t.Errorf("text = %q, want %q", text, report)
+	}
+
+	if _, err := c.GetHotThreads(context.Background(), ""); err == nil {
+		t.Error("expected error for empty node ID, got nil")
+	}
+}
+
+func TestGetShards(t *testing.T) {
+	fixture := `[
+		{"index":"logs","shard":"0","prirep":"p","state":"STARTED","docs":"1200","store":"52428800","node":"es-1","unassigned.reason":null},
+		{"index":"logs","shard":"0","prirep":"r","state":"UNASSIGNED","docs":null,"store":null,"node":null,"unassigned.reason":"NODE_LEFT"}
+	]`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cat/shards" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawQuery, "unassigned.reason") {
+			t.Errorf("unassigned.reason missing from query: %q", r.URL.RawQuery)
+		}
+		if !strings.Contains(r.URL.RawQuery, "bytes=b") {
+			t.Errorf("bytes=b missing from query: %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	shards, err := c.GetShards(context.Background())
+	if err != nil {
+		t.Fatalf("GetShards: %v", err)
+	}
+	if len(shards) != 2 {
+		t.Fatalf("len(shards) = %d, want 2", len(shards))
+	}
+	if shards[1].State != "UNASSIGNED" || shards[1].UnassignedReason != "NODE_LEFT" || shards[1].PriRep != "r" {
+		t.Errorf("shards[1] = %+v", shards[1])
+	}
+	if shards[0].UnassignedReason != "" {
+		t.Errorf("shards[0].UnassignedReason = %q, want empty for null", shards[0].UnassignedReason)
+	}
+	if shards[0].Docs != "1200" || shards[0].Store != "52428800" || shards[0].Node != "es-1" {
+		t.Errorf("shards[0] = %+v", shards[0])
+	}
+}
+
+func TestGetAllocationExplain(t *testing.T) {
+	fixture := `{
+		"index":"logs","shard":0,"primary":false,"current_state":"unassigned",
+		"unassigned_info":{"reason":"NODE_LEFT","at":"2026-10-18T10:00:00.000Z","last_allocation_status":"no_attempt"},
+		"can_allocate":"no",
+		"allocate_explanation":"cannot allocate because allocation is not permitted to any of the nodes",
+		"node_allocation_decisions":[
+			{"node_id":"n1","node_name":"es-1","node_decision":"no","weight_ranking":1,
+			 "deciders":[{"decider":"same_shard","decision":"NO","explanation":"a copy of this shard is already allocated to this node"}]}
+		]
+	}`
+
+	var gotMethod string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		if r.URL.Path != "/_cluster/allocation/explain" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	exp, err := c.GetAllocationExplain(context.Background(), "logs", 0, false)
+	if err != nil {
+		t.Fatalf("GetAllocationExplain: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if gotBody["index"] != "logs" || gotBody["shard"] != float64(0) || gotBody["primary"] != false {
+		t.Errorf("request body = %v", gotBody)
+	}
+	if exp.CanAllocate != "no" || exp.UnassignedInfo == nil || exp.UnassignedInfo.Reason != "NODE_LEFT" {
+		t.Errorf("explain = %+v", exp)
+	}
+	if len(exp.NodeAllocationDecisions) != 1 || len(exp.NodeAllocationDecisions[0].Deciders) != 1 {
+		t.Fatalf("NodeAllocationDecisions = %+v", exp.NodeAllocationDecisions)
+	}
+	if exp.NodeAllocationDecisions[0].Deciders[0].Decider != "same_shard" {
+		t.Errorf("decider = %q, want same_shard", exp.NodeAllocationDecisions[0].Deciders[0].Decider)
+	}
+
+	if _, err := c.GetAllocationExplain(context.Background(), "", 0, true); err == nil {
+		t.Error("expected error for empty index, got nil")
+	}
+}
+
+func TestCancelTask_Success(t *testing.T) {
+	var gotMethod, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodes":{}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if err := c.CancelTask(context.Background(), "nodeB:42"); err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if gotPath != "/_tasks/nodeB:42/_cancel" {
+		t.Errorf("path = %q, want %q", gotPath, "/_tasks/nodeB:42/_cancel")
+	}
+}
+
+func TestCancelTask_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"task not found"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.CancelTask(context.Background(), "nodeB:42")
+	if err == nil {
+		t.Fatal("expected error for 404, got nil")
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Errorf("error %q should mention status 404", err.Error())
+	}
+}
+
+func TestCancelTask_EmptyID(t *testing.T) {
+	c := newTestClient(t, "http://unused:9200")
+	if err := c.CancelTask(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty task ID, got nil")
+	}
+}
+
+func TestDeleteIndex_Success(t *testing.T) {
+	var gotMethod, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.DeleteIndex(context.Background(), []string{"my-index"})
+	if err != nil {
+		t.Fatalf("DeleteIndex: %v", err)
+	}
+	if gotMethod != http.MethodDelete {
+		t.Errorf("method = %q, want DELETE", gotMethod)
+	}
+	if gotPath != "/my-index" {
+		t.Errorf("path = %q, want /my-index", gotPath)
+	}
+}
+
+func TestDeleteIndex_BatchPath(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.DeleteIndex(context.Background(), []string{"index-a", "index-b", "index-c"})
+	if err != nil {
+		t.Fatalf("DeleteIndex: %v", err)
+	}
+	if gotPath != "/index-a,index-b,index-c" {
+		t.Errorf("path = %q, want /index-a,index-b,index-c", gotPath)
+	}
+}
+
+func TestDeleteIndex_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"index not found"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.DeleteIndex(context.Background(), []string{"missing-index"})
+	if err == nil {
+		t.Fatal("expected error on 404, got nil")
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Errorf("error %q does not contain 404", err.Error())
+	}
+}
+
+func TestDeleteIndex_EmptyNames(t *testing.T) {
+	// A server that records whether it received any request.
+	received := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.DeleteIndex(context.Background(), []string{})
+	if err == nil {
+		t.Fatal("expected error on empty names slice, got nil")
+	}
+	if received {
+		t.Error("DeleteIndex with empty names must not send any HTTP request")
+	}
+}
+
+func TestGetIndexSettings(t *testing.T) {
+	fixture := `{
+		"my-index": {
+			"settings": {
+				"index": {
+					"number_of_replicas": "1",
+					"refresh_interval": "5s",
+					"routing": {
+						"allocation": {
+							"include": {"_name": "node-1,node-2", "_ip": "10.0.0.1"},
+							"exclude": {"_name": "", "_ip": ""},
+							"require": {"_name": "", "_ip": ""},
+							"total_shards_per_node": "-1"
+						}
+					},
+					"mapping": {
+						"total_fields": {"limit": "2000"}
+					},
+					"blocks": {
+						"read_only_allow_delete": "false"
+					}
+				}
+			}
+		}
+	}`
+
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	vals, err := c.GetIndexSettings(context.Background(), "my-index")
+	if err != nil {
+		t.Fatalf("GetIndexSettings: %v", err)
+	}
+
+	if gotPath != "/my-index/_settings" {
+		t.Errorf("path = %q, want /my-index/_settings", gotPath)
+	}
+	if !strings.Contains(gotQuery, "filter_path") {
+		t.Errorf("filter_path missing from query: %q", gotQuery)
+	}
+	if vals.NumberOfReplicas != "1" {
+		t.Errorf("NumberOfReplicas = %q, want %q", vals.NumberOfReplicas, "1")
+	}
+	if vals.RefreshInterval != "5s" {
+		t.Errorf("RefreshInterval = %q, want %q", vals.RefreshInterval, "5s")
+	}
+	if vals.Routing.Allocation.Include.Name != "node-1,node-2" {
+		t.Errorf("Routing.Allocation.Include.Name = %q, want %q", vals.Routing.Allocation.Include.Name, "node-1,node-2")
+	}
+	if vals.Routing.Allocation.TotalShardsPerNode != "-1" {
+		t.Errorf("TotalShardsPerNode = %q, want %q", vals.Routing.Allocation.TotalShardsPerNode, "-1")
+	}
+	if vals.Mapping.TotalFields.Limit != "2000" {
+		t.Errorf("Mapping.TotalFields.Limit = %q, want %q", vals.Mapping.TotalFields.Limit, "2000")
+	}
+	if vals.Blocks.ReadOnlyAllowDelete != "false" {
+		t.Errorf("Blocks.ReadOnlyAllowDelete = %q, want %q", vals.Blocks.ReadOnlyAllowDelete, "false")
+	}
+}
+
+func TestGetIndexSettings_EmptyName(t *testing.T) {
+	received := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	_, err := c.GetIndexSettings(context.Background(), "")
+	if err == nil {
+		t.Fatal("expected error for empty name, got nil")
+	}
+	if received {
+		t.Error("GetIndexSettings with empty name must not send any HTTP request")
+	}
+}
+
+func TestGetIndexSettings_EmptyResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	_, err := c.GetIndexSettings(context.Background(), "missing-index")
+	if err == nil {
+		t.Fatal("expected error for empty response, got nil")
+	}
+}
+
+func TestUpdateIndexSettings_Success(t *testing.T) {
+	var gotMethod, gotPath, gotContentType string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		var err error
+		gotBody, err = io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	settings := map[string]any{
+		"index.number_of_replicas": "2",
+		"index.refresh_interval":   "30s",
+	}
+	err := c.UpdateIndexSettings(context.Background(), []string{"my-index"}, settings)
+	if err != nil {
+		t.Fatalf("UpdateIndexSettings: %v", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Errorf("method = %q, want PUT", gotMethod)
+	}
+	if gotPath != "/my-index/_settings" {
+		t.Errorf("path = %q, want /my-index/_settings", gotPath)
+	}
+	if !strings.Contains(gotContentType, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(gotBody, &parsed); err != nil {
+		t.Fatalf("unmarshal sent body: %v", err)
+	}
+	index, ok := parsed["index"].(map[string]any)
+	if !ok {
+		t.Fatalf("body missing index key, got: %v", parsed)
+	}
+	if index["number_of_replicas"] != "2" {
+		t.Errorf("number_of_replicas = %v, want 2", index["number_of_replicas"])
+	}
+	if index["refresh_interval"] != "30s" {
+		t.Errorf("refresh_interval = %v, want 30s", index["refresh_interval"])
+	}
+}
+
+func TestUpdateIndexSettings_BatchPath(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.UpdateIndexSettings(context.Background(), []string{"idx-a", "idx-b"}, map[string]any{
+		"index.number_of_replicas": "1",
+	})
+	if err != nil {
+		t.Fatalf("UpdateIndexSettings: %v", err)
+	}
+	if gotPath != "/idx-a,idx-b/_settings" {
+		t.Errorf("path = %q, want /idx-a,idx-b/_settings", gotPath)
+	}
+}
+
+func TestUpdateIndexSettings_NoOp(t *testing.T) {
+	received := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.UpdateIndexSettings(context.Background(), []string{"my-index"}, map[string]any{})
+	if err != nil {
+		t.Fatalf("UpdateIndexSettings with empty map: %v", err)
+	}
+	if received {
+		t.Error("UpdateIndexSettings with empty settings must not send any HTTP request")
+	}
+}
+
+func TestUpdateIndexSettings_EmptyNames(t *testing.T) {
+	received := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.UpdateIndexSettings(context.Background(), []string{}, map[string]any{"index.number_of_replicas": "1"})
+	if err == nil {
+		t.Fatal("expected error on empty names, got nil")
+	}
+	if received {
+		t.Error("UpdateIndexSettings with empty names must not send any HTTP request")
+	}
+}
+
+func TestBuildNestedMap(t *testing.T) {
+	tests := []struct {
 		name     string
 		input    map[string]any
 		wantPath []string // dotted path to check
 		wantVal  any
 	}{
-		{
-			name:     "single level",
-			input:    map[string]any{"key": "val"},
-			wantPath: []string{"key"},
-			wantVal:  "val",
-		},
-		{
-			name:     "two levels",
-			input:    map[string]any{"index.number_of_replicas": "2"},
-			wantPath: []string{"index", "number_of_replicas"},
-			wantVal:  "2",
-		},
-		{
-			name:     "deep nesting",
-			input:    map[string]any{"index.routing.allocation.include._name": "node-1"},
-			wantPath: []string{"index", "routing", "allocation", "include", "_name"},
-			wantVal:  "node-1",
-		},
+		{
+			name:     "single level",
+			input:    map[string]any{"key": "val"},
+			wantPath: []string{"key"},
+			wantVal:  "val",
+		},
+		{
+			name:     "two levels",
+			input:    map[string]any{"index.number_of_replicas": "2"},
+			wantPath: []string{"index", "number_of_replicas"},
+			wantVal:  "2",
+		},
+		{
+			name:     "deep nesting",
+			input:    map[string]any{"index.routing.allocation.include._name": "node-1"},
+			wantPath: []string{"index", "routing", "allocation", "include", "_name"},
+			wantVal:  "node-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildNestedMap(tt.input)
+			// walk the path
+			var cur any = result
+			for i, seg := range tt.wantPath {
+				m, ok := cur.(map[string]any)
+				if !ok {
+					t.Fatalf("step %d: expected map, got %T", i, cur)
+				}
+				cur = m[seg]
+			}
+			if cur != tt.wantVal {
+				t.Errorf("value at path = %v, want %v", cur, tt.wantVal)
+			}
+		})
+	}
+}
+
+// TestBuildNestedMap_MultipleKeys verifies that two dotted keys sharing the
+// same parent prefix are both preserved under that parent — not overwritten.
+func TestBuildNestedMap_MultipleKeys(t *testing.T) {
+	result := buildNestedMap(map[string]any{
+		"index.number_of_replicas": "2",
+		"index.refresh_interval":   "30s",
+	})
+
+	indexMap, ok := result["index"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected result[\"index\"] to be map[string]any, got %T", result["index"])
+	}
+	if got := indexMap["number_of_replicas"]; got != "2" {
+		t.Errorf("number_of_replicas = %v, want \"2\"", got)
+	}
+	if got := indexMap["refresh_interval"]; got != "30s" {
+		t.Errorf("refresh_interval = %v, want \"30s\"", got)
+	}
+}
+
+// TestBuildNestedMap_DeepSharedPrefix verifies that keys sharing a deep
+// common prefix (e.g. routing.allocation.*) are all preserved. Previously
+// the loop overwrote sibling branches at any shared intermediate node.
+func TestBuildNestedMap_DeepSharedPrefix(t *testing.T) {
+	result := buildNestedMap(map[string]any{
+		"index.routing.allocation.include._name": "node-a",
+		"index.routing.allocation.exclude._name": "node-b",
+		"index.routing.allocation.require._name": "node-c",
+		"index.routing.allocation.include._ip":   "1.2.3.4",
+	})
+
+	walk := func(m map[string]any, path ...string) (any, bool) {
+		var cur any = m
+		for _, seg := range path {
+			mm, ok := cur.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			cur = mm[seg]
+		}
+		return cur, true
+	}
+
+	checks := []struct {
+		path []string
+		want string
+	}{
+		{[]string{"index", "routing", "allocation", "include", "_name"}, "node-a"},
+		{[]string{"index", "routing", "allocation", "exclude", "_name"}, "node-b"},
+		{[]string{"index", "routing", "allocation", "require", "_name"}, "node-c"},
+		{[]string{"index", "routing", "allocation", "include", "_ip"}, "1.2.3.4"},
+	}
+	for _, c := range checks {
+		got, ok := walk(result, c.path...)
+		if !ok {
+			t.Errorf("path %v: intermediate node missing", c.path)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("path %v: got %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestGetTemplates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_index_template":
+			_, _ = w.Write([]byte(`{"index_templates":[{"name":"logs","index_template":{
+				"index_patterns":["logs-*"],"priority":200,"composed_of":["logs-settings"],"data_stream":{},
+				"template":{"settings":{"index":{"number_of_shards":"2","lifecycle":{"name":"logs"}}}}}}]}`))
+		case "/_component_template":
+			_, _ = w.Write([]byte(`{"component_templates":[{"name":"logs-settings","component_template":{
+				"template":{"settings":{"index.number_of_replicas":"1"}}}}]}`))
+		case "/_template":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"boom"}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	templates, err := c.GetTemplates(context.Background())
+	if err != nil {
+		t.Fatalf("GetTemplates: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("templates = %+v, want composable and component despite legacy failure", templates)
+	}
+	comp, composable := templates[0], templates[1]
+	if comp.Kind != TemplateComponent || comp.Settings.Replicas != "1" {
+		t.Errorf("component = %+v", comp)
+	}
+	if composable.Kind != TemplateComposable || composable.Priority != 200 || !composable.DataStream {
+		t.Errorf("composable = %+v", composable)
+	}
+	if composable.Settings.Shards != "2" || composable.Settings.LifecyclePolicy != "logs" {
+		t.Errorf("nested settings not resolved: %+v", composable.Settings)
+	}
+}
+
+func TestGetTemplates_AllFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if _, err := c.GetTemplates(context.Background()); err == nil {
+		t.Fatal("expected error when every template endpoint fails")
+	}
+}
+
+func TestGetTemplates_Legacy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_template":
+			_, _ = w.Write([]byte(`{"old":{"order":5,"index_patterns":["old-*"],"settings":{"index.number_of_shards":"3"}}}`))
+		case "/_index_template":
+			_, _ = w.Write([]byte(`{"index_templates":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"component_templates":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	templates, err := c.GetTemplates(context.Background())
+	if err != nil {
+		t.Fatalf("GetTemplates: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Kind != TemplateLegacy || templates[0].Priority != 5 || templates[0].Settings.Shards != "3" {
+		t.Errorf("templates = %+v", templates)
+	}
+}
+
+func TestSimulateIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/_index_template/_simulate_index/logs-2024.01.01" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"template":{"settings":{"index":{"number_of_shards":"2","number_of_replicas":"1"}}},
+			"overlapping":[{"name":"logs-old","index_patterns":["logs-*"]}]}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	sim, err := c.SimulateIndex(context.Background(), "logs-2024.01.01")
+	if err != nil {
+		t.Fatalf("SimulateIndex: %v", err)
+	}
+	if sim.Settings.Shards != "2" || sim.Settings.Replicas != "1" {
+		t.Errorf("settings = %+v", sim.Settings)
+	}
+	if len(sim.Overlapping) != 1 || sim.Overlapping[0].Name != "logs-old" {
+		t.Errorf("overlapping = %+v", sim.Overlapping)
+	}
+
+	if _, err := c.SimulateIndex(context.Background(), ""); err == nil {
+		t.Error("expected error for empty index name")
+	}
+}
+
+func TestGetMappingStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_mapping":
+			_, _ = w.Write([]byte(`{
+				"logs":{"mappings":{
+					"runtime":{"day":{"type":"keyword"}},
+					"properties":{
+						"message":{"type":"text","fields":{"raw":{"type":"keyword"}}},
+						"host":{"properties":{"name":{"type":"keyword"},"ip":{"type":"ip"}}},
+						"hostname":{"type":"alias","path":"host.name"}}}},
+				"legacy":{"mappings":{"_doc":{"properties":{"a":{"type":"long"}}}}},
+				"empty":{"mappings":{}}}`))
+		case "/_all/_settings/index.mapping.total_fields.limit":
+			if !strings.Contains(r.URL.RawQuery, "include_defaults=true") {
+				t.Errorf("include_defaults missing from query: %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{
+				"logs":{"settings":{"index.mapping.total_fields.limit":"2000"},"defaults":{}},
+				"legacy":{"settings":{},"defaults":{"index.mapping.total_fields.limit":"1000"}}}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetMappingStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetMappingStats: %v", err)
+	}
+	// message, message.raw, host, host.name, host.ip, hostname, day.
+	if got := stats["logs"]; got.FieldCount != 7 || got.FieldLimit != 2000 {
+		t.Errorf("logs = %+v, want 7 fields and limit 2000", got)
+	}
+	if got := stats["legacy"]; got.FieldCount != 1 || got.FieldLimit != 1000 {
+		t.Errorf("legacy = %+v, want typed mapping unwrapped", got)
+	}
+	if got := stats["empty"]; got.FieldCount != 0 || got.FieldLimit != DefaultTotalFieldsLimit {
+		t.Errorf("empty = %+v, want default limit when not reported", got)
+	}
+}
+
+func TestGetMappingStats_LimitUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_mapping" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"a":{"mappings":{"properties":{"x":{"type":"long"}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetMappingStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetMappingStats: %v", err)
+	}
+	if got := stats["a"]; got.FieldCount != 1 || got.FieldLimit != DefaultTotalFieldsLimit {
+		t.Errorf("a = %+v", got)
+	}
+}
+
+func TestGetIndexMapping(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs-alias/_mapping" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"logs-000001":{"mappings":{"_doc":{"properties":{"a":{"type":"long"}}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	m, err := c.GetIndexMapping(context.Background(), "logs-alias")
+	if err != nil {
+		t.Fatalf("GetIndexMapping: %v", err)
+	}
+	if _, ok := m["properties"]; !ok {
+		t.Errorf("mapping = %v, want properties at top level", m)
+	}
+	if _, err := c.GetIndexMapping(context.Background(), ""); err == nil {
+		t.Error("expected error for empty index name")
+	}
+}
+
+func TestGetClusterSettings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cluster/settings" || r.URL.Query().Get("include_defaults") != "true" {
+			t.Errorf("unexpected request %q", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"persistent":{"cluster.routing.allocation.enable":"primaries"},
+			"transient":{"cluster":{"routing":{"allocation":{"enable":"none"}}}},
+			"defaults":{"cluster.routing.allocation.enable":"all","discovery.seed_hosts":["a","b"],"cluster.max_shards_per_node":"1000"}
+		}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	cs, err := c.GetClusterSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterSettings: %v", err)
+	}
+	if got := cs.Transient["cluster.routing.allocation.enable"]; got != "none" {
+		t.Errorf("nested transient value = %q, want none", got)
+	}
+	if got := cs.Defaults["discovery.seed_hosts"]; got != "a,b" {
+		t.Errorf("array default = %q, want a,b", got)
+	}
+	if v, src := cs.Effective("cluster.routing.allocation.enable"); v != "none" || src != "transient" {
+		t.Errorf("Effective = %q/%q, want none/transient", v, src)
+	}
+	if v, src := cs.Effective("cluster.max_shards_per_node"); v != "1000" || src != "default" {
+		t.Errorf("Effective = %q/%q, want 1000/default", v, src)
+	}
+	if v, src := cs.Effective("unknown.key"); v != "" || src != "" {
+		t.Errorf("Effective(unknown) = %q/%q, want empty", v, src)
+	}
+}
+
+func TestUpdateClusterSettings(t *testing.T) {
+	var calls int
+	var got map[string]map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPut || r.URL.Path != "/_cluster/settings" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	err := c.UpdateClusterSettings(context.Background(),
+		map[string]any{"cluster.routing.allocation.enable": "all", "cluster.max_shards_per_node": nil},
+		map[string]any{"cluster.routing.allocation.enable": nil})
+	if err != nil {
+		t.Fatalf("UpdateClusterSettings: %v", err)
+	}
+	if got["persistent"]["cluster.routing.allocation.enable"] != "all" {
+		t.Errorf("persistent body = %v", got["persistent"])
+	}
+	if v, ok := got["persistent"]["cluster.max_shards_per_node"]; !ok || v != nil {
+		t.Errorf("reset key should be sent as null, got %v (present=%v)", v, ok)
+	}
+	if v, ok := got["transient"]["cluster.routing.allocation.enable"]; !ok || v != nil {
+		t.Errorf("transient reset should be sent as null, got %v (present=%v)", v, ok)
+	}
+
+	got = nil
+	if err := c.UpdateClusterSettings(context.Background(), map[string]any{"a": "b"}, nil); err != nil {
+		t.Fatalf("UpdateClusterSettings: %v", err)
+	}
+	if _, ok := got["transient"]; ok {
+		t.Error("empty transient map should be omitted")
+	}
+	if err := c.UpdateClusterSettings(context.Background(), nil, nil); err != nil {
+		t.Fatalf("UpdateClusterSettings(empty): %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (nothing sent for empty update)", calls)
+	}
+}
+
+func TestParseDiskWatermark(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    DiskWatermark
+		wantErr bool
+	}{
+		{"85%", DiskWatermark{Percent: 85}, false},
+		{"0.9", DiskWatermark{Percent: 90}, false},
+		{"50gb", DiskWatermark{FreeBytes: 50 << 30}, false},
+		{"512MB", DiskWatermark{FreeBytes: 512 << 20}, false},
+		{"150%", DiskWatermark{}, true},
+		{"lots", DiskWatermark{}, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseDiskWatermark(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseDiskWatermark(%q) err = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseDiskWatermark(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
 	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := buildNestedMap(tt.input)
-			// walk the path
-			var cur any = result
-			for i, seg := range tt.wantPath {
-				m, ok := cur.(map[string]any)
-				if !ok {
-					t.Fatalf("step %d: expected map, got %T", i, cur)
-				}
-				cur = m[seg]
-			}
-			if cur != tt.wantVal {
-				t.Errorf("value at path = %v, want %v", cur, tt.wantVal)
+func TestGetDiskWatermarks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter_path") == "" {
+			t.Errorf("expected filter_path, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"persistent":{"cluster.routing.allocation.disk.watermark.flood_stage":"10gb"},
+			"defaults":{
+				"cluster.routing.allocation.disk.threshold_enabled":"true",
+				"cluster.routing.allocation.disk.watermark.low":"85%",
+				"cluster.routing.allocation.disk.watermark.low.max_headroom":"200gb",
+				"cluster.routing.allocation.disk.watermark.high":"0.92",
+				"cluster.routing.allocation.disk.watermark.high.max_headroom":"-1",
+				"cluster.routing.allocation.disk.watermark.flood_stage":"95%"
 			}
-		})
+		}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	wm, err := c.GetDiskWatermarks(context.Background())
+	if err != nil {
+		t.Fatalf("GetDiskWatermarks: %v", err)
+	}
+	if !wm.ThresholdEnabled {
+		t.Error("ThresholdEnabled = false, want true")
+	}
+	if wm.Low != (DiskWatermark{Percent: 85, MaxHeadroom: 200 << 30}) {
+		t.Errorf("Low = %+v", wm.Low)
+	}
+	if wm.High != (DiskWatermark{Percent: 92}) {
+		t.Errorf("High = %+v, want 92%% without headroom", wm.High)
+	}
+	if wm.FloodStage != (DiskWatermark{FreeBytes: 10 << 30}) {
+		t.Errorf("FloodStage = %+v, want persistent 10gb override", wm.FloodStage)
 	}
 }
 
-// TestBuildNestedMap_MultipleKeys verifies that two dotted keys sharing the
-// same parent prefix are both preserved under that parent — not overwritten.
-func TestBuildNestedMap_MultipleKeys(t *testing.T) {
-	result := buildNestedMap(map[string]any{
-		"index.number_of_replicas": "2",
-		"index.refresh_interval":   "30s",
-	})
+func TestGetNodeAttributes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cat/nodeattrs" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"node":"node-1","id":"aB3x","attr":"zone","value":"us-east-1a"},
+			{"node":"node-1","id":"aB3x","attr":"xpack.installed","value":"true"}
+		]`))
+	}))
+	defer srv.Close()
 
-	indexMap, ok := result["index"].(map[string]any)
-	if !ok {
-		t.Fatalf("expected result[\"index\"] to be map[string]any, got %T", result["index"])
+	c := newTestClient(t, srv.URL)
+	attrs, err := c.GetNodeAttributes(context.Background())
+	if err != nil {
+		t.Fatalf("GetNodeAttributes: %v", err)
 	}
-	if got := indexMap["number_of_replicas"]; got != "2" {
-		t.Errorf("number_of_replicas = %v, want \"2\"", got)
+	if len(attrs) != 2 || attrs[0] != (NodeAttribute{Node: "node-1", ID: "aB3x", Attr: "zone", Value: "us-east-1a"}) {
+		t.Errorf("attrs = %+v", attrs)
 	}
-	if got := indexMap["refresh_interval"]; got != "30s" {
-		t.Errorf("refresh_interval = %v, want \"30s\"", got)
+}
+
+func TestGetIngestStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_nodes/stats/ingest" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodes":{"aB3x":{"name":"node-1","ingest":{"pipelines":{
+			"logs":{"count":100,"time_in_millis":50,"current":2,"failed":3,
+				"processors":[{"grok:parse":{"type":"grok","stats":{"count":100,"time_in_millis":40,"current":0,"failed":3}}}]}
+		}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetIngestStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetIngestStats: %v", err)
+	}
+	p := stats.Nodes["aB3x"].Ingest.Pipelines["logs"]
+	if p.Count != 100 || p.TimeInMillis != 50 || p.Current != 2 || p.Failed != 3 {
+		t.Errorf("pipeline counters = %+v", p.IngestCounters)
+	}
+	if len(p.Processors) != 1 || p.Processors[0]["grok:parse"].Type != "grok" || p.Processors[0]["grok:parse"].Stats.TimeInMillis != 40 {
+		t.Errorf("processors = %+v", p.Processors)
 	}
 }
 
-// TestBuildNestedMap_DeepSharedPrefix verifies that keys sharing a deep
-// common prefix (e.g. routing.allocation.*) are all preserved. Previously
-// the loop overwrote sibling branches at any shared intermediate node.
-func TestBuildNestedMap_DeepSharedPrefix(t *testing.T) {
-	result := buildNestedMap(map[string]any{
-		"index.routing.allocation.include._name": "node-a",
-		"index.routing.allocation.exclude._name": "node-b",
-		"index.routing.allocation.require._name": "node-c",
-		"index.routing.allocation.include._ip":   "1.2.3.4",
-	})
+func TestGetIngestStats_Empty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
 
-	walk := func(m map[string]any, path ...string) (any, bool) {
-		var cur any = m
-		for _, seg := range path {
-			mm, ok := cur.(map[string]any)
-			if !ok {
-				return nil, false
-			}
-			cur = mm[seg]
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetIngestStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetIngestStats: %v", err)
+	}
+	if stats.Nodes == nil {
+		t.Error("expected non-nil node map for an empty response")
+	}
+}
+
+func TestGetRemoteInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_remote/info" {
+			t.Errorf("unexpected path %q", r.URL.Path)
 		}
-		return cur, true
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"prod":{"connected":true,"mode":"sniff","seeds":["10.0.0.1:9300"],"num_nodes_connected":3,"skip_unavailable":false},
+			"dr":{"connected":false,"mode":"proxy","proxy_address":"dr.example:9400","num_proxy_sockets_connected":0}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	remotes, err := c.GetRemoteInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetRemoteInfo: %v", err)
 	}
+	if p := remotes["prod"]; !p.Connected || p.Mode != "sniff" || p.NumNodesConnected != 3 || len(p.Seeds) != 1 {
+		t.Errorf("prod = %+v", p)
+	}
+	if d := remotes["dr"]; d.Connected || d.ProxyAddress != "dr.example:9400" {
+		t.Errorf("dr = %+v", d)
+	}
+}
 
-	checks := []struct {
-		path []string
-		want string
-	}{
-		{[]string{"index", "routing", "allocation", "include", "_name"}, "node-a"},
-		{[]string{"index", "routing", "allocation", "exclude", "_name"}, "node-b"},
-		{[]string{"index", "routing", "allocation", "require", "_name"}, "node-c"},
-		{[]string{"index", "routing", "allocation", "include", "_ip"}, "1.2.3.4"},
+func TestGetCCRStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_ccr/stats" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"follow_stats":{"indices":[{"index":"dr-logs","shards":[{
+			"remote_cluster":"prod","leader_index":"logs","follower_index":"dr-logs","shard_id":0,
+			"leader_global_checkpoint":1000,"follower_global_checkpoint":990,"time_since_last_read_millis":42,
+			"read_exceptions":[],"fatal_exception":{"type":"index_not_found_exception","reason":"no such index"}}]}]}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetCCRStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetCCRStats: %v", err)
 	}
-	for _, c := range checks {
-		got, ok := walk(result, c.path...)
-		if !ok {
-			t.Errorf("path %v: intermediate node missing", c.path)
-			continue
+	if len(stats.FollowStats.Indices) != 1 || len(stats.FollowStats.Indices[0].Shards) != 1 {
+		t.Fatalf("indices = %+v", stats.FollowStats.Indices)
+	}
+	s := stats.FollowStats.Indices[0].Shards[0]
+	if s.LeaderGlobalCheckpoint != 1000 || s.FollowerGlobalCheckpoint != 990 || s.TimeSinceLastReadMillis != 42 {
+		t.Errorf("shard = %+v", s)
+	}
+	if s.FatalException == nil || s.FatalException.Type != "index_not_found_exception" {
+		t.Errorf("fatal = %+v", s.FatalException)
+	}
+}
+
+func TestGetCCRStats_NoFollowers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetCCRStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetCCRStats: %v", err)
+	}
+	if stats.FollowStats.Indices == nil {
+		t.Error("expected non-nil indices for a cluster without followers")
+	}
+}
+
+func TestGetIndexingPressure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_nodes/stats/indexing_pressure" {
+			t.Errorf("unexpected path %q", r.URL.Path)
 		}
-		if got != c.want {
-			t.Errorf("path %v: got %v, want %v", c.path, got, c.want)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodes":{"aB3x":{"name":"node-1","indexing_pressure":{"memory":{
+			"current":{"combined_coordinating_and_primary_in_bytes":4096,"coordinating_in_bytes":1024,"primary_in_bytes":3072,"replica_in_bytes":512,"all_in_bytes":4608},
+			"total":{"coordinating_rejections":2,"primary_rejections":1,"replica_rejections":4},
+			"limit_in_bytes":104857600}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	stats, err := c.GetIndexingPressure(context.Background())
+	if err != nil {
+		t.Fatalf("GetIndexingPressure: %v", err)
+	}
+	m := stats.Nodes["aB3x"].IndexingPressure.Memory
+	if m.Current.CombinedCoordinatingAndPrimaryInBytes != 4096 || m.Current.ReplicaInBytes != 512 || m.Current.AllInBytes != 4608 {
+		t.Errorf("current = %+v", m.Current)
+	}
+	if m.LimitInBytes != 104857600 {
+		t.Errorf("LimitInBytes = %d, want 104857600", m.LimitInBytes)
+	}
+	if got := m.Rejections(); got != 7 {
+		t.Errorf("Rejections() = %d, want 7", got)
+	}
+}
+
+func TestGetIndexingPressure_Unsupported(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"type":"illegal_argument_exception","reason":"request [/_nodes/stats/indexing_pressure] contains unrecognized metric: [indexing_pressure]"}}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	if _, err := c.GetIndexingPressure(context.Background()); err == nil {
+		t.Error("expected an error from a cluster without indexing pressure stats")
+	}
+}
+
+func TestGetNodeVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_nodes/jvm" {
+			t.Errorf("unexpected path %q", r.URL.Path)
 		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"nodes":{
+			"aB3x":{"name":"es-1","version":"7.17.9","build_flavor":"default","jvm":{"version":"19.0.2"}},
+			"cD4y":{"name":"es-2","version":"8.12.0","jvm":{"version":"21.0.1"}}}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	versions, err := c.GetNodeVersions(context.Background())
+	if err != nil {
+		t.Fatalf("GetNodeVersions: %v", err)
+	}
+	if v := versions["aB3x"]; v.Name != "es-1" || v.Version != "7.17.9" || v.BuildFlavor != "default" || v.JVM.Version != "19.0.2" {
+		t.Errorf("aB3x = %+v", v)
+	}
+	if v := versions["cD4y"]; v.Version != "8.12.0" || v.BuildFlavor != "" {
+		t.Errorf("cD4y = %+v", v)
 	}
 }
@@ -8,16 +8,49 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 const (
 	endpointClusterHealth = "/_cluster/health?filter_path=cluster_name,status,number_of_nodes,active_shards,unassigned_shards"
 	endpointNodes         = "/_cat/nodes?v&format=json&h=node.role,name,ip&s=node.role,ip"
-	endpointNodeStats     = "/_nodes/stats/indices,os,jvm,fs?filter_path=nodes.*.name,nodes.*.host,nodes.*.ip,nodes.*.roles,nodes.*.indices.indexing.index_total,nodes.*.indices.indexing.index_time_in_millis,nodes.*.indices.search.query_total,nodes.*.indices.search.query_time_in_millis,nodes.*.os.cpu.percent,nodes.*.jvm.mem.heap_used_in_bytes,nodes.*.jvm.mem.heap_max_in_bytes,nodes.*.fs.total.total_in_bytes,nodes.*.fs.total.available_in_bytes"
-	endpointIndices       = "/_cat/indices?v&format=json&h=index,pri,rep,pri.store.size,store.size,docs.count&s=index"
+	endpointNodeStats     = "/_nodes/stats/indices,os,jvm,fs,process?filter_path=nodes.*.name,nodes.*.host,nodes.*.ip,nodes.*.roles,nodes.*.indices.indexing.index_total,nodes.*.indices.indexing.index_time_in_millis,nodes.*.indices.search.query_total,nodes.*.indices.search.query_time_in_millis,nodes.*.os.cpu.percent,nodes.*.os.cpu.load_average,nodes.*.os.mem,nodes.*.os.swap,nodes.*.process.open_file_descriptors,nodes.*.process.max_file_descriptors,nodes.*.jvm.uptime_in_millis,nodes.*.jvm.mem.heap_used_in_bytes,nodes.*.jvm.mem.heap_max_in_bytes,nodes.*.fs.total.total_in_bytes,nodes.*.fs.total.available_in_bytes"
+	endpointIndices       = "/_cat/indices?v&format=json&h=index,pri,rep,pri.store.size,store.size,docs.count,docs.deleted&s=index"
 	endpointIndexStats    = "/_stats?filter_path=indices.*.primaries.indexing.index_total,indices.*.primaries.indexing.index_time_in_millis,indices.*.total.indexing.index_total,indices.*.total.indexing.index_time_in_millis,indices.*.total.search.query_total,indices.*.total.search.query_time_in_millis,indices.*.primaries.search.query_total,indices.*.primaries.search.query_time_in_millis,indices.*.primaries.store.size_in_bytes,indices.*.total.store.size_in_bytes"
 	endpointAllocation    = "/_cat/allocation?format=json&h=node,shards,disk.percent&s=node"
+	endpointPendingTasks  = "/_cluster/pending_tasks"
+	endpointTasks         = "/_tasks?detailed&group_by=parents"
+	endpointShards        = "/_cat/shards?format=json&bytes=b&h=index,shard,prirep,state,docs,store,node,unassigned.reason&s=index,shard,prirep"
+	endpointAllocExplain  = "/_cluster/allocation/explain"
+	endpointSnapshotRepos = "/_snapshot"
+	endpointILMExplain    = "/_all/_ilm/explain?filter_path=indices.*.index,indices.*.managed,indices.*.policy,indices.*.phase,indices.*.action,indices.*.step,indices.*.lifecycle_date_millis,indices.*.failed_step,indices.*.step_info"
+	endpointISMExplain    = "/_plugins/_ism/explain/*"
+	endpointDataStreams   = "/_data_stream?expand_wildcards=all"
+	endpointNodeAttrs     = "/_cat/nodeattrs?format=json&h=node,id,attr,value&s=node,attr"
+	endpointIngestStats   = "/_nodes/stats/ingest?filter_path=nodes.*.name,nodes.*.ingest.pipelines"
+	endpointRemoteInfo    = "/_remote/info"
+	endpointCCRStats      = "/_ccr/stats?filter_path=follow_stats.indices"
+	endpointIdxPressure   = "/_nodes/stats/indexing_pressure?filter_path=nodes.*.name,nodes.*.indexing_pressure.memory"
+	endpointNodeVersions  = "/_nodes/jvm?filter_path=nodes.*.name,nodes.*.version,nodes.*.build_flavor,nodes.*.jvm.version"
+	endpointAliases       = "/_cat/aliases?format=json&h=alias,index,is_write_index&s=alias,index"
+	endpointIndexTmpl     = "/_index_template?flat_settings=true"
+	endpointComponentTmpl = "/_component_template?flat_settings=true"
+	endpointLegacyTmpl    = "/_template?flat_settings=true"
+	endpointSimulateIndex = "/_index_template/_simulate_index/"
+	endpointMappings      = "/_mapping"
+	endpointFieldLimits   = "/_all/_settings/index.mapping.total_fields.limit?flat_settings=true&include_defaults=true"
+	endpointClusterSet    = "/_cluster/settings"
+	endpointWatermarks    = "/_cluster/settings?include_defaults=true&flat_settings=true&filter_path=*.cluster.routing.allocation.disk.*"
+	endpointRecovery      = "/_cat/recovery?active_only=true&format=json&bytes=b&time=ms&h=index,shard,time,type,stage,source_node,target_node,files_percent,bytes_recovered,bytes_total,bytes_percent&s=index,shard"
+
+	// endpointSnapshotsParams lists a repository's snapshots oldest first with
+	// epoch-second timestamps and millisecond durations.
+	endpointSnapshotsParams = "format=json&time=ms&h=id,status,start_epoch,end_epoch,duration,indices,successful_shards,failed_shards,total_shards&s=start_epoch"
+
+	// endpointHotThreadsParams samples the 10 busiest non-idle threads.
+	endpointHotThreadsParams = "threads=10&ignore_idle_threads=true"
 )
 
 // GetClusterHealth fetches cluster health from /_cluster/health.
@@ -90,6 +123,53 @@ func (c *DefaultClient) GetAllocation(ctx context.Context) ([]AllocationInfo, er
 	return result, nil
 }
 
+// GetPendingTasks fetches the master's queue of cluster-state update tasks
+// from /_cluster/pending_tasks. An empty queue returns an empty slice.
+func (c *DefaultClient) GetPendingTasks(ctx context.Context) ([]PendingTask, error) {
+	body, err := c.doGet(ctx, endpointPendingTasks)
+	if err != nil {
+		return nil, fmt.Errorf("GetPendingTasks: %w", err)
+	}
+
+	var result struct {
+		Tasks []PendingTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetPendingTasks decode: %w", err)
+	}
+	if result.Tasks == nil {
+		return []PendingTask{}, nil
+	}
+	return result.Tasks, nil
+}
+
+// GetTasks fetches currently running tasks from /_tasks, grouped by parent.
+// Top-level entries are parent tasks (or standalone tasks); child tasks are
+// nested in TaskInfo.Children. Results are sorted by task ID for stable order.
+func (c *DefaultClient) GetTasks(ctx context.Context) ([]TaskInfo, error) {
+	body, err := c.doGet(ctx, endpointTasks)
+	if err != nil {
+		return nil, fmt.Errorf("GetTasks: %w", err)
+	}
+
+	var result struct {
+		Tasks map[string]TaskInfo `json:"tasks"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetTasks decode: %w", err)
+	}
+	ids := make([]string, 0, len(result.Tasks))
+	for id := range result.Tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	tasks := make([]TaskInfo, 0, len(ids))
+	for _, id := range ids {
+		tasks = append(tasks, result.Tasks[id])
+	}
+	return tasks, nil
+}
+
 // GetIndexStats fetches per-index statistics from /_stats.
 func (c *DefaultClient) GetIndexStats(ctx context.Context) (*IndexStatsResponse, error) {
 	body, err := c.doGet(ctx, endpointIndexStats)
@@ -183,6 +263,883 @@ func (c *DefaultClient) doPutJSON(ctx context.Context, path string, body []byte)
 	return nil
 }
 
+// GetHotThreads fetches the plain-text hot threads report for a single node
+// from /_nodes/<id>/hot_threads. nodeID is the node ID key of /_nodes/stats.
+func (c *DefaultClient) GetHotThreads(ctx context.Context, nodeID string) (string, error) {
+	if nodeID == "" {
+		return "", fmt.Errorf("GetHotThreads: nodeID must not be empty")
+	}
+	path := "/_nodes/" + url.PathEscape(nodeID) + "/hot_threads?" + endpointHotThreadsParams
+	body, err := c.doGet(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("GetHotThreads: %w", err)
+	}
+	return string(body), nil
+}
+
+// GetShards fetches one row per shard copy from /_cat/shards.
+func (c *DefaultClient) GetShards(ctx context.Context) ([]ShardInfo, error) {
+	body, err := c.doGet(ctx, endpointShards)
+	if err != nil {
+		return nil, fmt.Errorf("GetShards: %w", err)
+	}
+
+	var result []ShardInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetShards decode: %w", err)
+	}
+	return result, nil
+}
+
+// GetRecovery fetches active shard recoveries from /_cat/recovery. No active
+// recoveries returns an empty slice.
+func (c *DefaultClient) GetRecovery(ctx context.Context) ([]RecoveryInfo, error) {
+	body, err := c.doGet(ctx, endpointRecovery)
+	if err != nil {
+		return nil, fmt.Errorf("GetRecovery: %w", err)
+	}
+
+	var result []RecoveryInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetRecovery decode: %w", err)
+	}
+	if result == nil {
+		return []RecoveryInfo{}, nil
+	}
+	return result, nil
+}
+
+// GetSnapshotRepositories fetches the registered snapshot repositories from
+// /_snapshot, sorted by name. No repositories returns an empty slice.
+func (c *DefaultClient) GetSnapshotRepositories(ctx context.Context) ([]SnapshotRepository, error) {
+	body, err := c.doGet(ctx, endpointSnapshotRepos)
+	if err != nil {
+		return nil, fmt.Errorf("GetSnapshotRepositories: %w", err)
+	}
+
+	var raw map[string]struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("GetSnapshotRepositories decode: %w", err)
+	}
+	repos := make([]SnapshotRepository, 0, len(raw))
+	for name, r := range raw {
+		repos = append(repos, SnapshotRepository{Name: name, Type: r.Type})
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	return repos, nil
+}
+
+// GetSnapshots fetches the snapshots of one repository from
+// /_cat/snapshots/<repo>, oldest first.
+func (c *DefaultClient) GetSnapshots(ctx context.Context, repo string) ([]SnapshotInfo, error) {
+	if repo == "" {
+		return nil, fmt.Errorf("GetSnapshots: repository must not be empty")
+	}
+	path := "/_cat/snapshots/" + url.PathEscape(repo) + "?" + endpointSnapshotsParams
+	body, err := c.doGet(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("GetSnapshots: %w", err)
+	}
+
+	var result []SnapshotInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetSnapshots decode: %w", err)
+	}
+	return result, nil
+}
+
+// GetTemplates fetches composable (_index_template), component
+// (_component_template) and legacy (_template) templates. Each kind is
+// fetched independently so that clusters lacking one API (e.g. pre-7.8
+// without composable templates) still list the others; an error is returned
+// only when all three fail. Templates are sorted by kind, then name.
+func (c *DefaultClient) GetTemplates(ctx context.Context) ([]IndexTemplate, error) {
+	var result []IndexTemplate
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if body, err := c.doGet(ctx, endpointIndexTmpl); err != nil {
+		fail(err)
+	} else {
+		var raw struct {
+			IndexTemplates []struct {
+				Name          string `json:"name"`
+				IndexTemplate struct {
+					IndexPatterns []string        `json:"index_patterns"`
+					Priority      int             `json:"priority"`
+					ComposedOf    []string        `json:"composed_of"`
+					DataStream    json.RawMessage `json:"data_stream"`
+					Template      struct {
+						Settings map[string]any `json:"settings"`
+					} `json:"template"`
+				} `json:"index_template"`
+			} `json:"index_templates"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			fail(fmt.Errorf("decode index templates: %w", err))
+		} else {
+			for _, t := range raw.IndexTemplates {
+				result = append(result, IndexTemplate{
+					Name:       t.Name,
+					Kind:       TemplateComposable,
+					Patterns:   t.IndexTemplate.IndexPatterns,
+					Priority:   t.IndexTemplate.Priority,
+					ComposedOf: t.IndexTemplate.ComposedOf,
+					DataStream: len(t.IndexTemplate.DataStream) > 0 && string(t.IndexTemplate.DataStream) != "null",
+					Settings:   templateSettingsFrom(t.IndexTemplate.Template.Settings),
+				})
+			}
+		}
+	}
+
+	if body, err := c.doGet(ctx, endpointComponentTmpl); err != nil {
+		fail(err)
+	} else {
+		var raw struct {
+			ComponentTemplates []struct {
+				Name              string `json:"name"`
+				ComponentTemplate struct {
+					Template struct {
+						Settings map[string]any `json:"settings"`
+					} `json:"template"`
+				} `json:"component_template"`
+			} `json:"component_templates"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			fail(fmt.Errorf("decode component templates: %w", err))
+		} else {
+			for _, t := range raw.ComponentTemplates {
+				result = append(result, IndexTemplate{
+					Name:     t.Name,
+					Kind:     TemplateComponent,
+					Settings: templateSettingsFrom(t.ComponentTemplate.Template.Settings),
+				})
+			}
+		}
+	}
+
+	if body, err := c.doGet(ctx, endpointLegacyTmpl); err != nil {
+		fail(err)
+	} else {
+		var raw map[string]struct {
+			Order         int            `json:"order"`
+			IndexPatterns []string       `json:"index_patterns"`
+			Settings      map[string]any `json:"settings"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			fail(fmt.Errorf("decode legacy templates: %w", err))
+		} else {
+			for name, t := range raw {
+				result = append(result, IndexTemplate{
+					Name:     name,
+					Kind:     TemplateLegacy,
+					Patterns: t.IndexPatterns,
+					Priority: t.Order,
+					Settings: templateSettingsFrom(t.Settings),
+				})
+			}
+		}
+	}
+
+	if result == nil && firstErr != nil {
+		return nil, fmt.Errorf("GetTemplates: %w", firstErr)
+	}
+	if result == nil {
+		return []IndexTemplate{}, nil
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// SimulateIndex resolves the settings a new index called name would receive
+// from the composable templates, and which other templates also match but
+// lose on priority, via POST _index_template/_simulate_index/<name>.
+func (c *DefaultClient) SimulateIndex(ctx context.Context, name string) (*SimulatedIndex, error) {
+	if name == "" {
+		return nil, fmt.Errorf("SimulateIndex: name must not be empty")
+	}
+	body, err := c.doPostJSON(ctx, endpointSimulateIndex+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("SimulateIndex: %w", err)
+	}
+	var raw struct {
+		Template struct {
+			Settings map[string]any `json:"settings"`
+		} `json:"template"`
+		Overlapping []TemplateRef `json:"overlapping"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("SimulateIndex decode: %w", err)
+	}
+	return &SimulatedIndex{
+		Settings:    templateSettingsFrom(raw.Template.Settings),
+		Overlapping: raw.Overlapping,
+	}, nil
+}
+
+// GetMappingStats fetches the mappings of every index and returns the mapped
+// field count of each, keyed by index name, together with its
+// index.mapping.total_fields.limit. The mappings themselves are discarded.
+// When the limit cannot be read, DefaultTotalFieldsLimit is assumed.
+func (c *DefaultClient) GetMappingStats(ctx context.Context) (map[string]MappingStats, error) {
+	body, err := c.doGet(ctx, endpointMappings)
+	if err != nil {
+		return nil, fmt.Errorf("GetMappingStats: %w", err)
+	}
+	var raw map[string]struct {
+		Mappings map[string]any `json:"mappings"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("GetMappingStats: decode: %w", err)
+	}
+
+	// The limit is best-effort: without it the default still gives a useful
+	// comparison for the common case of an untouched setting.
+	limits := c.getFieldLimits(ctx)
+
+	result := make(map[string]MappingStats, len(raw))
+	for name, m := range raw {
+		limit, ok := limits[name]
+		if !ok {
+			limit = DefaultTotalFieldsLimit
+		}
+		result[name] = MappingStats{
+			Index:      name,
+			FieldCount: countMappingFields(m.Mappings),
+			FieldLimit: limit,
+		}
+	}
+	return result, nil
+}
+
+// getFieldLimits returns index.mapping.total_fields.limit per index, taking
+// an explicit setting over the reported default. Returns nil on failure.
+func (c *DefaultClient) getFieldLimits(ctx context.Context) map[string]int {
+	body, err := c.doGet(ctx, endpointFieldLimits)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]struct {
+		Settings map[string]any `json:"settings"`
+		Defaults map[string]any `json:"defaults"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+	const key = "index.mapping.total_fields.limit"
+	limits := make(map[string]int, len(raw))
+	for name, e := range raw {
+		for _, src := range []map[string]any{e.Settings, e.Defaults} {
+			if v, ok := src[key].(string); ok {
+				if n, err := strconv.Atoi(v); err == nil && n > 0 {
+					limits[name] = n
+					break
+				}
+			}
+		}
+	}
+	return limits
+}
+
+// GetIndexMapping fetches the mapping of a single index and returns its
+// "mappings" object.
+func (c *DefaultClient) GetIndexMapping(ctx context.Context, index string) (map[string]any, error) {
+	if index == "" {
+		return nil, fmt.Errorf("GetIndexMapping: index must not be empty")
+	}
+	body, err := c.doGet(ctx, "/"+url.PathEscape(index)+endpointMappings)
+	if err != nil {
+		return nil, fmt.Errorf("GetIndexMapping: %w", err)
+	}
+	var raw map[string]struct {
+		Mappings map[string]any `json:"mappings"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("GetIndexMapping: decode: %w", err)
+	}
+	// The response is keyed by concrete index name, which differs from the
+	// request when index is an alias; with a single entry either works.
+	if e, ok := raw[index]; ok {
+		return mappingBody(e.Mappings), nil
+	}
+	for _, e := range raw {
+		return mappingBody(e.Mappings), nil
+	}
+	return nil, fmt.Errorf("GetIndexMapping: index %q not in response", index)
+}
+
+// mappingBody returns the part of a mappings object that holds "properties"
+// and "runtime". Mappings from Elasticsearch 6.x nest these under a single
+// type name ({"_doc": {"properties": ...}}), which is unwrapped here.
+func mappingBody(mappings map[string]any) map[string]any {
+	if _, ok := mappings["properties"]; ok {
+		return mappings
+	}
+	if _, ok := mappings["runtime"]; ok {
+		return mappings
+	}
+	if len(mappings) == 1 {
+		for _, v := range mappings {
+			if inner, ok := v.(map[string]any); ok {
+				if _, ok := inner["properties"]; ok {
+					return inner
+				}
+			}
+		}
+	}
+	return mappings
+}
+
+// countMappingFields counts the fields of a mappings object the way
+// index.mapping.total_fields.limit does: every entry under "properties" at
+// any depth (object fields included), every multi-field, and every runtime
+// field.
+func countMappingFields(mappings map[string]any) int {
+	body := mappingBody(mappings)
+	n := 0
+	if props, ok := body["properties"].(map[string]any); ok {
+		n += countProperties(props)
+	}
+	if runtime, ok := body["runtime"].(map[string]any); ok {
+		n += len(runtime)
+	}
+	return n
+}
+
+// countProperties counts the entries of a "properties" object, recursing
+// into object sub-properties and multi-fields.
+func countProperties(props map[string]any) int {
+	n := 0
+	for _, v := range props {
+		n++
+		field, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if sub, ok := field["properties"].(map[string]any); ok {
+			n += countProperties(sub)
+		}
+		if multi, ok := field["fields"].(map[string]any); ok {
+			n += countProperties(multi)
+		}
+	}
+	return n
+}
+
+// templateSettingsFrom extracts the key settings from a settings object that
+// may be flat ("index.number_of_shards") or nested ({"index":{...}}).
+func templateSettingsFrom(settings map[string]any) TemplateSettings {
+	flat := make(map[string]string)
+	flattenSettings("", settings, flat)
+	get := func(keys ...string) string {
+		for _, k := range keys {
+			if v, ok := flat[k]; ok {
+				return v
+			}
+			if v, ok := flat[strings.TrimPrefix(k, "index.")]; ok {
+				return v
+			}
+		}
+		return ""
+	}
+	return TemplateSettings{
+		Shards:          get("index.number_of_shards"),
+		Replicas:        get("index.number_of_replicas"),
+		LifecyclePolicy: get("index.lifecycle.name", "index.plugins.index_state_management.policy_id", "index.opendistro.index_state_management.policy_id"),
+	}
+}
+
+// flattenSettings writes every leaf of settings into out keyed by its dotted
+// path. Non-string leaves are formatted with %v.
+func flattenSettings(prefix string, settings map[string]any, out map[string]string) {
+	for k, v := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flattenSettings(key, val, out)
+		case string:
+			out[key] = val
+		case []any:
+			parts := make([]string, len(val))
+			for i, e := range val {
+				parts[i] = fmt.Sprintf("%v", e)
+			}
+			out[key] = strings.Join(parts, ",")
+		case nil:
+		default:
+			out[key] = fmt.Sprintf("%v", val)
+		}
+	}
+}
+
+// GetClusterSettings fetches the persistent, transient and default cluster
+// settings, flattened to dotted keys. List values are joined with commas.
+func (c *DefaultClient) GetClusterSettings(ctx context.Context) (*ClusterSettings, error) {
+	cs, err := c.getClusterSettings(ctx, endpointClusterSet+"?include_defaults=true&flat_settings=true")
+	if err != nil {
+		return nil, fmt.Errorf("GetClusterSettings: %w", err)
+	}
+	return cs, nil
+}
+
+// getClusterSettings fetches and flattens a _cluster/settings response from
+// path.
+func (c *DefaultClient) getClusterSettings(ctx context.Context, path string) (*ClusterSettings, error) {
+	body, err := c.doGet(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var raw struct {
+		Persistent map[string]any `json:"persistent"`
+		Transient  map[string]any `json:"transient"`
+		Defaults   map[string]any `json:"defaults"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	cs := &ClusterSettings{
+		Persistent: make(map[string]string),
+		Transient:  make(map[string]string),
+		Defaults:   make(map[string]string),
+	}
+	flattenSettings("", raw.Persistent, cs.Persistent)
+	flattenSettings("", raw.Transient, cs.Transient)
+	flattenSettings("", raw.Defaults, cs.Defaults)
+	return cs, nil
+}
+
+// GetDiskWatermarks fetches the effective disk allocation watermarks.
+// Settings that are missing or unparseable keep Elasticsearch's defaults.
+func (c *DefaultClient) GetDiskWatermarks(ctx context.Context) (*DiskWatermarks, error) {
+	cs, err := c.getClusterSettings(ctx, endpointWatermarks)
+	if err != nil {
+		return nil, fmt.Errorf("GetDiskWatermarks: %w", err)
+	}
+	wm := DefaultDiskWatermarks()
+	if v, _ := cs.Effective("cluster.routing.allocation.disk.threshold_enabled"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			wm.ThresholdEnabled = b
+		}
+	}
+	for _, w := range []struct {
+		name string
+		dst  *DiskWatermark
+	}{
+		{"low", &wm.Low},
+		{"high", &wm.High},
+		{"flood_stage", &wm.FloodStage},
+	} {
+		key := "cluster.routing.allocation.disk.watermark." + w.name
+		if v, _ := cs.Effective(key); v != "" {
+			if parsed, err := ParseDiskWatermark(v); err == nil {
+				*w.dst = parsed
+			}
+		}
+		if v, _ := cs.Effective(key + ".max_headroom"); v != "" && w.dst.Percent > 0 {
+			if b, err := parseByteSize(v); err == nil && b > 0 {
+				w.dst.MaxHeadroom = b
+			}
+		}
+	}
+	return &wm, nil
+}
+
+// ParseDiskWatermark parses a disk watermark value: a percentage ("85%"), a
+// ratio ("0.85") or a byte size ("50gb", "500mb").
+func ParseDiskWatermark(v string) (DiskWatermark, error) {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if strings.HasSuffix(v, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return DiskWatermark{}, fmt.Errorf("invalid watermark %q", v)
+		}
+		return DiskWatermark{Percent: pct}, nil
+	}
+	if ratio, err := strconv.ParseFloat(v, 64); err == nil {
+		if ratio < 0 || ratio > 1 {
+			return DiskWatermark{}, fmt.Errorf("invalid watermark %q", v)
+		}
+		return DiskWatermark{Percent: ratio * 100}, nil
+	}
+	b, err := parseByteSize(v)
+	if err != nil || b < 0 {
+		return DiskWatermark{}, fmt.Errorf("invalid watermark %q", v)
+	}
+	return DiskWatermark{FreeBytes: b}, nil
+}
+
+// parseByteSize parses an Elasticsearch byte size value such as "50gb",
+// "1.5tb" or "512b". Units are binary (1kb = 1024 bytes). "-1" parses as -1.
+func parseByteSize(v string) (int64, error) {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if v == "-1" {
+		return -1, nil
+	}
+	units := []struct {
+		suffix string
+		mult   float64
+	}{
+		{"pb", 1 << 50}, {"tb", 1 << 40}, {"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10}, {"b", 1},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(v, u.suffix)), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid byte size %q", v)
+			}
+			return int64(n * u.mult), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid byte size %q", v)
+}
+
+// UpdateClusterSettings applies flat dotted key→value maps via
+// PUT /_cluster/settings. A nil value resets that key. Empty maps are
+// omitted from the request; nothing is sent when both are empty.
+func (c *DefaultClient) UpdateClusterSettings(ctx context.Context, persistent, transient map[string]any) error {
+	if len(persistent) == 0 && len(transient) == 0 {
+		return nil
+	}
+	body := make(map[string]any, 2)
+	if len(persistent) > 0 {
+		body["persistent"] = persistent
+	}
+	if len(transient) > 0 {
+		body["transient"] = transient
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("UpdateClusterSettings marshal: %w", err)
+	}
+	if err := c.doPutJSON(ctx, endpointClusterSet, data); err != nil {
+		return fmt.Errorf("UpdateClusterSettings: %w", err)
+	}
+	return nil
+}
+
+// GetAliases fetches every alias-to-index pair from _cat/aliases, sorted by
+// alias then index. Returns an empty slice when no aliases exist.
+func (c *DefaultClient) GetAliases(ctx context.Context) ([]AliasInfo, error) {
+	body, err := c.doGet(ctx, endpointAliases)
+	if err != nil {
+		return nil, fmt.Errorf("GetAliases: %w", err)
+	}
+	var result []AliasInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetAliases: decode: %w", err)
+	}
+	if result == nil {
+		return []AliasInfo{}, nil
+	}
+	return result, nil
+}
+
+// GetNodeAttributes fetches the custom attributes of every node from
+// _cat/nodeattrs. Returns an empty slice when no node has attributes.
+func (c *DefaultClient) GetNodeAttributes(ctx context.Context) ([]NodeAttribute, error) {
+	body, err := c.doGet(ctx, endpointNodeAttrs)
+	if err != nil {
+		return nil, fmt.Errorf("GetNodeAttributes: %w", err)
+	}
+	var result []NodeAttribute
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetNodeAttributes: decode: %w", err)
+	}
+	if result == nil {
+		return []NodeAttribute{}, nil
+	}
+	return result, nil
+}
+
+// GetIngestStats fetches per-node ingest pipeline and processor counters.
+// Returns a response with an empty node map when no node reports pipelines.
+func (c *DefaultClient) GetIngestStats(ctx context.Context) (*IngestStatsResponse, error) {
+	body, err := c.doGet(ctx, endpointIngestStats)
+	if err != nil {
+		return nil, fmt.Errorf("GetIngestStats: %w", err)
+	}
+	var result IngestStatsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetIngestStats: decode: %w", err)
+	}
+	if result.Nodes == nil {
+		result.Nodes = map[string]NodeIngestStats{}
+	}
+	return &result, nil
+}
+
+// GetRemoteInfo fetches the configured remote clusters and their connection
+// state, keyed by remote cluster alias. Returns an empty map when none are
+// configured.
+func (c *DefaultClient) GetRemoteInfo(ctx context.Context) (map[string]RemoteInfo, error) {
+	body, err := c.doGet(ctx, endpointRemoteInfo)
+	if err != nil {
+		return nil, fmt.Errorf("GetRemoteInfo: %w", err)
+	}
+	var result map[string]RemoteInfo
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetRemoteInfo: decode: %w", err)
+	}
+	if result == nil {
+		return map[string]RemoteInfo{}, nil
+	}
+	return result, nil
+}
+
+// GetCCRStats fetches the replication state of every follower index. It
+// fails on clusters without cross-cluster replication (no license or
+// OpenSearch).
+func (c *DefaultClient) GetCCRStats(ctx context.Context) (*CCRStatsResponse, error) {
+	body, err := c.doGet(ctx, endpointCCRStats)
+	if err != nil {
+		return nil, fmt.Errorf("GetCCRStats: %w", err)
+	}
+	var result CCRStatsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetCCRStats: decode: %w", err)
+	}
+	if result.FollowStats.Indices == nil {
+		result.FollowStats.Indices = []FollowIndexStats{}
+	}
+	return &result, nil
+}
+
+// GetDataStreams fetches all data streams, including hidden ones, with their
+// backing indices. Returns an empty slice when the cluster has none.
+func (c *DefaultClient) GetDataStreams(ctx context.Context) ([]DataStream, error) {
+	body, err := c.doGet(ctx, endpointDataStreams)
+	if err != nil {
+		return nil, fmt.Errorf("GetDataStreams: %w", err)
+	}
+	var resp DataStreamsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("GetDataStreams: decode: %w", err)
+	}
+	if resp.DataStreams == nil {
+		return []DataStream{}, nil
+	}
+	return resp.DataStreams, nil
+}
+
+// GetLifecycleExplain fetches the lifecycle state of every index, keyed by
+// index name. Elasticsearch ILM (_ilm/explain) is tried first; when that
+// fails, OpenSearch ISM (_plugins/_ism/explain) is tried. The ILM error is
+// returned when neither is available.
+func (c *DefaultClient) GetLifecycleExplain(ctx context.Context) (map[string]LifecycleExplain, error) {
+	result, ilmErr := c.getILMExplain(ctx)
+	if ilmErr == nil {
+		return result, nil
+	}
+	if result, err := c.getISMExplain(ctx); err == nil {
+		return result, nil
+	}
+	return nil, fmt.Errorf("GetLifecycleExplain: %w", ilmErr)
+}
+
+// getILMExplain decodes /_all/_ilm/explain. Indices in an ERROR step carry
+// the failed step and the reason from step_info.
+func (c *DefaultClient) getILMExplain(ctx context.Context) (map[string]LifecycleExplain, error) {
+	body, err := c.doGet(ctx, endpointILMExplain)
+	if err != nil {
+		return nil, err
+	}
+	var raw struct {
+		Indices map[string]ilmExplainEntry `json:"indices"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	result := make(map[string]LifecycleExplain, len(raw.Indices))
+	for name, e := range raw.Indices {
+		le := LifecycleExplain{
+			Index:               name,
+			Managed:             e.Managed,
+			Policy:              e.Policy,
+			Phase:               e.Phase,
+			Action:              e.Action,
+			Step:                e.Step,
+			LifecycleDateMillis: e.LifecycleDateMillis,
+			FailedStep:          e.FailedStep,
+		}
+		if e.Step == "ERROR" {
+			le.Failed = true
+			le.StepError = e.StepInfo.summary()
+		}
+		result[name] = le
+	}
+	return result, nil
+}
+
+// getISMExplain decodes /_plugins/_ism/explain/*, whose top level maps index
+// names to entries alongside a total_managed_indices counter.
+func (c *DefaultClient) getISMExplain(ctx context.Context) (map[string]LifecycleExplain, error) {
+	body, err := c.doGet(ctx, endpointISMExplain)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	result := make(map[string]LifecycleExplain, len(raw))
+	for name, msg := range raw {
+		var e ismExplainEntry
+		if err := json.Unmarshal(msg, &e); err != nil {
+			continue // total_managed_indices and other non-object keys
+		}
+		le := LifecycleExplain{
+			Index:               name,
+			Managed:             e.PolicyID != "",
+			Policy:              e.PolicyID,
+			Phase:               e.State.Name,
+			Action:              e.Action.Name,
+			Step:                e.Step.Name,
+			LifecycleDateMillis: e.IndexCreationDate,
+		}
+		if e.Action.Failed || e.Step.StepStatus == "failed" {
+			le.Failed = true
+			le.FailedStep = e.Step.Name
+			le.StepError = e.Info.Message
+		}
+		result[name] = le
+	}
+	return result, nil
+}
+
+// GetAllocationExplain asks the master why a specific shard copy is (or is
+// not) allocated, via POST /_cluster/allocation/explain.
+func (c *DefaultClient) GetAllocationExplain(ctx context.Context, index string, shard int, primary bool) (*AllocationExplain, error) {
+	if index == "" {
+		return nil, fmt.Errorf("GetAllocationExplain: index must not be empty")
+	}
+	reqBody, err := json.Marshal(map[string]any{
+		"index":   index,
+		"shard":   shard,
+		"primary": primary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAllocationExplain marshal: %w", err)
+	}
+	body, err := c.doPostJSON(ctx, endpointAllocExplain, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllocationExplain: %w", err)
+	}
+
+	var result AllocationExplain
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetAllocationExplain decode: %w", err)
+	}
+	return &result, nil
+}
+
+// doPostJSON performs a POST request with a JSON body to the given path
+// (relative to BaseURL) and returns the response body. It sets Content-Type
+// and Accept: application/json headers and Basic Auth if credentials are
+// configured. Returns an error on non-2xx status.
+func (c *DefaultClient) doPostJSON(ctx context.Context, path string, body []byte) ([]byte, error) {
+	urlStr := strings.TrimRight(c.config.BaseURL, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlStr, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	if c.config.Username != "" || c.config.Password != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	const maxResponseBytes = 32 * 1024 * 1024
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	if len(respBody) > maxResponseBytes {
+		return nil, fmt.Errorf("response body exceeds %d MB limit", maxResponseBytes/(1024*1024))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, truncate(respBody, 200))
+	}
+
+	return respBody, nil
+}
+
+// doPost performs a POST request without a body to the given path (relative
+// to BaseURL). It sets Accept: application/json and Basic Auth if credentials
+// are configured. Returns an error on non-2xx status.
+func (c *DefaultClient) doPost(ctx context.Context, path string) error {
+	urlStr := strings.TrimRight(c.config.BaseURL, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlStr, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.config.Username != "" || c.config.Password != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	const maxResponseBytes = 32 * 1024 * 1024
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	if len(respBody) > maxResponseBytes {
+		return fmt.Errorf("response body exceeds %d MB limit", maxResponseBytes/(1024*1024))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, truncate(respBody, 200))
+	}
+
+	return nil
+}
+
+// CancelTask requests cancellation of a running task via
+// POST /_tasks/<node:id>/_cancel. Child tasks are cancelled by ES along with
+// their parent.
+func (c *DefaultClient) CancelTask(ctx context.Context, taskID string) error {
+	if taskID == "" {
+		return fmt.Errorf("CancelTask: taskID must not be empty")
+	}
+	path := "/_tasks/" + url.PathEscape(taskID) + "/_cancel"
+	if err := c.doPost(ctx, path); err != nil {
+		return fmt.Errorf("CancelTask: %w", err)
+	}
+	return nil
+}
+
 // DeleteIndex deletes one or more indices by name.
 // Names are joined with commas into a single DELETE /<names> request.
 func (c *DefaultClient) DeleteIndex(ctx context.Context, names []string) error {
@@ -261,3 +1218,39 @@ func (c *DefaultClient) UpdateIndexSettings(ctx context.Context, names []string,
 	return nil
 }
 
+// GetIndexingPressure fetches per-node indexing pressure memory stats
+// (ES 7.9+). Returns a response with an empty node map when no node reports
+// them; older versions reject the metric and return an error.
+func (c *DefaultClient) GetIndexingPressure(ctx context.Context) (*IndexingPressureResponse, error) {
+	body, err := c.doGet(ctx, endpointIdxPressure)
+	if err != nil {
+		return nil, fmt.Errorf("GetIndexingPressure: %w", err)
+	}
+	var result IndexingPressureResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetIndexingPressure: decode: %w", err)
+	}
+	if result.Nodes == nil {
+		result.Nodes = map[string]NodeIndexingPressure{}
+	}
+	return &result, nil
+}
+
+// GetNodeVersions fetches the Elasticsearch version, build flavor and JVM
+// version of every node, keyed by node ID.
+func (c *DefaultClient) GetNodeVersions(ctx context.Context) (map[string]NodeVersion, error) {
+	body, err := c.doGet(ctx, endpointNodeVersions)
+	if err != nil {
+		return nil, fmt.Errorf("GetNodeVersions: %w", err)
+	}
+	var result struct {
+		Nodes map[string]NodeVersion `json:"nodes"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("GetNodeVersions: decode: %w", err)
+	}
+	if result.Nodes == nil {
+		result.Nodes = map[string]NodeVersion{}
+	}
+	return result.Nodes, nil
+}
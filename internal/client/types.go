@@ -1,5 +1,7 @@
 package client
 
+import "fmt"
+
 // ClusterHealth represents the response from /_cluster/health.
 type ClusterHealth struct {
 	ClusterName      string `json:"cluster_name"`
@@ -31,6 +33,7 @@ type NodePerformanceStats struct {
 	OS      *NodeOSStats      `json:"os,omitempty"`
 	JVM     *NodeJVMStats     `json:"jvm,omitempty"`
 	FS      *NodeFSStats      `json:"fs,omitempty"`
+	Process *NodeProcessStats `json:"process,omitempty"`
 }
 
 // NodeIndicesStats holds indexing and search counters for a node.
@@ -51,16 +54,36 @@ type NodeSearchStats struct {
 	QueryTimeInMillis int64 `json:"query_time_in_millis"`
 }
 
-// NodeOSStats holds OS-level metrics.
+// NodeOSStats holds OS-level metrics. LoadAverage is keyed by "1m", "5m"
+// and "15m" and is absent on Windows.
 type NodeOSStats struct {
 	CPU struct {
-		Percent int `json:"percent"`
+		Percent     int                `json:"percent"`
+		LoadAverage map[string]float64 `json:"load_average,omitempty"`
 	} `json:"cpu"`
+	Mem  NodeMemoryStats `json:"mem"`
+	Swap NodeMemoryStats `json:"swap"`
+}
+
+// NodeMemoryStats holds physical memory or swap space totals of a host.
+type NodeMemoryStats struct {
+	TotalInBytes int64 `json:"total_in_bytes"`
+	FreeInBytes  int64 `json:"free_in_bytes"`
+	UsedInBytes  int64 `json:"used_in_bytes"`
 }
 
-// NodeJVMStats holds JVM heap metrics.
+// NodeProcessStats holds the file descriptor usage of the node's process.
+// Both values are -1 on platforms that do not report them (Windows).
+type NodeProcessStats struct {
+	OpenFileDescriptors int64 `json:"open_file_descriptors"`
+	MaxFileDescriptors  int64 `json:"max_file_descriptors"`
+}
+
+// NodeJVMStats holds JVM heap metrics and the JVM uptime, which drops when
+// the node restarts.
 type NodeJVMStats struct {
-	Mem struct {
+	UptimeInMillis int64 `json:"uptime_in_millis"`
+	Mem            struct {
 		HeapUsedInBytes int64 `json:"heap_used_in_bytes"`
 		HeapMaxInBytes  int64 `json:"heap_max_in_bytes"`
 	} `json:"mem"`
@@ -82,6 +105,7 @@ type IndexInfo struct {
 	PriStoreSize string `json:"pri.store.size"`
 	StoreSize    string `json:"store.size"`
 	DocsCount    string `json:"docs.count"`
+	DocsDeleted  string `json:"docs.deleted"`
 }
 
 // IndexStatsResponse represents the response from /_stats.
@@ -126,6 +150,424 @@ type AllocationInfo struct {
 	DiskPercent string `json:"disk.percent"`
 }
 
+// PendingTask represents a single queued cluster-state update from
+// /_cluster/pending_tasks.
+type PendingTask struct {
+	InsertOrder       int64  `json:"insert_order"`
+	Priority          string `json:"priority"`
+	Source            string `json:"source"`
+	Executing         bool   `json:"executing"`
+	TimeInQueueMillis int64  `json:"time_in_queue_millis"`
+}
+
+// TaskInfo represents a single running task from /_tasks. With
+// group_by=parents, child tasks are nested under their parent.
+type TaskInfo struct {
+	Node               string     `json:"node"`
+	ID                 int64      `json:"id"`
+	Type               string     `json:"type"`
+	Action             string     `json:"action"`
+	Description        string     `json:"description"`
+	StartTimeInMillis  int64      `json:"start_time_in_millis"`
+	RunningTimeInNanos int64      `json:"running_time_in_nanos"`
+	Cancellable        bool       `json:"cancellable"`
+	Cancelled          bool       `json:"cancelled"`
+	Children           []TaskInfo `json:"children,omitempty"`
+}
+
+// TaskID returns the "<node>:<id>" identifier ES uses in task APIs.
+func (t TaskInfo) TaskID() string {
+	return fmt.Sprintf("%s:%d", t.Node, t.ID)
+}
+
+// ShardInfo represents a single shard copy from /_cat/shards.
+type ShardInfo struct {
+	Index            string `json:"index"`
+	Shard            string `json:"shard"`
+	PriRep           string `json:"prirep"` // "p" or "r"
+	State            string `json:"state"`  // STARTED, RELOCATING, INITIALIZING, UNASSIGNED
+	Docs             string `json:"docs"`   // null for unassigned shards
+	Store            string `json:"store"`  // bytes (bytes=b); null for unassigned shards
+	Node             string `json:"node"`   // null for unassigned shards
+	UnassignedReason string `json:"unassigned.reason"`
+}
+
+// RecoveryInfo represents a single active shard recovery from /_cat/recovery.
+// Percentages are reported as strings such as "42.5%".
+type RecoveryInfo struct {
+	Index          string `json:"index"`
+	Shard          string `json:"shard"`
+	Time           string `json:"time"`  // elapsed milliseconds (time=ms)
+	Type           string `json:"type"`  // peer, store, snapshot, existing_store, empty_store, local_shards
+	Stage          string `json:"stage"` // init, index, verify_index, translog, finalize, done
+	SourceNode     string `json:"source_node"`
+	TargetNode     string `json:"target_node"`
+	FilesPercent   string `json:"files_percent"`
+	BytesRecovered string `json:"bytes_recovered"`
+	BytesTotal     string `json:"bytes_total"`
+	BytesPercent   string `json:"bytes_percent"`
+}
+
+// SnapshotRepository is a registered snapshot repository from /_snapshot.
+type SnapshotRepository struct {
+	Name string
+	Type string // fs, s3, gcs, azure, url, ...
+}
+
+// SnapshotInfo represents a single snapshot from /_cat/snapshots/<repo>.
+type SnapshotInfo struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`      // IN_PROGRESS, SUCCESS, PARTIAL, FAILED, INCOMPATIBLE
+	StartEpoch       string `json:"start_epoch"` // seconds
+	EndEpoch         string `json:"end_epoch"`   // seconds; 0 while in progress
+	Duration         string `json:"duration"`    // milliseconds (time=ms)
+	Indices          string `json:"indices"`
+	SuccessfulShards string `json:"successful_shards"`
+	FailedShards     string `json:"failed_shards"`
+	TotalShards      string `json:"total_shards"`
+}
+
+// Template kinds reported in IndexTemplate.Kind.
+const (
+	TemplateComposable = "composable"
+	TemplateComponent  = "component"
+	TemplateLegacy     = "legacy"
+)
+
+// IndexTemplate is a composable, component or legacy template normalised
+// from _index_template, _component_template and _template.
+type IndexTemplate struct {
+	Name       string
+	Kind       string   // TemplateComposable, TemplateComponent or TemplateLegacy
+	Patterns   []string // index patterns; empty for component templates
+	Priority   int      // priority for composable templates, order for legacy ones
+	ComposedOf []string // component templates, composable only
+	DataStream bool     // composable template that creates data streams
+	Settings   TemplateSettings
+}
+
+// TemplateSettings holds the key index settings of a template or of a
+// simulated index. Empty strings mean the setting is not set.
+type TemplateSettings struct {
+	Shards          string
+	Replicas        string
+	LifecyclePolicy string // ILM policy, or the ISM policy on OpenSearch
+}
+
+// TemplateRef names a template and its patterns, as listed in the
+// overlapping section of a simulate response.
+type TemplateRef struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
+// SimulatedIndex is the result of POST _index_template/_simulate_index.
+type SimulatedIndex struct {
+	Settings    TemplateSettings
+	Overlapping []TemplateRef // lower-priority templates that also match
+}
+
+// ClusterSettings holds the cluster settings from
+// GET /_cluster/settings?include_defaults, flattened to dotted keys.
+type ClusterSettings struct {
+	Persistent map[string]string
+	Transient  map[string]string
+	Defaults   map[string]string
+}
+
+// Effective returns the value Elasticsearch applies for key (transient over
+// persistent over default) and which of the three it came from. source is
+// "" when the key is unknown.
+func (cs *ClusterSettings) Effective(key string) (value, source string) {
+	if v, ok := cs.Transient[key]; ok {
+		return v, "transient"
+	}
+	if v, ok := cs.Persistent[key]; ok {
+		return v, "persistent"
+	}
+	if v, ok := cs.Defaults[key]; ok {
+		return v, "default"
+	}
+	return "", ""
+}
+
+// DiskWatermark is one disk-based allocation watermark. Elasticsearch
+// accepts either a used-space ratio ("85%", "0.85") or an absolute amount of
+// free space ("50gb"); exactly one of Percent and FreeBytes is set.
+type DiskWatermark struct {
+	Percent     float64 // maximum used disk %; 0 when given in bytes
+	FreeBytes   int64   // minimum free bytes; 0 when given as a ratio
+	MaxHeadroom int64   // cap on the free space a ratio requires; 0 = none
+}
+
+// DiskWatermarks holds the effective cluster.routing.allocation.disk.*
+// settings.
+type DiskWatermarks struct {
+	ThresholdEnabled bool
+	Low              DiskWatermark
+	High             DiskWatermark
+	FloodStage       DiskWatermark
+}
+
+// DefaultDiskWatermarks returns Elasticsearch's default watermarks, assumed
+// when the cluster settings are unavailable.
+func DefaultDiskWatermarks() DiskWatermarks {
+	return DiskWatermarks{
+		ThresholdEnabled: true,
+		Low:              DiskWatermark{Percent: 85},
+		High:             DiskWatermark{Percent: 90},
+		FloodStage:       DiskWatermark{Percent: 95},
+	}
+}
+
+// DefaultTotalFieldsLimit is Elasticsearch's default for
+// index.mapping.total_fields.limit, assumed when the setting is not reported.
+const DefaultTotalFieldsLimit = 1000
+
+// MappingStats holds the number of mapped fields of one index as counted
+// against index.mapping.total_fields.limit: object fields, leaf fields,
+// multi-fields, field aliases and runtime fields.
+type MappingStats struct {
+	Index      string
+	FieldCount int
+	FieldLimit int
+}
+
+// AliasInfo represents one alias-to-index pair from GET /_cat/aliases.
+// IsWriteIndex is "true", "false", or "-" when not set explicitly.
+type AliasInfo struct {
+	Alias        string `json:"alias"`
+	Index        string `json:"index"`
+	IsWriteIndex string `json:"is_write_index"`
+}
+
+// NodeAttribute represents one custom node attribute from
+// GET /_cat/nodeattrs, e.g. node.attr.zone: us-east-1a.
+type NodeAttribute struct {
+	Node  string `json:"node"`
+	ID    string `json:"id"`
+	Attr  string `json:"attr"`
+	Value string `json:"value"`
+}
+
+// IngestStatsResponse represents the response from GET /_nodes/stats/ingest.
+type IngestStatsResponse struct {
+	Nodes map[string]NodeIngestStats `json:"nodes"`
+}
+
+// NodeIngestStats holds one node's ingest pipeline counters.
+type NodeIngestStats struct {
+	Name   string `json:"name"`
+	Ingest struct {
+		Pipelines map[string]PipelineStats `json:"pipelines"`
+	} `json:"ingest"`
+}
+
+// IngestCounters holds the cumulative counters ES keeps per pipeline and per
+// processor since the node started.
+type IngestCounters struct {
+	Count        int64 `json:"count"`
+	TimeInMillis int64 `json:"time_in_millis"`
+	Current      int64 `json:"current"`
+	Failed       int64 `json:"failed"`
+}
+
+// PipelineStats holds a pipeline's counters on one node. Processors lists the
+// pipeline's processors in order, each as a single-key object keyed by the
+// processor type and optional tag, e.g. "grok:parse-message".
+type PipelineStats struct {
+	IngestCounters
+	Processors []map[string]ProcessorStats `json:"processors"`
+}
+
+// ProcessorStats holds one processor's counters within a pipeline.
+type ProcessorStats struct {
+	Type  string         `json:"type"`
+	Stats IngestCounters `json:"stats"`
+}
+
+// RemoteInfo describes one remote cluster from GET /_remote/info. Sniff mode
+// reports NumNodesConnected; proxy mode reports NumProxySocketsConnected.
+type RemoteInfo struct {
+	Connected                bool     `json:"connected"`
+	Mode                     string   `json:"mode"`
+	Seeds                    []string `json:"seeds"`
+	ProxyAddress             string   `json:"proxy_address"`
+	NumNodesConnected        int      `json:"num_nodes_connected"`
+	NumProxySocketsConnected int      `json:"num_proxy_sockets_connected"`
+	SkipUnavailable          bool     `json:"skip_unavailable"`
+}
+
+// CCRStatsResponse represents the response from GET /_ccr/stats.
+type CCRStatsResponse struct {
+	FollowStats struct {
+		Indices []FollowIndexStats `json:"indices"`
+	} `json:"follow_stats"`
+}
+
+// FollowIndexStats holds the per-shard replication state of one follower
+// index.
+type FollowIndexStats struct {
+	Index  string             `json:"index"`
+	Shards []FollowShardStats `json:"shards"`
+}
+
+// FollowShardStats holds one follower shard's position relative to its
+// leader shard.
+type FollowShardStats struct {
+	RemoteCluster            string        `json:"remote_cluster"`
+	LeaderIndex              string        `json:"leader_index"`
+	FollowerIndex            string        `json:"follower_index"`
+	ShardID                  int           `json:"shard_id"`
+	LeaderGlobalCheckpoint   int64         `json:"leader_global_checkpoint"`
+	FollowerGlobalCheckpoint int64         `json:"follower_global_checkpoint"`
+	TimeSinceLastReadMillis  int64         `json:"time_since_last_read_millis"`
+	ReadExceptions           []any         `json:"read_exceptions"`
+	FatalException           *CCRException `json:"fatal_exception,omitempty"`
+}
+
+// CCRException is an error reported by cross-cluster replication.
+type CCRException struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// DataStreamsResponse represents the response from GET /_data_stream.
+type DataStreamsResponse struct {
+	DataStreams []DataStream `json:"data_streams"`
+}
+
+// DataStream is one data stream from GET /_data_stream. Indices lists the
+// backing indices oldest generation first; the last one is the write index.
+type DataStream struct {
+	Name       string            `json:"name"`
+	Generation int               `json:"generation"`
+	Status     string            `json:"status"`
+	Template   string            `json:"template"`
+	ILMPolicy  string            `json:"ilm_policy"`
+	Hidden     bool              `json:"hidden"`
+	Indices    []DataStreamIndex `json:"indices"`
+}
+
+// DataStreamIndex is one backing index of a data stream.
+type DataStreamIndex struct {
+	IndexName string `json:"index_name"`
+}
+
+// LifecycleExplain is the lifecycle state of one index, normalised from
+// Elasticsearch ILM (_ilm/explain) or OpenSearch ISM (_plugins/_ism/explain).
+// For ISM, Phase holds the state name.
+type LifecycleExplain struct {
+	Index               string
+	Managed             bool
+	Policy              string
+	Phase               string
+	Action              string
+	Step                string
+	LifecycleDateMillis int64  // creation or rollover time the phase ages count from; 0 = unknown
+	Failed              bool   // true while stuck in an ERROR (ILM) or failed (ISM) step
+	FailedStep          string // step that failed
+	StepError           string // failure reason reported by the cluster
+}
+
+// ilmExplainEntry is one index entry of an _ilm/explain response.
+type ilmExplainEntry struct {
+	Managed             bool        `json:"managed"`
+	Policy              string      `json:"policy"`
+	Phase               string      `json:"phase"`
+	Action              string      `json:"action"`
+	Step                string      `json:"step"`
+	LifecycleDateMillis int64       `json:"lifecycle_date_millis"`
+	FailedStep          string      `json:"failed_step"`
+	StepInfo            ilmStepInfo `json:"step_info"`
+}
+
+// ilmStepInfo carries the failure details of an ILM step in ERROR.
+type ilmStepInfo struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// summary returns the most descriptive reason available.
+func (s ilmStepInfo) summary() string {
+	switch {
+	case s.Reason != "" && s.Type != "":
+		return s.Type + ": " + s.Reason
+	case s.Reason != "":
+		return s.Reason
+	case s.Message != "":
+		return s.Message
+	default:
+		return s.Type
+	}
+}
+
+// ismExplainEntry is one index entry of a _plugins/_ism/explain response.
+type ismExplainEntry struct {
+	PolicyID          string `json:"policy_id"`
+	IndexCreationDate int64  `json:"index_creation_date"`
+	State             struct {
+		Name string `json:"name"`
+	} `json:"state"`
+	Action struct {
+		Name   string `json:"name"`
+		Failed bool   `json:"failed"`
+	} `json:"action"`
+	Step struct {
+		Name       string `json:"name"`
+		StepStatus string `json:"step_status"`
+	} `json:"step"`
+	Info struct {
+		Message string `json:"message"`
+	} `json:"info"`
+}
+
+// AllocationExplain represents the response from /_cluster/allocation/explain.
+type AllocationExplain struct {
+	Index                   string                   `json:"index"`
+	Shard                   int                      `json:"shard"`
+	Primary                 bool                     `json:"primary"`
+	CurrentState            string                   `json:"current_state"`
+	CurrentNode             *AllocationExplainNode   `json:"current_node,omitempty"`
+	UnassignedInfo          *UnassignedInfo          `json:"unassigned_info,omitempty"`
+	CanAllocate             string                   `json:"can_allocate"`
+	AllocateExplanation     string                   `json:"allocate_explanation"`
+	CanRemainOnCurrentNode  string                   `json:"can_remain_on_current_node"`
+	CanRebalanceCluster     string                   `json:"can_rebalance_cluster"`
+	NodeAllocationDecisions []NodeAllocationDecision `json:"node_allocation_decisions"`
+}
+
+// AllocationExplainNode identifies the node currently holding an assigned shard.
+type AllocationExplainNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UnassignedInfo describes why and since when a shard copy is unassigned.
+type UnassignedInfo struct {
+	Reason               string `json:"reason"`
+	At                   string `json:"at"`
+	LastAllocationStatus string `json:"last_allocation_status"`
+	Details              string `json:"details"`
+}
+
+// NodeAllocationDecision is the allocation verdict for one candidate node.
+type NodeAllocationDecision struct {
+	NodeID        string              `json:"node_id"`
+	NodeName      string              `json:"node_name"`
+	NodeDecision  string              `json:"node_decision"` // yes, no, throttled, worse_balance, ...
+	WeightRanking int                 `json:"weight_ranking"`
+	Deciders      []AllocationDecider `json:"deciders"`
+}
+
+// AllocationDecider is a single allocation decider's verdict for a node.
+type AllocationDecider struct {
+	Decider     string `json:"decider"`
+	Decision    string `json:"decision"` // YES, NO, THROTTLE
+	Explanation string `json:"explanation"`
+}
+
 // IndexAllocationFilter holds the _name and _ip filter values for a routing allocation filter.
 // All values are strings as returned by the ES settings API.
 type IndexAllocationFilter struct {
@@ -170,3 +612,51 @@ type IndexSettingsValues struct {
 	Mapping          IndexMappingSettings `json:"mapping,omitempty"`
 	Blocks           IndexBlocksSettings  `json:"blocks,omitempty"`
 }
+
+// IndexingPressureResponse represents the response from
+// GET /_nodes/stats/indexing_pressure.
+type IndexingPressureResponse struct {
+	Nodes map[string]NodeIndexingPressure `json:"nodes"`
+}
+
+// NodeIndexingPressure holds one node's indexing pressure stats.
+type NodeIndexingPressure struct {
+	Name             string `json:"name"`
+	IndexingPressure struct {
+		Memory IndexingPressureMemory `json:"memory"`
+	} `json:"indexing_pressure"`
+}
+
+// IndexingPressureMemory holds the bytes of in-flight indexing requests and
+// the cumulative rejections since the node started. LimitInBytes is the
+// indexing_pressure.memory.limit; it is 0 on versions that do not report it.
+type IndexingPressureMemory struct {
+	Current struct {
+		CombinedCoordinatingAndPrimaryInBytes int64 `json:"combined_coordinating_and_primary_in_bytes"`
+		ReplicaInBytes                        int64 `json:"replica_in_bytes"`
+		AllInBytes                            int64 `json:"all_in_bytes"`
+	} `json:"current"`
+	Total struct {
+		CoordinatingRejections int64 `json:"coordinating_rejections"`
+		PrimaryRejections      int64 `json:"primary_rejections"`
+		ReplicaRejections      int64 `json:"replica_rejections"`
+	} `json:"total"`
+	LimitInBytes int64 `json:"limit_in_bytes"`
+}
+
+// Rejections returns the coordinating, primary and replica rejections summed.
+func (m IndexingPressureMemory) Rejections() int64 {
+	return m.Total.CoordinatingRejections + m.Total.PrimaryRejections + m.Total.ReplicaRejections
+}
+
+// NodeVersion holds a node's software versions from GET /_nodes/jvm.
+// BuildFlavor is "default" or "oss" and is empty on OpenSearch and on
+// Elasticsearch 8+, which no longer reports it.
+type NodeVersion struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	BuildFlavor string `json:"build_flavor"`
+	JVM         struct {
+		Version string `json:"version"`
+	} `json:"jvm"`
+}
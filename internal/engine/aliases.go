@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// aliasWriteIndices returns the write index of each alias. An index flagged
+// is_write_index=true is the write index; an alias over a single index with
+// no explicit flag writes to that index implicitly. Aliases over several
+// indices without a flagged one have no write index and are omitted.
+func aliasWriteIndices(aliases []client.AliasInfo) map[string]string {
+	indices := make(map[string][]client.AliasInfo)
+	for _, a := range aliases {
+		indices[a.Alias] = append(indices[a.Alias], a)
+	}
+	result := make(map[string]string)
+	for alias, entries := range indices {
+		for _, e := range entries {
+			if e.IsWriteIndex == "true" {
+				result[alias] = e.Index
+			}
+		}
+		if _, ok := result[alias]; !ok && len(entries) == 1 && entries[0].IsWriteIndex != "false" {
+			result[alias] = entries[0].Index
+		}
+	}
+	return result
+}
+
+// applyAliases sets Aliases and WriteAliases on every row from snap.Aliases.
+func applyAliases(rows []model.IndexRow, aliases []client.AliasInfo) {
+	if len(aliases) == 0 {
+		return
+	}
+	writeIndex := aliasWriteIndices(aliases)
+	byIndex := make(map[string][]string)
+	for _, a := range aliases {
+		byIndex[a.Index] = append(byIndex[a.Index], a.Alias)
+	}
+	for i := range rows {
+		names := byIndex[rows[i].Name]
+		if len(names) == 0 {
+			continue
+		}
+		sort.Strings(names)
+		rows[i].Aliases = names
+		for _, alias := range names {
+			if writeIndex[alias] == rows[i].Name {
+				rows[i].WriteAliases = append(rows[i].WriteAliases, alias)
+			}
+		}
+	}
+}
+
+// CalcAliasRows aggregates indexRows into one row per alias by summing
+// sizes, document counts and rates of the indices behind it. Indices missing
+// from indexRows are listed but contribute nothing. Rates skip indices
+// without a rate and are MetricNotAvailable when none has one. Rows are
+// sorted by alias name.
+func CalcAliasRows(aliases []client.AliasInfo, indexRows []model.IndexRow) []model.AliasRow {
+	if len(aliases) == 0 {
+		return nil
+	}
+	byName := make(map[string]model.IndexRow, len(indexRows))
+	for _, r := range indexRows {
+		byName[r.Name] = r
+	}
+	writeIndex := aliasWriteIndices(aliases)
+
+	rowsByAlias := make(map[string]*model.AliasRow)
+	var names []string
+	for _, a := range aliases {
+		row, ok := rowsByAlias[a.Alias]
+		if !ok {
+			row = &model.AliasRow{
+				Name:         a.Alias,
+				WriteIndex:   writeIndex[a.Alias],
+				IndexingRate: model.MetricNotAvailable,
+				SearchRate:   model.MetricNotAvailable,
+			}
+			rowsByAlias[a.Alias] = row
+			names = append(names, a.Alias)
+		}
+		row.Indices = append(row.Indices, a.Index)
+		if idx, ok := byName[a.Index]; ok {
+			row.TotalSizeBytes += idx.TotalSizeBytes
+			row.DocCount += idx.DocCount
+			row.IndexingRate = addRate(row.IndexingRate, idx.IndexingRate)
+			row.SearchRate = addRate(row.SearchRate, idx.SearchRate)
+		}
+	}
+
+	sort.Strings(names)
+	rows := make([]model.AliasRow, 0, len(names))
+	for _, name := range names {
+		row := rowsByAlias[name]
+		sort.Strings(row.Indices)
+		rows = append(rows, *row)
+	}
+	return rows
+}
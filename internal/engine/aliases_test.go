@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestAliasWriteIndices(t *testing.T) {
+	got := aliasWriteIndices([]client.AliasInfo{
+		{Alias: "logs", Index: "logs-000001", IsWriteIndex: "false"},
+		{Alias: "logs", Index: "logs-000002", IsWriteIndex: "true"},
+		{Alias: "single", Index: "orders", IsWriteIndex: "-"},
+		{Alias: "readonly", Index: "archive", IsWriteIndex: "false"},
+		{Alias: "search-all", Index: "a", IsWriteIndex: "-"},
+		{Alias: "search-all", Index: "b", IsWriteIndex: "-"},
+	})
+	assert.Equal(t, map[string]string{
+		"logs":   "logs-000002",
+		"single": "orders",
+	}, got)
+}
+
+func TestCalcIndexRows_AppliesAliases(t *testing.T) {
+	curr := &model.Snapshot{
+		Indices: []client.IndexInfo{
+			{Index: "logs-000001", Pri: "1", Rep: "0", DocsCount: "1"},
+			{Index: "logs-000002", Pri: "1", Rep: "0", DocsCount: "1"},
+			{Index: "plain", Pri: "1", Rep: "0", DocsCount: "1"},
+		},
+		Aliases: []client.AliasInfo{
+			{Alias: "logs", Index: "logs-000001", IsWriteIndex: "false"},
+			{Alias: "logs", Index: "logs-000002", IsWriteIndex: "true"},
+			{Alias: "all", Index: "logs-000002", IsWriteIndex: "-"},
+			{Alias: "all", Index: "plain", IsWriteIndex: "-"},
+		},
+	}
+	rows := CalcIndexRows(nil, curr, 10*time.Second)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"logs"}, rows[0].Aliases)
+	assert.Empty(t, rows[0].WriteAliases)
+	assert.Equal(t, []string{"all", "logs"}, rows[1].Aliases)
+	assert.Equal(t, []string{"logs"}, rows[1].WriteAliases)
+	assert.Equal(t, []string{"all"}, rows[2].Aliases)
+	assert.Empty(t, rows[2].WriteAliases)
+}
+
+func TestCalcAliasRows(t *testing.T) {
+	aliases := []client.AliasInfo{
+		{Alias: "logs", Index: "logs-000002", IsWriteIndex: "true"},
+		{Alias: "logs", Index: "logs-000001", IsWriteIndex: "false"},
+		{Alias: "archive", Index: "closed-idx", IsWriteIndex: "-"},
+	}
+	indexRows := []model.IndexRow{
+		{Name: "logs-000001", TotalSizeBytes: 100, DocCount: 10, IndexingRate: model.MetricNotAvailable, SearchRate: 2},
+		{Name: "logs-000002", TotalSizeBytes: 200, DocCount: 20, IndexingRate: 5, SearchRate: 3},
+	}
+	rows := CalcAliasRows(aliases, indexRows)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "archive", rows[0].Name, "sorted by alias")
+	assert.Equal(t, model.MetricNotAvailable, rows[0].IndexingRate)
+	assert.Equal(t, "closed-idx", rows[0].WriteIndex)
+
+	logs := rows[1]
+	assert.Equal(t, []string{"logs-000001", "logs-000002"}, logs.Indices)
+	assert.Equal(t, "logs-000002", logs.WriteIndex)
+	assert.Equal(t, int64(300), logs.TotalSizeBytes)
+	assert.Equal(t, int64(30), logs.DocCount)
+	assert.Equal(t, 5.0, logs.IndexingRate)
+	assert.Equal(t, 5.0, logs.SearchRate)
+
+	assert.Nil(t, CalcAliasRows(nil, indexRows))
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// backgroundTimeout bounds one background fetch. It is independent of the
+// poll interval because background endpoints are the slow ones.
+const backgroundTimeout = 30 * time.Second
+
+// mappingStatsInterval is how often the full mapping and the field limits
+// are fetched. Both responses grow with the number of indices and fields, and
+// field counts change slowly.
+const mappingStatsInterval = 5 * time.Minute
+
+// shardsInterval is how often the shard copies of the whole cluster are
+// fetched for the zone placement and rolling upgrade checks. The Shards and
+// Unassigned Shards views load their own copy on demand.
+const shardsInterval = time.Minute
+
+// snapshotReposInterval is how often the snapshot repositories and their
+// snapshots are listed. Listing a blob store repository is slow, and
+// snapshots are usually taken hours apart.
+const snapshotReposInterval = 5 * time.Minute
+
+// lifecycleInterval is how often the ILM/ISM explain state of every index is
+// fetched. The response lists every index, and lifecycle steps advance on the
+// order of minutes.
+const lifecycleInterval = time.Minute
+
+// dataStreamsInterval is how often data streams and their backing indices are
+// listed. Streams only gain a backing index on rollover.
+const dataStreamsInterval = time.Minute
+
+// aliasesInterval is how often the alias-to-index mapping is listed. Aliases
+// move on rollover, which is minutes apart at the most.
+const aliasesInterval = time.Minute
+
+// diskWatermarksInterval is how often the effective disk watermarks are read
+// from the cluster settings. They change only when an operator edits them.
+const diskWatermarksInterval = time.Minute
+
+// nodeAttributesInterval is how often the custom node attributes are listed.
+// Attributes are set in elasticsearch.yml and only change on a node restart.
+const nodeAttributesInterval = time.Minute
+
+// remoteInfoInterval is how often the remote cluster connections are listed.
+// A remote that drops out also shows as stalled followers in the meantime.
+const remoteInfoInterval = time.Minute
+
+// nodeVersionsInterval is how often the node versions are read. A version
+// only changes when a node restarts on a new build.
+const nodeVersionsInterval = time.Minute
+
+// backgroundFetch is an optional endpoint too heavy to request every poll.
+// The Poller refreshes it on its own interval in a goroutine of its own, so
+// it never delays or fails a poll, and copies its last result into every
+// snapshot. All fields but interval and fetch are guarded by Poller.mu.
+type backgroundFetch struct {
+	interval time.Duration
+	fetch    func(ctx context.Context, c client.ESClient) func(*model.Snapshot)
+
+	running bool
+	started time.Time
+	apply   func(*model.Snapshot) // sets the last result; nil until the first fetch completes
+}
+
+// newBackgroundFetch returns a backgroundFetch that calls fn every interval
+// and stores its result on each snapshot with set. A failed fetch stores the
+// zero value, like a failed optional endpoint in FetchAll.
+func newBackgroundFetch[T any](interval time.Duration, fn func(context.Context, client.ESClient) (T, error), set func(*model.Snapshot, T)) *backgroundFetch {
+	return &backgroundFetch{
+		interval: interval,
+		fetch: func(ctx context.Context, c client.ESClient) func(*model.Snapshot) {
+			v, err := fn(ctx, c)
+			if err != nil {
+				var zero T
+				v = zero
+			}
+			return func(s *model.Snapshot) { set(s, v) }
+		},
+	}
+}
+
+// defaultBackgroundFetches returns the background fetches every Poller runs.
+func defaultBackgroundFetches() []*backgroundFetch {
+	return []*backgroundFetch{
+		newBackgroundFetch(mappingStatsInterval,
+			func(ctx context.Context, c client.ESClient) (map[string]client.MappingStats, error) {
+				return c.GetMappingStats(ctx)
+			},
+			func(s *model.Snapshot, v map[string]client.MappingStats) { s.Mappings = v }),
+		newBackgroundFetch(shardsInterval,
+			func(ctx context.Context, c client.ESClient) ([]client.ShardInfo, error) {
+				return c.GetShards(ctx)
+			},
+			func(s *model.Snapshot, v []client.ShardInfo) { s.Shards = v }),
+		newBackgroundFetch(snapshotReposInterval, FetchSnapshotRepos,
+			func(s *model.Snapshot, v []model.RepositorySnapshots) { s.SnapshotRepos = v }),
+		newBackgroundFetch(lifecycleInterval,
+			func(ctx context.Context, c client.ESClient) (map[string]client.LifecycleExplain, error) {
+				return c.GetLifecycleExplain(ctx)
+			},
+			func(s *model.Snapshot, v map[string]client.LifecycleExplain) { s.Lifecycle = v }),
+		newBackgroundFetch(dataStreamsInterval,
+			func(ctx context.Context, c client.ESClient) ([]client.DataStream, error) {
+				return c.GetDataStreams(ctx)
+			},
+			func(s *model.Snapshot, v []client.DataStream) { s.DataStreams = v }),
+		newBackgroundFetch(aliasesInterval,
+			func(ctx context.Context, c client.ESClient) ([]client.AliasInfo, error) {
+				return c.GetAliases(ctx)
+			},
+			func(s *model.Snapshot, v []client.AliasInfo) { s.Aliases = v }),
+		newBackgroundFetch(diskWatermarksInterval,
+			func(ctx context.Context, c client.ESClient) (*client.DiskWatermarks, error) {
+				return c.GetDiskWatermarks(ctx)
+			},
+			func(s *model.Snapshot, v *client.DiskWatermarks) { s.DiskWatermarks = v }),
+		newBackgroundFetch(nodeAttributesInterval,
+			func(ctx context.Context, c client.ESClient) ([]client.NodeAttribute, error) {
+				return c.GetNodeAttributes(ctx)
+			},
+			func(s *model.Snapshot, v []client.NodeAttribute) { s.NodeAttributes = v }),
+		newBackgroundFetch(remoteInfoInterval,
+			func(ctx context.Context, c client.ESClient) (map[string]client.RemoteInfo, error) {
+				return c.GetRemoteInfo(ctx)
+			},
+			func(s *model.Snapshot, v map[string]client.RemoteInfo) { s.Remotes = v }),
+		newBackgroundFetch(nodeVersionsInterval,
+			func(ctx context.Context, c client.ESClient) (map[string]client.NodeVersion, error) {
+				return c.GetNodeVersions(ctx)
+			},
+			func(s *model.Snapshot, v map[string]client.NodeVersion) { s.NodeVersions = v }),
+	}
+}
+
+// startBackground starts every background fetch that is due and not already
+// running. The fetches use ctx, so they stop when polling does.
+func (p *Poller) startBackground(ctx context.Context, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, bf := range p.background {
+		if bf.running || (!bf.started.IsZero() && now.Sub(bf.started) < bf.interval) {
+			continue
+		}
+		bf.running = true
+		bf.started = now
+		go func(bf *backgroundFetch) {
+			fetchCtx, cancel := context.WithTimeout(ctx, backgroundTimeout)
+			defer cancel()
+			apply := bf.fetch(fetchCtx, p.client)
+			p.mu.Lock()
+			bf.apply = apply
+			bf.running = false
+			p.mu.Unlock()
+		}(bf)
+	}
+}
+
+// applyBackground copies the last result of every background fetch into snap.
+func (p *Poller) applyBackground(snap *model.Snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, bf := range p.background {
+		if bf.apply != nil {
+			bf.apply(snap)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// TestPoller_BackgroundEndpoints checks that each background endpoint is left
+// out of FetchAll, reaches the snapshot through Poll, and is requested once
+// per interval.
+func TestPoller_BackgroundEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(calls *atomic.Int32) *MockESClient
+		fetched func(*model.Snapshot) bool
+	}{
+		{
+			name: "lifecycle explain",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{LifecycleFn: func(_ context.Context) (map[string]client.LifecycleExplain, error) {
+					calls.Add(1)
+					return map[string]client.LifecycleExplain{"test-index": {Index: "test-index", Managed: true}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.Lifecycle != nil },
+		},
+		{
+			name: "data streams",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{DataStreamsFn: func(_ context.Context) ([]client.DataStream, error) {
+					calls.Add(1)
+					return []client.DataStream{{Name: "logs-app"}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.DataStreams != nil },
+		},
+		{
+			name: "aliases",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{AliasesFn: func(_ context.Context) ([]client.AliasInfo, error) {
+					calls.Add(1)
+					return []client.AliasInfo{{Alias: "logs", Index: "logs-000001"}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.Aliases != nil },
+		},
+		{
+			name: "disk watermarks",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{DiskWatermarksFn: func(_ context.Context) (*client.DiskWatermarks, error) {
+					calls.Add(1)
+					return &client.DiskWatermarks{}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.DiskWatermarks != nil },
+		},
+		{
+			name: "node attributes",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{NodeAttributesFn: func(_ context.Context) ([]client.NodeAttribute, error) {
+					calls.Add(1)
+					return []client.NodeAttribute{{Node: "node1", Attr: "zone", Value: "z1"}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.NodeAttributes != nil },
+		},
+		{
+			name: "remote info",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{RemoteInfoFn: func(_ context.Context) (map[string]client.RemoteInfo, error) {
+					calls.Add(1)
+					return map[string]client.RemoteInfo{"prod": {Connected: true}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.Remotes != nil },
+		},
+		{
+			name: "node versions",
+			mock: func(calls *atomic.Int32) *MockESClient {
+				return &MockESClient{NodeVersionsFn: func(_ context.Context) (map[string]client.NodeVersion, error) {
+					calls.Add(1)
+					return map[string]client.NodeVersion{"node1": {Version: "8.12.0"}}, nil
+				}}
+			},
+			fetched: func(s *model.Snapshot) bool { return s.NodeVersions != nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			mc := tt.mock(&calls)
+
+			snap, err := FetchAll(context.Background(), mc)
+			require.NoError(t, err)
+			assert.False(t, tt.fetched(snap), "FetchAll leaves the field to the background fetch")
+			assert.Zero(t, calls.Load(), "FetchAll does not request the endpoint")
+
+			p := NewPoller(mc, 10*time.Second, DefaultRecommendationConfig())
+			var r PollResult
+			require.Eventually(t, func() bool {
+				r = p.Poll(context.Background())
+				return tt.fetched(r.Snapshot)
+			}, time.Second, 10*time.Millisecond)
+			r = p.Poll(context.Background())
+			assert.True(t, tt.fetched(r.Snapshot), "the last result is kept between fetches")
+			assert.Equal(t, int32(1), calls.Load(), "the endpoint is not due again within the interval")
+		})
+	}
+}
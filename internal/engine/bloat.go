@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// bloatMinSizeBytes is the store size below which an index is never
+	// flagged for deleted documents; merging a small index reclaims little.
+	bloatMinSizeBytes = 1 << 30
+)
+
+// DeletedDocsPercent returns row's deleted documents as a percentage of all
+// documents still held in its segments (live plus deleted), or -1 when
+// docs.deleted is unavailable.
+func DeletedDocsPercent(row model.IndexRow) float64 {
+	if !row.DeletedKnown || !row.DocCountKnown {
+		return -1
+	}
+	total := row.DocCount + row.DocsDeleted
+	if total <= 0 {
+		return 0
+	}
+	return float64(row.DocsDeleted) / float64(total) * 100
+}
+
+// ReclaimableBytes estimates the store size, replicas included, that merging
+// away row's deleted documents would free, assuming deleted documents are
+// the same size as live ones.
+func ReclaimableBytes(row model.IndexRow) int64 {
+	pct := DeletedDocsPercent(row)
+	if pct <= 0 {
+		return 0
+	}
+	return int64(float64(row.TotalSizeBytes) * pct / 100)
+}
+
+// deletedDocsRecs flags indices of at least bloatMinSizeBytes whose deleted
+// documents exceed thresholdPct percent. Deleted documents keep using disk,
+// page cache and heap until their segments are merged, which Lucene only
+// does eagerly for small segments. A zero thresholdPct disables the check.
+func deletedDocsRecs(indexRows []model.IndexRow, thresholdPct float64) []model.Recommendation {
+	if thresholdPct <= 0 {
+		return nil
+	}
+	var bloated []model.IndexRow
+	var reclaimable int64
+	for _, idx := range indexRows {
+		if idx.TotalSizeBytes >= bloatMinSizeBytes && DeletedDocsPercent(idx) > thresholdPct {
+			bloated = append(bloated, idx)
+			reclaimable += ReclaimableBytes(idx)
+		}
+	}
+	if len(bloated) == 0 {
+		return nil
+	}
+	sort.Slice(bloated, func(i, j int) bool {
+		ri, rj := ReclaimableBytes(bloated[i]), ReclaimableBytes(bloated[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return bloated[i].Name < bloated[j].Name
+	})
+
+	names := nameList(bloated, func(idx model.IndexRow) string {
+		return fmt.Sprintf("%s (%.0f%% deleted, ~%s)", idx.Name, DeletedDocsPercent(idx), format.FormatBytes(ReclaimableBytes(idx)))
+	})
+	return []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryIndexConfig,
+		Title:    "Deleted documents bloat",
+		Detail: fmt.Sprintf("%d index(es) have more than %.0f%% deleted documents, roughly %s reclaimable: %s. Deleted documents keep using disk, cache and heap until their segments merge. Run POST <index>/_forcemerge?only_expunge_deletes=true off-peak, or lower index.merge.policy.deletes_pct_allowed on update-heavy indices.",
+			len(bloated), thresholdPct, format.FormatBytes(reclaimable), names),
+	}}
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestCalcIndexRows_DocsDeleted(t *testing.T) {
+	snap := &model.Snapshot{Indices: []client.IndexInfo{
+		{Index: "users", Pri: "1", Rep: "1", DocsCount: "600", DocsDeleted: "400"},
+		{Index: "closed", Pri: "1", Rep: "1", DocsCount: "-", DocsDeleted: "-"},
+	}}
+	rows := CalcIndexRows(nil, snap, 0)
+	require.Len(t, rows, 2)
+	byName := map[string]model.IndexRow{rows[0].Name: rows[0], rows[1].Name: rows[1]}
+	assert.Equal(t, int64(400), byName["users"].DocsDeleted)
+	assert.True(t, byName["users"].DeletedKnown)
+	assert.False(t, byName["closed"].DeletedKnown)
+}
+
+func TestDeletedDocsPercent(t *testing.T) {
+	row := model.IndexRow{DocCount: 600, DocsDeleted: 400, DocCountKnown: true, DeletedKnown: true, TotalSizeBytes: 10 << 30}
+	assert.InDelta(t, 40.0, DeletedDocsPercent(row), 1e-9)
+	assert.Equal(t, int64(4<<30), ReclaimableBytes(row))
+
+	assert.Equal(t, -1.0, DeletedDocsPercent(model.IndexRow{DocCountKnown: true}), "docs.deleted unavailable")
+	assert.Equal(t, 0.0, DeletedDocsPercent(model.IndexRow{DocCountKnown: true, DeletedKnown: true}), "empty index")
+	assert.Equal(t, int64(0), ReclaimableBytes(model.IndexRow{TotalSizeBytes: 10 << 30}))
+}
+
+func TestDeletedDocsRecs(t *testing.T) {
+	idx := func(name string, live, deleted, size int64) model.IndexRow {
+		return model.IndexRow{Name: name, DocCount: live, DocsDeleted: deleted, DocCountKnown: true, DeletedKnown: true, TotalSizeBytes: size}
+	}
+	rows := []model.IndexRow{
+		idx("users", 500, 500, 8<<30),   // 50%, 4 GB reclaimable
+		idx("orders", 700, 300, 10<<30), // 30%, 3 GB reclaimable
+		idx("logs", 900, 100, 100<<30),  // 10%, below threshold
+		idx("tiny", 100, 900, 100<<20),  // too small to matter
+	}
+
+	assert.Empty(t, deletedDocsRecs(rows, 0), "zero threshold disables the check")
+
+	recs := deletedDocsRecs(rows, 25)
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, model.CategoryIndexConfig, recs[0].Category)
+	assert.Equal(t, "Deleted documents bloat", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "2 index(es) have more than 25% deleted documents, roughly 7.0 GB reclaimable")
+	assert.Contains(t, recs[0].Detail, "users (50% deleted, ~4.0 GB), orders (30% deleted, ~3.0 GB)")
+	assert.NotContains(t, recs[0].Detail, "tiny")
+}
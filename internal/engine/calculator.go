@@ -15,11 +15,13 @@ import (
 //
 // Role and IP are looked up from curr.Nodes by matching on node name.
 // Shards and DiskPercent are populated from curr.Allocation keyed by node name.
-// Nodes present in curr but not in prev get zero rates.
+// Nodes present in curr but not in prev get zero rates. Rates are skipped
+// when either snapshot holds stale node stats.
 func CalcNodeRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.NodeRow {
 	if curr == nil {
 		return nil
 	}
+	prev = deltaBaseline(prev, curr, model.SectionNodeStats)
 
 	// Build name → NodeInfo lookup from _cat/nodes endpoint.
 	nameToNode := make(map[string]client.NodeInfo, len(curr.Nodes))
@@ -37,8 +39,22 @@ func CalcNodeRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.Nod
 		nameToAlloc[a.Node] = a
 	}
 
+	// Build name → custom attributes lookup from _cat/nodeattrs.
+	nameToAttrs := make(map[string]map[string]string)
+	for _, a := range curr.NodeAttributes {
+		if a.Node == "" || a.Attr == "" {
+			continue
+		}
+		if nameToAttrs[a.Node] == nil {
+			nameToAttrs[a.Node] = make(map[string]string)
+		}
+		nameToAttrs[a.Node][a.Attr] = a.Value
+	}
+
 	elapsedSec := elapsed.Seconds()
 	enoughTime := prev != nil && elapsedSec >= minTimeDiffSeconds
+	watermarks := EffectiveWatermarks(curr)
+	restarted := restartedNodes(prev, curr)
 
 	rows := make([]model.NodeRow, 0, len(curr.NodeStats.Nodes))
 	for nodeID, node := range curr.NodeStats.Nodes {
@@ -53,6 +69,15 @@ func CalcNodeRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.Nod
 			row.IP = info.IP
 		}
 
+		row.Attributes = nameToAttrs[node.Name]
+		row.Roles = node.Roles
+
+		// Populate CPU from OS stats.
+		row.CPUPercent = -1.0
+		if node.OS != nil {
+			row.CPUPercent = float64(node.OS.CPU.Percent)
+		}
+
 		// Populate heap bytes from JVM stats (zero when JVM data is absent).
 		if node.JVM != nil {
 			row.HeapMaxBytes = node.JVM.Mem.HeapMaxInBytes
@@ -76,7 +101,22 @@ func CalcNodeRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.Nod
 			row.DiskPercent = -1.0
 		}
 
-		if enoughTime {
+		// Evaluate free space against the disk watermarks.
+		if node.FS != nil {
+			row.DiskTotalBytes = node.FS.Total.TotalInBytes
+			row.DiskAvailBytes = node.FS.Total.AvailableInBytes
+		}
+		row.DiskWatermark = WatermarkLevelFor(row, watermarks)
+		row.Restarted = restarted[nodeID]
+		applyIndexingPressure(&row, nodeID, prev, curr)
+		applyNodeResources(&row, node)
+		if v, ok := curr.NodeVersions[nodeID]; ok {
+			row.Version = v.Version
+			row.BuildFlavor = v.BuildFlavor
+			row.JVMVersion = v.JVM.Version
+		}
+
+		if enoughTime && !row.Restarted {
 			prevNode, hasPrev := prev.NodeStats.Nodes[nodeID]
 			if hasPrev && node.Indices != nil && prevNode.Indices != nil {
 				idxOpsDelta := maxFloat64(0, float64(node.Indices.Indexing.IndexTotal-prevNode.Indices.Indexing.IndexTotal))
@@ -96,6 +136,7 @@ func CalcNodeRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.Nod
 				row.SearchLatency = model.MetricNotAvailable
 			}
 		} else {
+			// No baseline, or the node restarted and its counters were reset.
 			row.IndexingRate = model.MetricNotAvailable
 			row.SearchRate = model.MetricNotAvailable
 			row.IndexLatency = model.MetricNotAvailable
@@ -157,17 +198,33 @@ func maxFloat64(a, b float64) float64 {
 // between two consecutive snapshots. Aggregates indexing ops from primaries and
 // search ops from totals across all index stats, per the spec.
 //
+// When a node restarted in between, its counters were reset and the index
+// stats totals would understate the interval, so the rates are summed from
+// the node stats of the nodes that did not restart instead.
+//
 // Returns MetricNotAvailable for all rate/latency fields when:
 //   - prev or curr is nil (first snapshot, no baseline)
+//   - either snapshot holds stale index stats (no fresh baseline)
 //   - elapsed < minTimeDiffSeconds (interval too short, data unreliable)
+//   - every node with a baseline restarted, or node stats are stale while
+//     a restart is detected
 func CalcClusterMetrics(prev, curr *model.Snapshot, elapsed time.Duration) model.PerformanceMetrics {
+	unavailable := model.PerformanceMetrics{
+		IndexingRate:  model.MetricNotAvailable,
+		SearchRate:    model.MetricNotAvailable,
+		IndexLatency:  model.MetricNotAvailable,
+		SearchLatency: model.MetricNotAvailable,
+	}
+	nodePrev := deltaBaseline(prev, curr, model.SectionNodeStats)
+	prev = deltaBaseline(prev, curr, model.SectionIndexStats)
 	if prev == nil || curr == nil || elapsed.Seconds() < minTimeDiffSeconds {
-		return model.PerformanceMetrics{
-			IndexingRate:  model.MetricNotAvailable,
-			SearchRate:    model.MetricNotAvailable,
-			IndexLatency:  model.MetricNotAvailable,
-			SearchLatency: model.MetricNotAvailable,
+		return unavailable
+	}
+	if restarted := restartedNodes(prev, curr); len(restarted) > 0 {
+		if nodePrev == nil {
+			return unavailable
 		}
+		return nodeClusterMetrics(nodePrev, curr, elapsed, restarted)
 	}
 
 	var (
@@ -248,6 +305,60 @@ func CalcClusterMetrics(prev, curr *model.Snapshot, elapsed time.Duration) model
 	}
 }
 
+// nodeClusterMetrics sums the node stats deltas of the nodes in both prev
+// and curr that are not in restarted. Node stats count indexing on every
+// shard copy, so the indexing rate is scaled by the primaries' share of
+// indexing in curr's index stats to match the primaries-only rate of
+// CalcClusterMetrics. Latencies are taken over all copies.
+func nodeClusterMetrics(prev, curr *model.Snapshot, elapsed time.Duration, restarted map[string]bool) model.PerformanceMetrics {
+	var idxOps, idxTime, srchOps, srchTime float64
+	counted := 0
+	for nodeID, node := range curr.NodeStats.Nodes {
+		prevNode, ok := prev.NodeStats.Nodes[nodeID]
+		if restarted[nodeID] || !ok || node.Indices == nil || prevNode.Indices == nil {
+			continue
+		}
+		idxOps += maxFloat64(0, float64(node.Indices.Indexing.IndexTotal-prevNode.Indices.Indexing.IndexTotal))
+		idxTime += maxFloat64(0, float64(node.Indices.Indexing.IndexTimeInMillis-prevNode.Indices.Indexing.IndexTimeInMillis))
+		srchOps += maxFloat64(0, float64(node.Indices.Search.QueryTotal-prevNode.Indices.Search.QueryTotal))
+		srchTime += maxFloat64(0, float64(node.Indices.Search.QueryTimeInMillis-prevNode.Indices.Search.QueryTimeInMillis))
+		counted++
+	}
+	if counted == 0 {
+		return model.PerformanceMetrics{
+			IndexingRate:  model.MetricNotAvailable,
+			SearchRate:    model.MetricNotAvailable,
+			IndexLatency:  model.MetricNotAvailable,
+			SearchLatency: model.MetricNotAvailable,
+		}
+	}
+
+	elapsedSec := elapsed.Seconds()
+	return model.PerformanceMetrics{
+		IndexingRate:  clampRate(idxOps * primaryIndexingShare(curr) / elapsedSec),
+		SearchRate:    clampRate(srchOps / elapsedSec),
+		IndexLatency:  clampLatency(safeDivide(idxTime, idxOps)),
+		SearchLatency: clampLatency(safeDivide(srchTime, srchOps)),
+	}
+}
+
+// primaryIndexingShare returns the fraction of indexing operations in snap's
+// index stats that ran on primaries, or 1 when the totals are unknown.
+func primaryIndexingShare(snap *model.Snapshot) float64 {
+	var pri, total int64
+	for _, entry := range snap.IndexStats.Indices {
+		if entry.Primaries == nil || entry.Primaries.Indexing == nil || entry.Total == nil || entry.Total.Indexing == nil {
+			continue
+		}
+		pri += entry.Primaries.Indexing.IndexTotal
+		total += entry.Total.Indexing.IndexTotal
+	}
+	if pri <= 0 || total <= 0 || pri > total {
+		return 1
+	}
+	return float64(pri) / float64(total)
+}
+
 // CalcClusterResources aggregates OS, JVM, and filesystem metrics across all nodes
 // in the snapshot. Ported from App.tsx lines 193-240.
 //
@@ -319,10 +430,13 @@ func CalcClusterResources(snap *model.Snapshot) model.ClusterResources {
 // Critical primaries-vs-total rule (IndexTable.tsx lines 73-76):
 //   - Indexing ops/time: use primaries (fallback to total if primaries nil)
 //   - Search ops/time:   use total     (fallback to primaries if total nil)
+//
+// Rates are skipped when either snapshot holds stale index stats.
 func CalcIndexRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.IndexRow {
 	if curr == nil {
 		return nil
 	}
+	prev = deltaBaseline(prev, curr, model.SectionIndexStats)
 
 	// Build prev index stats lookup (nil safe).
 	var prevStats map[string]client.IndexStatEntry
@@ -332,6 +446,7 @@ func CalcIndexRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.In
 
 	elapsedSec := elapsed.Seconds()
 	enoughTime := prev != nil && elapsedSec >= minTimeDiffSeconds
+	streamOf := backingIndexStreams(curr.DataStreams)
 
 	rows := make([]model.IndexRow, 0, len(curr.Indices))
 	for _, info := range curr.Indices {
@@ -356,6 +471,12 @@ func CalcIndexRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.In
 			docCount = v
 			docCountKnown = true
 		}
+		docsDeleted := int64(0)
+		deletedKnown := false
+		if v, err := strconv.ParseInt(info.DocsDeleted, 10, 64); err == nil {
+			docsDeleted = v
+			deletedKnown = true
+		}
 		totalShards := pri * (1 + rep)
 
 		// Size from _stats shard data.
@@ -386,6 +507,14 @@ func CalcIndexRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.In
 			PriSizeBytes:   primarySizeBytes,
 			AvgShardSize:   avgShardSize,
 			DocCount:       docCount,
+			DocsDeleted:    docsDeleted,
+			DeletedKnown:   deletedKnown,
+		}
+		row.DataStream = streamOf[name]
+		applyLifecycle(&row, curr)
+		if ms, ok := curr.Mappings[name]; ok {
+			row.FieldCount = ms.FieldCount
+			row.FieldLimit = ms.FieldLimit
 		}
 
 		if enoughTime {
@@ -475,5 +604,42 @@ func CalcIndexRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.In
 		rows = append(rows, row)
 	}
 
+	applyAliases(rows, curr.Aliases)
+	return rows
+}
+
+// CalcTaskRows flattens the parent-grouped task list from _tasks into one row
+// per top-level task, resolving node IDs to names via curr.NodeStats. Child
+// tasks are counted, not listed: cancelling a parent cancels its children.
+func CalcTaskRows(tasks []client.TaskInfo, curr *model.Snapshot) []model.TaskRow {
+	rows := make([]model.TaskRow, 0, len(tasks))
+	for _, t := range tasks {
+		row := model.TaskRow{
+			TaskID:      t.TaskID(),
+			NodeID:      t.Node,
+			Node:        t.Node,
+			Action:      t.Action,
+			Description: t.Description,
+			RunningTime: time.Duration(t.RunningTimeInNanos),
+			Cancellable: t.Cancellable,
+			Cancelled:   t.Cancelled,
+			Children:    countTaskChildren(t),
+		}
+		if curr != nil {
+			if n, ok := curr.NodeStats.Nodes[t.Node]; ok && n.Name != "" {
+				row.Node = n.Name
+			}
+		}
+		rows = append(rows, row)
+	}
 	return rows
 }
+
+// countTaskChildren returns the number of descendants of t.
+func countTaskChildren(t client.TaskInfo) int {
+	n := len(t.Children)
+	for _, c := range t.Children {
+		n += countTaskChildren(c)
+	}
+	return n
+}
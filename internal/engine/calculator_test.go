@@ -769,3 +769,45 @@ func TestCalcNodeRows_AllocationEmptySlice(t *testing.T) {
 	assert.Equal(t, -1, rows[0].Shards)
 	assert.Equal(t, -1.0, rows[0].DiskPercent)
 }
+
+func TestCalcTaskRows(t *testing.T) {
+	snap := &model.Snapshot{
+		NodeStats: client.NodeStatsResponse{
+			Nodes: map[string]client.NodePerformanceStats{
+				"nodeA": {Name: "es-data-1"},
+			},
+		},
+	}
+	tasks := []client.TaskInfo{
+		{
+			Node: "nodeA", ID: 42, Action: "indices:data/write/reindex",
+			Description: "reindex from [a] to [b]", RunningTimeInNanos: int64(90 * time.Second), Cancellable: true,
+			Children: []client.TaskInfo{
+				{Node: "nodeA", ID: 43, Children: []client.TaskInfo{{Node: "nodeB", ID: 1}}},
+				{Node: "nodeB", ID: 2},
+			},
+		},
+		{Node: "nodeZ", ID: 7, Action: "cluster:monitor/tasks/lists"},
+	}
+
+	rows := CalcTaskRows(tasks, snap)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, "nodeA:42", rows[0].TaskID)
+	assert.Equal(t, "nodeA", rows[0].NodeID)
+	assert.Equal(t, "es-data-1", rows[0].Node)
+	assert.Equal(t, 90*time.Second, rows[0].RunningTime)
+	assert.True(t, rows[0].Cancellable)
+	assert.Equal(t, 3, rows[0].Children)
+
+	// Unknown node IDs fall back to the raw ID.
+	assert.Equal(t, "nodeZ", rows[1].Node)
+	assert.Equal(t, 0, rows[1].Children)
+}
+
+func TestCalcTaskRows_NilSnapshot(t *testing.T) {
+	rows := CalcTaskRows([]client.TaskInfo{{Node: "n1", ID: 1}}, nil)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "n1", rows[0].Node)
+	assert.NotNil(t, CalcTaskRows(nil, nil))
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// followerStallPolls is how many consecutive polls a follower may lag
+// without advancing its global checkpoint before it is reported stalled.
+// A single quiet interval is normal while a large batch is in flight.
+const followerStallPolls = 3
+
+// CalcFollowerRows summarizes the follower indices in curr, sorted by index
+// name. Follow rates and stall streaks are computed against the same index in
+// prev and are unavailable on the first poll or when elapsed is too short.
+// Streaks continue from prev.FollowerStalls.
+func CalcFollowerRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.FollowerRow {
+	if curr == nil || curr.CCRStats == nil {
+		return nil
+	}
+	type checkpoints struct{ leader, follower int64 }
+	sumCheckpoints := func(snap *model.Snapshot) map[string]checkpoints {
+		out := make(map[string]checkpoints)
+		if snap == nil || snap.CCRStats == nil {
+			return out
+		}
+		for _, idx := range snap.CCRStats.FollowStats.Indices {
+			var c checkpoints
+			for _, s := range idx.Shards {
+				c.leader += s.LeaderGlobalCheckpoint
+				c.follower += s.FollowerGlobalCheckpoint
+			}
+			out[idx.Index] = c
+		}
+		return out
+	}
+	elapsedSec := elapsed.Seconds()
+	enoughTime := prev != nil && elapsedSec >= minTimeDiffSeconds
+	prevCheckpoints := sumCheckpoints(prev)
+	var prevStreak map[string]int
+	if prev != nil {
+		prevStreak = prev.FollowerStalls
+	}
+
+	rows := make([]model.FollowerRow, 0, len(curr.CCRStats.FollowStats.Indices))
+	for _, idx := range curr.CCRStats.FollowStats.Indices {
+		row := model.FollowerRow{
+			Index:      idx.Index,
+			Shards:     len(idx.Shards),
+			TimeLag:    -1,
+			FollowRate: model.MetricNotAvailable,
+		}
+		var cp checkpoints
+		for _, s := range idx.Shards {
+			if row.LeaderIndex == "" {
+				row.LeaderIndex = s.LeaderIndex
+				row.RemoteCluster = s.RemoteCluster
+			}
+			if lag := s.LeaderGlobalCheckpoint - s.FollowerGlobalCheckpoint; lag > 0 {
+				row.OpsLag += lag
+			}
+			cp.leader += s.LeaderGlobalCheckpoint
+			cp.follower += s.FollowerGlobalCheckpoint
+			if d := time.Duration(s.TimeSinceLastReadMillis) * time.Millisecond; d > row.LastRead {
+				row.LastRead = d
+			}
+			row.ReadExceptions += len(s.ReadExceptions)
+			if s.FatalException != nil && row.FatalError == "" {
+				row.FatalError = s.FatalException.Type + ": " + s.FatalException.Reason
+			}
+		}
+		if row.OpsLag == 0 {
+			row.TimeLag = 0
+		}
+
+		if before, ok := prevCheckpoints[idx.Index]; ok && enoughTime {
+			followed := cp.follower - before.follower
+			if followed < 0 {
+				followed = 0
+			}
+			row.FollowRate = float64(followed) / elapsedSec
+			if written := cp.leader - before.leader; written > 0 && row.OpsLag > 0 {
+				// The leader produced `written` ops in elapsed, so the lag
+				// is roughly as old as the time it took to write OpsLag ops.
+				row.TimeLag = time.Duration(float64(row.OpsLag) / (float64(written) / elapsedSec) * float64(time.Second))
+			}
+			if row.OpsLag > 0 && followed == 0 {
+				row.StalledPolls = prevStreak[idx.Index] + 1
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Index < rows[j].Index })
+	return rows
+}
+
+// FollowerStalls returns the stall streak of every stalled follower in rows,
+// keyed by index name, to be kept on the snapshot for the next poll.
+func FollowerStalls(rows []model.FollowerRow) map[string]int {
+	stalls := make(map[string]int)
+	for _, f := range rows {
+		if f.StalledPolls > 0 {
+			stalls[f.Index] = f.StalledPolls
+		}
+	}
+	return stalls
+}
+
+// FollowerStalled reports whether f has stopped replicating: a fatal error,
+// or lag without progress for followerStallPolls consecutive polls.
+func FollowerStalled(f model.FollowerRow) bool {
+	return f.FatalError != "" || f.StalledPolls >= followerStallPolls
+}
+
+// ccrRecs flags disconnected remote clusters and follower indices that have
+// stopped replicating: either with a fatal error or by lagging without
+// progress for followerStallPolls consecutive polls.
+func ccrRecs(snap *model.Snapshot, followers []model.FollowerRow) []model.Recommendation {
+	var recs []model.Recommendation
+
+	var down []string
+	for name, r := range snap.Remotes {
+		if !r.Connected {
+			down = append(down, name)
+		}
+	}
+	if len(down) > 0 {
+		sort.Strings(down)
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityCritical,
+			Category: model.CategoryShardHealth,
+			Title:    "Remote cluster disconnected",
+			Detail: fmt.Sprintf("%d remote cluster(s) are not connected: %s. Cross-cluster search and replication from them fail until the connection is restored. Check network access to the seed or proxy addresses on the transport port, and the remote's TLS and security settings.",
+				len(down), nameList(down, plainName)),
+			Link: model.LinkReplication,
+		})
+	}
+
+	var stalled []string
+	for _, f := range followers {
+		switch {
+		case !FollowerStalled(f):
+		case f.FatalError != "":
+			stalled = append(stalled, fmt.Sprintf("%s (%s)", f.Index, f.FatalError))
+		default:
+			stalled = append(stalled, fmt.Sprintf("%s (%s ops behind %s:%s)", f.Index, format.FormatNumber(f.OpsLag), f.RemoteCluster, f.LeaderIndex))
+		}
+	}
+	if len(stalled) > 0 {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityCritical,
+			Category: model.CategoryShardHealth,
+			Title:    "CCR follower stalled",
+			Detail: fmt.Sprintf("%d follower index(es) stopped advancing: %s. The follower falls further behind its leader and may lose history it needs once the leader's soft-deletes retention lease expires. Check GET <index>/_ccr/stats and the remote connection, then resume with POST <index>/_ccr/resume_follow.",
+				len(stalled), nameList(stalled, plainName)),
+			Link: model.LinkReplication,
+		})
+	}
+	return recs
+}
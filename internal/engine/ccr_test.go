@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func ccrSnap(leader, follower int64, fatal *client.CCRException) *model.Snapshot {
+	stats := &client.CCRStatsResponse{}
+	stats.FollowStats.Indices = []client.FollowIndexStats{{
+		Index: "dr-logs",
+		Shards: []client.FollowShardStats{
+			{RemoteCluster: "prod", LeaderIndex: "logs", ShardID: 0, LeaderGlobalCheckpoint: leader, FollowerGlobalCheckpoint: follower, TimeSinceLastReadMillis: 1500},
+			{RemoteCluster: "prod", LeaderIndex: "logs", ShardID: 1, LeaderGlobalCheckpoint: leader, FollowerGlobalCheckpoint: follower, TimeSinceLastReadMillis: 500, FatalException: fatal},
+		},
+	}}
+	return &model.Snapshot{CCRStats: stats}
+}
+
+func TestCalcFollowerRows(t *testing.T) {
+	prev := ccrSnap(1000, 900, nil)
+	curr := ccrSnap(1100, 1000, nil)
+
+	rows := CalcFollowerRows(nil, curr, 0)
+	require.Len(t, rows, 1)
+	r := rows[0]
+	assert.Equal(t, "logs", r.LeaderIndex)
+	assert.Equal(t, "prod", r.RemoteCluster)
+	assert.Equal(t, 2, r.Shards)
+	assert.Equal(t, int64(200), r.OpsLag)
+	assert.Equal(t, time.Duration(-1), r.TimeLag, "no leader rate on the first poll")
+	assert.Equal(t, model.MetricNotAvailable, r.FollowRate)
+	assert.Equal(t, 1500*time.Millisecond, r.LastRead)
+
+	r = CalcFollowerRows(prev, curr, 10*time.Second)[0]
+	assert.InDelta(t, 20.0, r.FollowRate, 0.001)
+	assert.Equal(t, 10*time.Second, r.TimeLag, "200 ops behind a leader writing 20 ops/s")
+	assert.Equal(t, 0, r.StalledPolls)
+
+	assert.Nil(t, CalcFollowerRows(prev, &model.Snapshot{}, time.Second))
+}
+
+func TestCalcFollowerRows_StallStreak(t *testing.T) {
+	snap := ccrSnap(1000, 900, nil)
+	for i := 1; i <= 3; i++ {
+		next := ccrSnap(1000+int64(i)*10, 900, nil)
+		rows := CalcFollowerRows(snap, next, 10*time.Second)
+		require.Len(t, rows, 1)
+		assert.Equal(t, i, rows[0].StalledPolls)
+		next.FollowerStalls = FollowerStalls(rows)
+		snap = next
+	}
+
+	caughtUp := ccrSnap(1030, 1030, nil)
+	rows := CalcFollowerRows(snap, caughtUp, 10*time.Second)
+	assert.Equal(t, 0, rows[0].StalledPolls)
+	assert.Empty(t, FollowerStalls(rows), "the streak ends once the follower advances")
+}
+
+func TestCCRRecs(t *testing.T) {
+	assert.Empty(t, ccrRecs(&model.Snapshot{
+		Remotes: map[string]client.RemoteInfo{"prod": {Connected: true}},
+	}, []model.FollowerRow{{Index: "dr-logs", StalledPolls: 2}}))
+
+	recs := ccrRecs(&model.Snapshot{
+		Remotes: map[string]client.RemoteInfo{"prod": {Connected: false}, "eu": {Connected: true}},
+	}, []model.FollowerRow{
+		{Index: "dr-logs", RemoteCluster: "prod", LeaderIndex: "logs", OpsLag: 1234, StalledPolls: 3},
+		{Index: "dr-metrics", FatalError: "index_not_found_exception: no such index [metrics]"},
+	})
+	require.Len(t, recs, 2)
+	assert.Equal(t, "Remote cluster disconnected", recs[0].Title)
+	assert.Equal(t, model.SeverityCritical, recs[0].Severity)
+	assert.Contains(t, recs[0].Detail, "1 remote cluster(s) are not connected: prod.")
+	assert.Equal(t, "CCR follower stalled", recs[1].Title)
+	assert.Contains(t, recs[1].Detail, "dr-logs (1,234 ops behind prod:logs)")
+	assert.Contains(t, recs[1].Detail, "dr-metrics (index_not_found_exception")
+	assert.NotContains(t, recs[1].Detail, "press")
+	assert.Equal(t, model.LinkReplication, recs[0].Link)
+	assert.Equal(t, model.LinkReplication, recs[1].Link)
+}
+
+func TestCalcRecommendations_StalledFollower(t *testing.T) {
+	snap := &model.Snapshot{Health: client.ClusterHealth{Status: "green"}}
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil,
+		[]model.FollowerRow{{Index: "dr-logs", OpsLag: 10, StalledPolls: followerStallPolls}})
+	assert.True(t, hasRec(recs, model.SeverityCritical, "CCR follower stalled"))
+}
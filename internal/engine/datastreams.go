@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// backingIndexStreams maps every backing index name to its data stream.
+func backingIndexStreams(streams []client.DataStream) map[string]string {
+	m := make(map[string]string)
+	for _, ds := range streams {
+		for _, idx := range ds.Indices {
+			m[idx.IndexName] = ds.Name
+		}
+	}
+	return m
+}
+
+// CalcDataStreamRows aggregates indexRows into one row per data stream by
+// summing sizes, document counts and rates of the backing indices. Backing
+// indices missing from indexRows (e.g. closed indices) are counted in the
+// generation but contribute nothing to the totals. Rates skip backing
+// indices without a rate and are MetricNotAvailable when none has one.
+// Streams keep the order of streams; backing indices are listed newest
+// generation first.
+func CalcDataStreamRows(streams []client.DataStream, indexRows []model.IndexRow) []model.DataStreamRow {
+	if len(streams) == 0 {
+		return nil
+	}
+	byName := make(map[string]model.IndexRow, len(indexRows))
+	for _, r := range indexRows {
+		byName[r.Name] = r
+	}
+
+	rows := make([]model.DataStreamRow, 0, len(streams))
+	for _, ds := range streams {
+		row := model.DataStreamRow{
+			Name:         ds.Name,
+			Template:     ds.Template,
+			ILMPolicy:    ds.ILMPolicy,
+			Status:       ds.Status,
+			Generation:   ds.Generation,
+			IndexCount:   len(ds.Indices),
+			IndexingRate: model.MetricNotAvailable,
+			SearchRate:   model.MetricNotAvailable,
+		}
+		row.BackingIndices = make([]model.IndexRow, 0, len(ds.Indices))
+		for i := len(ds.Indices) - 1; i >= 0; i-- {
+			idx, ok := byName[ds.Indices[i].IndexName]
+			if !ok {
+				continue
+			}
+			row.BackingIndices = append(row.BackingIndices, idx)
+			row.TotalSizeBytes += idx.TotalSizeBytes
+			row.PriSizeBytes += idx.PriSizeBytes
+			row.DocCount += idx.DocCount
+			row.IndexingRate = addRate(row.IndexingRate, idx.IndexingRate)
+			row.SearchRate = addRate(row.SearchRate, idx.SearchRate)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// addRate adds rate to sum, treating MetricNotAvailable on either side as
+// absent rather than as a negative value.
+func addRate(sum, rate float64) float64 {
+	if rate < 0 {
+		return sum
+	}
+	if sum < 0 {
+		return rate
+	}
+	return sum + rate
+}
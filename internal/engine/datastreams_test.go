@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleStreams() []client.DataStream {
+	return []client.DataStream{{
+		Name:       "logs-app",
+		Generation: 3,
+		Status:     "GREEN",
+		Template:   "logs",
+		Indices: []client.DataStreamIndex{
+			{IndexName: ".ds-logs-app-000001"},
+			{IndexName: ".ds-logs-app-000002"},
+			{IndexName: ".ds-logs-app-000003"},
+		},
+	}}
+}
+
+func TestCalcDataStreamRows_Aggregates(t *testing.T) {
+	indexRows := []model.IndexRow{
+		{Name: ".ds-logs-app-000001", TotalSizeBytes: 100, PriSizeBytes: 50, DocCount: 10, IndexingRate: model.MetricNotAvailable, SearchRate: 1},
+		{Name: ".ds-logs-app-000003", TotalSizeBytes: 300, PriSizeBytes: 150, DocCount: 30, IndexingRate: 40, SearchRate: 2},
+		{Name: "unrelated", TotalSizeBytes: 999, DocCount: 999, IndexingRate: 999},
+	}
+	rows := CalcDataStreamRows(sampleStreams(), indexRows)
+	require.Len(t, rows, 1)
+	r := rows[0]
+	assert.Equal(t, "logs-app", r.Name)
+	assert.Equal(t, 3, r.IndexCount, "closed backing index still counted")
+	require.Len(t, r.BackingIndices, 2)
+	assert.Equal(t, ".ds-logs-app-000003", r.BackingIndices[0].Name, "write index first")
+	assert.Equal(t, int64(400), r.TotalSizeBytes)
+	assert.Equal(t, int64(200), r.PriSizeBytes)
+	assert.Equal(t, int64(40), r.DocCount)
+	assert.Equal(t, 40.0, r.IndexingRate, "sentinel rates are skipped")
+	assert.Equal(t, 3.0, r.SearchRate)
+}
+
+func TestCalcDataStreamRows_NoRates(t *testing.T) {
+	indexRows := []model.IndexRow{
+		{Name: ".ds-logs-app-000003", IndexingRate: model.MetricNotAvailable, SearchRate: model.MetricNotAvailable},
+	}
+	rows := CalcDataStreamRows(sampleStreams(), indexRows)
+	require.Len(t, rows, 1)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].IndexingRate)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].SearchRate)
+	assert.Nil(t, CalcDataStreamRows(nil, indexRows))
+}
+
+func TestCalcIndexRows_SetsDataStream(t *testing.T) {
+	curr := &model.Snapshot{
+		Indices: []client.IndexInfo{
+			{Index: ".ds-logs-app-000001", Pri: "1", Rep: "0", DocsCount: "1"},
+			{Index: "plain", Pri: "1", Rep: "0", DocsCount: "1"},
+		},
+		DataStreams: sampleStreams(),
+	}
+	rows := CalcIndexRows(nil, curr, 10*time.Second)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "logs-app", rows[0].DataStream)
+	assert.Empty(t, rows[1].DataStream)
+}
+
+func TestDateRollupRecs_SkipsDataStreamMembers(t *testing.T) {
+	var rows []model.IndexRow
+	for _, name := range []string{
+		"app-logs-2024-01-01", "app-logs-2024-01-02", "app-logs-2024-01-03", "app-logs-2024-01-04",
+		"app-logs-2024-01-05", "app-logs-2024-01-06", "app-logs-2024-01-07",
+	} {
+		rows = append(rows, model.IndexRow{Name: name, PriSizeBytes: 50 * oneMiBInt64, TotalShards: 2, DataStream: "app-logs"})
+		rows = append(rows, model.IndexRow{Name: ".ds-" + name + "-000001", PriSizeBytes: 50 * oneMiBInt64, TotalShards: 2})
+	}
+	recs, _, _, _ := dateRollupRecs(rows)
+	assert.Empty(t, recs, "data stream backing indices must not get rollup suggestions")
+}
@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+var (
+	// hotThreadNodeRe matches the per-node section header, e.g.
+	// "::: {es-data-1}{nodeID}{...}".
+	hotThreadNodeRe = regexp.MustCompile(`^:::\s*\{([^}]*)\}`)
+	// hotThreadEntryRe matches a thread entry line, e.g.
+	// "12.3% [cpu=12.3%, other=0.0%] (61.5ms out of 500ms) cpu usage by thread 'name'"
+	// and the older "12.3% (61.5ms out of 500ms) cpu usage by thread 'name'".
+	hotThreadEntryRe = regexp.MustCompile(`^\s*([0-9.]+)%.*usage by thread '(.*)'\s*$`)
+	// hotThreadSnapshotRe matches the line introducing a shared stack, e.g.
+	// "2/10 snapshots sharing following 29 elements" or "unique snapshot".
+	hotThreadSnapshotRe = regexp.MustCompile(`^\s*(\d+/\d+ snapshots sharing following|unique snapshot)`)
+)
+
+// ParseHotThreads extracts thread entries from a plain-text hot threads
+// report. The top frame of each thread is the first stack line after its
+// first snapshot header, which ES lists most-shared first. Lines that do not
+// match the expected layout are ignored, so unknown formats yield an empty
+// result rather than an error.
+func ParseHotThreads(text string) []model.HotThread {
+	var (
+		threads   []model.HotThread
+		node      string
+		cur       *model.HotThread
+		wantFrame bool
+	)
+	flush := func() {
+		if cur != nil {
+			threads = append(threads, *cur)
+			cur = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := hotThreadNodeRe.FindStringSubmatch(line); m != nil {
+			flush()
+			node = m[1]
+			wantFrame = false
+			continue
+		}
+		if m := hotThreadEntryRe.FindStringSubmatch(line); m != nil {
+			flush()
+			pct, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				pct = 0
+			}
+			cur = &model.HotThread{Node: node, Thread: m[2], CPUPercent: pct}
+			wantFrame = false
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if hotThreadSnapshotRe.MatchString(line) {
+			wantFrame = cur.TopFrame == ""
+			continue
+		}
+		if wantFrame {
+			if frame := strings.TrimSpace(line); frame != "" {
+				cur.TopFrame = strings.TrimPrefix(frame, "at ")
+				wantFrame = false
+			}
+		}
+	}
+	flush()
+	return threads
+}
+
+// GroupHotThreads aggregates threads by top stack frame, ordered by summed
+// CPU share descending, then by frame. Threads without a parsed frame are
+// grouped under "(no stack)".
+func GroupHotThreads(threads []model.HotThread) []model.HotThreadGroup {
+	byFrame := make(map[string]*model.HotThreadGroup)
+	var order []string
+	for _, t := range threads {
+		frame := t.TopFrame
+		if frame == "" {
+			frame = "(no stack)"
+		}
+		g, ok := byFrame[frame]
+		if !ok {
+			g = &model.HotThreadGroup{TopFrame: frame}
+			byFrame[frame] = g
+			order = append(order, frame)
+		}
+		g.Threads++
+		g.CPUPercent += t.CPUPercent
+	}
+
+	groups := make([]model.HotThreadGroup, 0, len(order))
+	for _, f := range order {
+		groups = append(groups, *byFrame[f])
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].CPUPercent != groups[j].CPUPercent {
+			return groups[i].CPUPercent > groups[j].CPUPercent
+		}
+		return groups[i].TopFrame < groups[j].TopFrame
+	})
+	return groups
+}
@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hotThreadsFixture = `::: {es-data-1}{Xk2wQ}{abc}{10.0.0.1}{10.0.0.1:9300}{dim}
+   Hot threads at 2026-10-18T10:00:00.000Z, interval=500ms, busiestThreads=3, ignoreIdleThreads=true:
+
+   61.2% [cpu=61.2%, other=0.0%] (306ms out of 500ms) cpu usage by thread 'elasticsearch[es-data-1][search][T#3]'
+     6/10 snapshots sharing following 29 elements
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:123)
+       app//org.apache.lucene.search.Weight$DefaultBulkScorer.score(Weight.java:270)
+     4/10 snapshots sharing following 12 elements
+       app//org.apache.lucene.index.TermsEnum.next(TermsEnum.java:50)
+
+   20.0% [cpu=20.0%, other=0.0%] (100ms out of 500ms) cpu usage by thread 'elasticsearch[es-data-1][search][T#4]'
+     10/10 snapshots sharing following 29 elements
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:123)
+
+   5.5% (27.5ms out of 500ms) cpu usage by thread 'elasticsearch[es-data-1][write][T#1]'
+     unique snapshot
+       at org.elasticsearch.index.engine.InternalEngine.index(InternalEngine.java:900)
+`
+
+func TestParseHotThreads(t *testing.T) {
+	threads := ParseHotThreads(hotThreadsFixture)
+	require.Len(t, threads, 3)
+
+	assert.Equal(t, "es-data-1", threads[0].Node)
+	assert.Equal(t, "elasticsearch[es-data-1][search][T#3]", threads[0].Thread)
+	assert.InDelta(t, 61.2, threads[0].CPUPercent, 0.001)
+	// Only the first (most shared) snapshot provides the top frame.
+	assert.Equal(t, "app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:123)", threads[0].TopFrame)
+
+	// Older format without the [cpu=...] breakdown; "at " prefix stripped.
+	assert.InDelta(t, 5.5, threads[2].CPUPercent, 0.001)
+	assert.Equal(t, "org.elasticsearch.index.engine.InternalEngine.index(InternalEngine.java:900)", threads[2].TopFrame)
+}
+
+func TestParseHotThreads_UnknownFormat(t *testing.T) {
+	assert.Empty(t, ParseHotThreads(""))
+	assert.Empty(t, ParseHotThreads("something else entirely\n"))
+}
+
+func TestGroupHotThreads(t *testing.T) {
+	groups := GroupHotThreads(ParseHotThreads(hotThreadsFixture))
+	require.Len(t, groups, 2)
+	assert.Equal(t, "app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:123)", groups[0].TopFrame)
+	assert.Equal(t, 2, groups[0].Threads)
+	assert.InDelta(t, 81.2, groups[0].CPUPercent, 0.001)
+	assert.Equal(t, 1, groups[1].Threads)
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// applyIndexingPressure fills row's indexing pressure fields from the node's
+// stats in curr. Rejections are counted since the same node in prev and are
+// unavailable on the first poll, for new nodes, and across a restart.
+func applyIndexingPressure(row *model.NodeRow, nodeID string, prev, curr *model.Snapshot) {
+	row.IndexingPressureBytes = -1
+	row.IndexingPressurePercent = -1
+	row.IndexingRejections = -1
+	if curr.IndexingPressure == nil {
+		return
+	}
+	node, ok := curr.IndexingPressure.Nodes[nodeID]
+	if !ok {
+		return
+	}
+	mem := node.IndexingPressure.Memory
+	row.IndexingPressureBytes = mem.Current.AllInBytes
+	row.IndexingPressureLimit = mem.LimitInBytes
+	if mem.LimitInBytes > 0 {
+		// The limit applies to coordinating and primary bytes; replicas are
+		// only rejected at 1.5x the limit.
+		row.IndexingPressurePercent = float64(mem.Current.CombinedCoordinatingAndPrimaryInBytes) / float64(mem.LimitInBytes) * 100
+	}
+
+	if prev == nil || prev.IndexingPressure == nil || row.Restarted {
+		return
+	}
+	prevNode, ok := prev.IndexingPressure.Nodes[nodeID]
+	if !ok {
+		return
+	}
+	row.IndexingRejections = mem.Rejections() - prevNode.IndexingPressure.Memory.Rejections()
+	if row.IndexingRejections < 0 {
+		row.IndexingRejections = 0
+	}
+}
+
+// indexingPressureRecs flags nodes that rejected indexing requests for
+// indexing pressure since the previous poll. Such rejections are returned to
+// the client as 429 Too Many Requests, independent of the write thread pool.
+func indexingPressureRecs(nodeRows []model.NodeRow) []model.Recommendation {
+	var rejecting []model.NodeRow
+	for _, n := range nodeRows {
+		if n.IndexingRejections > 0 {
+			rejecting = append(rejecting, n)
+		}
+	}
+	if len(rejecting) == 0 {
+		return nil
+	}
+	sort.Slice(rejecting, func(i, j int) bool {
+		if rejecting[i].IndexingRejections != rejecting[j].IndexingRejections {
+			return rejecting[i].IndexingRejections > rejecting[j].IndexingRejections
+		}
+		return rejecting[i].Name < rejecting[j].Name
+	})
+
+	names := nameList(rejecting, func(n model.NodeRow) string {
+		desc := fmt.Sprintf("%s (%s rejected", n.Name, format.FormatNumber(n.IndexingRejections))
+		if n.IndexingPressurePercent >= 0 {
+			desc += fmt.Sprintf(", %.0f%% of %s in flight", n.IndexingPressurePercent, format.FormatBytes(n.IndexingPressureLimit))
+		}
+		return desc + ")"
+	})
+	return []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryResourcePressure,
+		Title:    "Indexing pressure rejections",
+		Detail: fmt.Sprintf("%d node(s) rejected indexing requests since the last poll because too many bytes of bulk requests were in flight: %s. Clients receive 429 responses and must retry. Reduce bulk request size or client concurrency, spread writes over more primaries, or raise indexing_pressure.memory.limit (default 10%% of heap) on nodes with heap to spare.",
+			len(rejecting), names),
+	}}
+}
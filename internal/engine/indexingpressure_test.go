@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// pressureSnap returns a single-node snapshot whose node reports the given
+// in-flight bytes, limit and cumulative rejections.
+func pressureSnap(combined, replica, limit, rejections int64) *model.Snapshot {
+	var node client.NodeIndexingPressure
+	node.Name = "node-a"
+	mem := &node.IndexingPressure.Memory
+	mem.Current.CombinedCoordinatingAndPrimaryInBytes = combined
+	mem.Current.ReplicaInBytes = replica
+	mem.Current.AllInBytes = combined + replica
+	mem.LimitInBytes = limit
+	mem.Total.PrimaryRejections = rejections
+	return &model.Snapshot{
+		NodeStats:        makeNodeStatsWithID("n1", "node-a", 100, 10, 100, 10),
+		IndexingPressure: &client.IndexingPressureResponse{Nodes: map[string]client.NodeIndexingPressure{"n1": node}},
+	}
+}
+
+func TestCalcNodeRows_IndexingPressure(t *testing.T) {
+	prev := pressureSnap(0, 0, 1000, 5)
+	curr := pressureSnap(600, 200, 1000, 12)
+
+	rows := CalcNodeRows(prev, curr, 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(800), rows[0].IndexingPressureBytes)
+	assert.Equal(t, int64(1000), rows[0].IndexingPressureLimit)
+	assert.InDelta(t, 60.0, rows[0].IndexingPressurePercent, 1e-9, "replica bytes do not count against the limit")
+	assert.Equal(t, int64(7), rows[0].IndexingRejections)
+
+	// First poll: no rejection baseline.
+	rows = CalcNodeRows(nil, curr, 10*time.Second)
+	assert.Equal(t, int64(-1), rows[0].IndexingRejections)
+
+	// Counter reset floors at zero.
+	rows = CalcNodeRows(curr, pressureSnap(0, 0, 1000, 0), 10*time.Second)
+	assert.Equal(t, int64(0), rows[0].IndexingRejections)
+}
+
+func TestCalcNodeRows_IndexingPressureUnavailable(t *testing.T) {
+	curr := pressureSnap(600, 0, 0, 0)
+	rows := CalcNodeRows(nil, curr, 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(600), rows[0].IndexingPressureBytes)
+	assert.Equal(t, -1.0, rows[0].IndexingPressurePercent, "no limit reported")
+
+	curr.IndexingPressure = nil
+	rows = CalcNodeRows(nil, curr, 10*time.Second)
+	assert.Equal(t, int64(-1), rows[0].IndexingPressureBytes)
+	assert.Equal(t, -1.0, rows[0].IndexingPressurePercent)
+	assert.Equal(t, int64(-1), rows[0].IndexingRejections)
+}
+
+func TestIndexingPressureRecs(t *testing.T) {
+	assert.Empty(t, indexingPressureRecs([]model.NodeRow{
+		{Name: "a", IndexingRejections: 0},
+		{Name: "b", IndexingRejections: -1},
+	}))
+
+	recs := indexingPressureRecs([]model.NodeRow{
+		{Name: "a", IndexingRejections: 3, IndexingPressurePercent: -1},
+		{Name: "b", IndexingRejections: 40, IndexingPressurePercent: 97, IndexingPressureLimit: 100 << 20},
+	})
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, model.CategoryResourcePressure, recs[0].Category)
+	assert.Equal(t, "Indexing pressure rejections", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "b (40 rejected, 97% of 100.0 MB in flight), a (3 rejected)")
+}
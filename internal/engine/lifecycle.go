@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// applyLifecycle copies the ILM/ISM explain state of row's index from snap
+// onto row. The age is measured from the lifecycle date to snap.FetchedAt.
+// Unmanaged indices and indices missing from the explain output are left
+// untouched.
+func applyLifecycle(row *model.IndexRow, snap *model.Snapshot) {
+	le, ok := snap.Lifecycle[row.Name]
+	if !ok || !le.Managed {
+		return
+	}
+	row.LifecycleManaged = true
+	row.LifecyclePolicy = le.Policy
+	row.LifecyclePhase = le.Phase
+	row.LifecycleAction = le.Action
+	row.LifecycleStep = le.Step
+	if le.LifecycleDateMillis > 0 {
+		if age := snap.FetchedAt.Sub(time.UnixMilli(le.LifecycleDateMillis)); age > 0 {
+			row.LifecycleAge = age
+		}
+	}
+	if le.Failed {
+		row.LifecycleError = le.StepError
+		if row.LifecycleError == "" && le.FailedStep != "" {
+			row.LifecycleError = "failed at step " + le.FailedStep
+		}
+		if row.LifecycleError == "" {
+			row.LifecycleError = "failed"
+		}
+	}
+}
+
+// lifecycleErrorRecs flags indices whose ILM/ISM policy is stuck in an ERROR
+// step. Such indices stop rolling over, shrinking or being deleted until the
+// step is retried, so disk usage grows silently.
+func lifecycleErrorRecs(indexRows []model.IndexRow) []model.Recommendation {
+	var failed []model.IndexRow
+	for _, idx := range indexRows {
+		if idx.LifecycleError != "" {
+			failed = append(failed, idx)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Name < failed[j].Name })
+
+	names := nameList(failed, func(idx model.IndexRow) string {
+		return fmt.Sprintf("%s (policy %q)", idx.Name, idx.LifecyclePolicy)
+	})
+	return []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryIndexLifecycle,
+		Title:    "Lifecycle policy stuck in ERROR",
+		Detail: fmt.Sprintf("%d index(es) are stuck in a failed lifecycle step: %s. First error: %s. Fix the cause and retry with POST <index>/_ilm/retry (or _plugins/_ism/retry on OpenSearch).",
+			len(failed), names, failed[0].LifecycleError),
+	}}
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestCalcIndexRows_AppliesLifecycle(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	curr := &model.Snapshot{
+		Indices: []client.IndexInfo{
+			{Index: "logs-000001", Pri: "1", Rep: "0", DocsCount: "10"},
+			{Index: "logs-000002", Pri: "1", Rep: "0", DocsCount: "10"},
+			{Index: "plain", Pri: "1", Rep: "0", DocsCount: "10"},
+		},
+		Lifecycle: map[string]client.LifecycleExplain{
+			"logs-000001": {Managed: true, Policy: "logs", Phase: "warm", Action: "shrink", Step: "ERROR",
+				LifecycleDateMillis: now.Add(-50 * time.Hour).UnixMilli(), Failed: true, FailedStep: "shrink"},
+			"logs-000002": {Managed: true, Policy: "logs", Phase: "hot", Action: "rollover", Step: "check-rollover-ready",
+				LifecycleDateMillis: now.Add(-2 * time.Hour).UnixMilli()},
+			"plain": {Managed: false},
+		},
+		FetchedAt: now,
+	}
+	rows := CalcIndexRows(nil, curr, 10*time.Second)
+	require.Len(t, rows, 3)
+	byName := make(map[string]model.IndexRow)
+	for _, r := range rows {
+		byName[r.Name] = r
+	}
+
+	failed := byName["logs-000001"]
+	assert.True(t, failed.LifecycleManaged)
+	assert.Equal(t, "logs", failed.LifecyclePolicy)
+	assert.Equal(t, "warm", failed.LifecyclePhase)
+	assert.Equal(t, 50*time.Hour, failed.LifecycleAge)
+	assert.Equal(t, "failed at step shrink", failed.LifecycleError, "falls back to the failed step when no reason is given")
+
+	ok := byName["logs-000002"]
+	assert.Equal(t, "rollover", ok.LifecycleAction)
+	assert.Equal(t, 2*time.Hour, ok.LifecycleAge)
+	assert.Empty(t, ok.LifecycleError)
+
+	assert.False(t, byName["plain"].LifecycleManaged)
+	assert.Empty(t, byName["plain"].LifecyclePolicy)
+}
+
+func TestLifecycleErrorRecs(t *testing.T) {
+	assert.Nil(t, lifecycleErrorRecs([]model.IndexRow{{Name: "a", LifecycleManaged: true}}))
+
+	var rows []model.IndexRow
+	for i := 1; i <= 7; i++ {
+		rows = append(rows, model.IndexRow{
+			Name:             fmt.Sprintf("logs-%06d", i),
+			LifecycleManaged: true,
+			LifecyclePolicy:  "logs",
+			LifecycleError:   "no node can hold all shards",
+		})
+	}
+	recs := lifecycleErrorRecs(rows)
+	require.Len(t, recs, 1)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "stuck in ERROR"))
+	assert.Equal(t, model.CategoryIndexLifecycle, recs[0].Category)
+	assert.Contains(t, recs[0].Detail, "7 index(es)")
+	assert.Contains(t, recs[0].Detail, `logs-000001 (policy "logs")`)
+	assert.Contains(t, recs[0].Detail, "and 2 more")
+	assert.NotContains(t, recs[0].Detail, "logs-000006")
+}
+
+func TestDateRollupRecs_SkipsLifecycleManaged(t *testing.T) {
+	var rows []model.IndexRow
+	for i := 1; i <= 7; i++ {
+		rows = append(rows, model.IndexRow{
+			Name:             fmt.Sprintf("app-logs-2024-01-%02d", i),
+			PriSizeBytes:     50 * oneMiBInt64,
+			TotalShards:      2,
+			LifecycleManaged: true,
+		})
+	}
+	recs, _, _, _ := dateRollupRecs(rows)
+	assert.Empty(t, recs, "indices under ILM/ISM must not get rollup suggestions")
+}
+
+func TestEmptyIndexRecs_SkipsLifecycleManaged(t *testing.T) {
+	rows := []model.IndexRow{
+		{Name: "logs-000001", DocCountKnown: true, LifecycleManaged: true},
+		{Name: "logs-000002", DocCountKnown: true, LifecycleManaged: true},
+		{Name: "logs-000003", DocCountKnown: true, LifecycleManaged: true},
+	}
+	assert.Nil(t, emptyIndexRecs(rows))
+}
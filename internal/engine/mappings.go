@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// FieldLimitUsage returns row's field count as a percentage of its total
+// fields limit, or -1 when the mapping stats are unavailable.
+func FieldLimitUsage(row model.IndexRow) float64 {
+	if row.FieldLimit <= 0 {
+		return -1
+	}
+	return float64(row.FieldCount) / float64(row.FieldLimit) * 100
+}
+
+// fieldLimitRecs flags indices whose mapped field count is within marginPct
+// percent of index.mapping.total_fields.limit. Once the limit is reached,
+// every document that introduces a new field is rejected, which usually
+// shows up as an indexing outage rather than a gradual slowdown.
+func fieldLimitRecs(indexRows []model.IndexRow, marginPct float64) []model.Recommendation {
+	if marginPct < 0 {
+		marginPct = 0
+	}
+	threshold := 100 - marginPct
+	var near []model.IndexRow
+	for _, idx := range indexRows {
+		if u := FieldLimitUsage(idx); u >= 0 && u >= threshold {
+			near = append(near, idx)
+		}
+	}
+	if len(near) == 0 {
+		return nil
+	}
+	sort.Slice(near, func(i, j int) bool {
+		ui, uj := FieldLimitUsage(near[i]), FieldLimitUsage(near[j])
+		if ui != uj {
+			return ui > uj
+		}
+		return near[i].Name < near[j].Name
+	})
+
+	names := nameList(near, func(idx model.IndexRow) string {
+		return fmt.Sprintf("%s (%d/%d fields)", idx.Name, idx.FieldCount, idx.FieldLimit)
+	})
+	return []model.Recommendation{{
+		Severity: model.SeverityCritical,
+		Category: model.CategoryIndexConfig,
+		Title:    "Mapping close to total_fields.limit",
+		Detail: fmt.Sprintf("%d index(es) are within %.0f%% of index.mapping.total_fields.limit: %s. Documents that add new fields are rejected once the limit is reached. Find the source of dynamic fields in the mapping and use dynamic: false, the flattened type or a key/value layout; raising the limit only buys time.",
+			len(near), marginPct, names),
+		Link: model.LinkMapping,
+	}}
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestCalcIndexRows_AppliesMappingStats(t *testing.T) {
+	curr := &model.Snapshot{
+		Indices: []client.IndexInfo{
+			{Index: "logs", Pri: "1", Rep: "0", DocsCount: "1"},
+			{Index: "other", Pri: "1", Rep: "0", DocsCount: "1"},
+		},
+		Mappings:  map[string]client.MappingStats{"logs": {Index: "logs", FieldCount: 950, FieldLimit: 1000}},
+		FetchedAt: time.Unix(1700000000, 0),
+	}
+	rows := CalcIndexRows(nil, curr, 10*time.Second)
+	require.Len(t, rows, 2)
+	for _, r := range rows {
+		if r.Name == "logs" {
+			assert.Equal(t, 950, r.FieldCount)
+			assert.Equal(t, 1000, r.FieldLimit)
+			assert.InDelta(t, 95.0, FieldLimitUsage(r), 0.001)
+		} else {
+			assert.Equal(t, 0, r.FieldLimit)
+			assert.Equal(t, -1.0, FieldLimitUsage(r))
+		}
+	}
+}
+
+func TestFieldLimitRecs(t *testing.T) {
+	rows := []model.IndexRow{
+		{Name: "ok", FieldCount: 500, FieldLimit: 1000},
+		{Name: "near", FieldCount: 920, FieldLimit: 1000},
+		{Name: "full", FieldCount: 2000, FieldLimit: 2000},
+		{Name: "unknown"},
+	}
+	recs := fieldLimitRecs(rows, 10)
+	require.Len(t, recs, 1)
+	assert.True(t, hasRec(recs, model.SeverityCritical, "total_fields.limit"))
+	assert.Equal(t, model.CategoryIndexConfig, recs[0].Category)
+	assert.Contains(t, recs[0].Detail, "2 index(es)")
+	assert.Contains(t, recs[0].Detail, "full (2000/2000 fields), near (920/1000 fields)")
+	assert.NotContains(t, recs[0].Detail, "press")
+	assert.Equal(t, model.LinkMapping, recs[0].Link)
+
+	recs = fieldLimitRecs(rows, 0)
+	require.Len(t, recs, 1)
+	assert.Contains(t, recs[0].Detail, "1 index(es)", "zero margin only flags indices at the limit")
+
+	assert.Nil(t, fieldLimitRecs(rows[:1], 10))
+}
+
+func TestCalcRecommendationsWithConfig_FieldLimitMargin(t *testing.T) {
+	rows := []model.IndexRow{{Name: "near", FieldCount: 850, FieldLimit: 1000}}
+	snap := &model.Snapshot{}
+	cfg := DefaultRecommendationConfig()
+	assert.False(t, hasRec(CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, rows, nil, nil, cfg), model.SeverityCritical, "total_fields.limit"))
+	cfg.FieldLimitMargin = 20
+	assert.True(t, hasRec(CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, rows, nil, nil, cfg), model.SeverityCritical, "total_fields.limit"))
+}
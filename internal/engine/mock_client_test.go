@@ -15,6 +15,31 @@ type MockESClient struct {
 	IndicesFn             func(ctx context.Context) ([]client.IndexInfo, error)
 	IndexStatsFn          func(ctx context.Context) (*client.IndexStatsResponse, error)
 	AllocationFn          func(ctx context.Context) ([]client.AllocationInfo, error)
+	PendingTasksFn        func(ctx context.Context) ([]client.PendingTask, error)
+	TasksFn               func(ctx context.Context) ([]client.TaskInfo, error)
+	CancelTaskFn          func(ctx context.Context, taskID string) error
+	HotThreadsFn          func(ctx context.Context, nodeID string) (string, error)
+	ShardsFn              func(ctx context.Context) ([]client.ShardInfo, error)
+	AllocExplainFn        func(ctx context.Context, index string, shard int, primary bool) (*client.AllocationExplain, error)
+	RecoveryFn            func(ctx context.Context) ([]client.RecoveryInfo, error)
+	SnapshotReposFn       func(ctx context.Context) ([]client.SnapshotRepository, error)
+	SnapshotsFn           func(ctx context.Context, repo string) ([]client.SnapshotInfo, error)
+	LifecycleFn           func(ctx context.Context) (map[string]client.LifecycleExplain, error)
+	DataStreamsFn         func(ctx context.Context) ([]client.DataStream, error)
+	AliasesFn             func(ctx context.Context) ([]client.AliasInfo, error)
+	NodeAttributesFn      func(ctx context.Context) ([]client.NodeAttribute, error)
+	IngestStatsFn         func(ctx context.Context) (*client.IngestStatsResponse, error)
+	RemoteInfoFn          func(ctx context.Context) (map[string]client.RemoteInfo, error)
+	CCRStatsFn            func(ctx context.Context) (*client.CCRStatsResponse, error)
+	IndexingPressureFn    func(ctx context.Context) (*client.IndexingPressureResponse, error)
+	NodeVersionsFn        func(ctx context.Context) (map[string]client.NodeVersion, error)
+	TemplatesFn           func(ctx context.Context) ([]client.IndexTemplate, error)
+	SimulateIndexFn       func(ctx context.Context, name string) (*client.SimulatedIndex, error)
+	MappingStatsFn        func(ctx context.Context) (map[string]client.MappingStats, error)
+	IndexMappingFn        func(ctx context.Context, index string) (map[string]any, error)
+	ClusterSettingsFn     func(ctx context.Context) (*client.ClusterSettings, error)
+	DiskWatermarksFn      func(ctx context.Context) (*client.DiskWatermarks, error)
+	UpdateClusterSetFn    func(ctx context.Context, persistent, transient map[string]any) error
 	DeleteIndexFn         func(ctx context.Context, names []string) error
 	GetIndexSettingsFn    func(ctx context.Context, name string) (*client.IndexSettingsValues, error)
 	UpdateIndexSettingsFn func(ctx context.Context, names []string, settings map[string]any) error
@@ -62,6 +87,182 @@ func (m *MockESClient) GetAllocation(ctx context.Context) ([]client.AllocationIn
 	return []client.AllocationInfo{}, nil
 }
 
+func (m *MockESClient) GetPendingTasks(ctx context.Context) ([]client.PendingTask, error) {
+	if m.PendingTasksFn != nil {
+		return m.PendingTasksFn(ctx)
+	}
+	return []client.PendingTask{}, nil
+}
+
+func (m *MockESClient) GetTasks(ctx context.Context) ([]client.TaskInfo, error) {
+	if m.TasksFn != nil {
+		return m.TasksFn(ctx)
+	}
+	return []client.TaskInfo{}, nil
+}
+
+func (m *MockESClient) CancelTask(ctx context.Context, taskID string) error {
+	if m.CancelTaskFn != nil {
+		return m.CancelTaskFn(ctx, taskID)
+	}
+	return nil
+}
+
+func (m *MockESClient) GetHotThreads(ctx context.Context, nodeID string) (string, error) {
+	if m.HotThreadsFn != nil {
+		return m.HotThreadsFn(ctx, nodeID)
+	}
+	return "", nil
+}
+
+func (m *MockESClient) GetShards(ctx context.Context) ([]client.ShardInfo, error) {
+	if m.ShardsFn != nil {
+		return m.ShardsFn(ctx)
+	}
+	return []client.ShardInfo{}, nil
+}
+
+func (m *MockESClient) GetAllocationExplain(ctx context.Context, index string, shard int, primary bool) (*client.AllocationExplain, error) {
+	if m.AllocExplainFn != nil {
+		return m.AllocExplainFn(ctx, index, shard, primary)
+	}
+	return &client.AllocationExplain{}, nil
+}
+
+func (m *MockESClient) GetRecovery(ctx context.Context) ([]client.RecoveryInfo, error) {
+	if m.RecoveryFn != nil {
+		return m.RecoveryFn(ctx)
+	}
+	return []client.RecoveryInfo{}, nil
+}
+
+func (m *MockESClient) GetSnapshotRepositories(ctx context.Context) ([]client.SnapshotRepository, error) {
+	if m.SnapshotReposFn != nil {
+		return m.SnapshotReposFn(ctx)
+	}
+	return []client.SnapshotRepository{}, nil
+}
+
+func (m *MockESClient) GetSnapshots(ctx context.Context, repo string) ([]client.SnapshotInfo, error) {
+	if m.SnapshotsFn != nil {
+		return m.SnapshotsFn(ctx, repo)
+	}
+	return []client.SnapshotInfo{}, nil
+}
+
+func (m *MockESClient) GetLifecycleExplain(ctx context.Context) (map[string]client.LifecycleExplain, error) {
+	if m.LifecycleFn != nil {
+		return m.LifecycleFn(ctx)
+	}
+	return map[string]client.LifecycleExplain{}, nil
+}
+
+func (m *MockESClient) GetDataStreams(ctx context.Context) ([]client.DataStream, error) {
+	if m.DataStreamsFn != nil {
+		return m.DataStreamsFn(ctx)
+	}
+	return []client.DataStream{}, nil
+}
+
+func (m *MockESClient) GetNodeAttributes(ctx context.Context) ([]client.NodeAttribute, error) {
+	if m.NodeAttributesFn != nil {
+		return m.NodeAttributesFn(ctx)
+	}
+	return []client.NodeAttribute{}, nil
+}
+
+func (m *MockESClient) GetIngestStats(ctx context.Context) (*client.IngestStatsResponse, error) {
+	if m.IngestStatsFn != nil {
+		return m.IngestStatsFn(ctx)
+	}
+	return &client.IngestStatsResponse{Nodes: map[string]client.NodeIngestStats{}}, nil
+}
+
+func (m *MockESClient) GetRemoteInfo(ctx context.Context) (map[string]client.RemoteInfo, error) {
+	if m.RemoteInfoFn != nil {
+		return m.RemoteInfoFn(ctx)
+	}
+	return map[string]client.RemoteInfo{}, nil
+}
+
+func (m *MockESClient) GetCCRStats(ctx context.Context) (*client.CCRStatsResponse, error) {
+	if m.CCRStatsFn != nil {
+		return m.CCRStatsFn(ctx)
+	}
+	return &client.CCRStatsResponse{}, nil
+}
+
+func (m *MockESClient) GetIndexingPressure(ctx context.Context) (*client.IndexingPressureResponse, error) {
+	if m.IndexingPressureFn != nil {
+		return m.IndexingPressureFn(ctx)
+	}
+	return &client.IndexingPressureResponse{Nodes: map[string]client.NodeIndexingPressure{}}, nil
+}
+
+func (m *MockESClient) GetNodeVersions(ctx context.Context) (map[string]client.NodeVersion, error) {
+	if m.NodeVersionsFn != nil {
+		return m.NodeVersionsFn(ctx)
+	}
+	return map[string]client.NodeVersion{}, nil
+}
+
+func (m *MockESClient) GetAliases(ctx context.Context) ([]client.AliasInfo, error) {
+	if m.AliasesFn != nil {
+		return m.AliasesFn(ctx)
+	}
+	return []client.AliasInfo{}, nil
+}
+
+func (m *MockESClient) GetTemplates(ctx context.Context) ([]client.IndexTemplate, error) {
+	if m.TemplatesFn != nil {
+		return m.TemplatesFn(ctx)
+	}
+	return []client.IndexTemplate{}, nil
+}
+
+func (m *MockESClient) SimulateIndex(ctx context.Context, name string) (*client.SimulatedIndex, error) {
+	if m.SimulateIndexFn != nil {
+		return m.SimulateIndexFn(ctx, name)
+	}
+	return &client.SimulatedIndex{}, nil
+}
+
+func (m *MockESClient) GetMappingStats(ctx context.Context) (map[string]client.MappingStats, error) {
+	if m.MappingStatsFn != nil {
+		return m.MappingStatsFn(ctx)
+	}
+	return map[string]client.MappingStats{}, nil
+}
+
+func (m *MockESClient) GetIndexMapping(ctx context.Context, index string) (map[string]any, error) {
+	if m.IndexMappingFn != nil {
+		return m.IndexMappingFn(ctx, index)
+	}
+	return map[string]any{}, nil
+}
+
+func (m *MockESClient) GetClusterSettings(ctx context.Context) (*client.ClusterSettings, error) {
+	if m.ClusterSettingsFn != nil {
+		return m.ClusterSettingsFn(ctx)
+	}
+	return &client.ClusterSettings{}, nil
+}
+
+func (m *MockESClient) GetDiskWatermarks(ctx context.Context) (*client.DiskWatermarks, error) {
+	if m.DiskWatermarksFn != nil {
+		return m.DiskWatermarksFn(ctx)
+	}
+	wm := client.DefaultDiskWatermarks()
+	return &wm, nil
+}
+
+func (m *MockESClient) UpdateClusterSettings(ctx context.Context, persistent, transient map[string]any) error {
+	if m.UpdateClusterSetFn != nil {
+		return m.UpdateClusterSetFn(ctx, persistent, transient)
+	}
+	return nil
+}
+
 func (m *MockESClient) DeleteIndex(ctx context.Context, names []string) error {
 	if m.DeleteIndexFn != nil {
 		return m.DeleteIndexFn(ctx, names)
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// fdCritPct is the share of the file descriptor limit above which the
+	// file descriptor recommendation becomes Critical.
+	fdCritPct = 95.0
+)
+
+// applyNodeResources fills row's host memory, swap, load average and file
+// descriptor fields from the node's stats.
+func applyNodeResources(row *model.NodeRow, node client.NodePerformanceStats) {
+	row.LoadAverage = [3]float64{-1, -1, -1}
+	row.OpenFDs = -1
+	row.MaxFDs = -1
+	row.FDPercent = -1
+
+	if node.OS != nil {
+		for i, key := range []string{"1m", "5m", "15m"} {
+			if v, ok := node.OS.CPU.LoadAverage[key]; ok && v >= 0 {
+				row.LoadAverage[i] = v
+			}
+		}
+		row.MemTotalBytes = node.OS.Mem.TotalInBytes
+		row.MemUsedBytes = node.OS.Mem.UsedInBytes
+		row.SwapTotalBytes = node.OS.Swap.TotalInBytes
+		row.SwapUsedBytes = node.OS.Swap.UsedInBytes
+	}
+	if node.Process != nil && node.Process.OpenFileDescriptors >= 0 {
+		row.OpenFDs = node.Process.OpenFileDescriptors
+		if node.Process.MaxFileDescriptors > 0 {
+			row.MaxFDs = node.Process.MaxFileDescriptors
+			row.FDPercent = float64(row.OpenFDs) / float64(row.MaxFDs) * 100
+		}
+	}
+}
+
+// nodeResourceRecs flags nodes whose host is swapping and nodes whose open
+// file descriptors exceed fdThreshold percent of their limit. A zero
+// fdThreshold disables the file descriptor check.
+func nodeResourceRecs(nodeRows []model.NodeRow, fdThreshold float64) []model.Recommendation {
+	var recs []model.Recommendation
+
+	var swapping []model.NodeRow
+	for _, n := range nodeRows {
+		if n.SwapUsedBytes > 0 {
+			swapping = append(swapping, n)
+		}
+	}
+	if len(swapping) > 0 {
+		sort.Slice(swapping, func(i, j int) bool {
+			if swapping[i].SwapUsedBytes != swapping[j].SwapUsedBytes {
+				return swapping[i].SwapUsedBytes > swapping[j].SwapUsedBytes
+			}
+			return swapping[i].Name < swapping[j].Name
+		})
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityWarning,
+			Category: model.CategoryResourcePressure,
+			Title:    "Swap in use",
+			Detail: fmt.Sprintf("%d node(s) have swap in use: %s. A swapped-out heap turns garbage collections into multi-second pauses that can drop the node from the cluster. Disable swap (swapoff -a), set vm.swappiness=1, or set bootstrap.memory_lock: true.",
+				len(swapping), nameList(swapping, func(n model.NodeRow) string {
+					return fmt.Sprintf("%s (%s of %s)", n.Name, format.FormatBytes(n.SwapUsedBytes), format.FormatBytes(n.SwapTotalBytes))
+				})),
+			Link: model.LinkNodeResources,
+		})
+	}
+
+	if fdThreshold <= 0 {
+		return recs
+	}
+	var nearLimit []model.NodeRow
+	for _, n := range nodeRows {
+		if n.FDPercent > fdThreshold {
+			nearLimit = append(nearLimit, n)
+		}
+	}
+	if len(nearLimit) > 0 {
+		sort.Slice(nearLimit, func(i, j int) bool {
+			if nearLimit[i].FDPercent != nearLimit[j].FDPercent {
+				return nearLimit[i].FDPercent > nearLimit[j].FDPercent
+			}
+			return nearLimit[i].Name < nearLimit[j].Name
+		})
+		severity := model.SeverityWarning
+		if nearLimit[0].FDPercent > fdCritPct {
+			severity = model.SeverityCritical
+		}
+		recs = append(recs, model.Recommendation{
+			Severity: severity,
+			Category: model.CategoryResourcePressure,
+			Title:    "File descriptors near limit",
+			Detail: fmt.Sprintf("%d node(s) have more than %.0f%% of their file descriptors open: %s. When the limit is reached the node cannot open segment files or accept connections, and shards fail. Raise the limit to at least 65535 (ulimit -n or LimitNOFILE) and reduce the shard and segment count on these nodes.",
+				len(nearLimit), fdThreshold, nameList(nearLimit, func(n model.NodeRow) string {
+					return fmt.Sprintf("%s (%s of %s)", n.Name, format.FormatNumber(n.OpenFDs), format.FormatNumber(n.MaxFDs))
+				})),
+			Link: model.LinkNodeResources,
+		})
+	}
+	return recs
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestCalcNodeRows_NodeResources(t *testing.T) {
+	stats := makeNodeStatsWithID("n1", "node-a", 100, 10, 100, 10)
+	node := stats.Nodes["n1"]
+	node.OS = makeNodeOS(30)
+	node.OS.CPU.LoadAverage = map[string]float64{"1m": 2.5, "5m": 1.5, "15m": 1.0}
+	node.OS.Mem = client.NodeMemoryStats{TotalInBytes: 16 << 30, UsedInBytes: 15 << 30}
+	node.OS.Swap = client.NodeMemoryStats{TotalInBytes: 2 << 30, UsedInBytes: 1 << 20}
+	node.Process = &client.NodeProcessStats{OpenFileDescriptors: 500, MaxFileDescriptors: 1000}
+	stats.Nodes["n1"] = node
+
+	rows := CalcNodeRows(nil, &model.Snapshot{NodeStats: stats}, 10*time.Second)
+	require.Len(t, rows, 1)
+	r := rows[0]
+	assert.Equal(t, [3]float64{2.5, 1.5, 1.0}, r.LoadAverage)
+	assert.Equal(t, int64(15<<30), r.MemUsedBytes)
+	assert.Equal(t, int64(1<<20), r.SwapUsedBytes)
+	assert.Equal(t, int64(500), r.OpenFDs)
+	assert.InDelta(t, 50.0, r.FDPercent, 1e-9)
+}
+
+func TestCalcNodeRows_NodeResourcesUnavailable(t *testing.T) {
+	stats := makeNodeStatsWithID("n1", "node-a", 100, 10, 100, 10)
+	node := stats.Nodes["n1"]
+	// Windows: no load average, both descriptor counts -1.
+	node.OS = makeNodeOS(30)
+	node.Process = &client.NodeProcessStats{OpenFileDescriptors: -1, MaxFileDescriptors: -1}
+	stats.Nodes["n1"] = node
+
+	rows := CalcNodeRows(nil, &model.Snapshot{NodeStats: stats}, 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.Equal(t, [3]float64{-1, -1, -1}, rows[0].LoadAverage)
+	assert.Equal(t, int64(-1), rows[0].OpenFDs)
+	assert.Equal(t, int64(-1), rows[0].MaxFDs)
+	assert.Equal(t, -1.0, rows[0].FDPercent)
+}
+
+func TestNodeResourceRecs_Swap(t *testing.T) {
+	assert.Empty(t, nodeResourceRecs([]model.NodeRow{{Name: "a", FDPercent: -1}}, 80))
+
+	recs := nodeResourceRecs([]model.NodeRow{
+		{Name: "a", SwapTotalBytes: 2 << 30, SwapUsedBytes: 1 << 20, FDPercent: -1},
+		{Name: "b", SwapTotalBytes: 2 << 30, SwapUsedBytes: 1 << 30, FDPercent: -1},
+	}, 80)
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, model.CategoryResourcePressure, recs[0].Category)
+	assert.Equal(t, "Swap in use", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "b (1.0 GB of 2.0 GB), a (1.0 MB of 2.0 GB)")
+	assert.NotContains(t, recs[0].Detail, "press")
+	assert.Equal(t, model.LinkNodeResources, recs[0].Link)
+}
+
+func TestNodeResourceRecs_FileDescriptors(t *testing.T) {
+	rows := []model.NodeRow{
+		{Name: "a", OpenFDs: 850, MaxFDs: 1000, FDPercent: 85},
+		{Name: "b", OpenFDs: 500, MaxFDs: 1000, FDPercent: 50},
+	}
+	recs := nodeResourceRecs(rows, 80)
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, "File descriptors near limit", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "a (850 of 1,000)")
+	assert.NotContains(t, recs[0].Detail, "b (")
+	assert.Equal(t, model.LinkNodeResources, recs[0].Link)
+
+	rows[0].FDPercent = 97
+	recs = nodeResourceRecs(rows, 80)
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityCritical, recs[0].Severity)
+
+	assert.Empty(t, nodeResourceRecs(rows, 0), "zero threshold disables the check")
+}
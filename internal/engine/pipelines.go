@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// pipelineFailCritPct is the share of documents a pipeline may fail in
+	// one interval before its failure recommendation becomes Critical.
+	pipelineFailCritPct = 10.0
+)
+
+// pipelineTotals sums a pipeline's counters over all nodes, with processor
+// counters summed by position in the pipeline.
+type pipelineTotals struct {
+	counters   client.IngestCounters
+	processors []processorTotals
+	baselined  bool // at least one node had the pipeline in the previous poll
+}
+
+type processorTotals struct {
+	name, typ string
+	counters  client.IngestCounters
+}
+
+// addCounters adds b to a.
+func addCounters(a *client.IngestCounters, b client.IngestCounters) {
+	a.Count += b.Count
+	a.TimeInMillis += b.TimeInMillis
+	a.Current += b.Current
+	a.Failed += b.Failed
+}
+
+// counterDelta returns curr - prev per counter, floored at zero so a node
+// restart (counters reset) contributes nothing rather than a negative rate.
+func counterDelta(curr, prev client.IngestCounters) client.IngestCounters {
+	d := func(c, p int64) int64 {
+		if c < p {
+			return 0
+		}
+		return c - p
+	}
+	return client.IngestCounters{
+		Count:        d(curr.Count, prev.Count),
+		TimeInMillis: d(curr.TimeInMillis, prev.TimeInMillis),
+		Failed:       d(curr.Failed, prev.Failed),
+	}
+}
+
+// addPipeline adds one node's pipeline stats to t. When prev is non-nil,
+// the difference from prev is added instead of the cumulative counters.
+func (t *pipelineTotals) addPipeline(p client.PipelineStats, prev *client.PipelineStats) {
+	if prev == nil {
+		addCounters(&t.counters, p.IngestCounters)
+	} else {
+		addCounters(&t.counters, counterDelta(p.IngestCounters, prev.IngestCounters))
+	}
+	for i, entry := range p.Processors {
+		for name, proc := range entry {
+			if i >= len(t.processors) {
+				t.processors = append(t.processors, processorTotals{name: name, typ: proc.Type})
+			}
+			c := proc.Stats
+			if prev != nil {
+				c = client.IngestCounters{}
+				if i < len(prev.Processors) {
+					if pp, ok := prev.Processors[i][name]; ok {
+						c = counterDelta(proc.Stats, pp.Stats)
+					}
+				}
+			}
+			addCounters(&t.processors[i].counters, c)
+		}
+	}
+}
+
+// CalcPipelineRows computes per-pipeline ingest throughput from two
+// consecutive snapshots, sorted by pipeline name. Counters are summed over
+// all nodes. Rates, time per doc and failure percent cover the interval
+// since prev and are MetricNotAvailable on the first poll, when elapsed
+// is too short, or for pipelines no node reported in prev. Processor time
+// shares use the interval when it recorded processor time, and the
+// cumulative counters otherwise, so idle pipelines still show where their
+// time went.
+func CalcPipelineRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.PipelineRow {
+	if curr == nil || curr.IngestStats == nil {
+		return nil
+	}
+	elapsedSec := elapsed.Seconds()
+	enoughTime := prev != nil && prev.IngestStats != nil && elapsedSec >= minTimeDiffSeconds
+
+	cumulative := make(map[string]*pipelineTotals)
+	interval := make(map[string]*pipelineTotals)
+	for nodeID, node := range curr.IngestStats.Nodes {
+		var prevNode client.NodeIngestStats
+		hasPrevNode := false
+		if enoughTime {
+			prevNode, hasPrevNode = prev.IngestStats.Nodes[nodeID]
+		}
+		for name, p := range node.Ingest.Pipelines {
+			if cumulative[name] == nil {
+				cumulative[name] = &pipelineTotals{}
+				interval[name] = &pipelineTotals{}
+			}
+			cumulative[name].addPipeline(p, nil)
+			if hasPrevNode {
+				if pp, ok := prevNode.Ingest.Pipelines[name]; ok {
+					interval[name].addPipeline(p, &pp)
+					interval[name].baselined = true
+				}
+			}
+		}
+	}
+
+	rows := make([]model.PipelineRow, 0, len(cumulative))
+	for name, cum := range cumulative {
+		iv := interval[name]
+		row := model.PipelineRow{
+			Name:           name,
+			DocsPerSec:     model.MetricNotAvailable,
+			TimePerDocMs:   model.MetricNotAvailable,
+			FailedPerSec:   model.MetricNotAvailable,
+			FailurePercent: model.MetricNotAvailable,
+			Current:        cum.counters.Current,
+			TotalCount:     cum.counters.Count,
+			TotalFailed:    cum.counters.Failed,
+		}
+		if iv.baselined {
+			row.DocsPerSec = clampRate(float64(iv.counters.Count) / elapsedSec)
+			row.FailedPerSec = clampRate(float64(iv.counters.Failed) / elapsedSec)
+			if iv.counters.Count > 0 {
+				row.TimePerDocMs = clampLatency(float64(iv.counters.TimeInMillis) / float64(iv.counters.Count))
+				row.FailurePercent = float64(iv.counters.Failed) / float64(iv.counters.Count) * 100
+			}
+		}
+
+		src := cum.processors
+		if iv.baselined && processorTime(iv.processors) > 0 {
+			src = iv.processors
+		}
+		total := processorTime(src)
+		for i, p := range cum.processors {
+			pr := model.ProcessorRow{
+				Name:         p.name,
+				Type:         p.typ,
+				Count:        p.counters.Count,
+				Failed:       p.counters.Failed,
+				TimePercent:  -1,
+				TimePerDocMs: -1,
+			}
+			if i < len(src) {
+				if total > 0 {
+					pr.TimePercent = float64(src[i].counters.TimeInMillis) / float64(total) * 100
+				}
+				if src[i].counters.Count > 0 {
+					pr.TimePerDocMs = float64(src[i].counters.TimeInMillis) / float64(src[i].counters.Count)
+				}
+			}
+			row.Processors = append(row.Processors, pr)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return rows
+}
+
+// processorTime sums the time spent in procs.
+func processorTime(procs []processorTotals) int64 {
+	var total int64
+	for _, p := range procs {
+		total += p.counters.TimeInMillis
+	}
+	return total
+}
+
+// pipelineRecs flags pipelines that failed documents since the previous
+// poll. Failed documents are not indexed unless the pipeline has an
+// on_failure handler, so any failures are worth a look.
+func pipelineRecs(pipelines []model.PipelineRow) []model.Recommendation {
+	var failing []model.PipelineRow
+	for _, p := range pipelines {
+		if p.FailedPerSec > 0 {
+			failing = append(failing, p)
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+	sort.Slice(failing, func(i, j int) bool {
+		if failing[i].FailurePercent != failing[j].FailurePercent {
+			return failing[i].FailurePercent > failing[j].FailurePercent
+		}
+		return failing[i].Name < failing[j].Name
+	})
+
+	severity := model.SeverityWarning
+	if failing[0].FailurePercent >= pipelineFailCritPct {
+		severity = model.SeverityCritical
+	}
+	names := nameList(failing, func(p model.PipelineRow) string {
+		return fmt.Sprintf("%s (%.1f%% of docs, %.1f/s)", p.Name, p.FailurePercent, p.FailedPerSec)
+	})
+	return []model.Recommendation{{
+		Severity: severity,
+		Category: model.CategoryIndexConfig,
+		Title:    "Ingest pipeline failures",
+		Detail: fmt.Sprintf("%d pipeline(s) failed documents since the last poll: %s. Documents failing a pipeline without an on_failure handler are rejected. Find the failing processor in the pipeline stats and test it with POST _ingest/pipeline/<name>/_simulate.",
+			len(failing), names),
+		Link: model.LinkPipelines,
+	}}
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func ingestSnap(nodes map[string]map[string]client.PipelineStats) *model.Snapshot {
+	resp := &client.IngestStatsResponse{Nodes: map[string]client.NodeIngestStats{}}
+	for id, pipelines := range nodes {
+		n := client.NodeIngestStats{Name: id}
+		n.Ingest.Pipelines = pipelines
+		resp.Nodes[id] = n
+	}
+	return &model.Snapshot{IngestStats: resp}
+}
+
+func pipelineStats(count, timeMs, failed int64, procTimes ...int64) client.PipelineStats {
+	p := client.PipelineStats{IngestCounters: client.IngestCounters{Count: count, TimeInMillis: timeMs, Failed: failed}}
+	for i, ms := range procTimes {
+		name, typ := []string{"grok:parse", "set"}[i], []string{"grok", "set"}[i]
+		p.Processors = append(p.Processors, map[string]client.ProcessorStats{
+			name: {Type: typ, Stats: client.IngestCounters{Count: count, TimeInMillis: ms}},
+		})
+	}
+	return p
+}
+
+func TestCalcPipelineRows(t *testing.T) {
+	prev := ingestSnap(map[string]map[string]client.PipelineStats{
+		"n1": {"logs": pipelineStats(1000, 500, 0, 400, 100)},
+		"n2": {"logs": pipelineStats(1000, 500, 0, 400, 100)},
+	})
+	curr := ingestSnap(map[string]map[string]client.PipelineStats{
+		"n1": {"logs": pipelineStats(1600, 800, 30, 500, 200), "idle": pipelineStats(5, 1, 0)},
+		"n2": {"logs": pipelineStats(1400, 700, 10, 500, 200)},
+	})
+
+	rows := CalcPipelineRows(prev, curr, 10*time.Second)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "idle", rows[0].Name)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].DocsPerSec, "pipeline missing from prev has no rate")
+
+	logs := rows[1]
+	assert.InDelta(t, 100.0, logs.DocsPerSec, 0.001)
+	assert.InDelta(t, 0.5, logs.TimePerDocMs, 0.001)
+	assert.InDelta(t, 4.0, logs.FailedPerSec, 0.001)
+	assert.InDelta(t, 4.0, logs.FailurePercent, 0.001)
+	assert.Equal(t, int64(3000), logs.TotalCount)
+	require.Len(t, logs.Processors, 2)
+	assert.Equal(t, "grok:parse", logs.Processors[0].Name)
+	assert.InDelta(t, 50.0, logs.Processors[0].TimePercent, 0.001, "share uses the interval: 200 of 400 ms")
+
+	// First poll: rates unavailable, processor shares from cumulative counters.
+	rows = CalcPipelineRows(nil, curr, 0)
+	assert.Equal(t, model.MetricNotAvailable, rows[1].DocsPerSec)
+	assert.Equal(t, model.MetricNotAvailable, rows[1].FailurePercent)
+	assert.InDelta(t, 1000.0/1400*100, rows[1].Processors[0].TimePercent, 0.001)
+
+	// Counter reset after a node restart never yields negative rates.
+	restarted := ingestSnap(map[string]map[string]client.PipelineStats{
+		"n1": {"logs": pipelineStats(10, 5, 0, 4, 1)},
+		"n2": {"logs": pipelineStats(1000, 500, 0, 400, 100)},
+	})
+	rows = CalcPipelineRows(prev, restarted, 10*time.Second)
+	assert.Equal(t, 0.0, rows[0].DocsPerSec)
+
+	assert.Nil(t, CalcPipelineRows(prev, &model.Snapshot{}, time.Second))
+}
+
+func TestPipelineRecs(t *testing.T) {
+	assert.Empty(t, pipelineRecs([]model.PipelineRow{
+		{Name: "ok", FailedPerSec: 0, FailurePercent: 0},
+		{Name: "first-poll", FailedPerSec: model.MetricNotAvailable},
+	}))
+
+	recs := pipelineRecs([]model.PipelineRow{
+		{Name: "a", FailedPerSec: 1, FailurePercent: 2},
+		{Name: "b", FailedPerSec: 0.5, FailurePercent: 5},
+	})
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, "Ingest pipeline failures", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "2 pipeline(s)")
+	assert.Contains(t, recs[0].Detail, "b (5.0% of docs, 0.5/s), a (2.0% of docs, 1.0/s)")
+	assert.NotContains(t, recs[0].Detail, "press")
+	assert.Equal(t, model.LinkPipelines, recs[0].Link)
+
+	recs = pipelineRecs([]model.PipelineRow{{Name: "a", FailedPerSec: 3, FailurePercent: 25}})
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityCritical, recs[0].Severity)
+}
+
+func TestCalcRecommendations_PipelineFailures(t *testing.T) {
+	snap := &model.Snapshot{Health: client.ClusterHealth{Status: "green"}}
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil,
+		[]model.PipelineRow{{Name: "logs", FailedPerSec: 1, FailurePercent: 2}}, nil)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "Ingest pipeline failures"))
+}
@@ -2,100 +2,533 @@ package engine
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/jtsunne/epm-go/internal/client"
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
-// FetchAll calls all 5 Elasticsearch endpoints concurrently, plus the optional
-// allocation endpoint. If any of the 5 core endpoints fails, FetchAll returns
-// the first error. Allocation failures are non-fatal (some ES versions may not
-// support /_cat/allocation); on error the field is left nil/empty.
+// FetchAll calls the 5 core Elasticsearch endpoints concurrently, along
+// with the optional allocation, pending tasks, recovery, ingest, CCR and
+// indexing pressure endpoints.
+//
+// Each core endpoint is a section of its own. When one fails, the snapshot
+// records the error in Sections and leaves that section empty; the Poller
+// then carries the last good data forward, so a slow /_stats does not blank
+// the node table. Every request gets its own deadline, shorter than ctx's,
+// so a request that times out fails only its own section. FetchAll returns
+// an error only when every core endpoint fails.
+//
+// Optional endpoints never fail a section: some ES versions lack them and
+// some need extra privileges, so on error the field is left nil.
+//
+// Endpoints too heavy for every poll are not requested here. Mapping stats,
+// shard copies, snapshot listings, lifecycle explain, data streams, aliases,
+// disk watermarks, node attributes, remote info and node versions come from
+// the Poller's background fetches.
 func FetchAll(ctx context.Context, c client.ESClient) (*model.Snapshot, error) {
 	var (
-		health     *client.ClusterHealth
-		nodes      []client.NodeInfo
-		nodeStats  *client.NodeStatsResponse
-		indices    []client.IndexInfo
-		indexStats *client.IndexStatsResponse
 		allocation []client.AllocationInfo
+		pending    []client.PendingTask
+		recoveries []client.RecoveryInfo
+		ingest     *client.IngestStatsResponse
+		ccr        *client.CCRStatsResponse
+		pressure   *client.IndexingPressureResponse
 	)
 
-	g, gctx := errgroup.WithContext(ctx)
-
-	g.Go(func() error {
-		var err error
-		health, err = c.GetClusterHealth(gctx)
-		return err
-	})
-
-	g.Go(func() error {
-		var err error
-		nodes, err = c.GetNodes(gctx)
-		return err
-	})
-
-	g.Go(func() error {
-		var err error
-		nodeStats, err = c.GetNodeStats(gctx)
-		return err
-	})
-
-	g.Go(func() error {
-		var err error
-		indices, err = c.GetIndices(gctx)
-		return err
-	})
-
-	g.Go(func() error {
-		var err error
-		indexStats, err = c.GetIndexStats(gctx)
-		return err
-	})
-
-	// Allocation is non-fatal and runs outside the errgroup so a slow or hung
-	// /_cat/allocation call does not delay the 5 core metrics.  Uses the
-	// parent ctx (not gctx) so the request is not prematurely cancelled when
-	// the core requests complete.  The buffered channel prevents a goroutine
-	// leak regardless of whether the result is consumed.
-	allocCh := make(chan []client.AllocationInfo, 1)
+	timeout := requestTimeout(ctx)
+	healthCh := fetchSection(ctx, timeout, c.GetClusterHealth)
+	nodesCh := fetchSection(ctx, timeout, c.GetNodes)
+	nodeStatsCh := fetchSection(ctx, timeout, c.GetNodeStats)
+	indicesCh := fetchSection(ctx, timeout, c.GetIndices)
+	indexStatsCh := fetchSection(ctx, timeout, c.GetIndexStats)
+
+	// Optional endpoints are non-fatal and never fail a section.
+	allocCh := fetchOptional(ctx, timeout, c.GetAllocation)
+	pendingCh := fetchOptional(ctx, timeout, c.GetPendingTasks)
+	recoveryCh := fetchOptional(ctx, timeout, c.GetRecovery)
+	ingestCh := fetchOptional(ctx, timeout, c.GetIngestStats)
+	ccrCh := fetchOptional(ctx, timeout, c.GetCCRStats)
+	pressureCh := fetchOptional(ctx, timeout, c.GetIndexingPressure)
+
+	health := awaitSection(ctx, healthCh)
+	nodes := awaitSection(ctx, nodesCh)
+	nodeStats := awaitSection(ctx, nodeStatsCh)
+	indices := awaitSection(ctx, indicesCh)
+	indexStats := awaitSection(ctx, indexStatsCh)
+
+	allocation = awaitOptional(ctx, allocCh)
+	pending = awaitOptional(ctx, pendingCh)
+	recoveries = awaitOptional(ctx, recoveryCh)
+	ingest = awaitOptional(ctx, ingestCh)
+	ccr = awaitOptional(ctx, ccrCh)
+	pressure = awaitOptional(ctx, pressureCh)
+
+	// A nil response without an error is a malformed reply; fail only that
+	// section.
+	if health.err == nil && health.v == nil {
+		health.err = errIncomplete
+	}
+	if nodeStats.err == nil && nodeStats.v == nil {
+		nodeStats.err = errIncomplete
+	}
+	if indexStats.err == nil && indexStats.v == nil {
+		indexStats.err = errIncomplete
+	}
+
+	now := time.Now()
+	errs := map[model.Section]error{
+		model.SectionHealth:     health.err,
+		model.SectionNodes:      nodes.err,
+		model.SectionNodeStats:  nodeStats.err,
+		model.SectionIndices:    indices.err,
+		model.SectionIndexStats: indexStats.err,
+	}
+	sections := make(map[model.Section]model.SectionState, len(errs))
+	var firstErr error
+	failed := 0
+	for _, sec := range model.CoreSections {
+		if err := errs[sec]; err != nil {
+			sections[sec] = model.SectionState{Err: err}
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		} else {
+			sections[sec] = model.SectionState{FetchedAt: now}
+		}
+	}
+	if failed == len(model.CoreSections) {
+		// Nothing reached the cluster: report it as unreachable.
+		return nil, firstErr
+	}
+
+	snap := &model.Snapshot{
+		Nodes:            nodes.v,
+		Indices:          indices.v,
+		Allocation:       allocation,
+		PendingTasks:     pending,
+		Recoveries:       recoveries,
+		IngestStats:      ingest,
+		CCRStats:         ccr,
+		IndexingPressure: pressure,
+		Sections:         sections,
+		FetchedAt:        now,
+	}
+	if health.v != nil {
+		snap.Health = *health.v
+	}
+	if nodeStats.v != nil {
+		snap.NodeStats = *nodeStats.v
+	}
+	if indexStats.v != nil {
+		snap.IndexStats = *indexStats.v
+	}
+	return snap, nil
+}
+
+// errIncomplete marks a core section whose endpoint returned no body.
+var errIncomplete = errors.New("incomplete response (unexpected nil)")
+
+// sectionResult is the value and error of one core endpoint request.
+type sectionResult[T any] struct {
+	v   T
+	err error
+}
+
+// requestTimeout returns the deadline of each request FetchAll makes: 90% of
+// the time left on ctx, so a request that times out is recorded as its own
+// section's error before the poll's deadline expires. It returns 0 when ctx
+// has no deadline.
+func requestTimeout(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return time.Until(deadline) * 9 / 10
+}
+
+// withTimeout derives the context of one request from ctx, with timeout d
+// unless d is 0.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// fetchSection starts the core endpoint request fn in its own goroutine with
+// its own timeout and returns a buffered channel that receives its result.
+func fetchSection[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) <-chan sectionResult[T] {
+	ch := make(chan sectionResult[T], 1)
+	go func() {
+		reqCtx, cancel := withTimeout(ctx, timeout)
+		defer cancel()
+		v, err := fn(reqCtx)
+		ch <- sectionResult[T]{v: v, err: err}
+	}()
+	return ch
+}
+
+// awaitSection waits for a core endpoint result. When ctx expires first, its
+// error becomes the section's error.
+func awaitSection[T any](ctx context.Context, ch <-chan sectionResult[T]) sectionResult[T] {
+	select {
+	case r := <-ch:
+		return r
+	case <-ctx.Done():
+		return sectionResult[T]{err: ctx.Err()}
+	}
+}
+
+// fetchOptional starts fn in its own goroutine with its own timeout and
+// returns a channel that receives the result, or the zero value when fn
+// fails. The buffered channel prevents a goroutine leak regardless of whether
+// the result is consumed.
+func fetchOptional[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) <-chan T {
+	ch := make(chan T, 1)
 	go func() {
-		alloc, err := c.GetAllocation(ctx)
+		reqCtx, cancel := withTimeout(ctx, timeout)
+		defer cancel()
+		v, err := fn(reqCtx)
 		if err != nil {
-			allocCh <- nil
+			var zero T
+			ch <- zero
 			return
 		}
-		allocCh <- alloc
+		ch <- v
 	}()
+	return ch
+}
+
+// awaitOptional waits for an optional result or context expiry. The request
+// behind ch times out before ctx does, so it normally completes (success or
+// error) first; ctx.Done() acts as the outer guard and yields the zero value.
+func awaitOptional[T any](ctx context.Context, ch <-chan T) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		var zero T
+		return zero
+	}
+}
+
+// PendingTasksStreak returns the number of consecutive polls, ending with
+// curr, in which the pending task queue was non-empty. A poll where the
+// endpoint was unavailable (nil PendingTasks) resets the streak.
+func PendingTasksStreak(prev, curr *model.Snapshot) int {
+	if curr == nil || len(curr.PendingTasks) == 0 {
+		return 0
+	}
+	if prev == nil {
+		return 1
+	}
+	return prev.PendingTasksStreak + 1
+}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+// OldestPendingTask returns the longest time any task has spent in the
+// pending queue, or zero when the queue is empty.
+func OldestPendingTask(tasks []client.PendingTask) time.Duration {
+	var oldest int64
+	for _, t := range tasks {
+		if t.TimeInQueueMillis > oldest {
+			oldest = t.TimeInQueueMillis
+		}
 	}
+	return time.Duration(oldest) * time.Millisecond
+}
+
+// PollResult is the outcome of one poll cycle: the snapshot with every
+// metric derived from it, or the error that failed the cycle.
+type PollResult struct {
+	Snapshot        *model.Snapshot
+	Metrics         model.PerformanceMetrics
+	Resources       model.ClusterResources
+	NodeRows        []model.NodeRow
+	IndexRows       []model.IndexRow
+	Recoveries      []model.RecoveryRow
+	DataStreams     []model.DataStreamRow
+	Aliases         []model.AliasRow
+	Pipelines       []model.PipelineRow
+	Followers       []model.FollowerRow
+	Recommendations []model.Recommendation
+
+	// Err is non-nil when a core endpoint failed; all other fields except
+	// Fails are then zero.
+	Err error
+	// Fails is the number of consecutive failed polls, 0 after a success.
+	Fails int
+	// Seq numbers the poll, from 1 in the order polls start. A result whose
+	// Seq is at most Started() at some moment came from a poll that started
+	// before that moment.
+	Seq uint64
+}
+
+// Poller polls a cluster on a fixed interval, backing off while it is
+// unreachable. Each cycle pairs the new snapshot with the last successful
+// one, runs the Calc* pipeline over the pair and publishes the result to
+// every subscriber and callback. It has no dependency on the TUI, so the
+// dashboard and headless consumers share the same stream.
+type Poller struct {
+	client   client.ESClient
+	interval time.Duration
+	refresh  chan struct{}
 
-	// Wait for allocation data or context expiry.  The goroutine above uses
-	// the parent ctx, so it will complete (success or error) before ctx
-	// expires; ctx.Done() acts as the outer timeout guard.
+	mu         sync.Mutex
+	cfg        RecommendationConfig
+	prev       *model.Snapshot // last successful snapshot; deltas are computed against it
+	fails      int
+	seq        uint64 // number of polls started
+	subs       []chan PollResult
+	callbacks  []func(PollResult)
+	background []*backgroundFetch
+}
+
+// NewPoller returns a Poller that polls c every interval and derives
+// recommendations with cfg. Polling starts when Run is called.
+func NewPoller(c client.ESClient, interval time.Duration, cfg RecommendationConfig) *Poller {
+	return &Poller{
+		client:     c,
+		interval:   interval,
+		refresh:    make(chan struct{}, 1),
+		cfg:        cfg,
+		background: defaultBackgroundFetches(),
+	}
+}
+
+// SetConfig replaces the recommendation thresholds used from the next poll on.
+func (p *Poller) SetConfig(cfg RecommendationConfig) {
+	p.mu.Lock()
+	p.cfg = cfg
+	p.mu.Unlock()
+}
+
+// Subscribe returns a channel that receives every published result. The
+// channel holds one result; when the subscriber falls behind, the
+// undelivered result is replaced by the newer one so the poller never blocks.
+func (p *Poller) Subscribe() <-chan PollResult {
+	ch := make(chan PollResult, 1)
+	p.mu.Lock()
+	p.subs = append(p.subs, ch)
+	p.mu.Unlock()
+	return ch
+}
+
+// OnResult registers fn to be called with every published result. fn runs on
+// the Run goroutine and delays the next poll until it returns.
+func (p *Poller) OnResult(fn func(PollResult)) {
+	p.mu.Lock()
+	p.callbacks = append(p.callbacks, fn)
+	p.mu.Unlock()
+}
+
+// Started returns the Seq of the last poll started, 0 before the first one.
+// A consumer that changes the cluster can record it and treat results with a
+// Seq at most that value as taken before the change.
+func (p *Poller) Started() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.seq
+}
+
+// Refresh asks Run to poll now instead of waiting for the interval or
+// backoff delay. A refresh requested while a poll is in flight starts
+// another poll as soon as that one completes. It never blocks.
+func (p *Poller) Refresh() {
 	select {
-	case allocation = <-allocCh:
-	case <-ctx.Done():
+	case p.refresh <- struct{}{}:
+	default: // a refresh is already pending
 	}
+}
+
+// Run polls immediately, then after every interval (or backoff delay while
+// polls fail) until ctx is cancelled, publishing each result. It returns
+// ctx.Err().
+func (p *Poller) Run(ctx context.Context) error {
+	for {
+		r := p.Poll(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		p.publish(r)
 
-	if health == nil || nodeStats == nil || indexStats == nil {
-		return nil, fmt.Errorf("FetchAll: incomplete response (unexpected nil)")
+		delay := p.interval
+		if r.Err != nil {
+			delay = BackoffDuration(r.Fails)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-p.refresh:
+			timer.Stop()
+		case <-timer.C:
+		}
 	}
+}
 
-	snap := &model.Snapshot{
-		Health:     *health,
-		Nodes:      nodes,
-		NodeStats:  *nodeStats,
-		Indices:    indices,
-		IndexStats: *indexStats,
-		Allocation: allocation,
-		FetchedAt:  time.Now(),
+// Poll runs one poll cycle: it fetches a snapshot, fills sections that failed
+// with the last good data, starts the background fetches that are due and
+// adds the last result of each, carries the cross-poll state (pending task
+// streak, restarts, pipeline and follower rates) over from the previous
+// successful snapshot, and computes all derived metrics.
+// A successful poll becomes the baseline of the next one; a failed poll
+// leaves the baseline in place. Poll does not publish the result and must
+// not be called concurrently with Run.
+func (p *Poller) Poll(ctx context.Context) PollResult {
+	p.mu.Lock()
+	p.seq++
+	prev, cfg, seq := p.prev, p.cfg, p.seq
+	p.mu.Unlock()
+
+	// The fetch context timeout is capped so that an in-flight request is
+	// always cancelled promptly when ctx is, whatever the poll interval.
+	fetchCtx, cancel := context.WithTimeout(ctx, FetchTimeout(p.interval))
+	defer cancel()
+
+	snap, err := FetchAll(fetchCtx, p.client)
+	if err != nil {
+		p.mu.Lock()
+		p.fails++
+		fails := p.fails
+		p.mu.Unlock()
+		return PollResult{Err: err, Fails: fails, Seq: seq}
 	}
-	return snap, nil
+	carryOverStale(prev, snap)
+	p.startBackground(ctx, snap.FetchedAt)
+	p.applyBackground(snap)
+	snap.PendingTasksStreak = PendingTasksStreak(prev, snap)
+
+	var elapsed time.Duration
+	if prev != nil {
+		elapsed = snap.FetchedAt.Sub(prev.FetchedAt)
+	}
+
+	snap.Restarts = NodeRestarts(prev, snap)
+	snap.NodesSeen = TrackNodesSeen(prev, snap)
+	r := PollResult{Snapshot: snap, Seq: seq}
+	r.Metrics = CalcClusterMetrics(prev, snap, elapsed)
+	r.Resources = CalcClusterResources(snap)
+	r.NodeRows = CalcNodeRows(prev, snap, elapsed)
+	r.IndexRows = CalcIndexRows(prev, snap, elapsed)
+	r.Recoveries = CalcRecoveryRows(prev, snap, elapsed)
+	r.Pipelines = CalcPipelineRows(prev, snap, elapsed)
+	r.Followers = CalcFollowerRows(prev, snap, elapsed)
+	snap.FollowerStalls = FollowerStalls(r.Followers)
+	r.DataStreams = CalcDataStreamRows(snap.DataStreams, r.IndexRows)
+	r.Aliases = CalcAliasRows(snap.Aliases, r.IndexRows)
+	r.Recommendations = CalcRecommendationsWithConfig(snap, r.Resources, r.NodeRows, r.IndexRows, r.Pipelines, r.Followers, cfg)
+
+	p.mu.Lock()
+	p.prev = snap
+	p.fails = 0
+	p.mu.Unlock()
+	return r
+}
+
+// carryOverStale copies the data of every stale section of curr from prev,
+// keeping the time it was fetched, so a failed endpoint shows its last good
+// data instead of nothing.
+func carryOverStale(prev, curr *model.Snapshot) {
+	if prev == nil {
+		return
+	}
+	for _, sec := range curr.Degraded() {
+		switch sec {
+		case model.SectionHealth:
+			curr.Health = prev.Health
+		case model.SectionNodes:
+			curr.Nodes = prev.Nodes
+		case model.SectionNodeStats:
+			curr.NodeStats = prev.NodeStats
+		case model.SectionIndices:
+			curr.Indices = prev.Indices
+		case model.SectionIndexStats:
+			curr.IndexStats = prev.IndexStats
+		}
+		state := curr.Sections[sec]
+		state.FetchedAt = prev.Sections[sec].FetchedAt
+		if prev.Sections == nil {
+			state.FetchedAt = prev.FetchedAt
+		}
+		curr.Sections[sec] = state
+	}
+}
+
+// deltaBaseline returns prev when each of secs is fresh in both prev and
+// curr, and nil otherwise. Rates are computed against the result, so a
+// section holding carried-over data never yields a delta: it would be zero
+// against an equal baseline, or span more time than elapsed.
+func deltaBaseline(prev, curr *model.Snapshot, secs ...model.Section) *model.Snapshot {
+	for _, sec := range secs {
+		if prev.Stale(sec) || curr.Stale(sec) {
+			return nil
+		}
+	}
+	return prev
+}
+
+// publish delivers r to every subscriber channel, replacing a result the
+// subscriber has not consumed yet, then calls every callback.
+func (p *Poller) publish(r PollResult) {
+	p.mu.Lock()
+	subs := append([]chan PollResult{}, p.subs...)
+	callbacks := append([]func(PollResult){}, p.callbacks...)
+	p.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- r:
+			continue
+		default:
+		}
+		select {
+		case <-ch: // drop the stale result
+		default:
+		}
+		select {
+		case ch <- r:
+		default:
+		}
+	}
+	for _, fn := range callbacks {
+		fn(r)
+	}
+}
+
+// FetchTimeout returns the context timeout for one poll's requests.
+// It is interval - 500ms, clamped to [500ms, 10s].
+// The 10s upper bound ensures that any in-flight fetch cancels promptly
+// when the poller is stopped, regardless of how large the poll interval is.
+func FetchTimeout(interval time.Duration) time.Duration {
+	const (
+		minTimeout = 500 * time.Millisecond
+		maxTimeout = 10 * time.Second
+	)
+	t := interval - 500*time.Millisecond
+	if t < minTimeout {
+		t = minTimeout
+	}
+	if t > maxTimeout {
+		t = maxTimeout
+	}
+	return t
+}
+
+// BackoffDuration returns the delay before the next poll after fails
+// consecutive failures: min(2^fails * time.Second, 60*time.Second).
+// At fails=1: 2s, fails=2: 4s, fails=3: 8s, ..., fails>=6: 60s.
+func BackoffDuration(fails int) time.Duration {
+	const maxBackoff = 60 * time.Second
+	if fails <= 0 {
+		return time.Second
+	}
+	if fails >= 6 {
+		return maxBackoff
+	}
+	return time.Duration(1<<fails) * time.Second
 }
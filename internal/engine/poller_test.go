@@ -2,12 +2,15 @@ package engine
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
 )
 
 func TestFetchAll_AllSuccess(t *testing.T) {
@@ -72,8 +75,31 @@ func TestFetchAll_PartialFailure(t *testing.T) {
 		},
 	}
 
+	// A failed core endpoint degrades only its own section.
 	snap, err := FetchAll(context.Background(), mc)
-	assert.Error(t, err)
+	require.NoError(t, err)
+	require.NotNil(t, snap)
+	assert.Equal(t, "green", snap.Health.Status)
+	assert.Empty(t, snap.NodeStats.Nodes)
+	assert.True(t, snap.Stale(model.SectionNodeStats))
+	assert.ErrorIs(t, snap.Sections[model.SectionNodeStats].Err, errMockFailure)
+	assert.True(t, snap.Sections[model.SectionNodeStats].FetchedAt.IsZero())
+	assert.False(t, snap.Stale(model.SectionHealth))
+	assert.Equal(t, snap.FetchedAt, snap.Sections[model.SectionHealth].FetchedAt)
+	assert.Equal(t, []model.Section{model.SectionNodeStats}, snap.Degraded())
+}
+
+func TestFetchAll_AllCoreFailures(t *testing.T) {
+	mc := &MockESClient{
+		HealthFn:     func(_ context.Context) (*client.ClusterHealth, error) { return nil, errMockFailure },
+		NodesFn:      func(_ context.Context) ([]client.NodeInfo, error) { return nil, errMockFailure },
+		NodeStatsFn:  func(_ context.Context) (*client.NodeStatsResponse, error) { return nil, errMockFailure },
+		IndicesFn:    func(_ context.Context) ([]client.IndexInfo, error) { return nil, errMockFailure },
+		IndexStatsFn: func(_ context.Context) (*client.IndexStatsResponse, error) { return nil, nil },
+	}
+
+	snap, err := FetchAll(context.Background(), mc)
+	assert.ErrorIs(t, err, errMockFailure)
 	assert.Nil(t, snap)
 }
 
@@ -81,21 +107,359 @@ func TestFetchAll_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // cancel before calling FetchAll
 
-	mc := &MockESClient{} // all defaults succeed, but context is already done
+	// stdlib HTTP honours context; the mock core endpoints check it
+	// explicitly. A cancelled ctx fails every core section, and so the fetch.
+	mc := &MockESClient{
+		HealthFn:     func(ctx context.Context) (*client.ClusterHealth, error) { return nil, ctx.Err() },
+		NodesFn:      func(ctx context.Context) ([]client.NodeInfo, error) { return nil, ctx.Err() },
+		NodeStatsFn:  func(ctx context.Context) (*client.NodeStatsResponse, error) { return nil, ctx.Err() },
+		IndicesFn:    func(ctx context.Context) ([]client.IndexInfo, error) { return nil, ctx.Err() },
+		IndexStatsFn: func(ctx context.Context) (*client.IndexStatsResponse, error) { return nil, ctx.Err() },
+	}
+
+	snap, err := FetchAll(ctx, mc)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, snap)
+}
 
-	// With a pre-cancelled context, at least one goroutine should detect it.
-	// errgroup propagates the first error; stdlib HTTP honours context.
-	// Since MockESClient doesn't honour context, we test that a pre-cancelled
-	// ctx passed through errgroup still causes a context error when the mock
-	// explicitly checks it.
-	mc.HealthFn = func(ctx context.Context) (*client.ClusterHealth, error) {
-		if ctx.Err() != nil {
+func TestFetchAll_SectionTimeout(t *testing.T) {
+	block := func(ctx context.Context) {
+		<-ctx.Done()
+	}
+	mc := &MockESClient{
+		IndexStatsFn: func(ctx context.Context) (*client.IndexStatsResponse, error) {
+			block(ctx)
 			return nil, ctx.Err()
-		}
-		return &client.ClusterHealth{Status: "green"}, nil
+		},
+		RecoveryFn: func(ctx context.Context) ([]client.RecoveryInfo, error) {
+			block(ctx)
+			return nil, ctx.Err()
+		},
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
 	snap, err := FetchAll(ctx, mc)
-	assert.Error(t, err)
-	assert.Nil(t, snap)
+	require.NoError(t, err, "a slow endpoint fails only its own section")
+	require.NotNil(t, snap)
+	assert.Equal(t, []model.Section{model.SectionIndexStats}, snap.Degraded())
+	assert.ErrorIs(t, snap.Sections[model.SectionIndexStats].Err, context.DeadlineExceeded)
+	assert.Equal(t, "green", snap.Health.Status)
+	assert.Len(t, snap.Indices, 1)
+	assert.Nil(t, snap.Recoveries)
+	assert.NoError(t, ctx.Err(), "requests time out before the poll deadline")
+}
+
+func TestFetchAll_PendingTasks(t *testing.T) {
+	tasks := []client.PendingTask{{InsertOrder: 7, Priority: "URGENT", Source: "create-index"}}
+	mc := &MockESClient{
+		PendingTasksFn: func(_ context.Context) ([]client.PendingTask, error) { return tasks, nil },
+	}
+
+	snap, err := FetchAll(context.Background(), mc)
+	require.NoError(t, err)
+	assert.Equal(t, tasks, snap.PendingTasks)
+}
+
+func TestFetchAll_PendingTasksFailureIsNonFatal(t *testing.T) {
+	mc := &MockESClient{
+		PendingTasksFn: func(_ context.Context) ([]client.PendingTask, error) {
+			return nil, errMockFailure
+		},
+	}
+
+	snap, err := FetchAll(context.Background(), mc)
+	require.NoError(t, err)
+	require.NotNil(t, snap)
+	assert.Nil(t, snap.PendingTasks)
+}
+
+func TestFetchAll_RecoveryFailureIsNonFatal(t *testing.T) {
+	mc := &MockESClient{
+		RecoveryFn: func(_ context.Context) ([]client.RecoveryInfo, error) {
+			return nil, errMockFailure
+		},
+	}
+
+	snap, err := FetchAll(context.Background(), mc)
+	require.NoError(t, err)
+	require.NotNil(t, snap)
+	assert.Nil(t, snap.Recoveries)
+}
+
+func TestPendingTasksStreak(t *testing.T) {
+	nonEmpty := []client.PendingTask{{InsertOrder: 1}}
+
+	assert.Equal(t, 0, PendingTasksStreak(nil, nil))
+	assert.Equal(t, 0, PendingTasksStreak(nil, &model.Snapshot{PendingTasks: []client.PendingTask{}}))
+	assert.Equal(t, 1, PendingTasksStreak(nil, &model.Snapshot{PendingTasks: nonEmpty}))
+
+	prev := &model.Snapshot{PendingTasks: nonEmpty, PendingTasksStreak: 2}
+	assert.Equal(t, 3, PendingTasksStreak(prev, &model.Snapshot{PendingTasks: nonEmpty}))
+	// Queue drained or endpoint unavailable resets the streak.
+	assert.Equal(t, 0, PendingTasksStreak(prev, &model.Snapshot{PendingTasks: []client.PendingTask{}}))
+	assert.Equal(t, 0, PendingTasksStreak(prev, &model.Snapshot{}))
+}
+
+func TestOldestPendingTask(t *testing.T) {
+	assert.Equal(t, time.Duration(0), OldestPendingTask(nil))
+	tasks := []client.PendingTask{
+		{TimeInQueueMillis: 120},
+		{TimeInQueueMillis: 4500},
+		{TimeInQueueMillis: 30},
+	}
+	assert.Equal(t, 4500*time.Millisecond, OldestPendingTask(tasks))
+}
+
+func TestFetchTimeout(t *testing.T) {
+	cases := []struct {
+		interval time.Duration
+		expected time.Duration
+	}{
+		{100 * time.Millisecond, 500 * time.Millisecond}, // below min → clamp to 500ms
+		{500 * time.Millisecond, 500 * time.Millisecond}, // 0ms after subtraction → min
+		{1 * time.Second, 500 * time.Millisecond},        // 500ms → min
+		{5 * time.Second, 4500 * time.Millisecond},       // normal
+		{10 * time.Second, 9500 * time.Millisecond},      // default interval
+		{10500 * time.Millisecond, 10 * time.Second},     // exactly at cap
+		{30 * time.Second, 10 * time.Second},             // large interval → capped at 10s
+		{300 * time.Second, 10 * time.Second},            // max interval → capped at 10s
+	}
+	for _, tc := range cases {
+		got := FetchTimeout(tc.interval)
+		assert.Equal(t, tc.expected, got, "interval=%v", tc.interval)
+	}
+}
+
+func TestBackoffDuration(t *testing.T) {
+	cases := []struct {
+		fails    int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{4, 16 * time.Second},
+		{5, 32 * time.Second},
+		{6, 60 * time.Second},
+		{10, 60 * time.Second},
+	}
+	for _, tc := range cases {
+		got := BackoffDuration(tc.fails)
+		assert.Equal(t, tc.expected, got, "fails=%d", tc.fails)
+	}
+}
+
+// flakyClient returns a mock whose core endpoints fail while *down is set,
+// and whose index stats fail while *statsDown is set.
+func flakyClient(down, statsDown *bool) *MockESClient {
+	fail := func() error {
+		if *down {
+			return errMockFailure
+		}
+		return nil
+	}
+	return &MockESClient{
+		HealthFn: func(_ context.Context) (*client.ClusterHealth, error) {
+			if err := fail(); err != nil {
+				return nil, err
+			}
+			return &client.ClusterHealth{ClusterName: "test", Status: "green"}, nil
+		},
+		NodesFn: func(_ context.Context) ([]client.NodeInfo, error) {
+			if err := fail(); err != nil {
+				return nil, err
+			}
+			return []client.NodeInfo{{Name: "node1"}}, nil
+		},
+		NodeStatsFn: func(_ context.Context) (*client.NodeStatsResponse, error) {
+			if err := fail(); err != nil {
+				return nil, err
+			}
+			return &client.NodeStatsResponse{Nodes: map[string]client.NodePerformanceStats{}}, nil
+		},
+		IndicesFn: func(_ context.Context) ([]client.IndexInfo, error) {
+			if err := fail(); err != nil {
+				return nil, err
+			}
+			return []client.IndexInfo{{Index: "test-index"}}, nil
+		},
+		IndexStatsFn: func(_ context.Context) (*client.IndexStatsResponse, error) {
+			if err := fail(); err != nil {
+				return nil, err
+			}
+			if *statsDown {
+				return nil, errMockFailure
+			}
+			return &client.IndexStatsResponse{Indices: map[string]client.IndexStatEntry{
+				"test-index": {Primaries: &client.IndexStatShard{Indexing: &client.IndexingStats{IndexTotal: 42}}},
+			}}, nil
+		},
+		PendingTasksFn: func(_ context.Context) ([]client.PendingTask, error) {
+			return []client.PendingTask{{InsertOrder: 1, Source: "put-mapping"}}, nil
+		},
+	}
+}
+
+func TestPoller_PollPairsWithLastSuccess(t *testing.T) {
+	var down, statsDown bool
+	p := NewPoller(flakyClient(&down, &statsDown), 10*time.Second, DefaultRecommendationConfig())
+	assert.Equal(t, uint64(0), p.Started())
+
+	r := p.Poll(context.Background())
+	require.NoError(t, r.Err)
+	require.NotNil(t, r.Snapshot)
+	assert.Equal(t, 0, r.Fails)
+	assert.Equal(t, uint64(1), r.Seq)
+	assert.Equal(t, uint64(1), p.Started())
+	assert.Equal(t, 1, r.Snapshot.PendingTasksStreak)
+	assert.Equal(t, model.MetricNotAvailable, r.Metrics.IndexingRate, "no baseline on the first poll")
+	assert.Len(t, r.IndexRows, 1)
+
+	down = true
+	r = p.Poll(context.Background())
+	assert.ErrorIs(t, r.Err, errMockFailure)
+	assert.Nil(t, r.Snapshot)
+	assert.Equal(t, 1, r.Fails)
+	r = p.Poll(context.Background())
+	assert.Equal(t, 2, r.Fails)
+	assert.Equal(t, uint64(3), r.Seq, "failed polls are numbered too")
+
+	// A failed poll leaves the last successful snapshot as the baseline.
+	down = false
+	r = p.Poll(context.Background())
+	require.NoError(t, r.Err)
+	assert.Equal(t, 0, r.Fails)
+	assert.Equal(t, 2, r.Snapshot.PendingTasksStreak)
+}
+
+func TestPoller_PollCarriesOverStaleSections(t *testing.T) {
+	var down, statsDown bool
+	p := NewPoller(flakyClient(&down, &statsDown), 10*time.Second, DefaultRecommendationConfig())
+
+	first := p.Poll(context.Background())
+	require.NoError(t, first.Err)
+	fetched := first.Snapshot.Sections[model.SectionIndexStats].FetchedAt
+	require.False(t, fetched.IsZero())
+
+	statsDown = true
+	r := p.Poll(context.Background())
+	require.NoError(t, r.Err, "one failed section does not fail the poll")
+	assert.Equal(t, 0, r.Fails)
+	assert.Equal(t, []model.Section{model.SectionIndexStats}, r.Snapshot.Degraded())
+	assert.Equal(t, first.Snapshot.IndexStats, r.Snapshot.IndexStats, "last good data is kept")
+	assert.Equal(t, fetched, r.Snapshot.Sections[model.SectionIndexStats].FetchedAt)
+	assert.Equal(t, "green", r.Snapshot.Health.Status)
+
+	// Still failing: the data keeps the time it was last fetched.
+	r = p.Poll(context.Background())
+	assert.Equal(t, first.Snapshot.IndexStats, r.Snapshot.IndexStats)
+	assert.Equal(t, fetched, r.Snapshot.Sections[model.SectionIndexStats].FetchedAt)
+
+	statsDown = false
+	r = p.Poll(context.Background())
+	assert.Empty(t, r.Snapshot.Degraded())
+	assert.True(t, r.Snapshot.Sections[model.SectionIndexStats].FetchedAt.After(fetched))
+}
+
+func TestPoller_MappingStatsFetchedInBackground(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	mc := &MockESClient{
+		MappingStatsFn: func(ctx context.Context) (map[string]client.MappingStats, error) {
+			calls.Add(1)
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return map[string]client.MappingStats{"test-index": {Index: "test-index", FieldCount: 950, FieldLimit: 1000}}, nil
+		},
+	}
+	p := NewPoller(mc, 10*time.Second, DefaultRecommendationConfig())
+
+	// A slow mapping fetch never holds up the poll.
+	r := p.Poll(context.Background())
+	require.NoError(t, r.Err)
+	assert.Nil(t, r.Snapshot.Mappings)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		r = p.Poll(context.Background())
+		return r.Snapshot.Mappings != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 950, r.IndexRows[0].FieldCount)
+	assert.Equal(t, int32(1), calls.Load(), "mapping stats are not due again within the interval")
+}
+
+func TestPoller_ShardsFetchedOncePerInterval(t *testing.T) {
+	var calls atomic.Int32
+	mc := &MockESClient{
+		ShardsFn: func(_ context.Context) ([]client.ShardInfo, error) {
+			calls.Add(1)
+			return []client.ShardInfo{{Index: "test-index", Shard: "0", PriRep: "p", State: "STARTED", Node: "node1"}}, nil
+		},
+	}
+	p := NewPoller(mc, 10*time.Second, DefaultRecommendationConfig())
+
+	var r PollResult
+	require.Eventually(t, func() bool {
+		r = p.Poll(context.Background())
+		return r.Snapshot.Shards != nil
+	}, time.Second, 10*time.Millisecond)
+	r = p.Poll(context.Background())
+	assert.Len(t, r.Snapshot.Shards, 1, "the last result is kept between fetches")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDeltaBaseline(t *testing.T) {
+	fresh := &model.Snapshot{}
+	stale := &model.Snapshot{Sections: map[model.Section]model.SectionState{
+		model.SectionIndexStats: {Err: errMockFailure},
+	}}
+
+	assert.Same(t, fresh, deltaBaseline(fresh, fresh, model.SectionIndexStats))
+	assert.Nil(t, deltaBaseline(stale, fresh, model.SectionIndexStats), "stale baseline")
+	assert.Nil(t, deltaBaseline(fresh, stale, model.SectionIndexStats), "stale current data")
+	assert.Same(t, fresh, deltaBaseline(fresh, stale, model.SectionNodeStats), "other sections are unaffected")
+	assert.Nil(t, deltaBaseline(nil, fresh, model.SectionIndexStats))
+}
+
+func TestPoller_RunPublishesAndRefreshes(t *testing.T) {
+	p := NewPoller(&MockESClient{}, time.Hour, DefaultRecommendationConfig())
+	results := p.Subscribe()
+	var calls atomic.Int32
+	p.OnResult(func(PollResult) { calls.Add(1) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- p.Run(ctx) }()
+
+	next := func() PollResult {
+		select {
+		case r := <-results:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a poll result")
+			return PollResult{}
+		}
+	}
+
+	first := next()
+	require.NoError(t, first.Err)
+	// The interval is an hour; only Refresh can trigger the second poll.
+	p.Refresh()
+	second := next()
+	require.NoError(t, second.Err)
+	assert.True(t, !second.Snapshot.FetchedAt.Before(first.Snapshot.FetchedAt))
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	assert.Equal(t, int32(2), calls.Load())
 }
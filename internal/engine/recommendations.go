@@ -5,7 +5,9 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/jtsunne/epm-go/internal/format"
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
@@ -13,8 +15,82 @@ const (
 	oneGiBInt64        = int64(1 << 30) // 1 GiB in bytes
 	oneMiBInt64        = int64(1 << 20) // 1 MiB in bytes
 	rollupThresholdMiB = int64(100)     // daily→monthly if avg primary < 100 MiB; else →weekly
+
+	// pendingTasksStreakThreshold is the number of consecutive polls with a
+	// non-empty master queue before a backlog recommendation is raised.
+	// A single non-empty poll is normal during index creation or rollover.
+	pendingTasksStreakThreshold = 3
+
+	// defaultSnapshotMaxAge is how old the newest successful snapshot in a
+	// repository may be before a stale-backup recommendation is raised.
+	defaultSnapshotMaxAge = 24 * time.Hour
+
+	// defaultFieldLimitMargin is how close, in percent of
+	// index.mapping.total_fields.limit, an index's field count may get before
+	// a mapping explosion recommendation is raised.
+	defaultFieldLimitMargin = 10.0
+
+	// defaultFDThreshold is the share, in percent, of a node's file
+	// descriptor limit it may have open before a recommendation is raised.
+	defaultFDThreshold = 80.0
+
+	// defaultDeletedDocsThreshold is the share, in percent, of an index's
+	// documents that may be deleted before a bloat recommendation is raised.
+	defaultDeletedDocsThreshold = 25.0
+
+	// recListLimit caps the number of indices, nodes or other items named in
+	// a recommendation detail.
+	recListLimit = 5
 )
 
+// nameList describes up to recListLimit items with desc, joined by commas,
+// and counts the rest as "and N more".
+func nameList[T any](items []T, desc func(T) string) string {
+	names := make([]string, 0, recListLimit+1)
+	for i, item := range items {
+		if i == recListLimit {
+			names = append(names, fmt.Sprintf("and %d more", len(items)-recListLimit))
+			break
+		}
+		names = append(names, desc(item))
+	}
+	return strings.Join(names, ", ")
+}
+
+// plainName is the desc of nameList for items that are already names.
+func plainName(s string) string { return s }
+
+// RecommendationConfig holds the user-tunable recommendation thresholds.
+type RecommendationConfig struct {
+	// SnapshotMaxAge is the maximum age of the newest successful snapshot per
+	// repository. Zero disables the stale-backup check.
+	SnapshotMaxAge time.Duration
+	// FieldLimitMargin is the distance, in percent of the total fields limit,
+	// within which an index's field count raises a Critical recommendation.
+	// Zero only flags indices that have reached the limit.
+	FieldLimitMargin float64
+	// FDThreshold is the percentage of a node's file descriptor limit above
+	// which a recommendation is raised. Zero disables the check.
+	FDThreshold float64
+	// DeletedDocsThreshold is the percentage of deleted documents above which
+	// an index is flagged as bloated. Zero disables the check.
+	DeletedDocsThreshold float64
+	// ZoneAttribute is the node attribute that identifies a node's zone or
+	// rack. Empty picks the first well-known attribute (zone, rack, ...).
+	ZoneAttribute string
+}
+
+// DefaultRecommendationConfig returns the thresholds used when none are
+// configured.
+func DefaultRecommendationConfig() RecommendationConfig {
+	return RecommendationConfig{
+		SnapshotMaxAge:       defaultSnapshotMaxAge,
+		FieldLimitMargin:     defaultFieldLimitMargin,
+		FDThreshold:          defaultFDThreshold,
+		DeletedDocsThreshold: defaultDeletedDocsThreshold,
+	}
+}
+
 // Package-level compiled regexes for date-patterned index detection.
 // Priority order: daily checked first to avoid misclassifying YYYY.MM.DD as monthly.
 var (
@@ -24,13 +100,30 @@ var (
 )
 
 // CalcRecommendations generates actionable recommendations for the cluster
-// based on the current snapshot, resources, and computed rows.
+// based on the current snapshot, resources, and computed rows, using the
+// default thresholds.
 // Returns an empty (non-nil) slice when snap is nil or data is unavailable.
 func CalcRecommendations(
 	snap *model.Snapshot,
 	resources model.ClusterResources,
 	nodeRows []model.NodeRow,
 	indexRows []model.IndexRow,
+	pipelines []model.PipelineRow,
+	followers []model.FollowerRow,
+) []model.Recommendation {
+	return CalcRecommendationsWithConfig(snap, resources, nodeRows, indexRows, pipelines, followers, DefaultRecommendationConfig())
+}
+
+// CalcRecommendationsWithConfig is CalcRecommendations with user-configured
+// thresholds.
+func CalcRecommendationsWithConfig(
+	snap *model.Snapshot,
+	resources model.ClusterResources,
+	nodeRows []model.NodeRow,
+	indexRows []model.IndexRow,
+	pipelines []model.PipelineRow,
+	followers []model.FollowerRow,
+	cfg RecommendationConfig,
 ) []model.Recommendation {
 	result := []model.Recommendation{}
 	if snap == nil {
@@ -51,6 +144,7 @@ func CalcRecommendations(
 			Category: model.CategoryShardHealth,
 			Title:    "Cluster status RED",
 			Detail:   detail,
+			Link:     model.LinkUnassignedShards,
 		})
 	case "yellow":
 		detail := "Cluster is in YELLOW status — some replica shards are unassigned. Data is available but redundancy is reduced."
@@ -62,9 +156,13 @@ func CalcRecommendations(
 			Category: model.CategoryShardHealth,
 			Title:    "Cluster status YELLOW",
 			Detail:   detail,
+			Link:     model.LinkUnassignedShards,
 		})
 	}
 
+	// Master task queue backlog.
+	result = append(result, pendingTasksRecs(snap)...)
+
 	// CPU pressure.
 	switch {
 	case resources.AvgCPUPercent > 90:
@@ -102,16 +200,18 @@ func CalcRecommendations(
 		})
 	}
 
-	// Storage pressure.
+	// Storage pressure, against the low and high watermarks when known.
+	storageWarn, storageCrit := StorageThresholds(snap)
+	floodStage := FormatWatermark(EffectiveWatermarks(snap).FloodStage)
 	switch {
-	case resources.StoragePercent > 90:
+	case resources.StoragePercent > storageCrit:
 		result = append(result, model.Recommendation{
 			Severity: model.SeverityCritical,
 			Category: model.CategoryResourcePressure,
 			Title:    "Critical storage usage",
-			Detail:   fmt.Sprintf("Cluster storage at %.0f%% capacity. Immediate action required — ES will reject writes at 95%%. Delete old indices or add storage.", resources.StoragePercent),
+			Detail:   fmt.Sprintf("Cluster storage at %.0f%% capacity. Immediate action required — ES makes indices read-only at the flood-stage watermark (%s). Delete old indices or add storage.", resources.StoragePercent, floodStage),
 		})
-	case resources.StoragePercent > 80:
+	case resources.StoragePercent > storageWarn:
 		result = append(result, model.Recommendation{
 			Severity: model.SeverityWarning,
 			Category: model.CategoryResourcePressure,
@@ -228,6 +328,30 @@ func CalcRecommendations(
 	// Per-node heap hotspot.
 	result = append(result, heapHotspotRecs(nodeRows)...)
 
+	// Per-node disk watermarks.
+	result = append(result, watermarkRecs(nodeRows, EffectiveWatermarks(snap))...)
+
+	// Nodes rejecting bulk requests for indexing pressure.
+	result = append(result, indexingPressureRecs(nodeRows)...)
+
+	// Host swap in use and file descriptors near the limit.
+	result = append(result, nodeResourceRecs(nodeRows, cfg.FDThreshold)...)
+
+	// Node restarts detected within the retention window.
+	result = append(result, restartRecs(snap)...)
+
+	// Mixed node versions and primaries stranded on upgraded nodes.
+	result = append(result, versionRecs(snap, nodeRows)...)
+
+	// Zone awareness: shard copies sharing a zone, unbalanced zones.
+	result = append(result, zoneRecs(snap, nodeRows, cfg.ZoneAttribute)...)
+
+	// Data tiers: hot disk filling while warm has room, misplaced traffic.
+	result = append(result, tierRecs(snap, nodeRows)...)
+
+	// Cross-cluster replication: disconnected remotes, stalled followers.
+	result = append(result, ccrRecs(snap, followers)...)
+
 	// Index lifecycle: date-rollup consolidation suggestions.
 	rollupRecs, savedIdx, totalGroupIdx, savedShards := dateRollupRecs(indexRows)
 	result = append(result, rollupRecs...)
@@ -235,6 +359,21 @@ func CalcRecommendations(
 	// Index lifecycle: empty index detection.
 	result = append(result, emptyIndexRecs(indexRows)...)
 
+	// Index lifecycle: ILM/ISM policies stuck in an ERROR step.
+	result = append(result, lifecycleErrorRecs(indexRows)...)
+
+	// Index config: mapping field counts close to total_fields.limit.
+	result = append(result, fieldLimitRecs(indexRows, cfg.FieldLimitMargin)...)
+
+	// Index config: deleted documents bloating update-heavy indices.
+	result = append(result, deletedDocsRecs(indexRows, cfg.DeletedDocsThreshold)...)
+
+	// Index config: ingest pipelines failing documents.
+	result = append(result, pipelineRecs(pipelines)...)
+
+	// Index lifecycle: failing or stale snapshot backups.
+	result = append(result, snapshotRecs(snap, cfg.SnapshotMaxAge)...)
+
 	// Cluster-level impact summary for rollup recommendations.
 	if savedShards > 0 && resources.TotalHeapMaxBytes > 0 {
 		activeShards := snap.Health.ActiveShards
@@ -260,6 +399,26 @@ func CalcRecommendations(
 	return result
 }
 
+// pendingTasksRecs returns a warning when the master's pending task queue has
+// stayed non-empty for pendingTasksStreakThreshold consecutive polls. A
+// persistent backlog means mapping, settings and shard-state updates are
+// waiting on an overloaded master.
+func pendingTasksRecs(snap *model.Snapshot) []model.Recommendation {
+	if snap.PendingTasksStreak < pendingTasksStreakThreshold || len(snap.PendingTasks) == 0 {
+		return nil
+	}
+	oldest := OldestPendingTask(snap.PendingTasks)
+	return []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryShardHealth,
+		Title:    "Master task queue backlog",
+		Detail: fmt.Sprintf(
+			"%d cluster task(s) pending for %d consecutive polls (oldest %s in queue). The elected master is falling behind; mapping, settings and shard allocation updates will be delayed. Check for mapping explosions, frequent index creation, or an undersized master node.",
+			len(snap.PendingTasks), snap.PendingTasksStreak, format.FormatAge(oldest),
+		),
+	}}
+}
+
 // dateRollupGroupKey identifies a group of date-patterned indices.
 type dateRollupGroupKey struct {
 	granularity string // "daily", "weekly", or "monthly"
@@ -270,14 +429,17 @@ type dateRollupGroupKey struct {
 // dateRollupRecs analyses date-patterned indices and emits consolidation
 // recommendations when enough indices exist to justify a rollup. Returns the
 // recommendations plus aggregate savedIndices, totalGroupIndices, and savedShards
-// counts for use in the cluster-level impact summary.
+// counts for use in the cluster-level impact summary. Indices managed by an
+// ILM/ISM policy and data stream backing indices are skipped: their retention
+// is already automated and they cannot be reindexed into a date rollup.
 func dateRollupRecs(indexRows []model.IndexRow) (recs []model.Recommendation, savedIndices int, totalGroupIndices int, savedShards int) {
 	// Group indices by (granularity, base).
 	groups := make(map[dateRollupGroupKey][]model.IndexRow)
 
 	for _, idx := range indexRows {
-		// Skip system indices.
-		if strings.HasPrefix(idx.Name, ".") {
+		// Skip system and .ds- backing indices, members of a data stream, and
+		// indices already under a lifecycle policy.
+		if strings.HasPrefix(idx.Name, ".") || idx.DataStream != "" || idx.LifecycleManaged {
 			continue
 		}
 		var key dateRollupGroupKey
@@ -403,11 +565,12 @@ func dateRollupRecs(indexRows []model.IndexRow) (recs []model.Recommendation, sa
 
 // emptyIndexRecs returns a warning recommendation when three or more non-system
 // indices have zero documents and zero storage — likely stale or forgotten indices
-// that can be safely deleted.
+// that can be safely deleted. Lifecycle-managed indices are skipped since a
+// freshly rolled-over write index is legitimately empty.
 func emptyIndexRecs(indexRows []model.IndexRow) []model.Recommendation {
 	var names []string
 	for _, idx := range indexRows {
-		if strings.HasPrefix(idx.Name, ".") {
+		if strings.HasPrefix(idx.Name, ".") || idx.LifecycleManaged {
 			continue
 		}
 		if idx.DocCountKnown && idx.DocCount == 0 && idx.TotalSizeBytes == 0 {
@@ -43,7 +43,7 @@ func hasRecCategory(recs []model.Recommendation, sev model.RecommendationSeverit
 }
 
 func TestCalcRecommendations_NilSnap(t *testing.T) {
-	recs := CalcRecommendations(nil, model.ClusterResources{}, nil, nil)
+	recs := CalcRecommendations(nil, model.ClusterResources{}, nil, nil, nil, nil)
 	assert.NotNil(t, recs, "must return non-nil slice")
 	assert.Empty(t, recs)
 }
@@ -56,25 +56,25 @@ func TestCalcRecommendations_HealthyCluster(t *testing.T) {
 		AvgJVMHeapPercent: 50,
 		StoragePercent:    40,
 	}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.Empty(t, recs, "healthy cluster should produce no recommendations")
 }
 
 func TestCalcRecommendations_ClusterStatusRed(t *testing.T) {
 	snap := makeSnap("red", 10, 0)
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityCritical, "RED"), "expect critical RED status recommendation")
 }
 
 func TestCalcRecommendations_ClusterStatusYellow(t *testing.T) {
 	snap := makeSnap("yellow", 10, 0)
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "YELLOW"), "expect warning YELLOW status recommendation")
 }
 
 func TestCalcRecommendations_UnassignedShards(t *testing.T) {
 	snap := makeSnap("yellow", 10, 5)
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
 	// Unassigned shard count is embedded in the YELLOW status recommendation,
 	// not emitted as a separate recommendation, to avoid duplicate entries.
 	assert.True(t, hasRec(recs, model.SeverityWarning, "YELLOW"), "expect warning YELLOW status recommendation")
@@ -86,13 +86,27 @@ func TestCalcRecommendations_UnassignedShards(t *testing.T) {
 	}
 }
 
+func TestCalcRecommendations_ClusterStatusLinksToExplain(t *testing.T) {
+	for _, status := range []string{"red", "yellow"} {
+		recs := CalcRecommendations(makeSnap(status, 10, 2), model.ClusterResources{}, nil, nil, nil, nil)
+		found := false
+		for _, r := range recs {
+			if strings.Contains(r.Title, "Cluster status") {
+				found = true
+				assert.Equal(t, model.LinkUnassignedShards, r.Link, "status %s should link to the explain view", status)
+			}
+		}
+		assert.True(t, found, "expected a cluster status recommendation for %s", status)
+	}
+}
+
 // Shard-to-heap: small RAM (4 GB heap, 200 shards) → critical (50/GB > 40).
 func TestCalcRecommendations_ShardHeapRatio_SmallRAM_Critical(t *testing.T) {
 	snap := makeSnap("green", 200, 0)
 	resources := model.ClusterResources{
 		TotalHeapMaxBytes: 4 * oneGiBInt64,
 	}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityCritical, "shards per GB heap (critical)"),
 		"4 GB heap with 200 shards (50/GB) must be critical")
 }
@@ -103,7 +117,7 @@ func TestCalcRecommendations_ShardHeapRatio_SmallRAM_Warning(t *testing.T) {
 	resources := model.ClusterResources{
 		TotalHeapMaxBytes: 4 * oneGiBInt64,
 	}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "shards per GB heap"),
 		"4 GB heap with 100 shards (25/GB) must be warning")
 	assert.False(t, hasRec(recs, model.SeverityCritical, "shards per GB heap (critical)"),
@@ -116,7 +130,7 @@ func TestCalcRecommendations_ShardHeapRatio_LargeRAM_Fine(t *testing.T) {
 	resources := model.ClusterResources{
 		TotalHeapMaxBytes: 64 * oneGiBInt64,
 	}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityCritical, "shards per GB heap"),
 		"64 GB heap with 200 shards is well within limits")
 	assert.False(t, hasRec(recs, model.SeverityWarning, "shards per GB heap"),
@@ -126,7 +140,7 @@ func TestCalcRecommendations_ShardHeapRatio_LargeRAM_Fine(t *testing.T) {
 func TestCalcRecommendations_CPUCritical(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{AvgCPUPercent: 95}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityCritical, "CPU pressure"))
 	assert.False(t, hasRec(recs, model.SeverityWarning, "CPU usage"), "critical CPU must not also emit warning")
 }
@@ -134,7 +148,7 @@ func TestCalcRecommendations_CPUCritical(t *testing.T) {
 func TestCalcRecommendations_CPUWarning(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{AvgCPUPercent: 85}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "CPU usage"))
 	assert.False(t, hasRec(recs, model.SeverityCritical, "CPU pressure"))
 }
@@ -142,7 +156,7 @@ func TestCalcRecommendations_CPUWarning(t *testing.T) {
 func TestCalcRecommendations_JVMCritical(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{AvgJVMHeapPercent: 90, TotalHeapMaxBytes: 8 * oneGiBInt64}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityCritical, "JVM heap pressure"))
 	assert.False(t, hasRec(recs, model.SeverityWarning, "JVM heap usage"), "critical JVM must not also emit warning")
 	// Detail should mention total heap GB.
@@ -156,14 +170,14 @@ func TestCalcRecommendations_JVMCritical(t *testing.T) {
 func TestCalcRecommendations_JVMWarning(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{AvgJVMHeapPercent: 80, TotalHeapMaxBytes: 16 * oneGiBInt64}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "JVM heap usage"))
 }
 
 func TestCalcRecommendations_StorageCritical(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{StoragePercent: 92}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityCritical, "storage usage"))
 	assert.False(t, hasRec(recs, model.SeverityWarning, "storage usage"), "critical storage must not also emit warning")
 }
@@ -171,7 +185,7 @@ func TestCalcRecommendations_StorageCritical(t *testing.T) {
 func TestCalcRecommendations_StorageWarning(t *testing.T) {
 	snap := makeSnap("green", 0, 0)
 	resources := model.ClusterResources{StoragePercent: 85}
-	recs := CalcRecommendations(snap, resources, nil, nil)
+	recs := CalcRecommendations(snap, resources, nil, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "storage usage"))
 	assert.False(t, hasRec(recs, model.SeverityCritical, "storage usage"), "storage warning must not also emit critical")
 }
@@ -184,7 +198,7 @@ func TestCalcRecommendations_ZeroReplicaIndices(t *testing.T) {
 		{Name: ".system", PrimaryShards: 1, TotalShards: 1, RepKnown: true},  // system — excluded
 		{Name: "closed", PrimaryShards: 1, TotalShards: 1, RepKnown: false},  // rep="-", must not count
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, indexRows)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, indexRows, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "without replicas"))
 	for _, r := range recs {
 		if strings.Contains(r.Title, "without replicas") {
@@ -199,7 +213,7 @@ func TestCalcRecommendations_OversizedShards(t *testing.T) {
 	indexRows := []model.IndexRow{
 		{Name: "bigindex", PrimaryShards: 1, TotalShards: 1, TotalSizeBytes: 60 * oneGiBInt64},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, indexRows)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, indexRows, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "Oversized shards"))
 }
 
@@ -217,7 +231,7 @@ func TestCalcRecommendations_OverSharding(t *testing.T) {
 		}
 	}
 	nodeRows := []model.NodeRow{{Name: "node1", Role: "d"}}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, indexRows)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, indexRows, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "Over-sharding"))
 }
 
@@ -231,7 +245,7 @@ func TestCalcRecommendations_DataToHeapRatio(t *testing.T) {
 	indexRows := []model.IndexRow{
 		{Name: "idx", PrimaryShards: 1, TotalShards: 1, TotalSizeBytes: 200 * oneGiBInt64},
 	}
-	recs := CalcRecommendations(snap, resources, nil, indexRows)
+	recs := CalcRecommendations(snap, resources, nil, indexRows, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "data-to-heap ratio"))
 }
 
@@ -245,7 +259,7 @@ func TestCalcRecommendations_DataToHeapRatio_Fine(t *testing.T) {
 	indexRows := []model.IndexRow{
 		{Name: "idx", PrimaryShards: 1, TotalShards: 1, TotalSizeBytes: 100 * oneGiBInt64},
 	}
-	recs := CalcRecommendations(snap, resources, nil, indexRows)
+	recs := CalcRecommendations(snap, resources, nil, indexRows, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "data-to-heap ratio"))
 }
 
@@ -255,7 +269,7 @@ func TestCalcRecommendations_SingleDataNode(t *testing.T) {
 		{Name: "node1", Role: "d"},
 		{Name: "master1", Role: "m"},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "Single data node"))
 }
 
@@ -265,7 +279,7 @@ func TestCalcRecommendations_TwoDataNodes_NoSPOF(t *testing.T) {
 		{Name: "node1", Role: "d"},
 		{Name: "node2", Role: "d"},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "Single data node"))
 }
 
@@ -276,7 +290,7 @@ func TestCalcRecommendations_HeapHotspot(t *testing.T) {
 		{Name: "node1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 9 / 10},  // 90%
 		{Name: "node2", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 5 / 10},  // 50%
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "heap utilization"))
 }
 
@@ -287,7 +301,7 @@ func TestCalcRecommendations_HeapHotspot_Fine(t *testing.T) {
 		{Name: "node1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 7 / 10},  // 70%
 		{Name: "node2", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 5 / 10},  // 50%
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "heap utilization"))
 }
 
@@ -297,7 +311,7 @@ func TestCalcRecommendations_HeapHotspot_SingleNode(t *testing.T) {
 	nodeRows := []model.NodeRow{
 		{Name: "node1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "heap utilization"))
 }
 
@@ -312,7 +326,7 @@ func TestCalcRecommendations_HeapHotspot_MasterExcluded(t *testing.T) {
 		{Name: "data1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 8 / 10},  // 80%
 	}
 	// Spread = 70pp > 30pp, but master is excluded → only 1 data node → no hotspot warning.
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "heap utilization"),
 		"spread between master and data node must not trigger hotspot warning")
 }
@@ -323,7 +337,7 @@ func TestCalcRecommendations_HotspotDetail(t *testing.T) {
 		{Name: "node1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 95 / 100}, // 95%
 		{Name: "node2", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 40 / 100}, // 40%
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	for _, r := range recs {
 		if strings.Contains(r.Title, "heap utilization") {
 			assert.Contains(t, r.Detail, "high: 95%")
@@ -346,7 +360,7 @@ func TestCalcRecommendations_AllCategories(t *testing.T) {
 		{Name: "n1", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 9 / 10},
 		{Name: "n2", Role: "d", HeapMaxBytes: oneGiBInt64, HeapUsedBytes: oneGiBInt64 * 4 / 10},
 	}
-	recs := CalcRecommendations(snap, resources, nodeRows, indexRows)
+	recs := CalcRecommendations(snap, resources, nodeRows, indexRows, nil, nil)
 
 	assert.True(t, hasRecCategory(recs, model.SeverityWarning, model.CategoryResourcePressure), "ResourcePressure")
 	assert.True(t, hasRecCategory(recs, model.SeverityCritical, model.CategoryShardHealth), "ShardHealth critical (unassigned)")
@@ -364,7 +378,7 @@ func TestCalcRecommendations_TieredDataNodes_NoSPOF(t *testing.T) {
 		{Name: "hot1", Role: "h"},
 		{Name: "hot2", Role: "h"},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.False(t, hasRec(recs, model.SeverityWarning, "Single data node"), "two hot-tier nodes must not trigger SPOF")
 }
 
@@ -375,7 +389,7 @@ func TestCalcRecommendations_TieredDataNodes_SingleNode_SPOF(t *testing.T) {
 		{Name: "warm1", Role: "w"},
 		{Name: "master1", Role: "m"},
 	}
-	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nodeRows, nil, nil, nil)
 	assert.True(t, hasRec(recs, model.SeverityWarning, "Single data node"), "single warm-tier node must trigger SPOF")
 }
 
@@ -650,7 +664,7 @@ func TestCalcRecommendations_ClusterImpactSummary(t *testing.T) {
 			TotalShards:  2,
 		})
 	}
-	recs := CalcRecommendations(snap, resources, nil, indexRows)
+	recs := CalcRecommendations(snap, resources, nil, indexRows, nil, nil)
 	var summary *model.Recommendation
 	for i := range recs {
 		if recs[i].Category == model.CategoryIndexLifecycle && recs[i].Severity == model.SeverityNormal {
@@ -668,3 +682,37 @@ func TestCalcRecommendations_ClusterImpactSummary(t *testing.T) {
 		assert.Contains(t, summary.Detail, "8.8")
 	}
 }
+
+func TestCalcRecommendations_PendingTasksBacklog(t *testing.T) {
+	snap := makeSnap("green", 10, 0)
+	snap.PendingTasks = []client.PendingTask{
+		{InsertOrder: 1, Priority: "URGENT", Source: "put-mapping", TimeInQueueMillis: 12_000},
+		{InsertOrder: 2, Priority: "HIGH", Source: "shard-started", TimeInQueueMillis: 500},
+	}
+	snap.PendingTasksStreak = pendingTasksStreakThreshold
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "Master task queue backlog"))
+	for _, r := range recs {
+		if r.Title == "Master task queue backlog" {
+			assert.Equal(t, model.CategoryShardHealth, r.Category)
+			assert.Contains(t, r.Detail, "2 cluster task(s)")
+			assert.Contains(t, r.Detail, "12.0s")
+		}
+	}
+}
+
+func TestCalcRecommendations_PendingTasksBelowStreak(t *testing.T) {
+	snap := makeSnap("green", 10, 0)
+	snap.PendingTasks = []client.PendingTask{{Priority: "URGENT", TimeInQueueMillis: 12_000}}
+	snap.PendingTasksStreak = pendingTasksStreakThreshold - 1
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.False(t, hasRec(recs, model.SeverityWarning, "Master task queue backlog"))
+}
+
+func TestNameList(t *testing.T) {
+	assert.Equal(t, "", nameList([]string(nil), plainName))
+	assert.Equal(t, "a, b", nameList([]string{"a", "b"}, plainName))
+	assert.Equal(t, "a, b, c, d, e", nameList([]string{"a", "b", "c", "d", "e"}, plainName))
+	assert.Equal(t, "a, b, c, d, e, and 2 more", nameList([]string{"a", "b", "c", "d", "e", "f", "g"}, plainName))
+	assert.Equal(t, "1 (x), 2 (x)", nameList([]int{1, 2}, func(i int) string { return fmt.Sprintf("%d (x)", i) }))
+}
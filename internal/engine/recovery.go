@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// CalcRecoveryRows converts the active recoveries in curr into display rows.
+// Throughput is the change in recovered bytes since the same recovery (same
+// index, shard and target node) in prev, divided by elapsed; the ETA is the
+// remaining bytes at that throughput. Both are unavailable on the first poll
+// of a recovery, when elapsed is too short, or when no progress was made.
+func CalcRecoveryRows(prev, curr *model.Snapshot, elapsed time.Duration) []model.RecoveryRow {
+	if curr == nil || len(curr.Recoveries) == 0 {
+		return nil
+	}
+
+	prevRecovered := make(map[string]int64)
+	if prev != nil {
+		for _, r := range prev.Recoveries {
+			prevRecovered[recoveryKey(r)] = parseCatInt(r.BytesRecovered)
+		}
+	}
+	elapsedSec := elapsed.Seconds()
+	enoughTime := prev != nil && elapsedSec >= minTimeDiffSeconds
+
+	rows := make([]model.RecoveryRow, 0, len(curr.Recoveries))
+	for _, r := range curr.Recoveries {
+		shard, err := strconv.Atoi(r.Shard)
+		if err != nil {
+			shard = -1
+		}
+		recovered := parseCatInt(r.BytesRecovered)
+		total := parseCatInt(r.BytesTotal)
+		var elapsedRec time.Duration
+		if ms := parseCatInt(r.Time); ms > 0 {
+			elapsedRec = time.Duration(ms) * time.Millisecond
+		}
+
+		row := model.RecoveryRow{
+			Index:          r.Index,
+			Shard:          shard,
+			Type:           r.Type,
+			Stage:          r.Stage,
+			SourceNode:     r.SourceNode,
+			TargetNode:     r.TargetNode,
+			FilesPercent:   parseCatPercent(r.FilesPercent),
+			BytesPercent:   parseCatPercent(r.BytesPercent),
+			BytesRecovered: recovered,
+			BytesTotal:     total,
+			Elapsed:        elapsedRec,
+			Throughput:     model.MetricNotAvailable,
+			ETA:            -1,
+		}
+
+		if before, ok := prevRecovered[recoveryKey(r)]; ok && enoughTime && before >= 0 && recovered >= before {
+			row.Throughput = float64(recovered-before) / elapsedSec
+			if row.Throughput > 0 && total >= recovered {
+				row.ETA = time.Duration(float64(total-recovered) / row.Throughput * float64(time.Second))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// recoveryKey identifies one recovery across polls. The target node is part
+// of the key so a recovery that restarts elsewhere is not treated as progress.
+func recoveryKey(r client.RecoveryInfo) string {
+	return r.Index + "\x00" + r.Shard + "\x00" + r.TargetNode
+}
+
+// parseCatPercent parses a _cat percentage such as "42.5%", returning
+// MetricNotAvailable when it is empty or malformed.
+func parseCatPercent(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return model.MetricNotAvailable
+	}
+	return v
+}
+
+// RecoveryProgress returns the overall completion of rows as a percentage of
+// bytes recovered across all recoveries. When no recovery reports a byte
+// total (e.g. empty shards), it falls back to the mean bytes percentage.
+// Returns MetricNotAvailable for an empty slice.
+func RecoveryProgress(rows []model.RecoveryRow) float64 {
+	if len(rows) == 0 {
+		return model.MetricNotAvailable
+	}
+	var recovered, total int64
+	var pctSum float64
+	var pctCount int
+	for _, r := range rows {
+		if r.BytesTotal > 0 && r.BytesRecovered >= 0 {
+			recovered += r.BytesRecovered
+			total += r.BytesTotal
+		}
+		if r.BytesPercent >= 0 {
+			pctSum += r.BytesPercent
+			pctCount++
+		}
+	}
+	if total > 0 {
+		return float64(recovered) / float64(total) * 100
+	}
+	if pctCount > 0 {
+		return pctSum / float64(pctCount)
+	}
+	return model.MetricNotAvailable
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func recoverySnap(recovered string) *model.Snapshot {
+	return &model.Snapshot{Recoveries: []client.RecoveryInfo{{
+		Index: "logs", Shard: "2", Time: "65000", Type: "peer", Stage: "index",
+		SourceNode: "es-1", TargetNode: "es-2",
+		FilesPercent: "40.0%", BytesRecovered: recovered, BytesTotal: "1000000", BytesPercent: "25.0%",
+	}}}
+}
+
+func TestCalcRecoveryRows_FirstPollHasNoThroughput(t *testing.T) {
+	rows := CalcRecoveryRows(nil, recoverySnap("250000"), 0)
+	require.Len(t, rows, 1)
+	r := rows[0]
+	assert.Equal(t, 2, r.Shard)
+	assert.Equal(t, "es-1", r.SourceNode)
+	assert.InDelta(t, 40.0, r.FilesPercent, 0.001)
+	assert.InDelta(t, 25.0, r.BytesPercent, 0.001)
+	assert.Equal(t, 65*time.Second, r.Elapsed)
+	assert.Equal(t, model.MetricNotAvailable, r.Throughput)
+	assert.Equal(t, time.Duration(-1), r.ETA)
+}
+
+func TestCalcRecoveryRows_ThroughputAndETA(t *testing.T) {
+	rows := CalcRecoveryRows(recoverySnap("250000"), recoverySnap("350000"), 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.InDelta(t, 10000.0, rows[0].Throughput, 0.001)
+	// 650000 bytes remaining at 10000 B/s.
+	assert.Equal(t, 65*time.Second, rows[0].ETA)
+}
+
+func TestCalcRecoveryRows_StalledHasNoETA(t *testing.T) {
+	rows := CalcRecoveryRows(recoverySnap("250000"), recoverySnap("250000"), 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 0.0, rows[0].Throughput)
+	assert.Equal(t, time.Duration(-1), rows[0].ETA)
+}
+
+func TestCalcRecoveryRows_NewTargetIsNotProgress(t *testing.T) {
+	prev := recoverySnap("250000")
+	prev.Recoveries[0].TargetNode = "es-3"
+	rows := CalcRecoveryRows(prev, recoverySnap("350000"), 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].Throughput)
+}
+
+func TestCalcRecoveryRows_Empty(t *testing.T) {
+	assert.Nil(t, CalcRecoveryRows(nil, nil, 0))
+	assert.Nil(t, CalcRecoveryRows(nil, &model.Snapshot{Recoveries: []client.RecoveryInfo{}}, 0))
+}
+
+func TestParseCatPercent(t *testing.T) {
+	assert.InDelta(t, 42.5, parseCatPercent("42.5%"), 0.001)
+	assert.InDelta(t, 100.0, parseCatPercent("100.0%"), 0.001)
+	assert.Equal(t, model.MetricNotAvailable, parseCatPercent(""))
+	assert.Equal(t, model.MetricNotAvailable, parseCatPercent("n/a"))
+}
+
+func TestRecoveryProgress(t *testing.T) {
+	assert.Equal(t, model.MetricNotAvailable, RecoveryProgress(nil))
+
+	rows := []model.RecoveryRow{
+		{BytesRecovered: 300, BytesTotal: 1000, BytesPercent: 30},
+		{BytesRecovered: 900, BytesTotal: 1000, BytesPercent: 90},
+	}
+	assert.InDelta(t, 60.0, RecoveryProgress(rows), 0.001)
+
+	// Byte-weighted: a large slow recovery dominates a small finished one.
+	rows = []model.RecoveryRow{
+		{BytesRecovered: 100, BytesTotal: 100, BytesPercent: 100},
+		{BytesRecovered: 0, BytesTotal: 900, BytesPercent: 0},
+	}
+	assert.InDelta(t, 10.0, RecoveryProgress(rows), 0.001)
+
+	// Empty shards report no bytes: fall back to the mean percentage.
+	rows = []model.RecoveryRow{{BytesPercent: 100}, {BytesPercent: 50}}
+	assert.InDelta(t, 75.0, RecoveryProgress(rows), 0.001)
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// RestartRetention is how long a detected node restart stays listed in the
+// header and on the Analytics screen.
+const RestartRetention = 15 * time.Minute
+
+// nodeSeenRetention is how long a node missing from node stats is
+// remembered. A node that returns later is treated as new.
+const nodeSeenRetention = 24 * time.Hour
+
+// lastSeen returns the last uptime of every node as of prev: prev.NodesSeen,
+// or prev's node stats when it has not tracked them.
+func lastSeen(prev *model.Snapshot) map[string]model.NodeSeen {
+	if prev.NodesSeen != nil {
+		return prev.NodesSeen
+	}
+	seen := make(map[string]model.NodeSeen, len(prev.NodeStats.Nodes))
+	for id, node := range prev.NodeStats.Nodes {
+		if node.JVM != nil && node.JVM.UptimeInMillis > 0 {
+			seen[id] = model.NodeSeen{Uptime: time.Duration(node.JVM.UptimeInMillis) * time.Millisecond, SeenAt: prev.FetchedAt}
+		}
+	}
+	return seen
+}
+
+// TrackNodesSeen returns the NodesSeen of curr: the uptime of every node in
+// curr's node stats, plus the entries of prev for nodes missing from them
+// that were seen within nodeSeenRetention. Node stats carried over from prev
+// because their request failed are not a new sighting.
+func TrackNodesSeen(prev, curr *model.Snapshot) map[string]model.NodeSeen {
+	seen := make(map[string]model.NodeSeen)
+	if prev != nil {
+		for id, s := range lastSeen(prev) {
+			if curr.FetchedAt.Sub(s.SeenAt) < nodeSeenRetention {
+				seen[id] = s
+			}
+		}
+	}
+	if curr.Stale(model.SectionNodeStats) {
+		return seen
+	}
+	for id, node := range curr.NodeStats.Nodes {
+		if node.JVM != nil && node.JVM.UptimeInMillis > 0 {
+			seen[id] = model.NodeSeen{Uptime: time.Duration(node.JVM.UptimeInMillis) * time.Millisecond, SeenAt: curr.FetchedAt}
+		}
+	}
+	return seen
+}
+
+// restartedNodes returns the IDs of nodes in curr that started since they
+// were last seen: their JVM uptime is lower than the last one seen, or
+// shorter than the time since then. The second case catches a node that
+// dropped out of node stats while it restarted. Restarted nodes' cumulative
+// counters were reset. Nodes without uptime are ignored.
+func restartedNodes(prev, curr *model.Snapshot) map[string]bool {
+	out := make(map[string]bool)
+	if prev == nil || curr == nil || curr.Stale(model.SectionNodeStats) {
+		return out
+	}
+	last := lastSeen(prev)
+	for id, node := range curr.NodeStats.Nodes {
+		s, ok := last[id]
+		if !ok || node.JVM == nil || node.JVM.UptimeInMillis <= 0 {
+			continue
+		}
+		uptime := time.Duration(node.JVM.UptimeInMillis) * time.Millisecond
+		if uptime < s.Uptime || uptime < curr.FetchedAt.Sub(s.SeenAt) {
+			out[id] = true
+		}
+	}
+	return out
+}
+
+// NodeRestarts returns the restarts detected between prev and curr, plus
+// those carried over from prev that are younger than RestartRetention,
+// newest first. A node that restarts again replaces its earlier entry.
+func NodeRestarts(prev, curr *model.Snapshot) []model.NodeRestart {
+	if curr == nil {
+		return nil
+	}
+	byID := make(map[string]model.NodeRestart)
+	if prev != nil {
+		for _, r := range prev.Restarts {
+			if curr.FetchedAt.Sub(r.DetectedAt) < RestartRetention {
+				byID[r.NodeID] = r
+			}
+		}
+	}
+	for id := range restartedNodes(prev, curr) {
+		node := curr.NodeStats.Nodes[id]
+		byID[id] = model.NodeRestart{
+			NodeID:     id,
+			Name:       node.Name,
+			StartedAt:  curr.FetchedAt.Add(-time.Duration(node.JVM.UptimeInMillis) * time.Millisecond),
+			DetectedAt: curr.FetchedAt,
+		}
+	}
+	if len(byID) == 0 {
+		return nil
+	}
+	out := make([]model.NodeRestart, 0, len(byID))
+	for _, r := range byID {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].StartedAt.Equal(out[j].StartedAt) {
+			return out[i].StartedAt.After(out[j].StartedAt)
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// restartRecs reports the node restarts still within RestartRetention. A
+// restart drops the node's caches and shard copies have to recover, so it is
+// worth knowing about even when the cluster is green again.
+func restartRecs(snap *model.Snapshot) []model.Recommendation {
+	if len(snap.Restarts) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(snap.Restarts))
+	for _, r := range snap.Restarts {
+		names = append(names, fmt.Sprintf("%s (%s ago)", r.Name, format.FormatAge(snap.FetchedAt.Sub(r.StartedAt).Truncate(time.Second))))
+	}
+	return []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryResourcePressure,
+		Title:    "Node restarted",
+		Detail: fmt.Sprintf("%d node(s) restarted in the last %d minutes: %s. Their rates for the interval of the restart are not shown, and cluster rates for it cover the other nodes only. If the restart was not planned, check the node logs for an OutOfMemoryError, a fatal error or the OOM killer.",
+			len(snap.Restarts), int(RestartRetention.Minutes()), strings.Join(names, ", ")),
+	}}
+}
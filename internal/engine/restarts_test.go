@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+var restartBase = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// restartSnap returns a single-node snapshot of node-a with the given JVM
+// uptime and indexing counters, fetched at restartBase+at.
+func restartSnap(at time.Duration, uptime time.Duration, indexOps int64) *model.Snapshot {
+	stats := makeNodeStatsWithID("n1", "node-a", indexOps, indexOps/10, 100, 50)
+	node := stats.Nodes["n1"]
+	node.JVM = makeNodeJVM(512, 1024)
+	node.JVM.UptimeInMillis = uptime.Milliseconds()
+	stats.Nodes["n1"] = node
+	return &model.Snapshot{NodeStats: stats, FetchedAt: restartBase.Add(at)}
+}
+
+func TestRestartedNodes(t *testing.T) {
+	prev := restartSnap(0, time.Hour, 1000)
+
+	assert.Empty(t, restartedNodes(nil, prev), "no baseline")
+	assert.Empty(t, restartedNodes(prev, restartSnap(10*time.Second, time.Hour+10*time.Second, 1100)), "uptime grew")
+	assert.Equal(t, map[string]bool{"n1": true}, restartedNodes(prev, restartSnap(10*time.Second, 5*time.Second, 10)))
+
+	noUptime := restartSnap(10*time.Second, 0, 10)
+	assert.Empty(t, restartedNodes(prev, noUptime), "missing uptime is not a restart")
+}
+
+func TestCalcNodeRows_RestartedNodeRatesUnavailable(t *testing.T) {
+	prev := restartSnap(0, time.Hour, 1000)
+	curr := restartSnap(10*time.Second, 5*time.Second, 10)
+
+	rows := CalcNodeRows(prev, curr, 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.True(t, rows[0].Restarted)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].IndexingRate)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].SearchRate)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].IndexLatency)
+	assert.Equal(t, model.MetricNotAvailable, rows[0].SearchLatency)
+
+	// The next interval has a valid baseline again.
+	next := restartSnap(20*time.Second, 15*time.Second, 110)
+	rows = CalcNodeRows(curr, next, 10*time.Second)
+	require.Len(t, rows, 1)
+	assert.False(t, rows[0].Restarted)
+	assert.InDelta(t, 10.0, rows[0].IndexingRate, 1e-9)
+}
+
+func TestCalcClusterMetrics_NodeRestart(t *testing.T) {
+	prev := restartSnap(0, time.Hour, 1000)
+	prev.IndexStats = makeClusterIndexStats(1000, 100, 500, 50)
+	curr := restartSnap(10*time.Second, 5*time.Second, 10)
+	curr.IndexStats = makeClusterIndexStats(10, 1, 5, 1)
+
+	got := CalcClusterMetrics(prev, curr, 10*time.Second)
+	assert.Equal(t, model.MetricNotAvailable, got.IndexingRate)
+	assert.Equal(t, model.MetricNotAvailable, got.SearchRate)
+	assert.Equal(t, model.MetricNotAvailable, got.IndexLatency)
+	assert.Equal(t, model.MetricNotAvailable, got.SearchLatency)
+}
+
+func TestCalcClusterMetrics_RestartUsesOtherNodes(t *testing.T) {
+	// Each node reports ops indexing ops taking ops/10 ms, and ops/4
+	// queries taking ops/8 ms.
+	twoNodes := func(at, uptimeB time.Duration, opsA, opsB int64) *model.Snapshot {
+		snap := &model.Snapshot{NodeStats: client.NodeStatsResponse{Nodes: map[string]client.NodePerformanceStats{}}, FetchedAt: restartBase.Add(at)}
+		for _, n := range []struct {
+			id     string
+			uptime time.Duration
+			ops    int64
+		}{{"n1", time.Hour + at, opsA}, {"n2", uptimeB, opsB}} {
+			node := makeNodeStatsWithID(n.id, "node-"+n.id, n.ops, n.ops/10, n.ops/4, n.ops/8).Nodes[n.id]
+			node.JVM = makeNodeJVM(512, 1024)
+			node.JVM.UptimeInMillis = n.uptime.Milliseconds()
+			snap.NodeStats.Nodes[n.id] = node
+		}
+		return snap
+	}
+	prev := twoNodes(0, time.Hour, 1000, 5000)
+	// Every operation is replicated once: primaries see half of the total.
+	prev.IndexStats = client.IndexStatsResponse{Indices: map[string]client.IndexStatEntry{
+		"idx": makeIndexStats(3000, 300, -1, -1, 6000, 600, 5100, 1020, -1, -1),
+	}}
+	// n2 restarted and its counters dropped; n1 indexed 400 ops.
+	curr := twoNodes(10*time.Second, 5*time.Second, 1400, 20)
+	curr.IndexStats = client.IndexStatsResponse{Indices: map[string]client.IndexStatEntry{
+		"idx": makeIndexStats(710, 71, -1, -1, 1420, 142, 120, 24, -1, -1),
+	}}
+
+	got := CalcClusterMetrics(prev, curr, 10*time.Second)
+	assert.InDelta(t, 20.0, got.IndexingRate, 1e-9, "n1's 400 ops over 10s, half of them on primaries")
+	assert.InDelta(t, 10.0, got.SearchRate, 1e-9, "n1's 100 queries over 10s")
+	assert.InDelta(t, 0.1, got.IndexLatency, 1e-9)
+	assert.InDelta(t, 0.5, got.SearchLatency, 1e-9)
+}
+
+func TestRestartedNodes_AcrossGap(t *testing.T) {
+	seen := restartSnap(0, 10*time.Minute, 1000)
+	gone := &model.Snapshot{FetchedAt: restartBase.Add(10 * time.Second)}
+	gone.NodesSeen = TrackNodesSeen(seen, gone)
+	require.Contains(t, gone.NodesSeen, "n1", "a missing node is remembered")
+
+	// Back 30 minutes later with 20 minutes of uptime: more than before the
+	// gap, but it started after it was last seen.
+	back := restartSnap(30*time.Minute, 20*time.Minute, 10)
+	assert.Equal(t, map[string]bool{"n1": true}, restartedNodes(gone, back))
+	got := NodeRestarts(gone, back)
+	require.Len(t, got, 1)
+	assert.Equal(t, restartBase.Add(10*time.Minute), got[0].StartedAt)
+
+	// Back without a restart: its uptime covers the gap.
+	assert.Empty(t, restartedNodes(gone, restartSnap(30*time.Minute, 40*time.Minute, 1100)))
+
+	// Forgotten after nodeSeenRetention.
+	later := &model.Snapshot{FetchedAt: restartBase.Add(nodeSeenRetention)}
+	assert.Empty(t, TrackNodesSeen(gone, later))
+}
+
+func TestRestartedNodes_StaleNodeStats(t *testing.T) {
+	prev := restartSnap(0, time.Second, 1000)
+	prev.NodesSeen = TrackNodesSeen(nil, prev)
+
+	// Node stats carried over from prev keep the old uptime; that is
+	// neither a restart nor a new sighting.
+	curr := restartSnap(time.Minute, time.Second, 1000)
+	curr.Sections = map[model.Section]model.SectionState{model.SectionNodeStats: {Err: errMockFailure}}
+	assert.Empty(t, restartedNodes(prev, curr))
+	assert.Equal(t, prev.NodesSeen, TrackNodesSeen(prev, curr))
+}
+
+func TestNodeRestarts(t *testing.T) {
+	prev := restartSnap(0, time.Hour, 1000)
+	curr := restartSnap(10*time.Second, 5*time.Second, 10)
+
+	assert.Nil(t, NodeRestarts(nil, prev))
+
+	got := NodeRestarts(prev, curr)
+	require.Len(t, got, 1)
+	assert.Equal(t, "n1", got[0].NodeID)
+	assert.Equal(t, "node-a", got[0].Name)
+	assert.Equal(t, restartBase.Add(5*time.Second), got[0].StartedAt)
+	assert.Equal(t, curr.FetchedAt, got[0].DetectedAt)
+	curr.Restarts = got
+
+	// Carried over while within the retention window.
+	later := restartSnap(RestartRetention-time.Second, RestartRetention, 500)
+	assert.Equal(t, got, NodeRestarts(curr, later))
+
+	// Dropped once the window has passed.
+	expired := restartSnap(RestartRetention+10*time.Second, RestartRetention+5*time.Second, 600)
+	assert.Nil(t, NodeRestarts(curr, expired))
+}
+
+func TestRestartRecs(t *testing.T) {
+	assert.Empty(t, restartRecs(&model.Snapshot{}))
+
+	snap := &model.Snapshot{
+		FetchedAt: restartBase.Add(2 * time.Minute),
+		Restarts:  []model.NodeRestart{{NodeID: "n1", Name: "node-a", StartedAt: restartBase, DetectedAt: restartBase}},
+	}
+	recs := restartRecs(snap)
+	require.Len(t, recs, 1)
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, "Node restarted", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "node-a (2m00s ago)")
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// CalcShardRows converts _cat/shards entries into display rows. Shard
+// numbers that fail to parse become -1, as do missing docs and store values
+// (ES reports null for shards that are not started).
+func CalcShardRows(shards []client.ShardInfo) []model.ShardRow {
+	rows := make([]model.ShardRow, 0, len(shards))
+	for _, s := range shards {
+		n, err := strconv.Atoi(s.Shard)
+		if err != nil {
+			n = -1
+		}
+		rows = append(rows, model.ShardRow{
+			Index:            s.Index,
+			Shard:            n,
+			Primary:          s.PriRep == "p",
+			State:            s.State,
+			UnassignedReason: s.UnassignedReason,
+			Docs:             parseCatInt(s.Docs),
+			StoreBytes:       parseCatInt(s.Store),
+			Node:             s.Node,
+		})
+	}
+	return rows
+}
+
+// parseCatInt parses an integer _cat column, returning -1 when it is empty
+// or malformed.
+func parseCatInt(s string) int64 {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
+// FilterShardRows returns the rows of one index (exact name) or held by one
+// node. Relocating shards match both their source and target node, since
+// _cat/shards reports them as "src -> ip id dst". An empty index and node
+// return all rows.
+func FilterShardRows(rows []model.ShardRow, index, node string) []model.ShardRow {
+	out := make([]model.ShardRow, 0, len(rows))
+	for _, r := range rows {
+		if index != "" && r.Index != index {
+			continue
+		}
+		if node != "" && !shardOnNode(r.Node, node) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// shardOnNode reports whether a _cat/shards node column refers to node,
+// either as the sole node or as the source or target of a relocation.
+func shardOnNode(column, node string) bool {
+	if column == node {
+		return true
+	}
+	src, rest, ok := strings.Cut(column, " -> ")
+	if !ok {
+		return false
+	}
+	if strings.TrimSpace(src) == node {
+		return true
+	}
+	fields := strings.Fields(rest)
+	return len(fields) > 0 && fields[len(fields)-1] == node
+}
+
+// shardSourceNode returns the node holding a shard copy from a _cat/shards
+// node column. Relocating copies report "source -> ip id target"; the source
+// still holds the data.
+func shardSourceNode(column string) string {
+	src, _, _ := strings.Cut(column, "->")
+	return strings.TrimSpace(src)
+}
+
+// UnassignedShardRows returns the UNASSIGNED rows, primaries first (they are
+// what turns the cluster RED), then by index and shard number.
+func UnassignedShardRows(rows []model.ShardRow) []model.ShardRow {
+	out := make([]model.ShardRow, 0)
+	for _, r := range rows {
+		if r.State == "UNASSIGNED" {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Primary != b.Primary {
+			return a.Primary
+		}
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return a.Shard < b.Shard
+	})
+	return out
+}
+
+// SummarizeDeciders groups the blocking (NO or THROTTLE) decider verdicts of
+// an allocation explain response by decider, so "same_shard blocked 3 nodes"
+// reads as one line instead of three. Summaries are ordered by number of
+// blocked nodes descending, then by decider name.
+func SummarizeDeciders(exp *client.AllocationExplain) []model.DeciderSummary {
+	if exp == nil {
+		return nil
+	}
+	byName := make(map[string]*model.DeciderSummary)
+	var order []string
+	for _, nd := range exp.NodeAllocationDecisions {
+		node := nd.NodeName
+		if node == "" {
+			node = nd.NodeID
+		}
+		for _, d := range nd.Deciders {
+			decision := strings.ToUpper(d.Decision)
+			if decision != "NO" && decision != "THROTTLE" {
+				continue
+			}
+			s, ok := byName[d.Decider]
+			if !ok {
+				s = &model.DeciderSummary{Decider: d.Decider, Decision: decision, Explanation: d.Explanation}
+				byName[d.Decider] = s
+				order = append(order, d.Decider)
+			}
+			if decision == "NO" {
+				s.Decision = "NO"
+			}
+			s.Nodes = append(s.Nodes, node)
+		}
+	}
+
+	out := make([]model.DeciderSummary, 0, len(order))
+	for _, name := range order {
+		out = append(out, *byName[name])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i].Nodes) != len(out[j].Nodes) {
+			return len(out[i].Nodes) > len(out[j].Nodes)
+		}
+		return out[i].Decider < out[j].Decider
+	})
+	return out
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+func TestCalcShardRows(t *testing.T) {
+	rows := CalcShardRows([]client.ShardInfo{
+		{Index: "logs", Shard: "3", PriRep: "p", State: "STARTED", Docs: "1200", Store: "4096", Node: "es-1"},
+		{Index: "logs", Shard: "x", PriRep: "r", State: "UNASSIGNED", UnassignedReason: "NODE_LEFT"},
+	})
+	require.Len(t, rows, 2)
+	assert.Equal(t, int64(1200), rows[0].Docs)
+	assert.Equal(t, int64(4096), rows[0].StoreBytes)
+	assert.Equal(t, "es-1", rows[0].Node)
+	assert.Equal(t, int64(-1), rows[1].Docs)
+	assert.Equal(t, int64(-1), rows[1].StoreBytes)
+	assert.Equal(t, 3, rows[0].Shard)
+	assert.True(t, rows[0].Primary)
+	assert.Equal(t, -1, rows[1].Shard)
+	assert.False(t, rows[1].Primary)
+	assert.Equal(t, "NODE_LEFT", rows[1].UnassignedReason)
+}
+
+func TestUnassignedShardRows_PrimariesFirst(t *testing.T) {
+	rows := CalcShardRows([]client.ShardInfo{
+		{Index: "b", Shard: "0", PriRep: "r", State: "UNASSIGNED"},
+		{Index: "a", Shard: "1", PriRep: "r", State: "UNASSIGNED"},
+		{Index: "c", Shard: "0", PriRep: "p", State: "STARTED"},
+		{Index: "z", Shard: "0", PriRep: "p", State: "UNASSIGNED"},
+	})
+	out := UnassignedShardRows(rows)
+	require.Len(t, out, 3)
+	assert.Equal(t, "z", out[0].Index)
+	assert.Equal(t, "a", out[1].Index)
+	assert.Equal(t, "b", out[2].Index)
+	assert.NotNil(t, UnassignedShardRows(nil))
+}
+
+func TestFilterShardRows(t *testing.T) {
+	rows := CalcShardRows([]client.ShardInfo{
+		{Index: "logs", Shard: "0", PriRep: "p", State: "STARTED", Node: "es-1"},
+		{Index: "logs-v2", Shard: "0", PriRep: "p", State: "STARTED", Node: "es-2"},
+		{Index: "metrics", Shard: "0", PriRep: "r", State: "RELOCATING", Node: "es-1 -> 10.0.0.3 Xk2wQ es-3"},
+		{Index: "metrics", Shard: "1", PriRep: "r", State: "UNASSIGNED"},
+	})
+
+	assert.Len(t, FilterShardRows(rows, "", ""), 4)
+
+	byIndex := FilterShardRows(rows, "logs", "")
+	require.Len(t, byIndex, 1, "index filter is an exact match")
+	assert.Equal(t, "logs", byIndex[0].Index)
+
+	// Relocating shards match both source and target node.
+	assert.Len(t, FilterShardRows(rows, "", "es-1"), 2)
+	assert.Len(t, FilterShardRows(rows, "", "es-3"), 1)
+	assert.Empty(t, FilterShardRows(rows, "", "es-9"))
+	assert.Len(t, FilterShardRows(rows, "metrics", "es-3"), 1)
+}
+
+func TestShardSourceNode(t *testing.T) {
+	assert.Equal(t, "es-1", shardSourceNode("es-1"))
+	assert.Equal(t, "es-1", shardSourceNode("es-1 -> 10.0.0.2 Xy7 es-2"))
+	assert.Equal(t, "", shardSourceNode(""))
+}
+
+func TestSummarizeDeciders(t *testing.T) {
+	exp := &client.AllocationExplain{
+		NodeAllocationDecisions: []client.NodeAllocationDecision{
+			{NodeName: "es-1", Deciders: []client.AllocationDecider{
+				{Decider: "same_shard", Decision: "NO", Explanation: "copy already on node"},
+				{Decider: "disk_threshold", Decision: "NO", Explanation: "above low watermark"},
+			}},
+			{NodeID: "n2", Deciders: []client.AllocationDecider{
+				{Decider: "disk_threshold", Decision: "NO", Explanation: "above high watermark"},
+				{Decider: "throttling", Decision: "THROTTLE"},
+				{Decider: "filter", Decision: "YES"},
+			}},
+		},
+	}
+	got := SummarizeDeciders(exp)
+	require.Len(t, got, 3)
+	assert.Equal(t, "disk_threshold", got[0].Decider)
+	assert.Equal(t, []string{"es-1", "n2"}, got[0].Nodes)
+	assert.Equal(t, "above low watermark", got[0].Explanation)
+	assert.Equal(t, "same_shard", got[1].Decider)
+	assert.Equal(t, "throttling", got[2].Decider)
+	assert.Equal(t, "THROTTLE", got[2].Decision)
+
+	assert.Nil(t, SummarizeDeciders(nil))
+}
@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// FetchSnapshotRepos lists the snapshot repositories and then the snapshots
+// of every repository concurrently, since listing a blob store repository
+// can take seconds. Only a failure to list the repositories is returned as
+// an error; a repository whose snapshots cannot be listed (e.g. an
+// unreachable S3 bucket) is kept with its Err set so the others still report.
+func FetchSnapshotRepos(ctx context.Context, c client.ESClient) ([]model.RepositorySnapshots, error) {
+	repos, err := c.GetSnapshotRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.RepositorySnapshots, len(repos))
+	var wg sync.WaitGroup
+	for i, r := range repos {
+		out[i].Repository = r
+		wg.Add(1)
+		go func(entry *model.RepositorySnapshots) {
+			defer wg.Done()
+			snaps, err := c.GetSnapshots(ctx, entry.Repository.Name)
+			if err != nil {
+				entry.Err = err.Error()
+				return
+			}
+			entry.Snapshots = snaps
+		}(&out[i])
+	}
+	wg.Wait()
+	return out, nil
+}
+
+// CalcRepositoryRows converts raw repository listings into display rows with
+// snapshots ordered newest first and the end time of the newest SUCCESS.
+func CalcRepositoryRows(repos []model.RepositorySnapshots) []model.RepositoryRow {
+	rows := make([]model.RepositoryRow, 0, len(repos))
+	for _, r := range repos {
+		row := model.RepositoryRow{
+			Name: r.Repository.Name,
+			Type: r.Repository.Type,
+			Err:  r.Err,
+		}
+		row.Snapshots = make([]model.SnapshotRow, 0, len(r.Snapshots))
+		for i := len(r.Snapshots) - 1; i >= 0; i-- {
+			s := calcSnapshotRow(r.Snapshots[i])
+			row.Snapshots = append(row.Snapshots, s)
+			if s.Status == "SUCCESS" && row.LastSuccess.IsZero() {
+				row.LastSuccess = s.End
+				if row.LastSuccess.IsZero() {
+					row.LastSuccess = s.Start
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// calcSnapshotRow parses the string columns of a _cat/snapshots entry.
+// Unparseable counts become 0 and unparseable times stay zero.
+func calcSnapshotRow(s client.SnapshotInfo) model.SnapshotRow {
+	row := model.SnapshotRow{
+		ID:     s.ID,
+		Status: s.Status,
+		Start:  parseEpochSeconds(s.StartEpoch),
+		End:    parseEpochSeconds(s.EndEpoch),
+	}
+	if ms := parseCatInt(s.Duration); ms > 0 {
+		row.Duration = time.Duration(ms) * time.Millisecond
+	}
+	row.Indices, _ = strconv.Atoi(s.Indices)
+	row.FailedShards, _ = strconv.Atoi(s.FailedShards)
+	row.TotalShards, _ = strconv.Atoi(s.TotalShards)
+	return row
+}
+
+// parseEpochSeconds parses a _cat epoch column, returning the zero time for
+// empty, malformed or zero values (ES reports end_epoch 0 while running).
+func parseEpochSeconds(s string) time.Time {
+	v := parseCatInt(s)
+	if v <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(v, 0)
+}
+
+// LatestCompletedSnapshot returns the newest snapshot of row that is no
+// longer running, or false when there is none.
+func LatestCompletedSnapshot(row model.RepositoryRow) (model.SnapshotRow, bool) {
+	for _, s := range row.Snapshots {
+		if s.Status != "IN_PROGRESS" {
+			return s, true
+		}
+	}
+	return model.SnapshotRow{}, false
+}
+
+// snapshotRecs flags repositories whose backups are failing: the latest
+// completed snapshot ended PARTIAL or FAILED, none succeeded, or the newest
+// SUCCESS is older than maxAge. A maxAge of 0 disables only the last check.
+// Repositories without any snapshot are skipped, since they are often
+// read-only restore sources.
+func snapshotRecs(snap *model.Snapshot, maxAge time.Duration) []model.Recommendation {
+	if snap.SnapshotRepos == nil {
+		return nil
+	}
+	var result []model.Recommendation
+	for _, row := range CalcRepositoryRows(snap.SnapshotRepos) {
+		latest, ok := LatestCompletedSnapshot(row)
+		if !ok {
+			continue
+		}
+		name := row.Name
+
+		switch latest.Status {
+		case "FAILED":
+			result = append(result, model.Recommendation{
+				Severity: model.SeverityCritical,
+				Category: model.CategoryIndexLifecycle,
+				Title:    "Latest snapshot FAILED",
+				Detail:   fmt.Sprintf("Snapshot %q in repository %q failed. Check the repository connectivity and the master logs; until a snapshot succeeds, new data is not backed up.", latest.ID, name),
+			})
+		case "PARTIAL":
+			result = append(result, model.Recommendation{
+				Severity: model.SeverityWarning,
+				Category: model.CategoryIndexLifecycle,
+				Title:    "Latest snapshot PARTIAL",
+				Detail:   fmt.Sprintf("Snapshot %q in repository %q completed with %d of %d shard(s) failed. Indices on those shards are not backed up; check for unassigned shards during the snapshot window.", latest.ID, name, latest.FailedShards, latest.TotalShards),
+			})
+		}
+
+		switch {
+		case row.LastSuccess.IsZero():
+			result = append(result, model.Recommendation{
+				Severity: model.SeverityWarning,
+				Category: model.CategoryIndexLifecycle,
+				Title:    "No successful snapshot",
+				Detail:   fmt.Sprintf("Repository %q has %d snapshot(s) but none completed successfully. Backups cannot be relied on for restore.", name, len(row.Snapshots)),
+			})
+		case maxAge > 0 && snap.FetchedAt.Sub(row.LastSuccess) > maxAge:
+			result = append(result, model.Recommendation{
+				Severity: model.SeverityWarning,
+				Category: model.CategoryIndexLifecycle,
+				Title:    "Stale snapshot backups",
+				Detail:   fmt.Sprintf("The newest successful snapshot in repository %q is %s old (threshold %s). Check the SLM policy or snapshot schedule.", name, format.FormatAge(snap.FetchedAt.Sub(row.LastSuccess)), format.FormatAge(maxAge)),
+			})
+		}
+	}
+	return result
+}
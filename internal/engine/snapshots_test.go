@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// snapInfo builds a _cat/snapshots entry that ended at end.
+func snapInfo(id, status string, end time.Time) client.SnapshotInfo {
+	return client.SnapshotInfo{
+		ID:           id,
+		Status:       status,
+		StartEpoch:   strconv.FormatInt(end.Add(-time.Minute).Unix(), 10),
+		EndEpoch:     strconv.FormatInt(end.Unix(), 10),
+		Duration:     "60000",
+		FailedShards: "0",
+		TotalShards:  "10",
+	}
+}
+
+func TestFetchSnapshotRepos_PerRepoErrorIsKept(t *testing.T) {
+	mc := &MockESClient{
+		SnapshotReposFn: func(context.Context) ([]client.SnapshotRepository, error) {
+			return []client.SnapshotRepository{{Name: "nightly", Type: "fs"}, {Name: "s3-offsite", Type: "s3"}}, nil
+		},
+		SnapshotsFn: func(_ context.Context, repo string) ([]client.SnapshotInfo, error) {
+			if repo == "s3-offsite" {
+				return nil, errMockFailure
+			}
+			return []client.SnapshotInfo{{ID: "snap-1", Status: "SUCCESS"}}, nil
+		},
+	}
+	repos, err := FetchSnapshotRepos(context.Background(), mc)
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Len(t, repos[0].Snapshots, 1)
+	assert.Empty(t, repos[0].Err)
+	assert.NotEmpty(t, repos[1].Err)
+}
+
+func TestFetchSnapshotRepos_ListsReposConcurrently(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(2)
+	mc := &MockESClient{
+		SnapshotReposFn: func(context.Context) ([]client.SnapshotRepository, error) {
+			return []client.SnapshotRepository{{Name: "nightly", Type: "fs"}, {Name: "s3-offsite", Type: "s3"}}, nil
+		},
+		SnapshotsFn: func(_ context.Context, repo string) ([]client.SnapshotInfo, error) {
+			// Each listing waits for the other to start, so a sequential
+			// fetch would never return.
+			started.Done()
+			started.Wait()
+			return []client.SnapshotInfo{{ID: repo + "-1", Status: "SUCCESS"}}, nil
+		},
+	}
+	repos, err := FetchSnapshotRepos(context.Background(), mc)
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, "nightly", repos[0].Repository.Name, "repository order is kept")
+	assert.Equal(t, "nightly-1", repos[0].Snapshots[0].ID)
+	assert.Equal(t, "s3-offsite-1", repos[1].Snapshots[0].ID)
+}
+
+func TestPoller_SnapshotReposFailureIsNonFatal(t *testing.T) {
+	var calls atomic.Int32
+	mc := &MockESClient{
+		SnapshotReposFn: func(context.Context) ([]client.SnapshotRepository, error) {
+			calls.Add(1)
+			return nil, errMockFailure
+		},
+	}
+	p := NewPoller(mc, 10*time.Second, DefaultRecommendationConfig())
+	r := p.Poll(context.Background())
+	require.NoError(t, r.Err)
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	r = p.Poll(context.Background())
+	require.NoError(t, r.Err)
+	assert.Nil(t, r.Snapshot.SnapshotRepos)
+}
+
+func TestCalcRepositoryRows_NewestFirstAndLastSuccess(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	rows := CalcRepositoryRows([]model.RepositorySnapshots{{
+		Repository: client.SnapshotRepository{Name: "nightly", Type: "fs"},
+		Snapshots: []client.SnapshotInfo{
+			snapInfo("snap-1", "SUCCESS", now.Add(-48*time.Hour)),
+			snapInfo("snap-2", "SUCCESS", now.Add(-24*time.Hour)),
+			snapInfo("snap-3", "PARTIAL", now.Add(-time.Hour)),
+			{ID: "snap-4", Status: "IN_PROGRESS", StartEpoch: strconv.FormatInt(now.Unix(), 10), EndEpoch: "0"},
+		},
+	}})
+	require.Len(t, rows, 1)
+	r := rows[0]
+	require.Len(t, r.Snapshots, 4)
+	assert.Equal(t, "snap-4", r.Snapshots[0].ID)
+	assert.True(t, r.Snapshots[0].End.IsZero())
+	assert.Equal(t, now.Add(-24*time.Hour), r.LastSuccess)
+	assert.Equal(t, time.Minute, r.Snapshots[1].Duration)
+	assert.Equal(t, 10, r.Snapshots[1].TotalShards)
+
+	latest, ok := LatestCompletedSnapshot(r)
+	require.True(t, ok)
+	assert.Equal(t, "snap-3", latest.ID, "in-progress snapshots are skipped")
+}
+
+func snapshotRepoSnap(now time.Time, snaps ...client.SnapshotInfo) *model.Snapshot {
+	snap := makeSnap("green", 10, 0)
+	snap.FetchedAt = now
+	snap.SnapshotRepos = []model.RepositorySnapshots{{
+		Repository: client.SnapshotRepository{Name: "nightly", Type: "fs"},
+		Snapshots:  snaps,
+	}}
+	return snap
+}
+
+func TestCalcRecommendations_StaleSnapshot(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	snap := snapshotRepoSnap(now, snapInfo("snap-1", "SUCCESS", now.Add(-30*time.Hour)))
+
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "Stale snapshot backups"))
+	assert.True(t, hasRecCategory(recs, model.SeverityWarning, model.CategoryIndexLifecycle))
+
+	cfg := DefaultRecommendationConfig()
+	cfg.SnapshotMaxAge = 48 * time.Hour
+	recs = CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, nil, nil, nil, cfg)
+	assert.False(t, hasRec(recs, model.SeverityWarning, "Stale snapshot backups"), "threshold is configurable")
+
+	cfg.SnapshotMaxAge = 0
+	recs = CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, nil, nil, nil, cfg)
+	assert.False(t, hasRec(recs, model.SeverityWarning, "Stale snapshot backups"), "zero disables the check")
+}
+
+func TestCalcRecommendations_FreshSnapshotNoRec(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	snap := snapshotRepoSnap(now, snapInfo("snap-1", "SUCCESS", now.Add(-time.Hour)))
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	for _, r := range recs {
+		assert.NotEqual(t, model.CategoryIndexLifecycle, r.Category, "unexpected %q", r.Title)
+	}
+}
+
+func TestCalcRecommendations_LatestSnapshotFailedOrPartial(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	snap := snapshotRepoSnap(now,
+		snapInfo("snap-1", "SUCCESS", now.Add(-2*time.Hour)),
+		snapInfo("snap-2", "FAILED", now.Add(-time.Hour)),
+	)
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.True(t, hasRec(recs, model.SeverityCritical, "Latest snapshot FAILED"))
+
+	cfg := DefaultRecommendationConfig()
+	cfg.SnapshotMaxAge = 0
+	recs = CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, nil, nil, nil, cfg)
+	assert.True(t, hasRec(recs, model.SeverityCritical, "Latest snapshot FAILED"), "a zero max age disables only the staleness check")
+
+	partial := snapInfo("snap-2", "PARTIAL", now.Add(-time.Hour))
+	partial.FailedShards = "3"
+	snap = snapshotRepoSnap(now, snapInfo("snap-1", "SUCCESS", now.Add(-2*time.Hour)), partial)
+	recs = CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "Latest snapshot PARTIAL"))
+	for _, r := range recs {
+		if r.Title == "Latest snapshot PARTIAL" {
+			assert.Contains(t, r.Detail, "3 of 10 shard(s)")
+		}
+	}
+}
+
+func TestCalcRecommendations_NoSuccessfulSnapshot(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	snap := snapshotRepoSnap(now, snapInfo("snap-1", "FAILED", now.Add(-time.Hour)))
+	recs := CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "No successful snapshot"))
+
+	cfg := DefaultRecommendationConfig()
+	cfg.SnapshotMaxAge = 0
+	recs = CalcRecommendationsWithConfig(snap, model.ClusterResources{}, nil, nil, nil, nil, cfg)
+	assert.True(t, hasRec(recs, model.SeverityWarning, "No successful snapshot"))
+
+	// Empty repositories are not flagged.
+	snap = snapshotRepoSnap(now)
+	recs = CalcRecommendations(snap, model.ClusterResources{}, nil, nil, nil, nil)
+	assert.False(t, hasRec(recs, model.SeverityWarning, "No successful snapshot"))
+}
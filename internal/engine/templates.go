@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// MatchTemplates returns the templates whose index patterns match index,
+// applying Elasticsearch's selection rules: when any composable template
+// matches, only the highest-priority one is applied and legacy templates are
+// ignored; otherwise every matching legacy template is applied, merged in
+// ascending order. Composable matches are listed first by descending
+// priority, then legacy matches by ascending order. Component templates
+// have no patterns and never match directly.
+func MatchTemplates(index string, templates []client.IndexTemplate) []model.TemplateMatch {
+	var composable, legacy []client.IndexTemplate
+	for _, t := range templates {
+		if t.Kind == client.TemplateComponent || !matchesAnyPattern(index, t.Patterns) {
+			continue
+		}
+		if t.Kind == client.TemplateLegacy {
+			legacy = append(legacy, t)
+		} else {
+			composable = append(composable, t)
+		}
+	}
+	sort.SliceStable(composable, func(i, j int) bool {
+		if composable[i].Priority != composable[j].Priority {
+			return composable[i].Priority > composable[j].Priority
+		}
+		return composable[i].Name < composable[j].Name
+	})
+	sort.SliceStable(legacy, func(i, j int) bool {
+		if legacy[i].Priority != legacy[j].Priority {
+			return legacy[i].Priority < legacy[j].Priority
+		}
+		return legacy[i].Name < legacy[j].Name
+	})
+
+	matches := make([]model.TemplateMatch, 0, len(composable)+len(legacy))
+	for i, t := range composable {
+		matches = append(matches, model.TemplateMatch{Template: t, Applied: i == 0})
+	}
+	for _, t := range legacy {
+		matches = append(matches, model.TemplateMatch{Template: t, Applied: len(composable) == 0})
+	}
+	return matches
+}
+
+// matchesAnyPattern reports whether name matches any of the index patterns.
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if wildcardMatch(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatch reports whether s matches pattern, where '*' matches any
+// run of characters (including none) and every other character matches
+// itself. This is the only wildcard index patterns support.
+func wildcardMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
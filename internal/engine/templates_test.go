@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+func TestWildcardMatch(t *testing.T) {
+	cases := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"logs-*", "logs-2024.01.01", true},
+		{"logs-*", "metrics-1", false},
+		{"*", "anything", true},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"*-app-*", "logs-app-000001", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+		{"logs-*-x", "logs-x", false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, wildcardMatch(tc.pattern, tc.s), "%q vs %q", tc.pattern, tc.s)
+	}
+}
+
+func TestMatchTemplates_ComposableWinsByPriority(t *testing.T) {
+	templates := []client.IndexTemplate{
+		{Name: "logs-low", Kind: client.TemplateComposable, Patterns: []string{"logs-*"}, Priority: 100},
+		{Name: "logs-high", Kind: client.TemplateComposable, Patterns: []string{"logs-app-*"}, Priority: 200},
+		{Name: "logs-legacy", Kind: client.TemplateLegacy, Patterns: []string{"logs-*"}},
+		{Name: "logs-settings", Kind: client.TemplateComponent},
+		{Name: "metrics", Kind: client.TemplateComposable, Patterns: []string{"metrics-*"}, Priority: 500},
+	}
+	matches := MatchTemplates("logs-app-000001", templates)
+	require.Len(t, matches, 3)
+	assert.Equal(t, "logs-high", matches[0].Template.Name)
+	assert.True(t, matches[0].Applied)
+	assert.Equal(t, "logs-low", matches[1].Template.Name)
+	assert.False(t, matches[1].Applied)
+	assert.Equal(t, "logs-legacy", matches[2].Template.Name)
+	assert.False(t, matches[2].Applied, "legacy templates are ignored when a composable template matches")
+}
+
+func TestMatchTemplates_LegacyOnly(t *testing.T) {
+	templates := []client.IndexTemplate{
+		{Name: "late", Kind: client.TemplateLegacy, Patterns: []string{"old-*"}, Priority: 10},
+		{Name: "early", Kind: client.TemplateLegacy, Patterns: []string{"*"}, Priority: 0},
+	}
+	matches := MatchTemplates("old-1", templates)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "early", matches[0].Template.Name, "legacy templates merge in ascending order")
+	assert.True(t, matches[0].Applied)
+	assert.True(t, matches[1].Applied)
+
+	assert.Empty(t, MatchTemplates("other", templates[:1]))
+}
@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// tierRoomGapPct is how many percentage points less full the warm tier
+	// must be than a filling hot tier before moving data is suggested.
+	tierRoomGapPct = 20.0
+
+	// tierSearchShare and tierIndexingShare are the shares of cluster search
+	// and indexing traffic the cold/frozen and non-hot tiers may take before
+	// a recommendation is raised; tierTrafficMinRate ignores idle clusters.
+	tierSearchShare    = 0.20
+	tierIndexingShare  = 0.10
+	tierTrafficMinRate = 1.0
+)
+
+// tierOrder lists the data tiers from hottest to coldest, followed by the
+// content tier and untiered data nodes. A node holding several tier roles is
+// classified by the first one in this order.
+var tierOrder = []string{"hot", "warm", "cold", "frozen", "content", "data"}
+
+// tierRoles maps Elasticsearch data role names to tiers.
+var tierRoles = map[string]string{
+	"data_hot":     "hot",
+	"data_warm":    "warm",
+	"data_cold":    "cold",
+	"data_frozen":  "frozen",
+	"data_content": "content",
+	"data":         "data",
+}
+
+// tierLetters maps _cat/nodes role abbreviations to tiers, used when the
+// full role names are missing from node stats.
+var tierLetters = map[rune]string{'h': "hot", 'w': "warm", 'c': "cold", 'f': "frozen", 's': "content", 'd': "data"}
+
+// NodeTier returns the data tier of row, or "" for nodes without a data
+// role (dedicated masters, coordinating-only, ingest-only nodes).
+func NodeTier(row model.NodeRow) string {
+	held := make(map[string]bool)
+	if len(row.Roles) > 0 {
+		for _, r := range row.Roles {
+			if t, ok := tierRoles[r]; ok {
+				held[t] = true
+			}
+		}
+	} else {
+		for _, c := range row.Role {
+			if t, ok := tierLetters[c]; ok {
+				held[t] = true
+			}
+		}
+	}
+	for _, t := range tierOrder {
+		if held[t] {
+			return t
+		}
+	}
+	return ""
+}
+
+// TierRank returns the position of tier in tierOrder, or len(tierOrder)
+// for unknown tiers.
+func TierRank(tier string) int {
+	for i, t := range tierOrder {
+		if t == tier {
+			return i
+		}
+	}
+	return len(tierOrder)
+}
+
+// CalcTierRows aggregates data nodes by tier, hottest first. Tiers without
+// nodes are omitted.
+func CalcTierRows(nodeRows []model.NodeRow) []model.TierRow {
+	type acc struct {
+		row                     model.TierRow
+		heapSum                 float64
+		heapN                   int
+		hasShards               bool
+		hasIdxRate, hasSrchRate bool
+	}
+	byTier := make(map[string]*acc)
+	for _, n := range nodeRows {
+		tier := NodeTier(n)
+		if tier == "" {
+			continue
+		}
+		a, ok := byTier[tier]
+		if !ok {
+			a = &acc{row: model.TierRow{Tier: tier}}
+			byTier[tier] = a
+		}
+		a.row.Nodes++
+		if n.DiskTotalBytes > 0 {
+			a.row.DiskTotalBytes += n.DiskTotalBytes
+			a.row.DiskUsedBytes += n.DiskTotalBytes - n.DiskAvailBytes
+		}
+		if n.HeapMaxBytes > 0 {
+			a.heapSum += float64(n.HeapUsedBytes) / float64(n.HeapMaxBytes) * 100
+			a.heapN++
+		}
+		if n.Shards >= 0 {
+			a.row.Shards += n.Shards
+			a.hasShards = true
+		}
+		if n.IndexingRate >= 0 {
+			a.row.IndexingRate += n.IndexingRate
+			a.hasIdxRate = true
+		}
+		if n.SearchRate >= 0 {
+			a.row.SearchRate += n.SearchRate
+			a.hasSrchRate = true
+		}
+	}
+
+	var rows []model.TierRow
+	for _, tier := range tierOrder {
+		a, ok := byTier[tier]
+		if !ok {
+			continue
+		}
+		r := a.row
+		r.UsedPercent = -1
+		if r.DiskTotalBytes > 0 {
+			r.UsedPercent = float64(r.DiskUsedBytes) / float64(r.DiskTotalBytes) * 100
+		}
+		r.AvgHeapPercent = -1
+		if a.heapN > 0 {
+			r.AvgHeapPercent = a.heapSum / float64(a.heapN)
+		}
+		if !a.hasShards {
+			r.Shards = -1
+		}
+		if !a.hasIdxRate {
+			r.IndexingRate = model.MetricNotAvailable
+		}
+		if !a.hasSrchRate {
+			r.SearchRate = model.MetricNotAvailable
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// tierRecs checks how data and traffic are spread across the data tiers:
+// a hot tier running out of disk while warm has room, search traffic landing
+// on cold/frozen nodes, and indexing landing outside the hot tier.
+func tierRecs(snap *model.Snapshot, nodeRows []model.NodeRow) []model.Recommendation {
+	tiers := make(map[string]model.TierRow)
+	for _, r := range CalcTierRows(nodeRows) {
+		tiers[r.Tier] = r
+	}
+	var recs []model.Recommendation
+
+	hot, hasHot := tiers["hot"]
+	warm, hasWarm := tiers["warm"]
+	warnPct, _ := StorageThresholds(snap)
+	if hasHot && hasWarm && hot.UsedPercent > warnPct && warm.UsedPercent >= 0 && warm.UsedPercent < hot.UsedPercent-tierRoomGapPct {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityWarning,
+			Category: model.CategoryResourcePressure,
+			Title:    "Hot tier disk filling while warm tier has room",
+			Detail: fmt.Sprintf("Hot tier is %.0f%% full (%s free of %s) while the warm tier is at %.0f%% (%s free). Move indices to warm sooner: lower the ILM warm phase min_age or the hot phase rollover max_age.",
+				hot.UsedPercent, format.FormatBytes(hot.DiskTotalBytes-hot.DiskUsedBytes), format.FormatBytes(hot.DiskTotalBytes),
+				warm.UsedPercent, format.FormatBytes(warm.DiskTotalBytes-warm.DiskUsedBytes)),
+		})
+	}
+
+	var totalSearch, coldSearch, totalIndexing, nonHotIndexing float64
+	var coldTiers []string
+	for _, r := range tiers {
+		if r.SearchRate > 0 {
+			totalSearch += r.SearchRate
+			if r.Tier == "cold" || r.Tier == "frozen" {
+				coldSearch += r.SearchRate
+			}
+		}
+		if r.IndexingRate > 0 {
+			totalIndexing += r.IndexingRate
+			if r.Tier == "warm" || r.Tier == "cold" || r.Tier == "frozen" {
+				nonHotIndexing += r.IndexingRate
+			}
+		}
+	}
+	for _, t := range []string{"cold", "frozen"} {
+		if _, ok := tiers[t]; ok {
+			coldTiers = append(coldTiers, t)
+		}
+	}
+	// Only warn when a hot or warm tier exists for the traffic to go to; it
+	// may be getting none of it. Content and generic data nodes do not count,
+	// since ILM does not move data back to them.
+	if coldSearch >= tierTrafficMinRate && (hasHot || hasWarm) && coldSearch/totalSearch > tierSearchShare {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityWarning,
+			Category: model.CategoryHotspot,
+			Title:    "Search traffic landing on cold tier",
+			Detail: fmt.Sprintf("%.0f%% of search traffic (%s) hits the %s tier, whose nodes are sized for rare queries on old data. Check for dashboards with long time ranges or wildcard index patterns, or keep recent data on hot/warm longer.",
+				coldSearch/totalSearch*100, format.FormatRate(coldSearch), strings.Join(coldTiers, "/")),
+		})
+	}
+	if hasHot && nonHotIndexing >= tierTrafficMinRate && nonHotIndexing/totalIndexing > tierIndexingShare {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityWarning,
+			Category: model.CategoryHotspot,
+			Title:    "Indexing traffic landing outside the hot tier",
+			Detail: fmt.Sprintf("%.0f%% of indexing traffic (%s) hits warm, cold or frozen nodes. Writes should go to the hot tier; check for updates to old indices, missed rollovers, or index templates without a hot tier preference.",
+				nonHotIndexing/totalIndexing*100, format.FormatRate(nonHotIndexing)),
+		})
+	}
+	return recs
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func tierNode(name, role string, usedPct float64, idx, srch float64) model.NodeRow {
+	return model.NodeRow{
+		Name: name, Roles: []string{role, "ingest"}, Shards: 10,
+		HeapMaxBytes: 100, HeapUsedBytes: 40,
+		DiskTotalBytes: 1000, DiskAvailBytes: int64(1000 * (100 - usedPct) / 100),
+		IndexingRate: idx, SearchRate: srch,
+	}
+}
+
+func TestNodeTier(t *testing.T) {
+	assert.Equal(t, "hot", NodeTier(model.NodeRow{Roles: []string{"data_content", "data_hot"}}), "hottest role wins")
+	assert.Equal(t, "frozen", NodeTier(model.NodeRow{Roles: []string{"data_frozen"}}))
+	assert.Equal(t, "data", NodeTier(model.NodeRow{Roles: []string{"data", "master"}}))
+	assert.Equal(t, "", NodeTier(model.NodeRow{Roles: []string{"master"}}))
+	assert.Equal(t, "warm", NodeTier(model.NodeRow{Role: "imw"}), "falls back to role letters")
+	assert.Equal(t, "", NodeTier(model.NodeRow{Role: "m"}))
+	assert.Less(t, TierRank("hot"), TierRank("cold"))
+	assert.Equal(t, len(tierOrder), TierRank("unknown"))
+}
+
+func TestCalcTierRows(t *testing.T) {
+	rows := []model.NodeRow{
+		tierNode("c1", "data_cold", 50, 0, 2),
+		tierNode("h1", "data_hot", 80, 100, 10),
+		tierNode("h2", "data_hot", 60, 50, model.MetricNotAvailable),
+		{Name: "master", Roles: []string{"master"}, Shards: -1},
+	}
+	tiers := CalcTierRows(rows)
+	require.Len(t, tiers, 2)
+	assert.Equal(t, model.TierRow{Tier: "hot", Nodes: 2, DiskTotalBytes: 2000, DiskUsedBytes: 1400, UsedPercent: 70,
+		AvgHeapPercent: 40, Shards: 20, IndexingRate: 150, SearchRate: 10}, tiers[0])
+	assert.Equal(t, "cold", tiers[1].Tier)
+
+	tiers = CalcTierRows([]model.NodeRow{{Name: "x", Role: "h", Shards: -1, IndexingRate: model.MetricNotAvailable, SearchRate: model.MetricNotAvailable}})
+	require.Len(t, tiers, 1)
+	assert.Equal(t, -1.0, tiers[0].UsedPercent)
+	assert.Equal(t, -1.0, tiers[0].AvgHeapPercent)
+	assert.Equal(t, -1, tiers[0].Shards)
+	assert.Equal(t, model.MetricNotAvailable, tiers[0].IndexingRate)
+}
+
+func TestTierRecs(t *testing.T) {
+	titles := func(recs []model.Recommendation) []string {
+		var out []string
+		for _, r := range recs {
+			out = append(out, r.Title)
+		}
+		return out
+	}
+
+	// Hot filling, warm mostly empty.
+	recs := tierRecs(nil, []model.NodeRow{
+		tierNode("h1", "data_hot", 85, 100, 100),
+		tierNode("w1", "data_warm", 40, 0, 0),
+	})
+	require.Len(t, recs, 1)
+	assert.Equal(t, "Hot tier disk filling while warm tier has room", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "85% full")
+
+	// Warm nearly as full as hot: nowhere to move data.
+	recs = tierRecs(nil, []model.NodeRow{
+		tierNode("h1", "data_hot", 85, 100, 100),
+		tierNode("w1", "data_warm", 75, 0, 0),
+	})
+	assert.Empty(t, recs)
+
+	// Search and indexing landing on colder tiers.
+	recs = tierRecs(nil, []model.NodeRow{
+		tierNode("h1", "data_hot", 50, 100, 60),
+		tierNode("w1", "data_warm", 50, 20, 0),
+		tierNode("c1", "data_cold", 50, 0, 40),
+	})
+	assert.Equal(t, []string{"Search traffic landing on cold tier", "Indexing traffic landing outside the hot tier"}, titles(recs))
+	assert.Contains(t, recs[0].Detail, "40% of search traffic")
+
+	// All search traffic on cold while the hot tier sits idle.
+	recs = tierRecs(nil, []model.NodeRow{
+		tierNode("h1", "data_hot", 50, 0, 0),
+		tierNode("c1", "data_cold", 50, 0, 40),
+	})
+	assert.Equal(t, []string{"Search traffic landing on cold tier"}, titles(recs))
+	assert.Contains(t, recs[0].Detail, "100% of search traffic")
+
+	// A cluster made only of cold and frozen nodes has nowhere else to search.
+	recs = tierRecs(nil, []model.NodeRow{tierNode("c1", "data_cold", 50, 0, 40)})
+	assert.Empty(t, recs)
+	recs = tierRecs(nil, []model.NodeRow{
+		tierNode("c1", "data_cold", 50, 0, 40),
+		tierNode("f1", "data_frozen", 50, 0, 40),
+	})
+	assert.Empty(t, recs)
+
+	// Content nodes are not a warmer tier for time series data to move to.
+	recs = tierRecs(nil, []model.NodeRow{
+		tierNode("c1", "data_cold", 50, 0, 40),
+		tierNode("k1", "data_content", 50, 0, 0),
+	})
+	assert.Empty(t, recs)
+}
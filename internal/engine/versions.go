@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// CompareVersions compares two Elasticsearch version strings such as
+// "8.12.0" or "8.13.0-SNAPSHOT" by their numeric components, returning -1,
+// 0 or 1. Versions that only differ in a suffix compare as strings, and an
+// empty version sorts before any other.
+func CompareVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// versionParts returns the leading numeric components of v, stopping at the
+// first suffix such as "-SNAPSHOT".
+func versionParts(v string) []int {
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// VersionGroups groups the nodes that report a version by that version,
+// oldest first. More than one group means the cluster runs mixed versions,
+// usually during a rolling upgrade.
+func VersionGroups(nodeRows []model.NodeRow) []model.VersionGroup {
+	byVersion := make(map[string][]string)
+	for _, n := range nodeRows {
+		if n.Version != "" {
+			byVersion[n.Version] = append(byVersion[n.Version], n.Name)
+		}
+	}
+	groups := make([]model.VersionGroup, 0, len(byVersion))
+	for v, names := range byVersion {
+		sort.Strings(names)
+		groups = append(groups, model.VersionGroup{Version: v, Nodes: names})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return CompareVersions(groups[i].Version, groups[j].Version) < 0
+	})
+	return groups
+}
+
+// versionRecs lists the nodes per version when the cluster runs mixed
+// versions, and flags primaries on upgraded nodes: Elasticsearch never
+// allocates a replica to a node older than its primary, nor moves a shard
+// from a newer node to an older one.
+func versionRecs(snap *model.Snapshot, nodeRows []model.NodeRow) []model.Recommendation {
+	groups := VersionGroups(nodeRows)
+	if len(groups) < 2 {
+		return nil
+	}
+
+	parts := make([]string, 0, len(groups))
+	for _, g := range groups {
+		parts = append(parts, fmt.Sprintf("%s on %d node(s) (%s)", g.Version, len(g.Nodes), nameList(g.Nodes, plainName)))
+	}
+	recs := []model.Recommendation{{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryShardHealth,
+		Title:    "Mixed node versions",
+		Detail: fmt.Sprintf("The cluster runs %d Elasticsearch versions: %s. Mixed versions are only supported during a rolling upgrade; upgrade the remaining nodes before changing indices or settings that need the new version.",
+			len(groups), strings.Join(parts, "; ")),
+	}}
+
+	oldest := groups[0].Version
+	nodeVersion := make(map[string]string, len(nodeRows))
+	for _, n := range nodeRows {
+		nodeVersion[n.Name] = n.Version
+	}
+	upgraded := make(map[string]bool) // index/shard with its primary on an upgraded node
+	indices := make(map[string]bool)
+	if snap != nil {
+		for _, s := range snap.Shards {
+			if s.PriRep != "p" || s.Node == "" {
+				continue
+			}
+			if v := nodeVersion[shardSourceNode(s.Node)]; v != "" && CompareVersions(v, oldest) > 0 {
+				upgraded[s.Index+"/"+s.Shard] = true
+				indices[s.Index] = true
+			}
+		}
+	}
+	if len(upgraded) == 0 {
+		return recs
+	}
+	var stuck int
+	for _, s := range snap.Shards {
+		if s.PriRep == "r" && s.State == "UNASSIGNED" && upgraded[s.Index+"/"+s.Shard] {
+			stuck++
+		}
+	}
+	severity := model.SeverityWarning
+	detail := fmt.Sprintf("%d primary shard(s) of %d index(es) are on nodes newer than %s. Their replicas cannot be allocated to the %s nodes and the shards cannot move back to them, so the upgrade cannot be rolled back.",
+		len(upgraded), len(indices), oldest, oldest)
+	if stuck > 0 {
+		severity = model.SeverityCritical
+		detail += fmt.Sprintf(" %d of their replicas are unassigned and stay so until enough nodes are upgraded to hold them.", stuck)
+	}
+	recs = append(recs, model.Recommendation{
+		Severity: severity,
+		Category: model.CategoryShardHealth,
+		Title:    "Primaries on upgraded nodes",
+		Detail:   detail + " Continue the rolling upgrade node by node.",
+	})
+	return recs
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("8.12.0", "8.12.0"))
+	assert.Equal(t, -1, CompareVersions("7.17.9", "8.12.0"))
+	assert.Equal(t, 1, CompareVersions("8.10.0", "8.9.2"), "components compare numerically")
+	assert.Equal(t, -1, CompareVersions("8.12", "8.12.1"))
+	assert.Equal(t, -1, CompareVersions("8.13.0", "8.13.0-SNAPSHOT"), "suffix breaks the tie as a string")
+	assert.Equal(t, -1, CompareVersions("", "7.0.0"))
+}
+
+func TestVersionGroups(t *testing.T) {
+	groups := VersionGroups([]model.NodeRow{
+		{Name: "es-3", Version: "8.12.0"},
+		{Name: "es-2", Version: "7.17.9"},
+		{Name: "es-1", Version: "7.17.9"},
+		{Name: "es-4"},
+	})
+	assert.Equal(t, []model.VersionGroup{
+		{Version: "7.17.9", Nodes: []string{"es-1", "es-2"}},
+		{Version: "8.12.0", Nodes: []string{"es-3"}},
+	}, groups)
+}
+
+func TestVersionRecs(t *testing.T) {
+	uniform := []model.NodeRow{{Name: "es-1", Version: "8.12.0"}, {Name: "es-2", Version: "8.12.0"}}
+	assert.Empty(t, versionRecs(&model.Snapshot{}, uniform))
+
+	mixed := []model.NodeRow{
+		{Name: "es-1", Version: "7.17.9"},
+		{Name: "es-2", Version: "7.17.9"},
+		{Name: "es-3", Version: "8.12.0"},
+	}
+	snap := &model.Snapshot{Shards: []client.ShardInfo{
+		{Index: "logs", Shard: "0", PriRep: "p", State: "STARTED", Node: "es-1"},
+		{Index: "logs", Shard: "0", PriRep: "r", State: "STARTED", Node: "es-3"},
+	}}
+	recs := versionRecs(snap, mixed)
+	require.Len(t, recs, 1, "replicas on newer nodes are fine")
+	assert.Equal(t, model.SeverityWarning, recs[0].Severity)
+	assert.Equal(t, model.CategoryShardHealth, recs[0].Category)
+	assert.Equal(t, "Mixed node versions", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "7.17.9 on 2 node(s) (es-1, es-2); 8.12.0 on 1 node(s) (es-3)")
+
+	snap.Shards = []client.ShardInfo{
+		{Index: "metrics", Shard: "0", PriRep: "p", State: "STARTED", Node: "es-3"},
+		{Index: "metrics", Shard: "0", PriRep: "r", State: "STARTED", Node: "es-3"},
+		{Index: "metrics", Shard: "1", PriRep: "p", State: "STARTED", Node: "es-3"},
+	}
+	recs = versionRecs(snap, mixed)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "Primaries on upgraded nodes", recs[1].Title)
+	assert.Equal(t, model.SeverityWarning, recs[1].Severity)
+	assert.Contains(t, recs[1].Detail, "2 primary shard(s) of 1 index(es) are on nodes newer than 7.17.9")
+
+	snap.Shards[1] = client.ShardInfo{Index: "metrics", Shard: "0", PriRep: "r", State: "UNASSIGNED"}
+	recs = versionRecs(snap, mixed)
+	require.Len(t, recs, 2)
+	assert.Equal(t, model.SeverityCritical, recs[1].Severity)
+	assert.Contains(t, recs[1].Detail, "1 of their replicas are unassigned")
+
+	// A primary relocating away from an upgraded node is still on it.
+	snap.Shards = []client.ShardInfo{
+		{Index: "metrics", Shard: "0", PriRep: "p", State: "RELOCATING", Node: "es-3 -> 10.0.0.4 Xy7 es-4"},
+	}
+	recs = versionRecs(snap, mixed)
+	require.Len(t, recs, 2)
+	assert.Contains(t, recs[1].Detail, "1 primary shard(s) of 1 index(es)")
+}
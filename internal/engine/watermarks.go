@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// fallbackStorageWarnPct and fallbackStorageCritPct are the cluster-wide
+	// storage thresholds used when the watermarks are unknown or given as
+	// absolute byte values, which cannot be compared to a cluster average.
+	fallbackStorageWarnPct = 80.0
+	fallbackStorageCritPct = 90.0
+)
+
+// EffectiveWatermarks returns the disk watermarks from snap, or
+// Elasticsearch's defaults when they were not fetched.
+func EffectiveWatermarks(snap *model.Snapshot) client.DiskWatermarks {
+	if snap == nil || snap.DiskWatermarks == nil {
+		return client.DefaultDiskWatermarks()
+	}
+	return *snap.DiskWatermarks
+}
+
+// RequiredFreeBytes returns the free space a node with total bytes of disk
+// must keep to stay under wm. A ratio watermark is capped by its max
+// headroom, as Elasticsearch does for large disks.
+func RequiredFreeBytes(wm client.DiskWatermark, total int64) int64 {
+	if wm.FreeBytes > 0 || wm.Percent <= 0 {
+		return wm.FreeBytes
+	}
+	free := int64(float64(total) * (100 - wm.Percent) / 100)
+	if wm.MaxHeadroom > 0 && free > wm.MaxHeadroom {
+		free = wm.MaxHeadroom
+	}
+	return free
+}
+
+// watermarkExceeded reports whether row's disk is past wm. Free and total
+// bytes are preferred; the _cat/allocation percentage is the fallback for
+// ratio watermarks when node filesystem stats are missing.
+func watermarkExceeded(wm client.DiskWatermark, row model.NodeRow) bool {
+	if row.DiskTotalBytes > 0 {
+		return row.DiskAvailBytes < RequiredFreeBytes(wm, row.DiskTotalBytes)
+	}
+	if wm.Percent > 0 && row.DiskPercent >= 0 {
+		return row.DiskPercent > wm.Percent
+	}
+	return false
+}
+
+// WatermarkLevelFor returns the highest watermark row's disk exceeds. It is
+// WatermarkNone when the disk threshold decider is disabled.
+func WatermarkLevelFor(row model.NodeRow, wms client.DiskWatermarks) model.WatermarkLevel {
+	switch {
+	case !wms.ThresholdEnabled:
+		return model.WatermarkNone
+	case watermarkExceeded(wms.FloodStage, row):
+		return model.WatermarkFloodStage
+	case watermarkExceeded(wms.High, row):
+		return model.WatermarkHigh
+	case watermarkExceeded(wms.Low, row):
+		return model.WatermarkLow
+	default:
+		return model.WatermarkNone
+	}
+}
+
+// StorageThresholds returns the warning and critical percentages for the
+// cluster-wide storage figure: the low and high watermarks when both are
+// ratios, otherwise the fixed 80% and 90% fallback.
+func StorageThresholds(snap *model.Snapshot) (warn, crit float64) {
+	if snap == nil || snap.DiskWatermarks == nil {
+		return fallbackStorageWarnPct, fallbackStorageCritPct
+	}
+	wms := snap.DiskWatermarks
+	if wms.Low.Percent <= 0 || wms.High.Percent <= 0 {
+		return fallbackStorageWarnPct, fallbackStorageCritPct
+	}
+	return wms.Low.Percent, wms.High.Percent
+}
+
+// FormatWatermark renders a watermark the way it is configured: "95%" or
+// "10.0 GB free".
+func FormatWatermark(wm client.DiskWatermark) string {
+	if wm.FreeBytes > 0 {
+		return format.FormatBytes(wm.FreeBytes) + " free"
+	}
+	s := fmt.Sprintf("%.0f%%", wm.Percent)
+	if wm.MaxHeadroom > 0 {
+		s += " (max headroom " + format.FormatBytes(wm.MaxHeadroom) + ")"
+	}
+	return s
+}
+
+// watermarkRecs flags nodes past the flood-stage watermark, whose indices
+// Elasticsearch has already made read-only, and nodes past the high
+// watermark, which are the next to reach it.
+func watermarkRecs(nodeRows []model.NodeRow, wms client.DiskWatermarks) []model.Recommendation {
+	var flood, near []model.NodeRow
+	for _, n := range nodeRows {
+		switch n.DiskWatermark {
+		case model.WatermarkFloodStage:
+			flood = append(flood, n)
+		case model.WatermarkHigh:
+			near = append(near, n)
+		}
+	}
+
+	var recs []model.Recommendation
+	if len(flood) > 0 {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityCritical,
+			Category: model.CategoryResourcePressure,
+			Title:    "Node disk past flood-stage watermark",
+			Detail: fmt.Sprintf("%d node(s) exceed the flood-stage watermark (%s): %s. Every index with a shard on these nodes is blocked with index.blocks.read_only_allow_delete and rejects writes. Free disk space, delete old indices or add nodes; the block is lifted automatically once usage drops below the high watermark.",
+				len(flood), FormatWatermark(wms.FloodStage), watermarkNodeList(flood, wms.FloodStage)),
+		})
+	}
+	if len(near) > 0 {
+		recs = append(recs, model.Recommendation{
+			Severity: model.SeverityWarning,
+			Category: model.CategoryResourcePressure,
+			Title:    "Node disk near flood-stage watermark",
+			Detail: fmt.Sprintf("%d node(s) exceed the high watermark (%s) and are relocating shards away: %s. At the flood-stage watermark (%s) their indices become read-only. Free disk space or add nodes before then.",
+				len(near), FormatWatermark(wms.High), watermarkNodeList(near, wms.FloodStage), FormatWatermark(wms.FloodStage)),
+		})
+	}
+	return recs
+}
+
+// watermarkNodeList names up to recListLimit nodes, fullest first,
+// with their disk usage and the space left before the flood-stage
+// watermark.
+func watermarkNodeList(rows []model.NodeRow, flood client.DiskWatermark) string {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].DiskPercent != rows[j].DiskPercent {
+			return rows[i].DiskPercent > rows[j].DiskPercent
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return nameList(rows, func(n model.NodeRow) string {
+		s := n.Name
+		if n.DiskPercent >= 0 {
+			s += " (" + format.FormatPercent(n.DiskPercent) + " used"
+			if n.DiskTotalBytes > 0 {
+				left := n.DiskAvailBytes - RequiredFreeBytes(flood, n.DiskTotalBytes)
+				if left > 0 {
+					s += ", " + format.FormatBytes(left) + " to flood stage"
+				}
+			}
+			s += ")"
+		}
+		return s
+	})
+}
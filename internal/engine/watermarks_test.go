@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const oneTiB = int64(1 << 40)
+
+func TestRequiredFreeBytes(t *testing.T) {
+	assert.Equal(t, oneTiB/10, RequiredFreeBytes(client.DiskWatermark{Percent: 90}, oneTiB))
+	assert.Equal(t, 50*oneGiBInt64, RequiredFreeBytes(client.DiskWatermark{Percent: 90, MaxHeadroom: 50 * oneGiBInt64}, oneTiB),
+		"max headroom caps the ratio on large disks")
+	assert.Equal(t, 20*oneGiBInt64, RequiredFreeBytes(client.DiskWatermark{FreeBytes: 20 * oneGiBInt64}, oneTiB))
+}
+
+func TestWatermarkLevelFor(t *testing.T) {
+	wms := client.DefaultDiskWatermarks()
+	row := func(avail int64) model.NodeRow {
+		return model.NodeRow{DiskTotalBytes: oneTiB, DiskAvailBytes: avail, DiskPercent: -1}
+	}
+	assert.Equal(t, model.WatermarkNone, WatermarkLevelFor(row(oneTiB/2), wms))
+	assert.Equal(t, model.WatermarkLow, WatermarkLevelFor(row(oneTiB*12/100), wms))
+	assert.Equal(t, model.WatermarkHigh, WatermarkLevelFor(row(oneTiB*7/100), wms))
+	assert.Equal(t, model.WatermarkFloodStage, WatermarkLevelFor(row(oneTiB*3/100), wms))
+
+	// Absolute watermarks apply to free bytes regardless of disk size.
+	abs := client.DiskWatermarks{
+		ThresholdEnabled: true,
+		Low:              client.DiskWatermark{FreeBytes: 100 * oneGiBInt64},
+		High:             client.DiskWatermark{FreeBytes: 50 * oneGiBInt64},
+		FloodStage:       client.DiskWatermark{FreeBytes: 10 * oneGiBInt64},
+	}
+	assert.Equal(t, model.WatermarkHigh, WatermarkLevelFor(row(40*oneGiBInt64), abs))
+
+	// Without filesystem stats the allocation percentage is used.
+	assert.Equal(t, model.WatermarkHigh, WatermarkLevelFor(model.NodeRow{DiskPercent: 91}, wms))
+	assert.Equal(t, model.WatermarkNone, WatermarkLevelFor(model.NodeRow{DiskPercent: 91}, abs))
+
+	wms.ThresholdEnabled = false
+	assert.Equal(t, model.WatermarkNone, WatermarkLevelFor(row(0), wms))
+}
+
+func TestStorageThresholds(t *testing.T) {
+	warn, crit := StorageThresholds(nil)
+	assert.Equal(t, [2]float64{80, 90}, [2]float64{warn, crit})
+
+	snap := &model.Snapshot{DiskWatermarks: &client.DiskWatermarks{
+		Low: client.DiskWatermark{Percent: 70}, High: client.DiskWatermark{Percent: 80},
+	}}
+	warn, crit = StorageThresholds(snap)
+	assert.Equal(t, [2]float64{70, 80}, [2]float64{warn, crit})
+
+	snap.DiskWatermarks.High = client.DiskWatermark{FreeBytes: oneGiBInt64}
+	warn, crit = StorageThresholds(snap)
+	assert.Equal(t, [2]float64{80, 90}, [2]float64{warn, crit}, "byte watermarks fall back to fixed thresholds")
+}
+
+func TestCalcNodeRows_DiskWatermark(t *testing.T) {
+	snap := &model.Snapshot{
+		NodeStats: client.NodeStatsResponse{Nodes: map[string]client.NodePerformanceStats{
+			"n1": {Name: "node-1", FS: &client.NodeFSStats{}},
+		}},
+		DiskWatermarks: &client.DiskWatermarks{
+			ThresholdEnabled: true,
+			Low:              client.DiskWatermark{Percent: 85},
+			High:             client.DiskWatermark{Percent: 90},
+			FloodStage:       client.DiskWatermark{FreeBytes: 20 * oneGiBInt64},
+		},
+	}
+	fs := snap.NodeStats.Nodes["n1"].FS
+	fs.Total.TotalInBytes = oneTiB
+	fs.Total.AvailableInBytes = 15 * oneGiBInt64
+
+	rows := CalcNodeRows(nil, snap, 0)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, oneTiB, rows[0].DiskTotalBytes)
+	assert.Equal(t, model.WatermarkFloodStage, rows[0].DiskWatermark)
+}
+
+func TestWatermarkRecs(t *testing.T) {
+	wms := client.DefaultDiskWatermarks()
+	rows := []model.NodeRow{
+		{Name: "ok", DiskPercent: 50},
+		{Name: "full", DiskPercent: 96, DiskWatermark: model.WatermarkFloodStage},
+		{Name: "near", DiskPercent: 92, DiskTotalBytes: oneTiB, DiskAvailBytes: oneTiB * 8 / 100, DiskWatermark: model.WatermarkHigh},
+	}
+	recs := watermarkRecs(rows, wms)
+	assert.Len(t, recs, 2)
+	assert.Equal(t, model.SeverityCritical, recs[0].Severity)
+	assert.Contains(t, recs[0].Detail, "full (96.0% used)")
+	assert.Equal(t, model.SeverityWarning, recs[1].Severity)
+	assert.Equal(t, "Node disk near flood-stage watermark", recs[1].Title)
+	assert.Contains(t, recs[1].Detail, "near (92.0% used, 30.7 GB to flood stage)")
+
+	assert.Empty(t, watermarkRecs(rows[:1], wms))
+}
@@ -0,0 +1,272 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// zoneImbalanceRatio is how far, as a fraction of the per-zone mean, the
+	// busiest and quietest zones may differ in shard count before a
+	// recommendation is raised. zoneImbalanceMinShards ignores small
+	// absolute differences on lightly loaded clusters.
+	zoneImbalanceRatio     = 0.25
+	zoneImbalanceMinShards = 10
+)
+
+// wellKnownZoneAttrs are the node attribute names tried, in order, when no
+// grouping attribute is configured.
+var wellKnownZoneAttrs = []string{"zone", "availability_zone", "az", "rack", "rack_id"}
+
+// internalAttrPrefixes mark attributes Elasticsearch sets on its own, which
+// are never useful for grouping.
+var internalAttrPrefixes = []string{"ml.", "xpack.", "transform."}
+
+// ZoneAttributeCandidates returns the custom node attributes usable for
+// grouping: well-known zone attributes first, then the rest by name.
+func ZoneAttributeCandidates(nodeRows []model.NodeRow) []string {
+	seen := make(map[string]bool)
+	for _, n := range nodeRows {
+		for attr := range n.Attributes {
+			seen[attr] = true
+		}
+	}
+	var out []string
+	for _, attr := range wellKnownZoneAttrs {
+		if seen[attr] {
+			out = append(out, attr)
+			delete(seen, attr)
+		}
+	}
+	var rest []string
+	for attr := range seen {
+		internal := false
+		for _, p := range internalAttrPrefixes {
+			if strings.HasPrefix(attr, p) {
+				internal = true
+				break
+			}
+		}
+		if !internal {
+			rest = append(rest, attr)
+		}
+	}
+	sort.Strings(rest)
+	return append(out, rest...)
+}
+
+// ZoneAttribute returns the attribute to group nodes by: preferred when a
+// node has it, otherwise the first well-known zone attribute present, or ""
+// when there is none.
+func ZoneAttribute(nodeRows []model.NodeRow, preferred string) string {
+	candidates := ZoneAttributeCandidates(nodeRows)
+	if preferred != "" {
+		for _, c := range candidates {
+			if c == preferred {
+				return preferred
+			}
+		}
+	}
+	for _, c := range candidates {
+		for _, w := range wellKnownZoneAttrs {
+			if c == w {
+				return c
+			}
+		}
+	}
+	return ""
+}
+
+// CalcZoneRows groups nodeRows by the value of attr, sorted by zone name.
+// Nodes without the attribute are grouped under the empty zone.
+func CalcZoneRows(nodeRows []model.NodeRow, attr string) []model.ZoneRow {
+	if attr == "" {
+		return nil
+	}
+	type acc struct {
+		row                     model.ZoneRow
+		cpuSum, heapSum         float64
+		cpuN, heapN             int
+		hasShards               bool
+		hasIdxRate, hasSrchRate bool
+	}
+	byZone := make(map[string]*acc)
+	for _, n := range nodeRows {
+		zone := n.Attributes[attr]
+		a, ok := byZone[zone]
+		if !ok {
+			a = &acc{row: model.ZoneRow{Zone: zone}}
+			byZone[zone] = a
+		}
+		a.row.Nodes++
+		if n.CPUPercent >= 0 {
+			a.cpuSum += n.CPUPercent
+			a.cpuN++
+		}
+		if n.HeapMaxBytes > 0 {
+			a.heapSum += float64(n.HeapUsedBytes) / float64(n.HeapMaxBytes) * 100
+			a.heapN++
+		}
+		if n.Shards >= 0 {
+			a.row.Shards += n.Shards
+			a.hasShards = true
+		}
+		if n.IndexingRate >= 0 {
+			a.row.IndexingRate += n.IndexingRate
+			a.hasIdxRate = true
+		}
+		if n.SearchRate >= 0 {
+			a.row.SearchRate += n.SearchRate
+			a.hasSrchRate = true
+		}
+	}
+
+	rows := make([]model.ZoneRow, 0, len(byZone))
+	for _, a := range byZone {
+		r := a.row
+		r.AvgCPUPercent = -1
+		if a.cpuN > 0 {
+			r.AvgCPUPercent = a.cpuSum / float64(a.cpuN)
+		}
+		r.AvgHeapPercent = -1
+		if a.heapN > 0 {
+			r.AvgHeapPercent = a.heapSum / float64(a.heapN)
+		}
+		if !a.hasShards {
+			r.Shards = -1
+		}
+		if !a.hasIdxRate {
+			r.IndexingRate = model.MetricNotAvailable
+		}
+		if !a.hasSrchRate {
+			r.SearchRate = model.MetricNotAvailable
+		}
+		rows = append(rows, r)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Zone < rows[j].Zone })
+	return rows
+}
+
+// zoneRecs checks shard placement and load balance across the values of the
+// zone attribute. It is a no-op unless the nodes span at least two zones.
+func zoneRecs(snap *model.Snapshot, nodeRows []model.NodeRow, attr string) []model.Recommendation {
+	attr = ZoneAttribute(nodeRows, attr)
+	zones := CalcZoneRows(nodeRows, attr)
+	var named []model.ZoneRow
+	for _, z := range zones {
+		if z.Zone != "" {
+			named = append(named, z)
+		}
+	}
+	if len(named) < 2 {
+		return nil
+	}
+
+	var recs []model.Recommendation
+	if r, ok := sameZoneCopiesRec(snap, nodeRows, attr, len(named)); ok {
+		recs = append(recs, r)
+	}
+	if r, ok := zoneImbalanceRec(named, attr); ok {
+		recs = append(recs, r)
+	}
+	return recs
+}
+
+// sameZoneCopiesRec flags shards whose assigned copies share a zone even
+// though enough zones exist to spread them, so losing that zone loses
+// more copies than necessary — often every copy, when a primary and its
+// only replica sit together.
+func sameZoneCopiesRec(snap *model.Snapshot, nodeRows []model.NodeRow, attr string, zoneCount int) (model.Recommendation, bool) {
+	if snap == nil || len(snap.Shards) == 0 {
+		return model.Recommendation{}, false
+	}
+	nodeZone := make(map[string]string, len(nodeRows))
+	for _, n := range nodeRows {
+		if z := n.Attributes[attr]; z != "" {
+			nodeZone[n.Name] = z
+		}
+	}
+
+	type copies struct {
+		zones map[string]int
+		total int
+	}
+	byShard := make(map[string]*copies)
+	var order []string
+	for _, s := range snap.Shards {
+		if s.State != "STARTED" && s.State != "RELOCATING" {
+			continue
+		}
+		zone, ok := nodeZone[shardSourceNode(s.Node)]
+		if !ok {
+			continue
+		}
+		key := s.Index + "[" + s.Shard + "]"
+		c, ok := byShard[key]
+		if !ok {
+			c = &copies{zones: make(map[string]int)}
+			byShard[key] = c
+			order = append(order, key)
+		}
+		c.zones[zone]++
+		c.total++
+	}
+
+	var bad []string
+	for _, key := range order {
+		c := byShard[key]
+		want := c.total
+		if want > zoneCount {
+			want = zoneCount
+		}
+		if c.total > 1 && len(c.zones) < want {
+			bad = append(bad, key)
+		}
+	}
+	if len(bad) == 0 {
+		return model.Recommendation{}, false
+	}
+	return model.Recommendation{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryShardHealth,
+		Title:    "Shard copies share a zone",
+		Detail: fmt.Sprintf("%d shard(s) keep two or more copies (e.g. a primary and its replica) in the same %s although %d zones exist: %s. Losing that zone loses those copies together. Set cluster.routing.allocation.awareness.attributes: %s so copies are spread across zones.",
+			len(bad), attr, zoneCount, nameList(bad, plainName), attr),
+		Link: model.LinkZones,
+	}, true
+}
+
+// zoneImbalanceRec flags zones whose shard counts differ by more than
+// zoneImbalanceRatio of the per-zone mean.
+func zoneImbalanceRec(zones []model.ZoneRow, attr string) (model.Recommendation, bool) {
+	minZ, maxZ := -1, -1
+	total := 0
+	for i, z := range zones {
+		if z.Shards < 0 {
+			return model.Recommendation{}, false
+		}
+		total += z.Shards
+		if minZ < 0 || z.Shards < zones[minZ].Shards {
+			minZ = i
+		}
+		if maxZ < 0 || z.Shards > zones[maxZ].Shards {
+			maxZ = i
+		}
+	}
+	mean := float64(total) / float64(len(zones))
+	diff := zones[maxZ].Shards - zones[minZ].Shards
+	if diff < zoneImbalanceMinShards || float64(diff) <= mean*zoneImbalanceRatio {
+		return model.Recommendation{}, false
+	}
+	return model.Recommendation{
+		Severity: model.SeverityWarning,
+		Category: model.CategoryHotspot,
+		Title:    "Zones unbalanced",
+		Detail: fmt.Sprintf("Shards are unevenly spread across %s values: %s holds %d shard(s) on %d node(s), %s holds %d on %d. Equalize node counts per zone and check allocation filters and awareness settings.",
+			attr, zones[maxZ].Zone, zones[maxZ].Shards, zones[maxZ].Nodes, zones[minZ].Zone, zones[minZ].Shards, zones[minZ].Nodes),
+		Link: model.LinkZones,
+	}, true
+}
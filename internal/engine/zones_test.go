@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func zoneNode(name, zone string, shards int, cpu float64) model.NodeRow {
+	return model.NodeRow{
+		Name: name, Shards: shards, CPUPercent: cpu,
+		HeapMaxBytes: 100, HeapUsedBytes: 50,
+		IndexingRate: 10, SearchRate: model.MetricNotAvailable,
+		Attributes: map[string]string{"zone": zone, "rack": "r1", "xpack.installed": "true"},
+	}
+}
+
+func TestZoneAttribute(t *testing.T) {
+	rows := []model.NodeRow{zoneNode("a", "z1", 1, 10)}
+	assert.Equal(t, []string{"zone", "rack"}, ZoneAttributeCandidates(rows), "well-known first, internal attributes skipped")
+	assert.Equal(t, "zone", ZoneAttribute(rows, ""))
+	assert.Equal(t, "rack", ZoneAttribute(rows, "rack"))
+	assert.Equal(t, "zone", ZoneAttribute(rows, "missing"), "unknown preference falls back to auto-detect")
+	assert.Equal(t, "", ZoneAttribute([]model.NodeRow{{Name: "a"}}, ""))
+}
+
+func TestCalcZoneRows(t *testing.T) {
+	rows := []model.NodeRow{
+		zoneNode("a", "z1", 10, 20),
+		zoneNode("b", "z1", 12, 40),
+		zoneNode("c", "z2", 5, -1),
+		{Name: "master", Shards: -1, CPUPercent: -1, IndexingRate: model.MetricNotAvailable, SearchRate: model.MetricNotAvailable},
+	}
+	zones := CalcZoneRows(rows, "zone")
+	require.Len(t, zones, 3)
+	assert.Equal(t, model.ZoneRow{Zone: "", Nodes: 1, AvgCPUPercent: -1, AvgHeapPercent: -1, Shards: -1,
+		IndexingRate: model.MetricNotAvailable, SearchRate: model.MetricNotAvailable}, zones[0])
+	assert.Equal(t, model.ZoneRow{Zone: "z1", Nodes: 2, AvgCPUPercent: 30, AvgHeapPercent: 50, Shards: 22,
+		IndexingRate: 20, SearchRate: model.MetricNotAvailable}, zones[1])
+	assert.Equal(t, -1.0, zones[2].AvgCPUPercent)
+	assert.Nil(t, CalcZoneRows(rows, ""))
+}
+
+func TestZoneRecs_SameZoneCopies(t *testing.T) {
+	rows := []model.NodeRow{
+		zoneNode("a1", "z1", 20, 10), zoneNode("a2", "z1", 20, 10),
+		zoneNode("b1", "z2", 20, 10), zoneNode("b2", "z2", 20, 10),
+	}
+	snap := &model.Snapshot{Shards: []client.ShardInfo{
+		{Index: "logs", Shard: "0", PriRep: "p", State: "STARTED", Node: "a1"},
+		{Index: "logs", Shard: "0", PriRep: "r", State: "STARTED", Node: "a2"},
+		{Index: "logs", Shard: "1", PriRep: "p", State: "STARTED", Node: "a1"},
+		{Index: "logs", Shard: "1", PriRep: "r", State: "RELOCATING", Node: "b1 -> 10.0.0.4 xyz a2"},
+		{Index: "logs", Shard: "2", PriRep: "p", State: "STARTED", Node: "b1"},
+		{Index: "logs", Shard: "2", PriRep: "r", State: "UNASSIGNED"},
+	}}
+	recs := zoneRecs(snap, rows, "")
+	require.Len(t, recs, 1)
+	assert.Equal(t, "Shard copies share a zone", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "1 shard(s)")
+	assert.Contains(t, recs[0].Detail, "logs[0]")
+	assert.NotContains(t, recs[0].Detail, "logs[1]", "relocating copies count on their source node")
+	assert.Equal(t, model.LinkZones, recs[0].Link)
+}
+
+func TestZoneRecs_Unbalanced(t *testing.T) {
+	rows := []model.NodeRow{
+		zoneNode("a1", "z1", 60, 10), zoneNode("a2", "z1", 60, 10),
+		zoneNode("b1", "z2", 40, 10),
+		zoneNode("c1", "z3", 45, 10),
+	}
+	recs := zoneRecs(&model.Snapshot{}, rows, "zone")
+	require.Len(t, recs, 1)
+	assert.Equal(t, "Zones unbalanced", recs[0].Title)
+	assert.Contains(t, recs[0].Detail, "z1 holds 120 shard(s) on 2 node(s), z2 holds 40 on 1")
+	assert.NotContains(t, recs[0].Detail, "press")
+	assert.Equal(t, model.LinkZones, recs[0].Link)
+
+	// A single zone has nothing to balance.
+	assert.Empty(t, zoneRecs(&model.Snapshot{}, rows[:2], "zone"))
+	// Small absolute differences are ignored.
+	small := []model.NodeRow{zoneNode("a", "z1", 8, 10), zoneNode("b", "z2", 2, 10)}
+	assert.Empty(t, zoneRecs(&model.Snapshot{}, small, "zone"))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FormatBytes formats a byte count into a human-readable string with 1 decimal place.
@@ -74,6 +75,27 @@ func FormatPercent(p float64) string {
 	return fmt.Sprintf("%.1f%%", p)
 }
 
+// FormatAge formats an elapsed duration compactly for queue ages, task
+// running times and recovery timings.
+// Examples: 86ms, 4.2s, 3m12s, 2h05m, 3d04h.
+// Negative values return "---".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "---"
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
 // formatCommaFloat formats a float with comma-separated thousands and one decimal place.
 func formatCommaFloat(f float64) string {
 	// Format with one decimal place first
@@ -3,6 +3,7 @@ package format
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -129,3 +130,24 @@ func TestFormatPercent(t *testing.T) {
 	}
 }
 
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		name  string
+		input time.Duration
+		want  string
+	}{
+		{"negative", -time.Second, "---"},
+		{"zero", 0, "0ms"},
+		{"millis", 86 * time.Millisecond, "86ms"},
+		{"seconds", 4200 * time.Millisecond, "4.2s"},
+		{"minutes", 3*time.Minute + 12*time.Second, "3m12s"},
+		{"hours", 2*time.Hour + 5*time.Minute, "2h05m"},
+		{"days", 76 * time.Hour, "3d04h"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FormatAge(tc.input))
+		})
+	}
+}
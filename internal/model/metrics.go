@@ -1,5 +1,11 @@
 package model
 
+import (
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
 // MetricNotAvailable signals that a rate/latency metric has not yet been
 // computed (requires two snapshots for delta calculation).
 const MetricNotAvailable float64 = -1.0
@@ -37,6 +43,143 @@ type NodeRow struct {
 	HeapUsedBytes int64
 	Shards        int     // allocated shards; -1 = not in allocation data
 	DiskPercent   float64 // node disk usage %; -1.0 = not available
+
+	DiskTotalBytes int64          // filesystem size; 0 = not available
+	DiskAvailBytes int64          // free bytes available to ES
+	DiskWatermark  WatermarkLevel // highest disk watermark exceeded
+
+	IndexingPressureBytes   int64   // in-flight indexing bytes (coordinating, primary, replica); -1 = not available
+	IndexingPressureLimit   int64   // indexing_pressure.memory.limit; 0 = not reported
+	IndexingPressurePercent float64 // coordinating+primary bytes of the limit; -1.0 = not available
+	IndexingRejections      int64   // indexing pressure rejections since the previous poll; -1 = not available
+
+	LoadAverage    [3]float64 // os.cpu.load_average 1m, 5m, 15m; -1.0 = not available
+	MemTotalBytes  int64      // host physical memory; 0 = not available
+	MemUsedBytes   int64      // includes the page cache
+	SwapTotalBytes int64      // 0 = no swap configured or not available
+	SwapUsedBytes  int64
+	OpenFDs        int64   // open file descriptors; -1 = not available
+	MaxFDs         int64   // file descriptor limit; -1 = not available
+	FDPercent      float64 // OpenFDs of MaxFDs; -1.0 = not available
+
+	Version     string // Elasticsearch version; "" = not available
+	BuildFlavor string // default or oss; "" on 8+ and OpenSearch
+	JVMVersion  string
+
+	CPUPercent float64           // os.cpu.percent; -1.0 = not available
+	Attributes map[string]string // custom node attributes (node.attr.*), e.g. zone
+	Roles      []string          // full role names from _nodes/stats, e.g. data_hot
+	Restarted  bool              // JVM restarted since the previous poll; rates are unavailable
+}
+
+// TierRow aggregates the data nodes of one data tier.
+type TierRow struct {
+	Tier           string // hot, warm, cold, frozen, content, or data for untiered nodes
+	Nodes          int
+	DiskTotalBytes int64   // summed filesystem size; 0 = not available
+	DiskUsedBytes  int64   // summed used bytes
+	UsedPercent    float64 // DiskUsedBytes / DiskTotalBytes; -1.0 = not available
+	AvgHeapPercent float64 // over nodes reporting heap; -1.0 = none did
+	Shards         int     // allocated shards; -1 = no allocation data
+	IndexingRate   float64 // sum over nodes; MetricNotAvailable when none has a rate
+	SearchRate     float64 // sum over nodes; MetricNotAvailable when none has a rate
+}
+
+// PipelineRow holds an ingest pipeline's throughput summed over all nodes.
+// Rates and per-doc times cover the interval since the previous poll.
+type PipelineRow struct {
+	Name           string
+	DocsPerSec     float64 // MetricNotAvailable until two polls are seen
+	TimePerDocMs   float64 // ms per document; MetricNotAvailable when no docs were processed
+	FailedPerSec   float64 // MetricNotAvailable until two polls are seen
+	FailurePercent float64 // failed / processed in the interval; MetricNotAvailable when none were processed
+	Current        int64   // documents in flight
+	TotalCount     int64   // cumulative since node start
+	TotalFailed    int64   // cumulative since node start
+	Processors     []ProcessorRow
+}
+
+// ProcessorRow holds one processor's share of its pipeline's processing time.
+type ProcessorRow struct {
+	Name         string // type and optional tag, e.g. grok:parse-message
+	Type         string
+	Count        int64   // cumulative documents processed
+	Failed       int64   // cumulative failures
+	TimePercent  float64 // share of the pipeline's processor time; -1.0 = no time recorded
+	TimePerDocMs float64 // cumulative ms per document; -1.0 = no documents
+}
+
+// FollowerRow holds the replication state of one CCR follower index, summed
+// over its shards.
+type FollowerRow struct {
+	Index          string
+	LeaderIndex    string
+	RemoteCluster  string
+	Shards         int
+	OpsLag         int64         // leader minus follower global checkpoint, summed over shards
+	TimeLag        time.Duration // OpsLag at the leader's write rate; -1 = unknown
+	FollowRate     float64       // follower checkpoint ops/sec; MetricNotAvailable on the first poll
+	LastRead       time.Duration // longest time since a shard last read from the leader
+	ReadExceptions int
+	FatalError     string // first fatal exception; non-empty means replication stopped
+	StalledPolls   int    // consecutive polls with lag and no follower progress
+}
+
+// NodeRestart records a node whose JVM uptime shows it started since it was
+// last seen.
+type NodeRestart struct {
+	NodeID     string
+	Name       string
+	StartedAt  time.Time // curr.FetchedAt minus the new JVM uptime
+	DetectedAt time.Time // FetchedAt of the poll that saw the restart
+}
+
+// NodeSeen is the last JVM uptime a node reported and when it was fetched.
+type NodeSeen struct {
+	Uptime time.Duration
+	SeenAt time.Time
+}
+
+// VersionGroup lists the nodes running one Elasticsearch version.
+type VersionGroup struct {
+	Version string
+	Nodes   []string // node names, sorted
+}
+
+// ZoneRow aggregates the NodeRows that share a value of the grouping node
+// attribute, e.g. one availability zone.
+type ZoneRow struct {
+	Zone           string // attribute value; "" for nodes without the attribute
+	Nodes          int
+	AvgCPUPercent  float64 // over nodes reporting CPU; -1.0 = none did
+	AvgHeapPercent float64 // over nodes reporting heap; -1.0 = none did
+	Shards         int     // allocated shards; -1 = no allocation data
+	IndexingRate   float64 // sum over nodes; MetricNotAvailable when none has a rate
+	SearchRate     float64 // sum over nodes; MetricNotAvailable when none has a rate
+}
+
+// WatermarkLevel is the highest disk allocation watermark a node exceeds.
+type WatermarkLevel int
+
+const (
+	WatermarkNone       WatermarkLevel = iota // below the low watermark, or unknown
+	WatermarkLow                              // no new shards are allocated to the node
+	WatermarkHigh                             // shards are relocated away from the node
+	WatermarkFloodStage                       // indices with a shard on the node are made read-only
+)
+
+// String returns the watermark name as used in the ES setting keys.
+func (l WatermarkLevel) String() string {
+	switch l {
+	case WatermarkLow:
+		return "low"
+	case WatermarkHigh:
+		return "high"
+	case WatermarkFloodStage:
+		return "flood_stage"
+	default:
+		return "none"
+	}
 }
 
 // IndexRow holds display-ready data for a single row in the index table.
@@ -44,14 +187,155 @@ type IndexRow struct {
 	Name           string
 	PrimaryShards  int
 	TotalShards    int
-	RepKnown       bool    // true when the replica count was successfully parsed (not "-")
-	DocCountKnown  bool    // true when docs.count was successfully parsed (not "-")
+	RepKnown       bool // true when the replica count was successfully parsed (not "-")
+	DocCountKnown  bool // true when docs.count was successfully parsed (not "-")
 	TotalSizeBytes int64
-	PriSizeBytes   int64   // primary data bytes, excluding replicas
+	PriSizeBytes   int64 // primary data bytes, excluding replicas
 	AvgShardSize   int64
 	DocCount       int64
+	DocsDeleted    int64   // deleted docs awaiting merge (primaries)
+	DeletedKnown   bool    // true when docs.deleted was successfully parsed (not "-")
 	IndexingRate   float64 // ops/sec (primaries)
 	SearchRate     float64 // ops/sec (total)
 	IndexLatency   float64 // ms/op (primaries)
 	SearchLatency  float64 // ms/op (total)
+
+	// Lifecycle fields come from ILM/ISM explain and stay zero when the
+	// index is unmanaged or the explain endpoint was unavailable.
+	LifecycleManaged bool
+	LifecyclePolicy  string
+	LifecyclePhase   string // ILM phase, or ISM state name
+	LifecycleAction  string
+	LifecycleStep    string
+	LifecycleAge     time.Duration // since the lifecycle date (creation or rollover); 0 = unknown
+	LifecycleError   string        // non-empty while stuck in an ERROR/failed step
+
+	DataStream string // owning data stream when this is a backing index
+
+	Aliases      []string // aliases pointing at this index, sorted
+	WriteAliases []string // subset of Aliases for which this is the write index
+
+	FieldCount int // mapped fields counted against the limit
+	FieldLimit int // index.mapping.total_fields.limit; 0 = mappings unavailable
+}
+
+// TemplateMatch is a template whose patterns match an index, with whether it
+// is applied when the index is created (see engine.MatchTemplates).
+type TemplateMatch struct {
+	Template client.IndexTemplate
+	Applied  bool
+}
+
+// AliasRow aggregates the IndexRows of the indices behind an alias.
+type AliasRow struct {
+	Name           string
+	Indices        []string // sorted index names
+	WriteIndex     string   // "" when the alias has no write index
+	TotalSizeBytes int64
+	DocCount       int64
+	IndexingRate   float64 // sum over indices; MetricNotAvailable when none has a rate
+	SearchRate     float64 // sum over indices; MetricNotAvailable when none has a rate
+}
+
+// DataStreamRow aggregates the IndexRows of a data stream's backing indices.
+type DataStreamRow struct {
+	Name           string
+	Template       string
+	ILMPolicy      string
+	Status         string // stream health: GREEN, YELLOW or RED
+	Generation     int
+	IndexCount     int        // backing indices reported by _data_stream
+	BackingIndices []IndexRow // newest generation (the write index) first; only indices present in _cat/indices
+	TotalSizeBytes int64
+	PriSizeBytes   int64
+	DocCount       int64
+	IndexingRate   float64 // sum over backing indices; MetricNotAvailable when none has a rate
+	SearchRate     float64 // sum over backing indices; MetricNotAvailable when none has a rate
+}
+
+// TaskRow holds display-ready data for a single top-level running task.
+type TaskRow struct {
+	TaskID      string // "<node>:<id>", as accepted by POST _tasks/<id>/_cancel
+	NodeID      string
+	Node        string // node name; falls back to NodeID when unknown
+	Action      string
+	Description string
+	RunningTime time.Duration
+	Cancellable bool
+	Cancelled   bool
+	Children    int // total number of descendant tasks
+}
+
+// HotThread is one thread entry parsed from a _nodes/hot_threads report.
+type HotThread struct {
+	Node       string  // node name from the "::: {name}" section header
+	Thread     string  // thread name, e.g. "elasticsearch[node-1][search][T#3]"
+	CPUPercent float64 // share of the sampling interval spent on CPU
+	TopFrame   string  // first stack frame of the most common snapshot; "" when absent
+}
+
+// HotThreadGroup aggregates hot threads sharing the same top stack frame.
+type HotThreadGroup struct {
+	TopFrame   string
+	Threads    int
+	CPUPercent float64 // sum across the grouped threads
+}
+
+// ShardRow holds display-ready data for a single shard copy from _cat/shards.
+type ShardRow struct {
+	Index            string
+	Shard            int
+	Primary          bool
+	State            string // STARTED, RELOCATING, INITIALIZING, UNASSIGNED
+	UnassignedReason string
+	Docs             int64  // -1 = not reported (unassigned or initializing)
+	StoreBytes       int64  // -1 = not reported
+	Node             string // "" when unassigned; "src -> ip id dst" while relocating
+}
+
+// RecoveryRow holds display-ready data for a single active shard recovery.
+type RecoveryRow struct {
+	Index          string
+	Shard          int
+	Type           string // peer, store, snapshot, ...
+	Stage          string
+	SourceNode     string // "" for store and snapshot recoveries
+	TargetNode     string
+	FilesPercent   float64 // -1.0 = not reported
+	BytesPercent   float64 // -1.0 = not reported
+	BytesRecovered int64
+	BytesTotal     int64
+	Elapsed        time.Duration
+	Throughput     float64       // bytes/sec since the previous poll; -1.0 = not available
+	ETA            time.Duration // -1 = unknown (no throughput yet or stalled)
+}
+
+// SnapshotRow holds display-ready data for a single snapshot.
+type SnapshotRow struct {
+	ID           string
+	Status       string // IN_PROGRESS, SUCCESS, PARTIAL, FAILED, INCOMPATIBLE
+	Start        time.Time
+	End          time.Time // zero while in progress
+	Duration     time.Duration
+	Indices      int
+	FailedShards int
+	TotalShards  int
+}
+
+// RepositoryRow summarises one snapshot repository and its snapshots.
+type RepositoryRow struct {
+	Name        string
+	Type        string
+	Snapshots   []SnapshotRow // newest first
+	LastSuccess time.Time     // end of the newest SUCCESS snapshot; zero = none
+	Err         string        // non-empty when the snapshot listing failed
+}
+
+// DeciderSummary aggregates one allocation decider's NO/THROTTLE verdicts
+// across the candidate nodes of an allocation explain response.
+type DeciderSummary struct {
+	Decider     string
+	Decision    string   // most severe decision seen: NO beats THROTTLE
+	Nodes       []string // node names the decider blocked, in response order
+	Explanation string   // explanation from the first blocked node
 }
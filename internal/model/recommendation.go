@@ -20,10 +20,26 @@ const (
 	CategoryIndexLifecycle
 )
 
+// RecommendationLink names a drill-down screen that explains the cause of a
+// recommendation. The engine only names the screen; the TUI shows the key that
+// opens it, so headless consumers get no key hints in the text.
+type RecommendationLink int
+
+const (
+	LinkNone RecommendationLink = iota
+	LinkUnassignedShards
+	LinkMapping
+	LinkZones
+	LinkPipelines
+	LinkReplication
+	LinkNodeResources
+)
+
 // Recommendation is a single actionable suggestion derived from cluster state.
 type Recommendation struct {
 	Severity RecommendationSeverity
 	Category RecommendationCategory
 	Title    string
 	Detail   string
+	Link     RecommendationLink
 }
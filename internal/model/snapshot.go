@@ -6,7 +6,7 @@ import (
 	"github.com/jtsunne/epm-go/internal/client"
 )
 
-// Snapshot holds the raw results of a single poll cycle across all 6 ES endpoints.
+// Snapshot holds the raw results of a single poll cycle across all ES endpoints.
 type Snapshot struct {
 	Health     client.ClusterHealth
 	Nodes      []client.NodeInfo
@@ -14,5 +14,148 @@ type Snapshot struct {
 	Indices    []client.IndexInfo
 	IndexStats client.IndexStatsResponse
 	Allocation []client.AllocationInfo
-	FetchedAt  time.Time
+	// PendingTasks is the master's cluster-state update queue. nil means the
+	// endpoint was unavailable this poll; an empty slice means an empty queue.
+	PendingTasks []client.PendingTask
+	// PendingTasksStreak counts consecutive polls (including this one) in
+	// which the pending task queue was non-empty. Carried forward from the
+	// previous snapshot by engine.PendingTasksStreak.
+	PendingTasksStreak int
+	// Recoveries lists active shard recoveries. nil means the endpoint was
+	// unavailable this poll; an empty slice means no recovery is running.
+	Recoveries []client.RecoveryInfo
+	// SnapshotRepos lists snapshot repositories with their snapshots, from
+	// the last background fetch. nil means the repositories endpoint was
+	// unavailable or has not been fetched yet.
+	SnapshotRepos []RepositorySnapshots
+	// Lifecycle maps index names to their ILM/ISM explain state, from the
+	// last background fetch. nil means neither explain endpoint was
+	// available or it has not been fetched yet.
+	Lifecycle map[string]client.LifecycleExplain
+	// DataStreams lists data streams with their backing indices, from the
+	// last background fetch. nil means the endpoint was unavailable (e.g.
+	// pre-7.9 clusters) or has not been fetched yet.
+	DataStreams []client.DataStream
+	// Aliases lists alias-to-index pairs, from the last background fetch.
+	// nil means the endpoint was unavailable or has not been fetched yet.
+	Aliases []client.AliasInfo
+	// Mappings maps index names to their mapped field count and limit, from
+	// the last background fetch. nil means the mapping endpoint was
+	// unavailable or has not been fetched yet.
+	Mappings map[string]client.MappingStats
+	// NodeAttributes lists the custom attributes of every node, from the last
+	// background fetch. nil means the endpoint was unavailable or has not
+	// been fetched yet.
+	NodeAttributes []client.NodeAttribute
+	// Shards lists every shard copy with its state and node, from the last
+	// background fetch, used for zone placement and rolling upgrade checks.
+	// nil means the endpoint was unavailable or has not been fetched yet.
+	Shards []client.ShardInfo
+	// DiskWatermarks holds the effective disk allocation watermarks, from the
+	// last background fetch. nil means the cluster settings endpoint was
+	// unavailable or has not been fetched yet.
+	DiskWatermarks *client.DiskWatermarks
+	// IngestStats holds per-node ingest pipeline counters. nil means the
+	// endpoint was unavailable this poll.
+	IngestStats *client.IngestStatsResponse
+	// Remotes maps remote cluster aliases to their connection state, from the
+	// last background fetch. nil means the endpoint was unavailable or has
+	// not been fetched yet.
+	Remotes map[string]client.RemoteInfo
+	// CCRStats holds the replication state of follower indices. nil means
+	// cross-cluster replication is unavailable on this cluster.
+	CCRStats *client.CCRStatsResponse
+	// FollowerStalls counts, per follower index, the consecutive polls
+	// (including this one) it lagged without advancing, set by
+	// engine.FollowerStalls. Carried forward into the next poll's
+	// engine.CalcFollowerRows.
+	FollowerStalls map[string]int
+	// IndexingPressure holds per-node indexing pressure memory stats. nil
+	// means the endpoint was unavailable this poll (ES before 7.9).
+	IndexingPressure *client.IndexingPressureResponse
+	// NodeVersions maps node IDs to their Elasticsearch and JVM versions
+	// from the last background fetch. nil means the endpoint was
+	// unavailable or has not been fetched yet.
+	NodeVersions map[string]client.NodeVersion
+	// Restarts lists node restarts detected within the retention window,
+	// newest first, set by engine.NodeRestarts from the previous snapshot.
+	Restarts []NodeRestart
+	// NodesSeen maps node IDs to the last JVM uptime each node reported,
+	// including nodes missing from this poll's node stats, set by
+	// engine.TrackNodesSeen. It lets a node that drops out for some polls
+	// be checked for a restart when it returns.
+	NodesSeen map[string]NodeSeen
+	// Sections records the freshness of each core section. A section whose
+	// request failed this poll holds the last good data (or none) and is
+	// stale. nil means every section is fresh.
+	Sections  map[Section]SectionState
+	FetchedAt time.Time
+}
+
+// Section names one of the core endpoints fetched every poll. Each is
+// fetched independently, so one can fail while the others stay fresh.
+type Section int
+
+const (
+	SectionHealth Section = iota
+	SectionNodes
+	SectionNodeStats
+	SectionIndices
+	SectionIndexStats
+)
+
+// CoreSections lists the core sections in display order.
+var CoreSections = []Section{SectionHealth, SectionNodes, SectionNodeStats, SectionIndices, SectionIndexStats}
+
+// String returns the section name shown in the header.
+func (s Section) String() string {
+	switch s {
+	case SectionHealth:
+		return "health"
+	case SectionNodes:
+		return "nodes"
+	case SectionNodeStats:
+		return "node stats"
+	case SectionIndices:
+		return "indices"
+	case SectionIndexStats:
+		return "index stats"
+	default:
+		return "unknown"
+	}
+}
+
+// SectionState is the freshness of one core section of a snapshot.
+type SectionState struct {
+	// Err is the error of this poll's request; nil when the data is fresh.
+	Err error
+	// FetchedAt is when the data held for the section was fetched. Zero
+	// when it has never been fetched successfully.
+	FetchedAt time.Time
+}
+
+// Stale reports whether sec failed this poll, so the snapshot holds the
+// last good data for it, or none.
+func (s *Snapshot) Stale(sec Section) bool {
+	return s != nil && s.Sections[sec].Err != nil
+}
+
+// Degraded returns the stale sections in display order, or nil when every
+// section is fresh.
+func (s *Snapshot) Degraded() []Section {
+	var out []Section
+	for _, sec := range CoreSections {
+		if s.Stale(sec) {
+			out = append(out, sec)
+		}
+	}
+	return out
+}
+
+// RepositorySnapshots pairs a snapshot repository with its snapshots as
+// listed by _cat/snapshots.
+type RepositorySnapshots struct {
+	Repository client.SnapshotRepository
+	Snapshots  []client.SnapshotInfo // oldest first
+	Err        string                // non-empty when listing this repository failed
 }
@@ -0,0 +1,235 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// AliasTableModel is a sortable, paginated, searchable table of aliases with
+// sizes and rates aggregated over the indices behind them, refreshed on
+// every poll.
+type AliasTableModel struct {
+	tableModel
+	allRows     []model.AliasRow // unfiltered source data
+	displayRows []model.AliasRow // after filter + sort applied
+}
+
+// NewAliasTable returns an AliasTableModel with a 7-column layout and
+// default sort by indexing rate (col 5) descending.
+func NewAliasTable() AliasTableModel {
+	cols := []columnDef{
+		{Title: "Alias", Width: 24, SortDesc: false},
+		{Title: "Indices", Width: 7, SortDesc: true},
+		{Title: "Write Index", Width: 28, SortDesc: false},
+		{Title: "Total Size", Width: 10, SortDesc: true},
+		{Title: "Doc Count", Width: 12, SortDesc: true},
+		{Title: "Idx/s", Width: 8, SortDesc: true},
+		{Title: "Srch/s", Width: 8, SortDesc: true},
+	}
+	m := AliasTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 5
+	m.sortDesc = true
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *AliasTableModel) SetData(rows []model.AliasRow) {
+	m.allRows = rows
+	m.displayRows = sortAliasRows(filterAliasRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m AliasTableModel) Update(msg tea.Msg) (AliasTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortAliasRows(filterAliasRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortAliasRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Name, 1=len(Indices), 2=WriteIndex, 3=TotalSizeBytes, 4=DocCount,
+//	5=IndexingRate, 6=SearchRate
+//
+// col -1 means no sort (preserve order). Ties are broken by name.
+func sortAliasRows(rows []model.AliasRow, col int, desc bool) []model.AliasRow {
+	out := make([]model.AliasRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case 1:
+			cmp = compareInt64(int64(len(a.Indices)), int64(len(b.Indices)))
+		case 2:
+			cmp = strings.Compare(strings.ToLower(a.WriteIndex), strings.ToLower(b.WriteIndex))
+		case 3:
+			cmp = compareInt64(a.TotalSizeBytes, b.TotalSizeBytes)
+		case 4:
+			cmp = compareInt64(a.DocCount, b.DocCount)
+		case 5:
+			if aSentinel, bSentinel := a.IndexingRate < 0, b.IndexingRate < 0; aSentinel != bSentinel {
+				return bSentinel // sentinel always last regardless of direction
+			}
+			cmp = compareFloat64(a.IndexingRate, b.IndexingRate)
+		case 6:
+			if aSentinel, bSentinel := a.SearchRate < 0, b.SearchRate < 0; aSentinel != bSentinel {
+				return bSentinel
+			}
+			cmp = compareFloat64(a.SearchRate, b.SearchRate)
+		}
+		if cmp == 0 {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterAliasRows returns rows whose alias or any index name contains search
+// (case-insensitive). Returns all rows when search is empty.
+func filterAliasRows(rows []model.AliasRow, search string) []model.AliasRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Name), lower) || aliasMatches(r.Indices, lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// aliasCellValue formats an AliasRow field for a given column index.
+func aliasCellValue(r model.AliasRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Name)
+	case 1:
+		return strconv.Itoa(len(r.Indices))
+	case 2:
+		if r.WriteIndex == "" {
+			return "---"
+		}
+		return sanitize(r.WriteIndex)
+	case 3:
+		return format.FormatBytes(r.TotalSizeBytes)
+	case 4:
+		return format.FormatNumber(r.DocCount)
+	case 5:
+		return format.FormatRate(r.IndexingRate)
+	case 6:
+		return format.FormatRate(r.SearchRate)
+	default:
+		return ""
+	}
+}
+
+// renderAliasesTitle renders the title bar for the alias screen.
+func renderAliasesTitle(width int) string {
+	return renderTitleBar("Aliases", "[A/esc: back  r: refresh]", width)
+}
+
+// fitAliasTable sizes the alias table page to the screen height.
+func (app *App) fitAliasTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderAliasesTitle(width))
+	app.aliasTable.fitHeight(availH, len(app.aliasTable.displayRows))
+}
+
+// renderAliases renders the alias screen: title bar, the current page of
+// aliases, and the indices behind the alias under the cursor.
+func renderAliases(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderAliasesTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.aliasTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case app.current.Aliases == nil:
+		body = "\n  " + StyleDim.Render("Aliases unavailable (_cat/aliases failed)")
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleDim.Render("No aliases")
+	default:
+		title := fmt.Sprintf("%d alias(es)", len(m.allRows))
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching aliases)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = aliasCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				switch col {
+				case 2:
+					return colorBlue
+				case 5:
+					return colorGreen
+				case 6:
+					return colorCyan
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			r := m.displayRows[idx]
+			names := make([]string, len(r.Indices))
+			for i, n := range r.Indices {
+				names[i] = sanitize(n)
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(sanitize(r.Name)+" → "+strings.Join(names, ", "), width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
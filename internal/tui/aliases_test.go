@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func aliasIndexRows() []model.IndexRow {
+	return []model.IndexRow{
+		{Name: "logs-000001", Aliases: []string{"logs"}},
+		{Name: "logs-000002", Aliases: []string{"all", "logs"}, WriteAliases: []string{"logs"}},
+		{Name: "orders"},
+	}
+}
+
+func TestIndexCellValue_AliasesWithWriteMarker(t *testing.T) {
+	rows := aliasIndexRows()
+	assert.Equal(t, "all, logs*", indexCellValue(rows[1], 11))
+	assert.Equal(t, "logs", indexCellValue(rows[0], 11))
+	assert.Equal(t, "---", indexCellValue(rows[2], 11))
+}
+
+func TestFilterIndexRows_MatchesAlias(t *testing.T) {
+	out := filterIndexRows(aliasIndexRows(), "ALL")
+	require.Len(t, out, 1)
+	assert.Equal(t, "logs-000002", out[0].Name)
+	assert.Len(t, filterIndexRows(aliasIndexRows(), "logs"), 2)
+}
+
+func TestSortAliasRows_RateSentinelLast(t *testing.T) {
+	rows := []model.AliasRow{
+		{Name: "b", IndexingRate: model.MetricNotAvailable},
+		{Name: "a", IndexingRate: 3},
+		{Name: "c", IndexingRate: 7},
+	}
+	out := sortAliasRows(rows, 5, true)
+	assert.Equal(t, []string{"c", "a", "b"}, []string{out[0].Name, out[1].Name, out[2].Name})
+	out = sortAliasRows(rows, 5, false)
+	assert.Equal(t, "b", out[2].Name)
+}
+
+func TestFilterAliasRows_MatchesIndex(t *testing.T) {
+	rows := []model.AliasRow{
+		{Name: "logs", Indices: []string{"logs-000001"}},
+		{Name: "orders", Indices: []string{"orders-v2"}},
+	}
+	out := filterAliasRows(rows, "v2")
+	require.Len(t, out, 1)
+	assert.Equal(t, "orders", out[0].Name)
+}
+
+func TestRenderDeleteConfirm_WarnsOnWriteIndex(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 140
+	app.height = 40
+	app.indexRows = aliasIndexRows()
+	app.pendingDeleteNames = []string{"logs-000001", "logs-000002"}
+
+	out := stripANSI(renderDeleteConfirm(app))
+	assert.Contains(t, out, "logs-000002  (write index of logs)")
+	assert.NotContains(t, out, "logs-000001  (write index")
+	assert.Contains(t, out, "1 index(es) are the current write index of an alias")
+
+	app.pendingDeleteNames = []string{"orders"}
+	assert.NotContains(t, stripANSI(renderDeleteConfirm(app)), "write index")
+}
+
+func TestApp_AliasesScreen_SearchKeepsScreenOpen(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 140
+	app.height = 40
+	snap := makeFixtureSnapshot()
+	snap.Aliases = []client.AliasInfo{}
+	app.current = snap
+	app.aliasTable.SetData([]model.AliasRow{
+		{Name: "logs", Indices: []string{"logs-000001", "logs-000002"}, WriteIndex: "logs-000002"},
+		{Name: "orders-all", Indices: []string{"orders"}},
+	})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	require.True(t, app.aliasesMode)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "2 alias(es)")
+	assert.True(t, strings.Contains(out, "logs → logs-000001, logs-000002"))
+
+	// The toggle key is search text while the search input is open.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Al")})
+	require.True(t, app.aliasesMode)
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "Al", app.aliasTable.search)
+
+	// The first esc clears the filter, the second closes the screen.
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, app.aliasTable.search)
+	assert.True(t, app.aliasesMode)
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.aliasesMode)
+}
@@ -5,8 +5,6 @@ import (
 	"strings"
 	"unicode/utf8"
 
-	"github.com/charmbracelet/lipgloss"
-
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
@@ -28,6 +26,27 @@ func categoryLabel(cat model.RecommendationCategory) string {
 	}
 }
 
+// recommendationLinkHint returns the key hint shown under a recommendation
+// that links to a drill-down screen, or "" when it has none.
+func recommendationLinkHint(link model.RecommendationLink) string {
+	switch link {
+	case model.LinkUnassignedShards:
+		return "↳ press x to explain unassigned shards"
+	case model.LinkMapping:
+		return "↳ press m on an index for its mapping tree"
+	case model.LinkZones:
+		return "↳ press z on the main screen for the zone summary"
+	case model.LinkPipelines:
+		return "↳ press i on the main screen for ingest pipelines"
+	case model.LinkReplication:
+		return "↳ press X on the main screen for replication status"
+	case model.LinkNodeResources:
+		return "↳ press n on a node for its resources"
+	default:
+		return ""
+	}
+}
+
 // severityBadge returns a colored, fixed-width badge for the given severity.
 func severityBadge(sev model.RecommendationSeverity) string {
 	switch sev {
@@ -114,6 +133,9 @@ func buildAnalyticsLines(recs []model.Recommendation, width int) []string {
 						lines = append(lines, "    "+dline)
 					}
 				}
+				if hint := recommendationLinkHint(r.Link); hint != "" {
+					lines = append(lines, "    "+StyleCyan.Render(hint))
+				}
 			}
 		}
 	}
@@ -125,17 +147,7 @@ func buildAnalyticsLines(recs []model.Recommendation, width int) []string {
 // analyticsMaxOffset measure the same rendered height instead of assuming a
 // constant of 1 line (which breaks on narrow terminals where the title wraps).
 func renderAnalyticsTitle(width int) string {
-	const titleText = "Analytics — Cluster Recommendations"
-	hintText := StyleDim.Render("[a/esc: back]")
-	hintVW := lipgloss.Width(hintText)
-	titleVW := lipgloss.Width(titleText)
-	innerWidth := width - 2 // StyleHeader has Padding(0,1) -> 1 char per side
-	gap := innerWidth - titleVW - hintVW
-	if gap < 1 {
-		gap = 1
-	}
-	titleRow := titleText + strings.Repeat(" ", gap) + hintText
-	return StyleHeader.Width(width).MaxWidth(width).Render(titleRow)
+	return renderTitleBar("Analytics — Cluster Recommendations", "[a/esc: back  x: explain unassigned]", width)
 }
 
 // analyticsMaxOffset returns the maximum valid analyticsScrollOffset for the
@@ -144,32 +156,10 @@ func renderAnalyticsTitle(width int) string {
 // exceeds the real content bound and subsequent CursorUp presses appear
 // non-responsive because the display stays clamped until the debt is paid down.
 func analyticsMaxOffset(app *App) int {
-	width := app.width
-	if width <= 0 {
-		width = 80
-	}
-	height := app.height
-	if height <= 0 {
-		height = 24
-	}
-	headerH := renderedHeight(renderHeader(app))
-	titleH := renderedHeight(renderAnalyticsTitle(width))
-	footerH := renderedHeight(renderFooter(app))
-	availH := height - headerH - titleH - footerH
-	if availH < 1 {
-		availH = 1
-	}
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderAnalyticsTitle(width))
 	lines := buildAnalyticsLines(app.recommendations, width)
-	overflows := len(lines) > availH
-	contentH := availH
-	if overflows && contentH > 1 {
-		contentH--
-	}
-	max := len(lines) - contentH
-	if max < 0 {
-		max = 0
-	}
-	return max
+	return scrollMaxOffset(len(lines), availH)
 }
 
 // renderAnalytics renders the analytics title bar followed by the scrollable
@@ -177,76 +167,15 @@ func analyticsMaxOffset(app *App) int {
 // footer below; renderAnalytics accounts for those heights when computing the
 // available content height so the full layout exactly fills the terminal.
 func renderAnalytics(app *App) string {
-	width := app.width
-	if width <= 0 {
-		width = 80
-	}
-	height := app.height
-	if height <= 0 {
-		height = 24
-	}
+	width, _ := screenSize(app)
 
 	// Title bar: left title + right hint, styled like the cluster header.
 	titleBar := renderAnalyticsTitle(width)
-	titleH := lipgloss.Height(titleBar)
 
 	// Available lines for scrollable content: total height minus the sections
 	// rendered outside this function (cluster header, analytics title, footer).
-	headerH := renderedHeight(renderHeader(app))
-	footerH := renderedHeight(renderFooter(app))
-	availH := height - headerH - titleH - footerH
-	if availH < 1 {
-		availH = 1
-	}
+	availH := screenAvailHeight(app, titleBar)
 
-	// Build the full list of content lines.
 	lines := buildAnalyticsLines(app.recommendations, width)
-
-	// When content overflows, reserve the last line for a scroll hint.
-	overflows := len(lines) > availH
-	contentH := availH
-	if overflows && contentH > 1 {
-		contentH--
-	}
-
-	// Clamp scroll offset to valid range (read-only; model state is not mutated in View).
-	maxOffset := len(lines) - contentH
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	offset := app.analyticsScrollOffset
-	if offset > maxOffset {
-		offset = maxOffset
-	}
-
-	// Slice visible content lines.
-	end := offset + contentH
-	if end > len(lines) {
-		end = len(lines)
-	}
-	var visibleLines []string
-	if offset < len(lines) {
-		visibleLines = append(visibleLines, lines[offset:end]...)
-	}
-
-	// Pad content area to contentH with empty lines.
-	for len(visibleLines) < contentH {
-		visibleLines = append(visibleLines, "")
-	}
-
-	// Append scroll hint as its own line (does not overwrite content).
-	if overflows {
-		var hint string
-		if offset == 0 {
-			hint = StyleDim.Render("  ↓ scroll for more")
-		} else if offset >= maxOffset {
-			hint = StyleDim.Render("  ↑ scroll up")
-		} else {
-			hint = StyleDim.Render("  ↑↓ scroll")
-		}
-		visibleLines = append(visibleLines, hint)
-	}
-
-	content := strings.Join(visibleLines, "\n")
-	return titleBar + "\n" + content
+	return titleBar + "\n" + renderScrollLines(lines, app.analyticsScrollOffset, availH)
 }
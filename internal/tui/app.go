@@ -26,17 +26,22 @@ const (
 type App struct {
 	client       client.ESClient
 	pollInterval time.Duration
+	recConfig    engine.RecommendationConfig
 
-	// Poll state
-	fetching  bool // true while a fetchCmd goroutine is in-flight
-	tickGen   int  // incremented each time a new tick is scheduled; stale ticks are dropped
-	current   *model.Snapshot
-	previous  *model.Snapshot
-	metrics   model.PerformanceMetrics
-	resources model.ClusterResources
-	nodeRows  []model.NodeRow
-	indexRows []model.IndexRow
-	history   *model.SparklineHistory
+	// Poll state: the poller owns the schedule and publishes every result to
+	// pollResults; stopPoller cancels it on quit.
+	poller      *engine.Poller
+	pollResults <-chan engine.PollResult
+	stopPoller  context.CancelFunc
+	fetching    bool   // true while a requested poll has not reported back
+	staleSeq    uint64 // results with a Seq up to this predate the last delete or settings update
+	current     *model.Snapshot
+	previous    *model.Snapshot
+	metrics     model.PerformanceMetrics
+	resources   model.ClusterResources
+	nodeRows    []model.NodeRow
+	indexRows   []model.IndexRow
+	history     *model.SparklineHistory
 
 	// Connection state
 	connState        connState
@@ -57,6 +62,135 @@ type App struct {
 	analyticsScrollOffset int
 	recommendations       []model.Recommendation
 
+	// Pending tasks panel
+	pendingTasksMode         bool
+	pendingTasksScrollOffset int
+
+	// Snapshot backups panel
+	backupsMode         bool
+	backupsScrollOffset int
+
+	// Running tasks screen
+	tasksMode          bool
+	tasksTable         TasksTableModel
+	tasksLoading       bool
+	tasksErr           string
+	tasksNonce         int  // incremented each time the screen opens; stale responses are dropped
+	tasksCancelConfirm bool // true while the cancel confirmation dialog is shown
+	pendingCancelTask  model.TaskRow
+	tasksStatus        string
+	tasksStatusErr     bool // true when tasksStatus represents an error
+
+	// Hot threads pane
+	hotThreadsMode         bool
+	hotThreadsNode         model.NodeRow
+	hotThreadsText         string
+	hotThreadsLoading      bool
+	hotThreadsErr          string
+	hotThreadsNonce        int // incremented on open and refresh; stale responses are dropped
+	hotThreadsGrouped      bool
+	hotThreadsScrollOffset int
+
+	// Node resources state: the node selected when the screen was opened,
+	// shown from the latest node rows.
+	resourcesMode     bool
+	resourcesNodeID   string
+	resourcesNodeName string
+
+	// Unassigned shard explain view
+	explainMode          bool
+	explainFromAnalytics bool // closing the list returns to the analytics screen
+	explainTable         UnassignedTableModel
+	explainLoading       bool
+	explainErr           string
+	explainNonce         int // incremented on open and reload; stale list responses are dropped
+	explainDetailNonce   int // incremented per explain request; stale detail responses are dropped
+	explainDetailMode    bool
+	explainTarget        model.ShardRow
+	explainDetail        *client.AllocationExplain
+	explainDetailLoading bool
+	explainDetailErr     string
+	explainScrollOffset  int
+
+	// Shard view, scoped to an index or node from the dashboard
+	shardsMode    bool
+	shardsIndex   string // exact index name filter; "" = any
+	shardsNode    string // node name filter; "" = any
+	shardsTable   ShardsTableModel
+	shardsLoading bool
+	shardsErr     string
+	shardsNonce   int // incremented on open and reload; stale responses are dropped
+
+	// Recovery screen, refreshed from every poll
+	recoveryMode  bool
+	recoveryRows  []model.RecoveryRow
+	recoveryTable RecoveryTableModel
+
+	// Data streams screen, refreshed from every poll; enter expands one
+	// stream into its backing indices
+	dataStreamsMode        bool
+	dataStreamTable        DataStreamTableModel
+	dataStreamDetailMode   bool
+	dataStreamTarget       string // name of the expanded stream
+	dataStreamScrollOffset int
+
+	// Alias screen, refreshed from every poll
+	aliasesMode bool
+	aliasTable  AliasTableModel
+
+	// Zone summary, regrouped from every poll by the selected node attribute
+	zonesMode bool
+	zoneTable ZoneTableModel
+	zoneAttr  string // resolved grouping attribute; "" = none available
+
+	// Data tier summary, regrouped from every poll
+	tiersMode bool
+	tierTable TierTableModel
+
+	// Ingest pipeline screen, refreshed from every poll
+	pipelinesMode bool
+	pipelineTable PipelineTableModel
+
+	// Cross-cluster replication screen, refreshed from every poll
+	ccrMode       bool
+	followerTable FollowerTableModel
+
+	// Template screen, loaded on demand; scoped to the focused index when
+	// opened from the index table
+	templatesMode       bool
+	templatesTable      TemplatesTableModel
+	templatesLoading    bool
+	templatesErr        string
+	templatesNonce      int    // incremented on open; stale responses are dropped
+	templatesIndex      string // index resolved against the templates; "" = none
+	templatesSim        *client.SimulatedIndex
+	templatesSimLoading bool
+	templatesSimErr     string
+
+	// Mapping tree of one index, loaded on demand
+	mappingMode         bool
+	mappingIndex        string
+	mappingRoots        []*mappingNode
+	mappingExpanded     map[string]bool // node paths currently expanded
+	mappingCursor       int             // index into the visible tree rows
+	mappingScrollOffset int
+	mappingLoading      bool
+	mappingErr          string
+	mappingNonce        int // incremented on open and reload; stale responses are dropped
+
+	// Cluster settings screen, loaded on demand, with a curated editor for
+	// persistent settings and a confirmation step before applying
+	clusterSettingsMode    bool
+	clusterSettingsTable   ClusterSettingsTableModel
+	clusterSettingsLoading bool
+	clusterSettingsErr     string
+	clusterSettingsNonce   int // incremented on open and reload; stale responses are dropped
+	clusterSettings        *client.ClusterSettings
+	clusterSettingsEditing bool
+	clusterSettingsForm    SettingsFormModel
+	clusterSettingsConfirm bool
+	clusterSettingsChanges []clusterSettingChange
+
 	// Tables
 	indexTable  IndexTableModel
 	nodeTable   NodeTableModel
@@ -67,15 +201,15 @@ type App struct {
 	pendingDeleteNames []string
 	deleteStatus       string
 	deleteStatusErr    bool // true when deleteStatus represents an error
-	pendingRefresh     bool // true when delete succeeded but a stale fetch was in-flight
+	pendingRefresh     bool // true when delete succeeded and no poll started after it has reported back
 
 	// Settings state
-	settingsMode             bool
-	settingsForm             SettingsFormModel
-	settingsStatus           string
-	settingsStatusErr        bool // true when settingsStatus represents an error
-	settingsPendingRefresh   bool // true when settings update succeeded but a stale fetch was in-flight
-	settingsNonce            int  // incremented each time a settings session opens; stale responses are dropped
+	settingsMode           bool
+	settingsForm           SettingsFormModel
+	settingsStatus         string
+	settingsStatusErr      bool // true when settingsStatus represents an error
+	settingsPendingRefresh bool // true when settings update succeeded and no poll started after it has reported back
+	settingsNonce          int  // incremented each time a settings session opens; stale responses are dropped
 }
 
 // NewApp creates a new App with the given ES client and poll interval.
@@ -83,21 +217,37 @@ func NewApp(c client.ESClient, interval time.Duration) *App {
 	it := NewIndexTable()
 	it.focused = true // index table is focused by default
 	nt := NewNodeTable()
+	cfg := engine.DefaultRecommendationConfig()
+	poller := engine.NewPoller(c, interval, cfg)
 	return &App{
-		client:       c,
-		pollInterval: interval,
-		history:      model.NewSparklineHistory(60),
-		connState:    stateDisconnected,
-		fetching:     true, // Init() always issues an immediate fetchCmd
-		indexTable:   it,
-		nodeTable:    nt,
-		activeTable:  0,
+		client:          c,
+		pollInterval:    interval,
+		recConfig:       cfg,
+		poller:          poller,
+		pollResults:     poller.Subscribe(),
+		history:         model.NewSparklineHistory(60),
+		connState:       stateDisconnected,
+		fetching:        true, // the poller polls immediately when Init starts it
+		indexTable:      it,
+		nodeTable:       nt,
+		tasksTable:      NewTasksTable(),
+		recoveryTable:   NewRecoveryTable(),
+		dataStreamTable: NewDataStreamTable(),
+		aliasTable:      NewAliasTable(),
+		zoneTable:       NewZoneTable(),
+		tierTable:       NewTierTable(),
+		pipelineTable:   NewPipelineTable(),
+		followerTable:   NewFollowerTable(),
+		activeTable:     0,
 	}
 }
 
-// Init implements tea.Model. Starts the first fetch immediately on launch.
+// Init implements tea.Model. Starts the poller, which polls immediately on
+// launch, and waits for its first result.
 func (app *App) Init() tea.Cmd {
-	return fetchCmd(app.client, nil, app.pollInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stopPoller = cancel
+	return tea.Batch(runPollerCmd(ctx, app.poller), waitForPollCmd(app.pollResults))
 }
 
 // Update implements tea.Model — the single state-mutation entry point.
@@ -108,11 +258,7 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		app.width = msg.Width
 		app.height = msg.Height
 		app.computeTablePageSizes()
-		if app.analyticsScrollOffset > 0 {
-			if max := analyticsMaxOffset(app); app.analyticsScrollOffset > max {
-				app.analyticsScrollOffset = max
-			}
-		}
+		app.clampScrollOffsets()
 
 	case DeleteResultMsg:
 		if msg.Err != nil {
@@ -121,15 +267,17 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			app.deleteStatus = fmt.Sprintf("Deleted %d index(es)", len(msg.Names))
 			app.deleteStatusErr = false
+			// Any poll started so far, including a scheduled one still in
+			// flight, predates the deletion; its result is dropped.
+			app.staleSeq = app.poller.Started()
+			app.pendingRefresh = true
 			// Trigger an immediate refresh so the index list reflects the deletion.
 			if !app.fetching {
 				app.fetching = true
-				app.tickGen++ // invalidate any pending tick to avoid double-fetch
-				return app, fetchCmd(app.client, app.current, app.pollInterval)
+				return app, refreshCmd(app.poller)
 			}
-			// A stale fetch is already in-flight. Mark that we need another
-			// refresh after it completes so the post-delete state is shown.
-			app.pendingRefresh = true
+			// A requested refresh is already in flight; another one is issued
+			// when its stale result lands.
 		}
 
 	case SettingsLoadedMsg:
@@ -157,33 +305,185 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			app.settingsStatus = fmt.Sprintf("Settings updated for %d index(es)", len(msg.Names))
 			app.settingsStatusErr = false
+			// Any poll started so far predates the update; its result is
+			// dropped so the status is not cleared before the user sees it.
+			app.staleSeq = app.poller.Started()
+			app.settingsPendingRefresh = true
 			// Trigger an immediate refresh so the index table reflects the new settings.
 			if !app.fetching {
 				app.fetching = true
-				app.tickGen++
-				return app, fetchCmd(app.client, app.current, app.pollInterval)
+				return app, refreshCmd(app.poller)
 			}
-			// A stale fetch is already in-flight; refresh after it lands so the
-			// status message is not cleared before the user sees it.
-			app.settingsPendingRefresh = true
 		}
 
-	case SnapshotMsg:
-		app.fetching = false
-		// Clear delete status only when this is the post-delete (or normal) refresh.
-		// If pendingRefresh is set, this SnapshotMsg arrived from the stale in-flight
-		// fetch that started before the deletion; keep the status until the next fetch.
-		if !app.pendingRefresh {
-			app.deleteStatus = ""
-			app.deleteStatusErr = false
+	case TasksLoadedMsg:
+		if !app.tasksMode || msg.Nonce != app.tasksNonce {
+			break // screen closed or stale response — discard
+		}
+		app.tasksLoading = false
+		if msg.Err != nil {
+			app.tasksErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.tasksErr = ""
+		app.tasksTable.SetData(engine.CalcTaskRows(msg.Tasks, app.current))
+		app.fitTasksTable()
+
+	case TaskCancelResultMsg:
+		if msg.Nonce != app.tasksNonce {
+			break // stale response from a prior session — discard
+		}
+		if msg.Err != nil {
+			app.tasksStatus = fmt.Sprintf("Cancel failed: %s", sanitize(msg.Err.Error()))
+			app.tasksStatusErr = true
+		} else {
+			app.tasksStatus = fmt.Sprintf("Cancelled task %s", sanitize(msg.TaskID))
+			app.tasksStatusErr = false
+		}
+		// Reload so the list reflects the cancellation.
+		if app.tasksMode {
+			app.tasksLoading = true
+			return app, tasksLoadCmd(app.client, app.tasksNonce)
+		}
+
+	case HotThreadsLoadedMsg:
+		if !app.hotThreadsMode || msg.Nonce != app.hotThreadsNonce {
+			break // pane closed or stale response — discard
+		}
+		app.hotThreadsLoading = false
+		if msg.Err != nil {
+			app.hotThreadsErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.hotThreadsErr = ""
+		app.hotThreadsText = msg.Text
+		app.clampScrollOffsets()
+
+	case ShardsLoadedMsg:
+		if !app.shardsMode || msg.Nonce != app.shardsNonce {
+			break // view closed or stale response — discard
+		}
+		app.shardsLoading = false
+		if msg.Err != nil {
+			app.shardsErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.shardsErr = ""
+		app.applyShards(msg.Shards)
+
+	case TemplatesLoadedMsg:
+		if !app.templatesMode || msg.Nonce != app.templatesNonce {
+			break // view closed or stale response — discard
+		}
+		app.templatesLoading = false
+		if msg.Err != nil {
+			app.templatesErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.templatesErr = ""
+		app.applyTemplates(msg.Templates)
+
+	case SimulateIndexLoadedMsg:
+		if !app.templatesMode || msg.Nonce != app.templatesNonce || msg.Index != app.templatesIndex {
+			break // view closed or stale response — discard
+		}
+		app.templatesSimLoading = false
+		if msg.Err != nil {
+			app.templatesSimErr = sanitize(msg.Err.Error())
+			app.templatesSim = nil
+		} else {
+			app.templatesSimErr = ""
+			app.templatesSim = msg.Result
+		}
+		app.fitTemplatesTable()
+
+	case ClusterSettingsLoadedMsg:
+		if !app.clusterSettingsMode || msg.Nonce != app.clusterSettingsNonce {
+			break // screen closed or stale response — discard
+		}
+		app.clusterSettingsLoading = false
+		if msg.Err != nil {
+			app.clusterSettingsErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.clusterSettingsErr = ""
+		app.clusterSettings = msg.Settings
+		app.clusterSettingsTable.SetData(clusterSettingRows(msg.Settings))
+		app.fitClusterSettingsTable()
+
+	case ClusterSettingsResultMsg:
+		if msg.Nonce != app.clusterSettingsNonce {
+			break // stale response from a prior session — discard
 		}
-		// Clear settings status only when this is the post-settings (or normal) refresh.
-		// If settingsPendingRefresh is set, the SnapshotMsg arrived from the stale
-		// in-flight fetch that started before the settings update; keep the status.
-		if !app.settingsPendingRefresh {
-			app.settingsStatus = ""
+		if msg.Err != nil {
+			app.settingsStatus = fmt.Sprintf("Cluster settings update failed: %s", sanitize(msg.Err.Error()))
+			app.settingsStatusErr = true
+		} else {
+			app.settingsStatus = fmt.Sprintf("Updated %d cluster setting(s)", msg.Changed)
 			app.settingsStatusErr = false
 		}
+		// Reload so the screen reflects the applied values.
+		if app.clusterSettingsMode {
+			app.clusterSettingsNonce++
+			app.clusterSettingsLoading = true
+			return app, clusterSettingsLoadCmd(app.client, app.clusterSettingsNonce)
+		}
+
+	case MappingLoadedMsg:
+		if !app.mappingMode || msg.Nonce != app.mappingNonce {
+			break // view closed or stale response — discard
+		}
+		app.mappingLoading = false
+		if msg.Err != nil {
+			app.mappingErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.mappingErr = ""
+		app.mappingRoots = buildMappingTree(msg.Mapping)
+		app.moveMappingCursor(0)
+
+	case UnassignedLoadedMsg:
+		if !app.explainMode || msg.Nonce != app.explainNonce {
+			break // view closed or stale response — discard
+		}
+		app.explainLoading = false
+		if msg.Err != nil {
+			app.explainErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.explainErr = ""
+		app.explainTable.SetData(engine.UnassignedShardRows(engine.CalcShardRows(msg.Shards)))
+		app.fitExplainTable()
+
+	case ExplainLoadedMsg:
+		if !app.explainDetailMode || msg.Nonce != app.explainDetailNonce {
+			break // detail closed or stale response — discard
+		}
+		app.explainDetailLoading = false
+		if msg.Err != nil {
+			app.explainDetailErr = sanitize(msg.Err.Error())
+			break
+		}
+		app.explainDetailErr = ""
+		app.explainDetail = msg.Explain
+		app.clampScrollOffsets()
+
+	case SnapshotMsg:
+		if (app.pendingRefresh || app.settingsPendingRefresh) && msg.Seq <= app.staleSeq {
+			// This poll started before the delete or settings update, so it
+			// would bring back deleted indices or old settings. Drop it, keep
+			// the status, and issue a fresh poll so the change is shown
+			// without waiting for the next interval.
+			app.fetching = true
+			return app, tea.Batch(refreshCmd(app.poller), waitForPollCmd(app.pollResults))
+		}
+		app.fetching = false
+		app.pendingRefresh = false
+		app.settingsPendingRefresh = false
+		app.deleteStatus = ""
+		app.deleteStatusErr = false
+		app.settingsStatus = ""
+		app.settingsStatusErr = false
 		app.previous = app.current
 		app.current = msg.Snapshot
 		app.metrics = msg.Metrics
@@ -191,8 +491,18 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		app.nodeRows = msg.NodeRows
 		app.indexRows = msg.IndexRows
 		app.recommendations = msg.Recommendations
+		app.recoveryRows = msg.Recoveries
 		app.indexTable.SetData(msg.IndexRows)
 		app.nodeTable.SetData(msg.NodeRows)
+		app.recoveryTable.SetData(msg.Recoveries)
+		app.dataStreamTable.SetData(msg.DataStreams)
+		app.aliasTable.SetData(msg.Aliases)
+		app.pipelineTable.SetData(msg.Pipelines)
+		app.fitPipelineTable()
+		app.followerTable.SetData(msg.Followers)
+		app.fitFollowerTable()
+		app.refreshZones()
+		app.refreshTiers()
 		app.computeTablePageSizes()
 		// Only push to history when we have a previous snapshot with valid deltas.
 		// Guard against MetricNotAvailable (-1.0) which is returned when prev is nil
@@ -206,28 +516,14 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				SearchLatency: msg.Metrics.SearchLatency,
 			})
 		}
-		if app.analyticsScrollOffset > 0 {
-			if max := analyticsMaxOffset(app); app.analyticsScrollOffset > max {
-				app.analyticsScrollOffset = max
-			}
-		}
+		app.clampScrollOffsets()
 		app.consecutiveFails = 0
 		app.lastError = nil
 		app.connState = stateConnected
 		app.lastUpdated = msg.Snapshot.FetchedAt
 		app.nextRetryAt = time.Time{}
 		app.countdownGen++ // invalidate any pending countdown tick
-		app.tickGen++
-		if app.pendingRefresh || app.settingsPendingRefresh {
-			// A delete or settings update completed while a stale fetch was in-flight.
-			// Issue a fresh fetch now that the stale one has landed so the index list
-			// reflects the change without waiting for the next poll tick.
-			app.pendingRefresh = false
-			app.settingsPendingRefresh = false
-			app.fetching = true
-			return app, fetchCmd(app.client, app.current, app.pollInterval)
-		}
-		return app, tickCmd(app.pollInterval, app.tickGen)
+		return app, waitForPollCmd(app.pollResults)
 
 	case FetchErrorMsg:
 		app.fetching = false
@@ -237,15 +533,17 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		app.deleteStatusErr = false
 		app.settingsStatus = ""
 		app.settingsStatusErr = false
-		app.consecutiveFails++
+		// Take the count from the poller: results the app did not receive
+		// would make a count of its own drift from the poller's backoff.
+		app.consecutiveFails = msg.Fails
 		app.lastError = msg.Err
 		app.connState = stateDisconnected
-		delay := backoffDuration(app.consecutiveFails)
-		app.nextRetryAt = time.Now().Add(delay)
-		app.tickGen++
+		// The poller retries after this backoff; mirror it for the header
+		// countdown.
+		app.nextRetryAt = time.Now().Add(engine.BackoffDuration(app.consecutiveFails))
 		app.countdownGen++
 		return app, tea.Batch(
-			tickCmd(delay, app.tickGen),
+			waitForPollCmd(app.pollResults),
 			countdownTickCmd(time.Second, app.countdownGen),
 		)
 
@@ -258,19 +556,12 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return app, countdownTickCmd(time.Second, app.countdownGen)
 
-	case TickMsg:
-		if msg.Gen != app.tickGen {
-			return app, nil
-		}
-		if app.fetching {
-			return app, nil
-		}
-		app.fetching = true
-		return app, fetchCmd(app.client, app.current, app.pollInterval)
-
 	case tea.KeyMsg:
 		// ctrl+c / q always quit, even during table search.
 		if key.Matches(msg, keys.Quit) {
+			if app.stopPoller != nil {
+				app.stopPoller()
+			}
 			return app, tea.Quit
 		}
 
@@ -311,12 +602,212 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return app, formCmd
 		}
 
-		// In analytics mode only esc/a close it, ↑↓ scroll, all others are ignored.
+		// In shards mode keys drive the shard table, with s/esc closing it.
+		if app.shardsMode {
+			return app, updateScreenTable(&app.shardsTable, msg, keys.Shards, func() { app.shardsMode = false }, app.reloadShards)
+		}
+
+		// In recovery mode keys drive the recovery table, with R/esc closing it
+		// and r forcing a poll (the table refreshes from every snapshot).
+		if app.recoveryMode {
+			return app, updateScreenTable(&app.recoveryTable, msg, keys.Recovery, func() { app.recoveryMode = false }, app.pollNow)
+		}
+
+		// In data streams mode the expanded stream takes esc/↑↓; the list
+		// drives the data stream table, with enter expanding a stream, D/esc
+		// closing it and r forcing a poll.
+		if app.dataStreamsMode {
+			if app.dataStreamDetailMode {
+				switch {
+				case key.Matches(msg, keys.Escape):
+					app.dataStreamDetailMode = false
+				default:
+					app.dataStreamScrollOffset = scrollBy(app.dataStreamScrollOffset,
+						key.Matches(msg, keys.CursorUp), key.Matches(msg, keys.CursorDown), dataStreamMaxOffset(app))
+				}
+				return app, nil
+			}
+			if !app.dataStreamTable.searching && key.Matches(msg, keys.Select) {
+				if r, ok := app.dataStreamTable.cursorRow(); ok {
+					app.dataStreamDetailMode = true
+					app.dataStreamTarget = r.Name
+					app.dataStreamScrollOffset = 0
+				}
+				return app, nil
+			}
+			return app, updateScreenTable(&app.dataStreamTable, msg, keys.DataStreams, func() { app.dataStreamsMode = false }, app.pollNow)
+		}
+
+		// In alias mode keys drive the alias table, with A/esc closing it and
+		// r forcing a poll (the table refreshes from every snapshot).
+		if app.aliasesMode {
+			return app, updateScreenTable(&app.aliasTable, msg, keys.Aliases, func() { app.aliasesMode = false }, app.pollNow)
+		}
+
+		// In zone mode keys drive the zone table, with z/esc closing it, g
+		// switching the grouping attribute and r forcing a poll.
+		if app.zonesMode {
+			if !app.zoneTable.searching && msg.String() == "g" {
+				app.cycleZoneAttribute()
+				return app, nil
+			}
+			return app, updateScreenTable(&app.zoneTable, msg, keys.Zones, func() { app.zonesMode = false }, app.pollNow)
+		}
+
+		// In replication mode keys drive the follower table, with X/esc
+		// closing it and r forcing a poll.
+		if app.ccrMode {
+			return app, updateScreenTable(&app.followerTable, msg, keys.CCR, func() { app.ccrMode = false }, app.pollNow)
+		}
+
+		// In pipeline mode keys drive the pipeline table, with i/esc closing
+		// it and r forcing a poll.
+		if app.pipelinesMode {
+			return app, updateScreenTable(&app.pipelineTable, msg, keys.Pipelines, func() { app.pipelinesMode = false }, app.pollNow)
+		}
+
+		// In tier mode keys drive the tier table, with w/esc closing it and r
+		// forcing a poll.
+		if app.tiersMode {
+			return app, updateScreenTable(&app.tierTable, msg, keys.Tiers, func() { app.tiersMode = false }, app.pollNow)
+		}
+
+		// In mapping mode keys move through the tree and expand or collapse
+		// the focused field, with m/esc closing it and r reloading.
+		if app.mappingMode {
+			switch {
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.Mapping):
+				app.mappingMode = false
+			case key.Matches(msg, keys.Refresh):
+				if !app.mappingLoading {
+					app.mappingNonce++
+					app.mappingLoading = true
+					return app, mappingLoadCmd(app.client, app.mappingIndex, app.mappingNonce)
+				}
+			case key.Matches(msg, keys.CursorUp):
+				app.moveMappingCursor(-1)
+			case key.Matches(msg, keys.CursorDown):
+				app.moveMappingCursor(1)
+			case key.Matches(msg, keys.Select), key.Matches(msg, keys.ToggleSelect):
+				lines := visibleMappingLines(app.mappingRoots, app.mappingExpanded)
+				if app.mappingCursor < len(lines) {
+					app.setMappingNodeExpanded(!app.mappingExpanded[lines[app.mappingCursor].node.Path])
+				}
+			case key.Matches(msg, keys.NextPage):
+				app.setMappingNodeExpanded(true)
+			case key.Matches(msg, keys.PrevPage):
+				app.setMappingNodeExpanded(false)
+			case msg.String() == "e":
+				app.setMappingExpanded(!anyMappingExpanded(app.mappingExpanded))
+				app.mappingCursor = 0
+				app.mappingScrollOffset = 0
+			}
+			return app, nil
+		}
+
+		// In cluster settings mode the confirmation step takes y/n/esc, the
+		// editor takes every key, and otherwise keys drive the settings table
+		// with e opening the editor and C/esc closing the screen.
+		if app.clusterSettingsMode {
+			if app.clusterSettingsConfirm {
+				switch {
+				case msg.String() == "y":
+					persistent, transient := clusterChangeMaps(app.clusterSettingsChanges, app.clusterSettings)
+					app.clusterSettingsConfirm = false
+					app.clusterSettingsEditing = false // exit immediately to prevent double-submit
+					app.clusterSettingsChanges = nil
+					app.settingsStatus = ""
+					app.settingsStatusErr = false
+					return app, clusterSettingsUpdateCmd(app.client, persistent, transient, app.clusterSettingsNonce)
+				case msg.String() == "n", key.Matches(msg, keys.Escape):
+					app.clusterSettingsConfirm = false
+					app.clusterSettingsChanges = nil
+				}
+				return app, nil
+			}
+			if app.clusterSettingsEditing {
+				var formCmd tea.Cmd
+				app.clusterSettingsForm, formCmd = app.clusterSettingsForm.Update(msg)
+				if app.clusterSettingsForm.submitted {
+					app.clusterSettingsForm.submitted = false
+					app.clusterSettingsChanges = pendingClusterChanges(app.clusterSettingsForm, app.clusterSettings)
+					if len(app.clusterSettingsChanges) == 0 {
+						app.clusterSettingsEditing = false
+						return app, nil
+					}
+					app.clusterSettingsConfirm = true
+					return app, nil
+				}
+				if app.clusterSettingsForm.cancelled {
+					app.clusterSettingsForm.cancelled = false
+					app.clusterSettingsEditing = false
+					return app, nil
+				}
+				return app, formCmd
+			}
+			if !app.clusterSettingsTable.searching && key.Matches(msg, keys.EditSettings) {
+				if app.clusterSettings != nil {
+					app.openClusterSettingsEditor()
+				}
+				return app, nil
+			}
+			return app, updateScreenTable(&app.clusterSettingsTable, msg, keys.ClusterSet, func() { app.clusterSettingsMode = false }, app.reloadClusterSettings)
+		}
+
+		// In template mode keys drive the template table, with T/esc closing
+		// it and r reloading the templates and the simulated index.
+		if app.templatesMode {
+			return app, updateScreenTable(&app.templatesTable, msg, keys.Templates, func() { app.templatesMode = false }, app.refreshTemplates)
+		}
+
+		// In explain mode the detail view takes esc/r/↑↓; the list drives the
+		// unassigned shard table, with enter opening the detail.
+		if app.explainMode {
+			if app.explainDetailMode {
+				switch {
+				case key.Matches(msg, keys.Escape):
+					app.explainDetailMode = false
+					app.explainDetail = nil
+					app.explainDetailNonce++ // drop any in-flight explain response
+				case key.Matches(msg, keys.Refresh):
+					if !app.explainDetailLoading {
+						app.explainDetailNonce++
+						app.explainDetailLoading = true
+						app.explainDetailErr = ""
+						return app, explainLoadCmd(app.client, app.explainTarget, app.explainDetailNonce)
+					}
+				default:
+					app.explainScrollOffset = scrollBy(app.explainScrollOffset,
+						key.Matches(msg, keys.CursorUp), key.Matches(msg, keys.CursorDown), explainMaxOffset(app))
+				}
+				return app, nil
+			}
+			if !app.explainTable.searching && key.Matches(msg, keys.Select) {
+				if r, ok := app.explainTable.cursorRow(); ok && r.Shard >= 0 {
+					app.explainDetailNonce++
+					app.explainDetailMode = true
+					app.explainTarget = r
+					app.explainDetail = nil
+					app.explainDetailErr = ""
+					app.explainDetailLoading = true
+					app.explainScrollOffset = 0
+					return app, explainLoadCmd(app.client, r, app.explainDetailNonce)
+				}
+				return app, nil
+			}
+			return app, updateScreenTable(&app.explainTable, msg, keys.Explain, app.closeExplain, app.reloadUnassigned)
+		}
+
+		// In analytics mode only esc/a close it, ↑↓ scroll, x opens the
+		// unassigned shard explain view, all others are ignored.
 		if app.analyticsMode {
 			switch {
 			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.Analytics):
 				app.analyticsMode = false
 				app.analyticsScrollOffset = 0
+			case key.Matches(msg, keys.Explain):
+				app.analyticsMode = false
+				return app, app.openExplain(true)
 			case key.Matches(msg, keys.CursorUp):
 				if app.analyticsScrollOffset > 0 {
 					app.analyticsScrollOffset--
@@ -330,6 +821,121 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return app, nil
 		}
 
+		// In pending tasks mode only esc/p close it, ↑↓ scroll.
+		if app.pendingTasksMode {
+			switch {
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.PendingTasks):
+				app.pendingTasksMode = false
+				app.pendingTasksScrollOffset = 0
+			default:
+				app.pendingTasksScrollOffset = scrollBy(app.pendingTasksScrollOffset,
+					key.Matches(msg, keys.CursorUp), key.Matches(msg, keys.CursorDown), pendingTasksMaxOffset(app))
+			}
+			return app, nil
+		}
+
+		// In backups mode only esc/b close it, ↑↓ scroll.
+		if app.backupsMode {
+			switch {
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.Backups):
+				app.backupsMode = false
+				app.backupsScrollOffset = 0
+			default:
+				app.backupsScrollOffset = scrollBy(app.backupsScrollOffset,
+					key.Matches(msg, keys.CursorUp), key.Matches(msg, keys.CursorDown), backupsMaxOffset(app))
+			}
+			return app, nil
+		}
+
+		// In node resources mode: n/esc close, r forces a poll.
+		if app.resourcesMode {
+			switch {
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.Resources):
+				app.resourcesMode = false
+			case key.Matches(msg, keys.Refresh):
+				if !app.fetching {
+					app.fetching = true
+					return app, refreshCmd(app.poller)
+				}
+			}
+			return app, nil
+		}
+
+		// In hot threads mode: h/esc close, r refreshes, g toggles grouping,
+		// ↑↓ scroll.
+		if app.hotThreadsMode {
+			switch {
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.HotThreads):
+				app.hotThreadsMode = false
+				app.hotThreadsScrollOffset = 0
+			case key.Matches(msg, keys.Refresh):
+				if !app.hotThreadsLoading {
+					app.hotThreadsNonce++
+					app.hotThreadsLoading = true
+					return app, hotThreadsLoadCmd(app.client, app.hotThreadsNode.ID, app.hotThreadsNonce)
+				}
+			case key.Matches(msg, keys.GroupThreads):
+				app.hotThreadsGrouped = !app.hotThreadsGrouped
+				app.hotThreadsScrollOffset = 0
+			default:
+				app.hotThreadsScrollOffset = scrollBy(app.hotThreadsScrollOffset,
+					key.Matches(msg, keys.CursorUp), key.Matches(msg, keys.CursorDown), hotThreadsMaxOffset(app))
+			}
+			return app, nil
+		}
+
+		// In tasks mode the cancel confirmation takes y/n/esc; otherwise keys
+		// drive the tasks table, with t/esc closing the screen.
+		if app.tasksMode {
+			if app.tasksCancelConfirm {
+				switch {
+				case msg.String() == "y":
+					app.tasksCancelConfirm = false
+					return app, taskCancelCmd(app.client, app.pendingCancelTask.TaskID, app.tasksNonce)
+				case msg.String() == "n", key.Matches(msg, keys.Escape):
+					app.tasksCancelConfirm = false
+				}
+				return app, nil
+			}
+			var cmd tea.Cmd
+			switch {
+			case app.tasksTable.searching:
+				app.tasksTable, cmd = app.tasksTable.Update(msg)
+			case key.Matches(msg, keys.Escape) && app.tasksTable.search != "":
+				app.tasksTable, cmd = app.tasksTable.Update(msg)
+			case key.Matches(msg, keys.Escape), key.Matches(msg, keys.Tasks):
+				app.tasksMode = false
+				app.tasksStatus = ""
+				app.tasksStatusErr = false
+			case key.Matches(msg, keys.Refresh):
+				if !app.tasksLoading {
+					app.tasksLoading = true
+					app.tasksStatus = ""
+					app.tasksStatusErr = false
+					cmd = tasksLoadCmd(app.client, app.tasksNonce)
+				}
+			case key.Matches(msg, keys.CancelTask):
+				if r, ok := app.tasksTable.cursorRow(); ok {
+					switch {
+					case r.Cancelled:
+						app.tasksStatus = fmt.Sprintf("Task %s is already being cancelled", sanitize(r.TaskID))
+						app.tasksStatusErr = true
+					case !r.Cancellable:
+						app.tasksStatus = fmt.Sprintf("Task %s is not cancellable", sanitize(r.TaskID))
+						app.tasksStatusErr = true
+					default:
+						app.pendingCancelTask = r
+						app.tasksCancelConfirm = true
+						app.tasksStatus = ""
+						app.tasksStatusErr = false
+					}
+				}
+			default:
+				app.tasksTable, cmd = app.tasksTable.Update(msg)
+			}
+			return app, cmd
+		}
+
 		// While the active table has its search input open, delegate all
 		// other keys to the table so typed characters reach the text field.
 		activeSearching := (app.activeTable == 0 && app.indexTable.searching) ||
@@ -386,13 +992,80 @@ func (app *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Analytics):
 			app.analyticsMode = true
 			app.analyticsScrollOffset = 0
+		case key.Matches(msg, keys.PendingTasks):
+			app.pendingTasksMode = true
+			app.pendingTasksScrollOffset = 0
+		case key.Matches(msg, keys.Backups):
+			app.backupsMode = true
+			app.backupsScrollOffset = 0
+		case key.Matches(msg, keys.Lifecycle) && app.activeTable == 0:
+			app.indexTable.toggleLifecycle()
+		case key.Matches(msg, keys.HotThreads) && app.activeTable == 1:
+			if r, ok := app.nodeTable.cursorRow(); ok && r.ID != "" {
+				app.hotThreadsNonce++
+				app.hotThreadsMode = true
+				app.hotThreadsNode = r
+				app.hotThreadsText = ""
+				app.hotThreadsErr = ""
+				app.hotThreadsLoading = true
+				app.hotThreadsScrollOffset = 0
+				return app, hotThreadsLoadCmd(app.client, r.ID, app.hotThreadsNonce)
+			}
+		case key.Matches(msg, keys.Resources) && app.activeTable == 1:
+			if r, ok := app.nodeTable.cursorRow(); ok && r.ID != "" {
+				app.resourcesMode = true
+				app.resourcesNodeID = r.ID
+				app.resourcesNodeName = r.Name
+			}
+		case key.Matches(msg, keys.Explain):
+			return app, app.openExplain(false)
+		case key.Matches(msg, keys.Shards):
+			return app, app.openShards()
+		case key.Matches(msg, keys.Recovery):
+			app.recoveryMode = true
+			app.fitRecoveryTable()
+		case key.Matches(msg, keys.DataStreams):
+			app.dataStreamsMode = true
+			app.dataStreamDetailMode = false
+			app.fitDataStreamTable()
+		case key.Matches(msg, keys.Aliases):
+			app.aliasesMode = true
+			app.fitAliasTable()
+		case key.Matches(msg, keys.Zones):
+			app.zonesMode = true
+			app.refreshZones()
+		case key.Matches(msg, keys.Tiers):
+			app.tiersMode = true
+			app.refreshTiers()
+		case key.Matches(msg, keys.Pipelines):
+			app.pipelinesMode = true
+			app.fitPipelineTable()
+		case key.Matches(msg, keys.CCR):
+			app.ccrMode = true
+			app.fitFollowerTable()
+		case key.Matches(msg, keys.Templates):
+			return app, app.openTemplates()
+		case key.Matches(msg, keys.ClusterSet):
+			return app, app.openClusterSettings()
+		case key.Matches(msg, keys.Mapping):
+			return app, app.openMapping()
+		case key.Matches(msg, keys.Tasks):
+			app.tasksNonce++
+			app.tasksMode = true
+			app.tasksLoading = true
+			app.tasksErr = ""
+			app.tasksStatus = ""
+			app.tasksStatusErr = false
+			app.tasksCancelConfirm = false
+			app.tasksTable = NewTasksTable()
+			app.fitTasksTable()
+			return app, tasksLoadCmd(app.client, app.tasksNonce)
 		case key.Matches(msg, keys.Refresh):
 			if app.fetching {
 				return app, nil
 			}
-			app.tickGen++ // invalidate any pending tick so it doesn't trigger a double-fetch
 			app.fetching = true
-			return app, fetchCmd(app.client, app.current, app.pollInterval)
+			return app, refreshCmd(app.poller)
 		case key.Matches(msg, keys.Tab):
 			app.activeTable = (app.activeTable + 1) % 2
 			app.indexTable.focused = app.activeTable == 0
@@ -446,6 +1119,148 @@ func (app *App) View() string {
 		return strings.Join(parts, "\n")
 	}
 
+	// Pending tasks mode: replace dashboard with the master queue panel.
+	if app.pendingTasksMode {
+		parts = append(parts, renderPendingTasks(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Backups mode: replace dashboard with the snapshot repositories panel.
+	if app.backupsMode {
+		parts = append(parts, renderBackups(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Shards mode: replace dashboard with the shard view.
+	if app.shardsMode {
+		parts = append(parts, renderShards(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Recovery mode: replace dashboard with the active recoveries table.
+	if app.recoveryMode {
+		parts = append(parts, renderRecovery(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Data streams mode: replace dashboard with the stream list or the
+	// backing indices of the expanded stream.
+	if app.dataStreamsMode {
+		if app.dataStreamDetailMode {
+			parts = append(parts, renderDataStreamDetail(app))
+		} else {
+			parts = append(parts, renderDataStreams(app))
+		}
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Replication mode: replace dashboard with remotes and followers.
+	if app.ccrMode {
+		parts = append(parts, renderCCR(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Pipeline mode: replace dashboard with the ingest pipeline table.
+	if app.pipelinesMode {
+		parts = append(parts, renderPipelines(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Tier mode: replace dashboard with the data tier summary.
+	if app.tiersMode {
+		parts = append(parts, renderTiers(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Zone mode: replace dashboard with the zone summary.
+	if app.zonesMode {
+		parts = append(parts, renderZones(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Alias mode: replace dashboard with the alias table.
+	if app.aliasesMode {
+		parts = append(parts, renderAliases(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Mapping mode: replace dashboard with the mapping tree.
+	if app.mappingMode {
+		parts = append(parts, renderMapping(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Cluster settings mode: replace dashboard with the settings table, the
+	// editor or its confirmation step.
+	if app.clusterSettingsMode {
+		switch {
+		case app.clusterSettingsConfirm:
+			parts = append(parts, renderClusterSettingsConfirm(app))
+		case app.clusterSettingsEditing:
+			parts = append(parts, renderClusterSettingsForm(app))
+		default:
+			parts = append(parts, renderClusterSettings(app))
+		}
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Template mode: replace dashboard with the template browser.
+	if app.templatesMode {
+		parts = append(parts, renderTemplates(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Explain mode: replace dashboard with the unassigned shard list or the
+	// allocation explain detail for the selected shard.
+	if app.explainMode {
+		if app.explainDetailMode {
+			parts = append(parts, renderExplainDetail(app))
+		} else {
+			parts = append(parts, renderExplainList(app))
+		}
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Node resources mode: replace dashboard with the node's resources.
+	if app.resourcesMode {
+		parts = append(parts, renderResources(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Hot threads mode: replace dashboard with the node's hot threads report.
+	if app.hotThreadsMode {
+		parts = append(parts, renderHotThreads(app))
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
+	// Tasks mode: replace dashboard with the running tasks screen, or with
+	// the cancel confirmation while one is pending.
+	if app.tasksMode {
+		if app.tasksCancelConfirm {
+			parts = append(parts, renderTaskCancelConfirm(app))
+		} else {
+			parts = append(parts, renderTasks(app))
+		}
+		parts = append(parts, renderFooter(app))
+		return strings.Join(parts, "\n")
+	}
+
 	if o := renderOverview(app); o != "" {
 		parts = append(parts, o)
 	}
@@ -469,14 +1284,6 @@ func (app *App) View() string {
 	return strings.Join(parts, "\n")
 }
 
-// tickCmd schedules the next poll after duration d, embedding gen so the
-// TickMsg handler can discard ticks that belong to a superseded schedule.
-func tickCmd(d time.Duration, gen int) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg {
-		return TickMsg{Time: t, Gen: gen}
-	})
-}
-
 // countdownTickCmd schedules a CountdownTickMsg after duration d with the given
 // gen so the header countdown display updates every second while disconnected.
 func countdownTickCmd(d time.Duration, gen int) tea.Cmd {
@@ -485,67 +1292,63 @@ func countdownTickCmd(d time.Duration, gen int) tea.Cmd {
 	})
 }
 
-// fetchTimeout returns the context timeout for a fetch command.
-// It is interval - 500ms, clamped to [500ms, 10s].
-// The 10s upper bound ensures that any in-flight fetch cancels promptly
-// when the user quits, regardless of how large the poll interval is.
-func fetchTimeout(interval time.Duration) time.Duration {
-	const (
-		minTimeout = 500 * time.Millisecond
-		maxTimeout = 10 * time.Second
-	)
-	t := interval - 500*time.Millisecond
-	if t < minTimeout {
-		t = minTimeout
-	}
-	if t > maxTimeout {
-		t = maxTimeout
-	}
-	return t
+// runPollerCmd runs p until ctx is cancelled. Bubble Tea runs commands on
+// their own goroutines, so the poller lives for as long as the program; its
+// results arrive through waitForPollCmd.
+func runPollerCmd(ctx context.Context, p *engine.Poller) tea.Cmd {
+	return func() tea.Msg {
+		_ = p.Run(ctx)
+		return nil
+	}
 }
 
-// fetchCmd is a Bubble Tea command that calls all 5 ES endpoints, computes
-// derived metrics, and returns a SnapshotMsg or FetchErrorMsg.
-//
-// The fetch context timeout is capped at 10s so that any in-flight HTTP
-// request is always cancelled promptly when the user quits — Bubble Tea
-// stops dispatching messages immediately on tea.Quit, but goroutines
-// spawned by commands run until their own contexts expire.  The errgroup
-// in FetchAll propagates cancellation to all 5 concurrent goroutines, and
-// the HTTP client respects context cancellation via NewRequestWithContext.
-func fetchCmd(c client.ESClient, prev *model.Snapshot, interval time.Duration) tea.Cmd {
+// waitForPollCmd waits for the next poll result on ch and converts it to a
+// SnapshotMsg or FetchErrorMsg. Each handled result issues the next wait.
+func waitForPollCmd(ch <-chan engine.PollResult) tea.Cmd {
 	return func() tea.Msg {
-		timeout := fetchTimeout(interval)
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-
-		snap, err := engine.FetchAll(ctx, c)
-		if err != nil {
-			return FetchErrorMsg{Err: err}
+		r, ok := <-ch
+		if !ok {
+			return nil
 		}
-
-		var elapsed time.Duration
-		if prev != nil {
-			elapsed = snap.FetchedAt.Sub(prev.FetchedAt)
+		if r.Err != nil {
+			return FetchErrorMsg{Err: r.Err, Fails: r.Fails}
 		}
-
-		metrics := engine.CalcClusterMetrics(prev, snap, elapsed)
-		resources := engine.CalcClusterResources(snap)
-		nodeRows := engine.CalcNodeRows(prev, snap, elapsed)
-		indexRows := engine.CalcIndexRows(prev, snap, elapsed)
-		recommendations := engine.CalcRecommendations(snap, resources, nodeRows, indexRows)
-
 		return SnapshotMsg{
-			Snapshot:        snap,
-			Metrics:         metrics,
-			Resources:       resources,
-			NodeRows:        nodeRows,
-			IndexRows:       indexRows,
-			Recommendations: recommendations,
+			Snapshot:        r.Snapshot,
+			Metrics:         r.Metrics,
+			Resources:       r.Resources,
+			NodeRows:        r.NodeRows,
+			IndexRows:       r.IndexRows,
+			Recoveries:      r.Recoveries,
+			DataStreams:     r.DataStreams,
+			Aliases:         r.Aliases,
+			Pipelines:       r.Pipelines,
+			Followers:       r.Followers,
+			Recommendations: r.Recommendations,
+			Seq:             r.Seq,
 		}
 	}
 }
 
+// refreshCmd asks the poller to poll now instead of waiting for the next
+// interval. The result arrives through the pending waitForPollCmd.
+func refreshCmd(p *engine.Poller) tea.Cmd {
+	return func() tea.Msg {
+		p.Refresh()
+		return nil
+	}
+}
+
+// pollNow forces a poll unless one is already in flight. Views fed from the
+// snapshot use it to refresh.
+func (app *App) pollNow() tea.Cmd {
+	if app.fetching {
+		return nil
+	}
+	app.fetching = true
+	return refreshCmd(app.poller)
+}
+
 // computeTablePageSizes updates the pageSize of both tables to fill the
 // available terminal height after the fixed UI sections (header, overview,
 // metrics, footer) are accounted for.
@@ -603,6 +1406,60 @@ func (app *App) computeTablePageSizes() {
 	app.nodeTable.clampPage(len(app.nodeTable.displayRows))
 	app.indexTable.clampCursor(app.indexTable.currentPageRowCount(len(app.indexTable.displayRows)))
 	app.nodeTable.clampCursor(app.nodeTable.currentPageRowCount(len(app.nodeTable.displayRows)))
+	app.fitTasksTable()
+	app.fitExplainTable()
+	app.fitShardsTable()
+	app.fitRecoveryTable()
+	app.fitDataStreamTable()
+	app.fitAliasTable()
+	app.fitTemplatesTable()
+	app.fitClusterSettingsTable()
+	app.fitZoneTable()
+	app.fitTierTable()
+	app.fitPipelineTable()
+	app.fitFollowerTable()
+}
+
+// clampScrollOffsets clamps the scroll offsets of the full-screen views after
+// a resize or data refresh shrinks their content.
+func (app *App) clampScrollOffsets() {
+	if app.analyticsScrollOffset > 0 {
+		if max := analyticsMaxOffset(app); app.analyticsScrollOffset > max {
+			app.analyticsScrollOffset = max
+		}
+	}
+	if app.pendingTasksScrollOffset > 0 {
+		if max := pendingTasksMaxOffset(app); app.pendingTasksScrollOffset > max {
+			app.pendingTasksScrollOffset = max
+		}
+	}
+	if app.backupsScrollOffset > 0 {
+		if max := backupsMaxOffset(app); app.backupsScrollOffset > max {
+			app.backupsScrollOffset = max
+		}
+	}
+	if app.dataStreamScrollOffset > 0 {
+		if max := dataStreamMaxOffset(app); app.dataStreamScrollOffset > max {
+			app.dataStreamScrollOffset = max
+		}
+	}
+	if app.explainScrollOffset > 0 {
+		if max := explainMaxOffset(app); app.explainScrollOffset > max {
+			app.explainScrollOffset = max
+		}
+	}
+	if app.hotThreadsScrollOffset > 0 {
+		if max := hotThreadsMaxOffset(app); app.hotThreadsScrollOffset > max {
+			app.hotThreadsScrollOffset = max
+		}
+	}
+}
+
+// SetRecommendationConfig replaces the recommendation thresholds used from
+// the next poll on. Used by main to apply command-line flags.
+func (app *App) SetRecommendationConfig(cfg engine.RecommendationConfig) {
+	app.recConfig = cfg
+	app.poller.SetConfig(cfg)
 }
 
 // LastError returns the most recent fetch error, or nil if the last fetch
@@ -619,16 +1476,3 @@ func renderedHeight(s string) int {
 	}
 	return lipgloss.Height(s)
 }
-
-// backoffDuration returns min(2^fails * time.Second, 60*time.Second).
-// At fails=1: 2s, fails=2: 4s, fails=3: 8s, ..., fails>=6: 60s.
-func backoffDuration(fails int) time.Duration {
-	const maxBackoff = 60 * time.Second
-	if fails <= 0 {
-		return time.Second
-	}
-	if fails >= 6 {
-		return maxBackoff
-	}
-	return time.Duration(1<<fails) * time.Second
-}
@@ -7,11 +7,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/jtsunne/epm-go/internal/engine"
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
@@ -86,7 +88,7 @@ func TestApp_FetchErrorIncreasesFails(t *testing.T) {
 	app := NewApp(nil, 10*time.Second)
 
 	err1 := errors.New("connection refused")
-	newModel, cmd1 := app.Update(FetchErrorMsg{Err: err1})
+	newModel, cmd1 := app.Update(FetchErrorMsg{Err: err1, Fails: 1})
 	app = newModel.(*App)
 
 	assert.Equal(t, 1, app.consecutiveFails)
@@ -94,19 +96,33 @@ func TestApp_FetchErrorIncreasesFails(t *testing.T) {
 	assert.Equal(t, stateDisconnected, app.connState)
 	require.NotNil(t, cmd1)
 
-	newModel, cmd2 := app.Update(FetchErrorMsg{Err: err1})
+	newModel, cmd2 := app.Update(FetchErrorMsg{Err: err1, Fails: 2})
 	app = newModel.(*App)
 
 	assert.Equal(t, 2, app.consecutiveFails)
 	require.NotNil(t, cmd2)
 }
 
+// TestApp_FetchErrorUsesPollerFails verifies that the fail count and the
+// retry countdown follow the poller's count, even when results were missed.
+func TestApp_FetchErrorUsesPollerFails(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+
+	before := time.Now()
+	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("timeout"), Fails: 4})
+	app = newModel.(*App)
+
+	assert.Equal(t, 4, app.consecutiveFails)
+	assert.False(t, app.nextRetryAt.Before(before.Add(engine.BackoffDuration(4))))
+	assert.True(t, app.nextRetryAt.Before(time.Now().Add(engine.BackoffDuration(4)+time.Second)))
+}
+
 func TestApp_FetchErrorResetsOnSuccess(t *testing.T) {
 	app := NewApp(nil, 10*time.Second)
 
 	// Simulate two failures
-	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("timeout")})
-	newModel, _ = newModel.(*App).Update(FetchErrorMsg{Err: errors.New("timeout")})
+	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("timeout"), Fails: 1})
+	newModel, _ = newModel.(*App).Update(FetchErrorMsg{Err: errors.New("timeout"), Fails: 2})
 	app = newModel.(*App)
 	require.Equal(t, 2, app.consecutiveFails)
 
@@ -177,43 +193,24 @@ func TestApp_HelpToggle(t *testing.T) {
 	assert.False(t, app.showHelp)
 }
 
-func TestFetchTimeout(t *testing.T) {
-	cases := []struct {
-		interval time.Duration
-		expected time.Duration
-	}{
-		{100 * time.Millisecond, 500 * time.Millisecond},  // below min → clamp to 500ms
-		{500 * time.Millisecond, 500 * time.Millisecond},  // 0ms after subtraction → min
-		{1 * time.Second, 500 * time.Millisecond},         // 500ms → min
-		{5 * time.Second, 4500 * time.Millisecond},        // normal
-		{10 * time.Second, 9500 * time.Millisecond},       // default interval
-		{10500 * time.Millisecond, 10 * time.Second},      // exactly at cap
-		{30 * time.Second, 10 * time.Second},              // large interval → capped at 10s
-		{300 * time.Second, 10 * time.Second},             // max interval → capped at 10s
+func TestHelpText_ListsEveryKeyOnOneScreen(t *testing.T) {
+	bindings := []key.Binding{
+		keys.Quit, keys.Refresh, keys.Tab, keys.Search, keys.Escape, keys.Help,
+		keys.Analytics, keys.ToggleSelect, keys.DeleteKey, keys.EditSettings,
+		keys.PendingTasks, keys.Tasks, keys.CancelTask, keys.HotThreads,
+		keys.Resources, keys.GroupThreads, keys.Explain, keys.Select, keys.Shards,
+		keys.Recovery, keys.Backups, keys.Lifecycle, keys.DataStreams,
+		keys.Aliases, keys.Templates, keys.Mapping, keys.ClusterSet, keys.Zones,
+		keys.Tiers, keys.Pipelines, keys.CCR,
 	}
-	for _, tc := range cases {
-		got := fetchTimeout(tc.interval)
-		assert.Equal(t, tc.expected, got, "interval=%v", tc.interval)
+	for _, b := range bindings {
+		assert.Contains(t, helpText, " "+b.Help().Key+":", "help should list %q", b.Help().Key)
 	}
-}
 
-func TestBackoffDuration(t *testing.T) {
-	cases := []struct {
-		fails    int
-		expected time.Duration
-	}{
-		{0, time.Second},
-		{1, 2 * time.Second},
-		{2, 4 * time.Second},
-		{3, 8 * time.Second},
-		{4, 16 * time.Second},
-		{5, 32 * time.Second},
-		{6, 60 * time.Second},
-		{10, 60 * time.Second},
-	}
-	for _, tc := range cases {
-		got := backoffDuration(tc.fails)
-		assert.Equal(t, tc.expected, got, "fails=%d", tc.fails)
+	lines := strings.Split(helpText, "\n")
+	assert.LessOrEqual(t, len(lines), 10)
+	for _, l := range lines {
+		assert.LessOrEqual(t, lipgloss.Width(l), 80, "line too wide: %q", l)
 	}
 }
 
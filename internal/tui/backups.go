@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// backupsRecentLimit is the number of most recent snapshots listed per
+// repository in the backups panel.
+const backupsRecentLimit = 10
+
+// snapshotStatusStyle returns the style for a snapshot status.
+func snapshotStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case "SUCCESS":
+		return StyleGreen
+	case "IN_PROGRESS":
+		return StyleCyan
+	case "PARTIAL":
+		return StyleYellow
+	case "FAILED", "INCOMPATIBLE":
+		return StyleRed
+	default:
+		return StyleDim
+	}
+}
+
+// snapshotAge formats how long ago t was relative to now, "---" for zero.
+func snapshotAge(now, t time.Time) string {
+	if t.IsZero() {
+		return "---"
+	}
+	return format.FormatAge(now.Sub(t))
+}
+
+// buildBackupsLines returns the full list of rendered content lines for the
+// backups panel: one block per repository with its last successful snapshot
+// and the most recent snapshots, newest first. Extracted so the same logic
+// can be used both during rendering and when computing the maximum scroll
+// offset in Update().
+func buildBackupsLines(repos []model.RepositorySnapshots, now time.Time, maxAge time.Duration, width int) []string {
+	lines := []string{""}
+	if repos == nil {
+		lines = append(lines, "  "+StyleDim.Render("Snapshot repositories unavailable (requires cluster:monitor/snapshot privileges)"))
+		return lines
+	}
+	if len(repos) == 0 {
+		lines = append(lines, "  "+StyleYellow.Render("No snapshot repositories registered — this cluster has no backups"))
+		return lines
+	}
+
+	const idW, statusW, startW, durW, shardsW = 32, 12, 9, 9, 10
+	for _, row := range engine.CalcRepositoryRows(repos) {
+		header := fmt.Sprintf("  %s (%s)  %d snapshot(s)", sanitize(row.Name), sanitize(row.Type), len(row.Snapshots))
+		lines = append(lines, StyleBlue.Bold(true).Render(truncateName(header, width)))
+
+		if row.Err != "" {
+			lines = append(lines, "    "+StyleError.Render(truncateName("Failed to list snapshots: "+sanitize(row.Err), width-4)), "")
+			continue
+		}
+		if len(row.Snapshots) == 0 {
+			lines = append(lines, "    "+StyleDim.Render("(no snapshots)"), "")
+			continue
+		}
+
+		last := "    Last success: "
+		switch {
+		case row.LastSuccess.IsZero():
+			last += StyleRed.Render("never")
+		case maxAge > 0 && now.Sub(row.LastSuccess) > maxAge:
+			last += StyleYellow.Render(snapshotAge(now, row.LastSuccess) + " ago")
+		default:
+			last += StyleGreen.Render(snapshotAge(now, row.LastSuccess) + " ago")
+		}
+		lines = append(lines, last)
+
+		lines = append(lines, StyleDim.Bold(true).Render(fmt.Sprintf("    %-*s %-*s %*s %*s %*s",
+			idW, "Snapshot", statusW, "Status", startW, "Age", durW, "Duration", shardsW, "Failed")))
+		for i, s := range row.Snapshots {
+			if i == backupsRecentLimit {
+				lines = append(lines, "    "+StyleDim.Render(fmt.Sprintf("... %d older snapshot(s)", len(row.Snapshots)-backupsRecentLimit)))
+				break
+			}
+			dur := "---"
+			if s.Duration > 0 {
+				dur = format.FormatAge(s.Duration)
+			}
+			failed := fmt.Sprintf("%d/%d", s.FailedShards, s.TotalShards)
+			status := snapshotStatusStyle(s.Status).Render(fmt.Sprintf("%-*s", statusW, sanitize(s.Status)))
+			lines = append(lines, fmt.Sprintf("    %-*s %s %*s %*s %*s",
+				idW, truncateName(sanitize(s.ID), idW), status, startW, snapshotAge(now, s.Start), durW, dur, shardsW, failed))
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// renderBackupsTitle renders the title bar for the backups panel.
+func renderBackupsTitle(width int) string {
+	return renderTitleBar("Snapshot Repositories — Backups", "[b/esc: back  ↑↓: scroll]", width)
+}
+
+// backupsMaxOffset returns the maximum valid backupsScrollOffset for the
+// current app state.
+func backupsMaxOffset(app *App) int {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderBackupsTitle(width))
+	return scrollMaxOffset(len(app.backupsLines(width)), availH)
+}
+
+// renderBackups renders the backups title bar followed by the scrollable
+// repository and snapshot list.
+func renderBackups(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderBackupsTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+	return titleBar + "\n" + renderScrollLines(app.backupsLines(width), app.backupsScrollOffset, availH)
+}
+
+// backupsLines builds the backups panel lines from the current snapshot.
+func (app *App) backupsLines(width int) []string {
+	if app.current == nil {
+		return []string{"", "  " + StyleDim.Render("Waiting for first poll...")}
+	}
+	return buildBackupsLines(app.current.SnapshotRepos, app.current.FetchedAt, app.recConfig.SnapshotMaxAge, width)
+}
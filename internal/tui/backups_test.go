@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleRepos(now time.Time) []model.RepositorySnapshots {
+	epoch := func(d time.Duration) string { return strconv.FormatInt(now.Add(-d).Unix(), 10) }
+	return []model.RepositorySnapshots{
+		{
+			Repository: client.SnapshotRepository{Name: "nightly", Type: "fs"},
+			Snapshots: []client.SnapshotInfo{
+				{ID: "snap-old", Status: "SUCCESS", StartEpoch: epoch(49 * time.Hour), EndEpoch: epoch(48 * time.Hour), Duration: "3600000", TotalShards: "10"},
+				{ID: "snap-new", Status: "PARTIAL", StartEpoch: epoch(2 * time.Hour), EndEpoch: epoch(time.Hour), Duration: "3600000", FailedShards: "2", TotalShards: "10"},
+			},
+		},
+		{Repository: client.SnapshotRepository{Name: "offsite", Type: "s3"}, Err: "connection refused"},
+	}
+}
+
+func TestBuildBackupsLines(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	out := stripANSI(strings.Join(buildBackupsLines(sampleRepos(now), now, 24*time.Hour, 120), "\n"))
+
+	assert.Contains(t, out, "nightly (fs)  2 snapshot(s)")
+	assert.Contains(t, out, "Last success: 2d00h ago")
+	assert.Contains(t, out, "2/10")
+	assert.Less(t, strings.Index(out, "snap-new"), strings.Index(out, "snap-old"), "newest snapshot first")
+	assert.Contains(t, out, "offsite (s3)")
+	assert.Contains(t, out, "Failed to list snapshots: connection refused")
+}
+
+func TestBuildBackupsLines_UnavailableAndEmpty(t *testing.T) {
+	now := time.Now()
+	assert.Contains(t, stripANSI(strings.Join(buildBackupsLines(nil, now, time.Hour, 80), "\n")), "unavailable")
+	assert.Contains(t, stripANSI(strings.Join(buildBackupsLines([]model.RepositorySnapshots{}, now, time.Hour, 80), "\n")), "No snapshot repositories")
+}
+
+func TestBuildBackupsLines_LimitsRecentSnapshots(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	repo := model.RepositorySnapshots{Repository: client.SnapshotRepository{Name: "r", Type: "fs"}}
+	for i := 0; i < backupsRecentLimit+3; i++ {
+		repo.Snapshots = append(repo.Snapshots, client.SnapshotInfo{ID: "s" + strconv.Itoa(i), Status: "SUCCESS"})
+	}
+	out := stripANSI(strings.Join(buildBackupsLines([]model.RepositorySnapshots{repo}, now, time.Hour, 120), "\n"))
+	assert.Contains(t, out, "... 3 older snapshot(s)")
+}
@@ -0,0 +1,336 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// ccrRemoteLimit caps the number of remote clusters listed above the
+// follower table.
+const ccrRemoteLimit = 6
+
+// FollowerTableModel is a sortable, paginated, searchable table of CCR
+// follower indices, refreshed on every poll.
+type FollowerTableModel struct {
+	tableModel
+	allRows     []model.FollowerRow // unfiltered source data
+	displayRows []model.FollowerRow // after filter + sort applied
+}
+
+// NewFollowerTable returns a FollowerTableModel with an 8-column layout and
+// default sort by ops lag (col 3) descending.
+func NewFollowerTable() FollowerTableModel {
+	cols := []columnDef{
+		{Title: "Follower", Width: 26, SortDesc: false},
+		{Title: "Leader", Width: 30, SortDesc: false},
+		{Title: "Shards", Width: 6, SortDesc: true},
+		{Title: "Ops Lag", Width: 10, SortDesc: true},
+		{Title: "Time Lag", Width: 9, SortDesc: true},
+		{Title: "Follow/s", Width: 10, SortDesc: true},
+		{Title: "Last Read", Width: 9, SortDesc: true},
+		{Title: "Status", Width: 8, SortDesc: true},
+	}
+	m := FollowerTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 3
+	m.sortDesc = true
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *FollowerTableModel) SetData(rows []model.FollowerRow) {
+	m.allRows = rows
+	m.displayRows = sortFollowerRows(filterFollowerRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m FollowerTableModel) Update(msg tea.Msg) (FollowerTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortFollowerRows(filterFollowerRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// followerStatus returns the replication status label of r.
+func followerStatus(r model.FollowerRow) string {
+	switch {
+	case r.FatalError != "":
+		return "FAILED"
+	case engine.FollowerStalled(r):
+		return "STALLED"
+	case r.OpsLag > 0:
+		return "LAGGING"
+	default:
+		return "OK"
+	}
+}
+
+// followerStatusRank orders statuses from healthy to broken for sorting.
+func followerStatusRank(r model.FollowerRow) int {
+	switch followerStatus(r) {
+	case "FAILED":
+		return 3
+	case "STALLED":
+		return 2
+	case "LAGGING":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortFollowerRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Index, 1=RemoteCluster:LeaderIndex, 2=Shards, 3=OpsLag, 4=TimeLag,
+//	5=FollowRate, 6=LastRead, 7=status
+//
+// col -1 means no sort (preserve order). Unknown values sort last; ties are
+// broken by index name.
+func sortFollowerRows(rows []model.FollowerRow, col int, desc bool) []model.FollowerRow {
+	out := make([]model.FollowerRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var av, bv float64
+		switch col {
+		case 0, 1:
+			cmp := strings.Compare(a.Index, b.Index)
+			if col == 1 {
+				cmp = strings.Compare(followerCellValue(a, 1), followerCellValue(b, 1))
+			}
+			if desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		case 2:
+			av, bv = float64(a.Shards), float64(b.Shards)
+		case 3:
+			av, bv = float64(a.OpsLag), float64(b.OpsLag)
+		case 4:
+			av, bv = float64(a.TimeLag), float64(b.TimeLag)
+		case 5:
+			av, bv = a.FollowRate, b.FollowRate
+		case 6:
+			av, bv = float64(a.LastRead), float64(b.LastRead)
+		case 7:
+			av, bv = float64(followerStatusRank(a)), float64(followerStatusRank(b))
+		}
+		if aSentinel, bSentinel := av < 0, bv < 0; aSentinel != bSentinel {
+			return bSentinel // sentinel always last regardless of direction
+		}
+		cmp := compareFloat64(av, bv)
+		if cmp == 0 {
+			return a.Index < b.Index
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterFollowerRows returns rows whose follower index, leader index or
+// remote cluster contains search (case-insensitive). Returns all rows when
+// search is empty.
+func filterFollowerRows(rows []model.FollowerRow, search string) []model.FollowerRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Index), lower) ||
+			strings.Contains(strings.ToLower(r.LeaderIndex), lower) ||
+			strings.Contains(strings.ToLower(r.RemoteCluster), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// followerCellValue formats a FollowerRow field for a given column index.
+func followerCellValue(r model.FollowerRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Index)
+	case 1:
+		return sanitize(r.RemoteCluster) + ":" + sanitize(r.LeaderIndex)
+	case 2:
+		return strconv.Itoa(r.Shards)
+	case 3:
+		return format.FormatNumber(r.OpsLag)
+	case 4:
+		return format.FormatAge(r.TimeLag)
+	case 5:
+		return format.FormatRate(r.FollowRate)
+	case 6:
+		return format.FormatAge(r.LastRead)
+	case 7:
+		return followerStatus(r)
+	default:
+		return ""
+	}
+}
+
+// buildRemoteLines lists the remote clusters with their connection state.
+func buildRemoteLines(app *App, width int) []string {
+	if app.current == nil {
+		return nil
+	}
+	if app.current.Remotes == nil {
+		return []string{StyleDim.Render("  Remote clusters unavailable (_remote/info failed)"), ""}
+	}
+	if len(app.current.Remotes) == 0 {
+		return []string{StyleDim.Render("  No remote clusters configured"), ""}
+	}
+	names := make([]string, 0, len(app.current.Remotes))
+	for name := range app.current.Remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{StyleDim.Render(fmt.Sprintf("  %d remote cluster(s):", len(names)))}
+	for i, name := range names {
+		if i == ccrRemoteLimit {
+			lines = append(lines, StyleDim.Render(fmt.Sprintf("    ... and %d more", len(names)-ccrRemoteLimit)))
+			break
+		}
+		r := app.current.Remotes[name]
+		state := StyleGreen.Render("● connected   ")
+		if !r.Connected {
+			state = StyleRed.Render("● disconnected")
+		}
+		var detail string
+		if r.Mode == "proxy" {
+			detail = fmt.Sprintf("proxy  %d socket(s)  %s", r.NumProxySocketsConnected, sanitize(r.ProxyAddress))
+		} else {
+			detail = fmt.Sprintf("%s  %d node(s)  %s", sanitize(r.Mode), r.NumNodesConnected, sanitize(strings.Join(r.Seeds, ",")))
+		}
+		if r.SkipUnavailable {
+			detail += "  skip_unavailable"
+		}
+		lines = append(lines, "    "+state+"  "+truncateName(fmt.Sprintf("%-20s %s", sanitize(name), detail), width-24))
+	}
+	return append(lines, "")
+}
+
+// renderCCRTitle renders the title bar for the replication screen.
+func renderCCRTitle(width int) string {
+	return renderTitleBar("Cross-Cluster Replication", "[X/esc: back  r: refresh]", width)
+}
+
+// fitFollowerTable sizes the follower table page to the screen height, less
+// the remote cluster panel.
+func (app *App) fitFollowerTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderCCRTitle(width)) - len(buildRemoteLines(app, width))
+	app.followerTable.fitHeight(availH, len(app.followerTable.displayRows))
+}
+
+// renderCCR renders the replication screen: title bar, the remote clusters
+// with their connection state, the current page of follower indices, and
+// the error detail of the follower under the cursor.
+func renderCCR(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderCCRTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.followerTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	default:
+		body = strings.Join(buildRemoteLines(app, width), "\n")
+		switch {
+		case app.current.CCRStats == nil:
+			body += "  " + StyleDim.Render("Cross-cluster replication unavailable (_ccr/stats failed)")
+		case len(m.allRows) == 0:
+			body += "  " + StyleDim.Render("No follower indices")
+		default:
+			title := fmt.Sprintf("%d follower index(es)", len(m.allRows))
+			tbl := m.renderPage(width, len(m.displayRows), "(no matching followers)",
+				func(i int) []string {
+					r := m.displayRows[i]
+					cells := make([]string, len(m.columns))
+					for col := range m.columns {
+						cells[col] = followerCellValue(r, col)
+					}
+					return cells
+				},
+				func(i, col int) lipgloss.TerminalColor {
+					r := m.displayRows[i]
+					switch col {
+					case 3, 4:
+						if r.TimeLag > time.Minute {
+							return colorYellow
+						}
+						return colorWhite
+					case 5:
+						return colorGreen
+					case 7:
+						switch followerStatus(r) {
+						case "FAILED", "STALLED":
+							return colorRed
+						case "LAGGING":
+							return colorYellow
+						default:
+							return colorGreen
+						}
+					default:
+						return colorWhite
+					}
+				})
+			body += m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+			if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+				r := m.displayRows[idx]
+				detail := fmt.Sprintf("%s: %d read exception(s)", sanitize(r.Index), r.ReadExceptions)
+				if r.FatalError != "" {
+					detail = sanitize(r.Index) + ": " + sanitize(r.FatalError)
+				}
+				body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+			}
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
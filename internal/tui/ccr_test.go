@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestFollowerStatusAndSort(t *testing.T) {
+	rows := []model.FollowerRow{
+		{Index: "a", OpsLag: 0, TimeLag: 0},
+		{Index: "b", OpsLag: 50, TimeLag: -1},
+		{Index: "c", OpsLag: 10, StalledPolls: 3, TimeLag: 5 * time.Second},
+		{Index: "d", FatalError: "boom", TimeLag: -1},
+	}
+	assert.Equal(t, "OK", followerStatus(rows[0]))
+	assert.Equal(t, "LAGGING", followerStatus(rows[1]))
+	assert.Equal(t, "STALLED", followerStatus(rows[2]))
+	assert.Equal(t, "FAILED", followerStatus(rows[3]))
+
+	out := sortFollowerRows(rows, 7, true)
+	assert.Equal(t, []string{"d", "c", "b", "a"}, []string{out[0].Index, out[1].Index, out[2].Index, out[3].Index})
+	out = sortFollowerRows(rows, 4, true)
+	assert.Equal(t, "c", out[0].Index)
+	assert.Equal(t, "d", out[3].Index, "unknown time lag sorts last")
+	assert.Equal(t, "---", followerCellValue(rows[1], 4))
+}
+
+func TestApp_CCR_ShowsRemotesAndFollowers(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	snap := &model.Snapshot{
+		Remotes: map[string]client.RemoteInfo{
+			"prod": {Connected: true, Mode: "sniff", NumNodesConnected: 3, Seeds: []string{"10.0.0.1:9300"}},
+			"eu":   {Connected: false, Mode: "proxy", ProxyAddress: "eu.example:9400"},
+		},
+		CCRStats: &client.CCRStatsResponse{},
+	}
+	app.Update(SnapshotMsg{Snapshot: snap, Followers: []model.FollowerRow{
+		{Index: "dr-logs", LeaderIndex: "logs", RemoteCluster: "prod", Shards: 2, OpsLag: 1200, TimeLag: 30 * time.Second, FollowRate: 40},
+	}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	require.True(t, app.ccrMode)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "2 remote cluster(s)")
+	assert.Contains(t, out, "● disconnected  eu")
+	assert.Contains(t, out, "eu.example:9400")
+	assert.Contains(t, out, "1 follower index(es)")
+	assert.Contains(t, out, "prod:logs")
+	assert.Contains(t, out, "1,200")
+	assert.Contains(t, out, "LAGGING")
+}
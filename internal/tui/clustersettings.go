@@ -0,0 +1,505 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+// clusterSettingRow is one cluster setting with its effective value and the
+// layer it comes from.
+type clusterSettingRow struct {
+	Key    string
+	Value  string
+	Source string // "transient", "persistent" or "default"
+}
+
+// clusterSourceRank orders setting sources so explicit overrides sort before
+// defaults.
+func clusterSourceRank(source string) int {
+	switch source {
+	case "transient":
+		return 0
+	case "persistent":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// clusterSettingRows merges the three setting layers into one row per key
+// carrying the effective value.
+func clusterSettingRows(cs *client.ClusterSettings) []clusterSettingRow {
+	if cs == nil {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(cs.Defaults)+len(cs.Persistent)+len(cs.Transient))
+	for _, layer := range []map[string]string{cs.Defaults, cs.Persistent, cs.Transient} {
+		for k := range layer {
+			keys[k] = struct{}{}
+		}
+	}
+	rows := make([]clusterSettingRow, 0, len(keys))
+	for k := range keys {
+		v, src := cs.Effective(k)
+		rows = append(rows, clusterSettingRow{Key: k, Value: v, Source: src})
+	}
+	return rows
+}
+
+// ClusterSettingsTableModel is a sortable, paginated, searchable table of
+// cluster settings.
+type ClusterSettingsTableModel struct {
+	tableModel
+	allRows     []clusterSettingRow // unfiltered source data
+	displayRows []clusterSettingRow // after filter + sort applied
+}
+
+// NewClusterSettingsTable returns a ClusterSettingsTableModel with a 3-column
+// layout and default sort by source (col 2), so transient and persistent
+// overrides come first.
+func NewClusterSettingsTable() ClusterSettingsTableModel {
+	cols := []columnDef{
+		{Title: "Setting", Width: 56, SortDesc: false},
+		{Title: "Value", Width: 32, SortDesc: false},
+		{Title: "Source", Width: 10, SortDesc: false},
+	}
+	m := ClusterSettingsTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 2
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *ClusterSettingsTableModel) SetData(rows []clusterSettingRow) {
+	m.allRows = rows
+	m.displayRows = sortClusterSettingRows(filterClusterSettingRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m ClusterSettingsTableModel) Update(msg tea.Msg) (ClusterSettingsTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortClusterSettingRows(filterClusterSettingRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortClusterSettingRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Key, 1=Value, 2=Source (transient, persistent, default)
+//
+// col -1 means no sort (preserve order). Ties are broken by key.
+func sortClusterSettingRows(rows []clusterSettingRow, col int, desc bool) []clusterSettingRow {
+	out := make([]clusterSettingRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(a.Key, b.Key)
+		case 1:
+			cmp = strings.Compare(a.Value, b.Value)
+		case 2:
+			cmp = compareInt64(int64(clusterSourceRank(a.Source)), int64(clusterSourceRank(b.Source)))
+		}
+		if cmp == 0 {
+			return a.Key < b.Key
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterClusterSettingRows returns rows whose key or value contains search
+// (case-insensitive). Returns all rows when search is empty.
+func filterClusterSettingRows(rows []clusterSettingRow, search string) []clusterSettingRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Key), lower) || strings.Contains(strings.ToLower(r.Value), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// clusterSettingCellValue formats a clusterSettingRow field for a given
+// column index.
+func clusterSettingCellValue(r clusterSettingRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Key)
+	case 1:
+		if r.Value == "" {
+			return `""`
+		}
+		return sanitize(r.Value)
+	case 2:
+		return r.Source
+	default:
+		return ""
+	}
+}
+
+// clusterSettingsLoadCmd fetches cluster settings and returns a
+// ClusterSettingsLoadedMsg. nonce is embedded in the message so the App can
+// discard stale responses.
+func clusterSettingsLoadCmd(c client.ESClient, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		cs, err := c.GetClusterSettings(ctx)
+		return ClusterSettingsLoadedMsg{Settings: cs, Err: err, Nonce: nonce}
+	}
+}
+
+// clusterSettingsUpdateCmd sends a PUT /_cluster/settings request and returns
+// a ClusterSettingsResultMsg.
+func clusterSettingsUpdateCmd(c client.ESClient, persistent, transient map[string]any, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := c.UpdateClusterSettings(ctx, persistent, transient)
+		return ClusterSettingsResultMsg{Changed: len(persistent), Err: err, Nonce: nonce}
+	}
+}
+
+// buildClusterSettingsForm creates a SettingsFormModel for the curated set of
+// dynamic cluster settings, pre-filled with their effective values.
+// nodeNames and nodeIPs are used as suggestions for allocation filters.
+func buildClusterSettingsForm(cs *client.ClusterSettings, nodeNames, nodeIPs []string) SettingsFormModel {
+	fields := []settingsField{
+		{
+			Label:       "Allocation Enable",
+			ESKey:       "cluster.routing.allocation.enable",
+			suggestions: []string{"all", "primaries", "new_primaries", "none"},
+		},
+		{
+			Label:       "Rebalance Enable",
+			ESKey:       "cluster.routing.rebalance.enable",
+			suggestions: []string{"all", "primaries", "replicas", "none"},
+		},
+		{
+			Label:       "Concurrent Rebalance",
+			ESKey:       "cluster.routing.allocation.cluster_concurrent_rebalance",
+			suggestions: []string{"2", "4", "8"},
+		},
+		{
+			Label:       "Node Concurrent Recoveries",
+			ESKey:       "cluster.routing.allocation.node_concurrent_recoveries",
+			suggestions: []string{"2", "4", "8"},
+		},
+		{
+			Label:       "Recovery Max Bytes/sec",
+			ESKey:       "indices.recovery.max_bytes_per_sec",
+			suggestions: []string{"40mb", "100mb", "250mb", "500mb"},
+		},
+		{
+			Label:       "Allocation Exclude Name",
+			ESKey:       "cluster.routing.allocation.exclude._name",
+			suggestions: nodeNames,
+		},
+		{
+			Label:       "Allocation Exclude IP",
+			ESKey:       "cluster.routing.allocation.exclude._ip",
+			suggestions: nodeIPs,
+		},
+		{
+			Label:       "Disk Threshold Enabled",
+			ESKey:       "cluster.routing.allocation.disk.threshold_enabled",
+			suggestions: []string{"true", "false"},
+		},
+		{
+			Label:       "Disk Watermark Low",
+			ESKey:       "cluster.routing.allocation.disk.watermark.low",
+			suggestions: []string{"85%", "90%", "50gb"},
+		},
+		{
+			Label:       "Disk Watermark High",
+			ESKey:       "cluster.routing.allocation.disk.watermark.high",
+			suggestions: []string{"90%", "95%", "20gb"},
+		},
+		{
+			Label:       "Disk Watermark Flood Stage",
+			ESKey:       "cluster.routing.allocation.disk.watermark.flood_stage",
+			suggestions: []string{"95%", "97%", "10gb"},
+		},
+		{
+			Label:       "Max Shards Per Node",
+			ESKey:       "cluster.max_shards_per_node",
+			suggestions: []string{"1000", "2000", "3000"},
+		},
+		{
+			Label:       "Destructive Requires Name",
+			ESKey:       "action.destructive_requires_name",
+			suggestions: []string{"true", "false"},
+		},
+	}
+
+	for i := range fields {
+		ti := textinput.New()
+		ti.CharLimit = 256
+		if cs != nil {
+			fields[i].currentVal, _ = cs.Effective(fields[i].ESKey)
+		}
+		ti.SetValue(fields[i].currentVal)
+		fields[i].input = ti
+	}
+	fields[0].input.Focus()
+
+	return SettingsFormModel{fields: fields}
+}
+
+// clusterSettingChange is one pending edit shown on the confirmation screen.
+type clusterSettingChange struct {
+	Key       string
+	Old       string
+	New       any    // nil resets the persistent value
+	Transient string // transient value that would override the edit; cleared with it
+}
+
+// pendingClusterChanges turns the form's changed fields into the persistent
+// updates to send, in form order. A key that also has a transient value is
+// reset there too, since transient settings override persistent ones.
+func pendingClusterChanges(form SettingsFormModel, cs *client.ClusterSettings) []clusterSettingChange {
+	changed := form.changedSettings()
+	var out []clusterSettingChange
+	for _, f := range form.fields {
+		v, ok := changed[f.ESKey]
+		if !ok {
+			continue
+		}
+		c := clusterSettingChange{Key: f.ESKey, Old: f.currentVal, New: v}
+		if cs != nil {
+			c.Transient = cs.Transient[f.ESKey]
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// clusterChangeMaps splits pending changes into the persistent and transient
+// request bodies.
+func clusterChangeMaps(changes []clusterSettingChange, cs *client.ClusterSettings) (persistent, transient map[string]any) {
+	persistent = make(map[string]any, len(changes))
+	transient = make(map[string]any)
+	for _, c := range changes {
+		persistent[c.Key] = c.New
+		if cs != nil {
+			if _, ok := cs.Transient[c.Key]; ok {
+				transient[c.Key] = nil
+			}
+		}
+	}
+	return persistent, transient
+}
+
+// openClusterSettings switches to the cluster settings screen and starts
+// loading the settings.
+func (app *App) openClusterSettings() tea.Cmd {
+	app.clusterSettingsNonce++
+	app.clusterSettingsMode = true
+	app.clusterSettingsLoading = true
+	app.clusterSettingsErr = ""
+	app.clusterSettings = nil
+	app.clusterSettingsEditing = false
+	app.clusterSettingsConfirm = false
+	app.clusterSettingsChanges = nil
+	app.clusterSettingsTable = NewClusterSettingsTable()
+	app.fitClusterSettingsTable()
+	return clusterSettingsLoadCmd(app.client, app.clusterSettingsNonce)
+}
+
+// reloadClusterSettings reloads the cluster settings unless a load is
+// already in flight.
+func (app *App) reloadClusterSettings() tea.Cmd {
+	if app.clusterSettingsLoading {
+		return nil
+	}
+	app.clusterSettingsNonce++
+	app.clusterSettingsLoading = true
+	return clusterSettingsLoadCmd(app.client, app.clusterSettingsNonce)
+}
+
+// openClusterSettingsEditor opens the curated editor pre-filled from the
+// loaded settings.
+func (app *App) openClusterSettingsEditor() {
+	var nodeNames, nodeIPs []string
+	for _, nr := range app.nodeRows {
+		if nr.Name != "" {
+			nodeNames = append(nodeNames, nr.Name)
+		}
+		if nr.IP != "" {
+			nodeIPs = append(nodeIPs, nr.IP)
+		}
+	}
+	app.clusterSettingsForm = buildClusterSettingsForm(app.clusterSettings, nodeNames, nodeIPs)
+	app.clusterSettingsEditing = true
+	app.clusterSettingsConfirm = false
+	app.clusterSettingsChanges = nil
+}
+
+// renderClusterSettingsTitle renders the title bar for the cluster settings
+// screen.
+func renderClusterSettingsTitle(width int) string {
+	return renderTitleBar("Cluster Settings", "[C/esc: back  e: edit  r: reload]", width)
+}
+
+// fitClusterSettingsTable sizes the cluster settings table page to the
+// screen height.
+func (app *App) fitClusterSettingsTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderClusterSettingsTitle(width))
+	app.clusterSettingsTable.fitHeight(availH, len(app.clusterSettingsTable.displayRows))
+}
+
+// renderClusterSettings renders the cluster settings screen: title bar, the
+// current page of settings, and every layer of the setting under the cursor.
+func renderClusterSettings(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderClusterSettingsTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.clusterSettingsTable
+	var body string
+	switch {
+	case app.clusterSettingsLoading && app.clusterSettings == nil:
+		body = "\n  " + StyleDim.Render("Loading cluster settings...")
+	case app.clusterSettingsErr != "":
+		body = "\n  " + StyleError.Render("Failed to load cluster settings: "+app.clusterSettingsErr)
+	default:
+		var overrides int
+		for _, r := range m.allRows {
+			if r.Source != "default" {
+				overrides++
+			}
+		}
+		title := fmt.Sprintf("%d setting(s), %d overridden", len(m.allRows), overrides)
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching settings)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = clusterSettingCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch {
+				case col == 2 && r.Source == "transient":
+					return colorYellow
+				case col == 2 && r.Source == "persistent":
+					return colorCyan
+				case r.Source == "default":
+					return colorGray
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 && app.clusterSettings != nil {
+			key := m.displayRows[idx].Key
+			layer := func(name string, vals map[string]string) string {
+				if v, ok := vals[key]; ok {
+					return name + "=" + sanitize(v)
+				}
+				return name + "=---"
+			}
+			detail := sanitize(key) + "  " + strings.Join([]string{
+				layer("transient", app.clusterSettings.Transient),
+				layer("persistent", app.clusterSettings.Persistent),
+				layer("default", app.clusterSettings.Defaults),
+			}, "  ")
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
+
+// renderClusterSettingsForm renders the curated cluster settings editor.
+func renderClusterSettingsForm(app *App) string {
+	return renderSettingsFormView(app, &app.clusterSettingsForm, "Edit Cluster Settings (persistent)",
+		"[ctrl+s: review  esc: cancel]", "  Only changed fields are sent. Clear a field to reset it to the default.")
+}
+
+// renderClusterSettingsConfirm renders the confirmation step listing each
+// pending change as old → new.
+func renderClusterSettingsConfirm(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderTitleBar("Apply Cluster Settings", "[y: apply  n/esc: back to form]", width)
+	availH := screenAvailHeight(app, titleBar)
+
+	lines := []string{
+		"",
+		fmt.Sprintf("  The following %d persistent cluster setting(s) will be changed:", len(app.clusterSettingsChanges)),
+		"",
+	}
+	for _, c := range app.clusterSettingsChanges {
+		newVal := StyleYellow.Render("(reset to default)")
+		if s, ok := c.New.(string); ok {
+			newVal = StyleGreen.Render(sanitize(s))
+		}
+		old := sanitize(c.Old)
+		if old == "" {
+			old = `""`
+		}
+		line := fmt.Sprintf("    • %s: %s → %s", sanitize(c.Key), old, newVal)
+		if c.Transient != "" {
+			line += "  " + StyleYellow.Render("(also clears transient value "+sanitize(c.Transient)+")")
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", "  "+StyleYellow.Render("Press y to apply, n or esc to return to the form."))
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
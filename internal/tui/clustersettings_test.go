@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+func sampleClusterSettings() *client.ClusterSettings {
+	return &client.ClusterSettings{
+		Persistent: map[string]string{"cluster.routing.allocation.enable": "primaries", "cluster.max_shards_per_node": "2000"},
+		Transient:  map[string]string{"cluster.routing.allocation.enable": "none"},
+		Defaults: map[string]string{
+			"cluster.routing.allocation.enable":  "all",
+			"cluster.max_shards_per_node":        "1000",
+			"indices.recovery.max_bytes_per_sec": "40mb",
+		},
+	}
+}
+
+func TestClusterSettingRows_EffectiveValue(t *testing.T) {
+	rows := sortClusterSettingRows(clusterSettingRows(sampleClusterSettings()), 2, false)
+	require.Len(t, rows, 3)
+	assert.Equal(t, clusterSettingRow{Key: "cluster.routing.allocation.enable", Value: "none", Source: "transient"}, rows[0])
+	assert.Equal(t, clusterSettingRow{Key: "cluster.max_shards_per_node", Value: "2000", Source: "persistent"}, rows[1])
+	assert.Equal(t, "default", rows[2].Source)
+	assert.Nil(t, clusterSettingRows(nil))
+}
+
+func TestFilterClusterSettingRows(t *testing.T) {
+	rows := clusterSettingRows(sampleClusterSettings())
+	assert.Len(t, filterClusterSettingRows(rows, "RECOVERY"), 1, "matches key case-insensitively")
+	assert.Len(t, filterClusterSettingRows(rows, "2000"), 1, "matches value")
+	assert.Len(t, filterClusterSettingRows(rows, ""), 3)
+}
+
+func TestPendingClusterChanges_ClearsTransient(t *testing.T) {
+	cs := sampleClusterSettings()
+	form := buildClusterSettingsForm(cs, nil, nil)
+	assert.Equal(t, "none", form.fields[0].input.Value(), "prefilled with the effective value")
+
+	form.fields[0].input.SetValue("all")
+	for i, f := range form.fields {
+		if f.ESKey == "cluster.max_shards_per_node" {
+			form.fields[i].input.SetValue("")
+		}
+	}
+	changes := pendingClusterChanges(form, cs)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "none", changes[0].Transient)
+	assert.Nil(t, changes[1].New, "cleared field resets the setting")
+
+	persistent, transient := clusterChangeMaps(changes, cs)
+	assert.Equal(t, map[string]any{"cluster.routing.allocation.enable": "all", "cluster.max_shards_per_node": nil}, persistent)
+	assert.Equal(t, map[string]any{"cluster.routing.allocation.enable": nil}, transient)
+}
+
+func TestApp_ClusterSettings_EditConfirmApply(t *testing.T) {
+	var gotPersistent, gotTransient map[string]any
+	mock := &tuiMockClient{
+		getClusterSettingsFn: func(ctx context.Context) (*client.ClusterSettings, error) {
+			return sampleClusterSettings(), nil
+		},
+		updateClusterSetFn: func(ctx context.Context, persistent, transient map[string]any) error {
+			gotPersistent, gotTransient = persistent, transient
+			return nil
+		},
+	}
+	app := NewApp(mock, 10*time.Second)
+	app.width = 160
+	app.height = 40
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	require.NotNil(t, cmd)
+	require.True(t, app.clusterSettingsMode)
+	app.Update(cmd())
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "3 setting(s), 2 overridden")
+	assert.Contains(t, out, "transient=none  persistent=primaries  default=all")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.True(t, app.clusterSettingsEditing)
+
+	// Saving without changes closes the editor without a request.
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.False(t, app.clusterSettingsEditing)
+	assert.False(t, app.clusterSettingsConfirm)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	app.clusterSettingsForm.fields[0].input.SetValue("all")
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.True(t, app.clusterSettingsConfirm)
+	out = stripANSI(app.View())
+	assert.Contains(t, out, "cluster.routing.allocation.enable: none → all")
+	assert.Contains(t, out, "also clears transient value none")
+
+	// n returns to the form with nothing sent.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.False(t, app.clusterSettingsConfirm)
+	assert.True(t, app.clusterSettingsEditing)
+	assert.Nil(t, gotPersistent)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd)
+	assert.False(t, app.clusterSettingsEditing)
+	_, cmd = app.Update(cmd())
+	assert.Equal(t, map[string]any{"cluster.routing.allocation.enable": "all"}, gotPersistent)
+	assert.Equal(t, map[string]any{"cluster.routing.allocation.enable": nil}, gotTransient)
+	assert.Equal(t, "Updated 1 cluster setting(s)", app.settingsStatus)
+	assert.NotNil(t, cmd, "applies trigger a reload")
+	assert.True(t, app.clusterSettingsLoading)
+}
+
+func TestApp_ClusterSettings_EditKeyIsSearchText(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	app.Update(ClusterSettingsLoadedMsg{Settings: sampleClusterSettings(), Nonce: app.clusterSettingsNonce})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, app.clusterSettingsEditing)
+	assert.Equal(t, "e", app.clusterSettingsTable.search)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.True(t, app.clusterSettingsEditing, "e opens the editor once the search is closed")
+}
+
+func TestApp_ClusterSettings_StaleResponseDropped(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	app.Update(ClusterSettingsLoadedMsg{Settings: sampleClusterSettings(), Nonce: app.clusterSettingsNonce - 1})
+	assert.Nil(t, app.clusterSettings)
+	assert.True(t, app.clusterSettingsLoading)
+}
@@ -0,0 +1,365 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// DataStreamTableModel is a sortable, paginated, searchable table of data
+// streams with sizes and rates aggregated over their backing indices,
+// refreshed on every poll.
+type DataStreamTableModel struct {
+	tableModel
+	allRows     []model.DataStreamRow // unfiltered source data
+	displayRows []model.DataStreamRow // after filter + sort applied
+}
+
+// NewDataStreamTable returns a DataStreamTableModel with a 9-column layout
+// and default sort by indexing rate (col 7) descending.
+func NewDataStreamTable() DataStreamTableModel {
+	cols := []columnDef{
+		{Title: "Data Stream", Width: 28, SortDesc: false},
+		{Title: "Template", Width: 20, SortDesc: false},
+		{Title: "Gen", Width: 5, SortDesc: true},
+		{Title: "Indices", Width: 7, SortDesc: true},
+		{Title: "Health", Width: 7, SortDesc: false},
+		{Title: "Total Size", Width: 10, SortDesc: true},
+		{Title: "Doc Count", Width: 12, SortDesc: true},
+		{Title: "Idx/s", Width: 8, SortDesc: true},
+		{Title: "Srch/s", Width: 8, SortDesc: true},
+	}
+	m := DataStreamTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 7
+	m.sortDesc = true
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *DataStreamTableModel) SetData(rows []model.DataStreamRow) {
+	m.allRows = rows
+	m.displayRows = sortDataStreamRows(filterDataStreamRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m DataStreamTableModel) Update(msg tea.Msg) (DataStreamTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortDataStreamRows(filterDataStreamRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// cursorRow returns the data stream under the cursor, or false when the
+// table is empty.
+func (m *DataStreamTableModel) cursorRow() (model.DataStreamRow, bool) {
+	idx := m.cursorIndex(len(m.displayRows))
+	if idx < 0 {
+		return model.DataStreamRow{}, false
+	}
+	return m.displayRows[idx], true
+}
+
+// row returns the data stream called name from the latest data, or false
+// when it no longer exists.
+func (m *DataStreamTableModel) row(name string) (model.DataStreamRow, bool) {
+	for _, r := range m.allRows {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return model.DataStreamRow{}, false
+}
+
+// sortDataStreamRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Name, 1=Template, 2=Generation, 3=IndexCount, 4=Status,
+//	5=TotalSizeBytes, 6=DocCount, 7=IndexingRate, 8=SearchRate
+//
+// col -1 means no sort (preserve order). Ties are broken by name.
+func sortDataStreamRows(rows []model.DataStreamRow, col int, desc bool) []model.DataStreamRow {
+	out := make([]model.DataStreamRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case 1:
+			cmp = strings.Compare(strings.ToLower(a.Template), strings.ToLower(b.Template))
+		case 2:
+			cmp = compareInt64(int64(a.Generation), int64(b.Generation))
+		case 3:
+			cmp = compareInt64(int64(a.IndexCount), int64(b.IndexCount))
+		case 4:
+			cmp = strings.Compare(a.Status, b.Status)
+		case 5:
+			cmp = compareInt64(a.TotalSizeBytes, b.TotalSizeBytes)
+		case 6:
+			cmp = compareInt64(a.DocCount, b.DocCount)
+		case 7:
+			if aSentinel, bSentinel := a.IndexingRate < 0, b.IndexingRate < 0; aSentinel != bSentinel {
+				return bSentinel // sentinel always last regardless of direction
+			}
+			cmp = compareFloat64(a.IndexingRate, b.IndexingRate)
+		case 8:
+			if aSentinel, bSentinel := a.SearchRate < 0, b.SearchRate < 0; aSentinel != bSentinel {
+				return bSentinel
+			}
+			cmp = compareFloat64(a.SearchRate, b.SearchRate)
+		}
+		if cmp == 0 {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterDataStreamRows returns rows whose name, template, or any backing
+// index name contains search (case-insensitive). Returns all rows when
+// search is empty.
+func filterDataStreamRows(rows []model.DataStreamRow, search string) []model.DataStreamRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		match := strings.Contains(strings.ToLower(r.Name), lower) ||
+			strings.Contains(strings.ToLower(r.Template), lower)
+		for _, idx := range r.BackingIndices {
+			if match {
+				break
+			}
+			match = strings.Contains(strings.ToLower(idx.Name), lower)
+		}
+		if match {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// dataStreamCellValue formats a DataStreamRow field for a given column index.
+func dataStreamCellValue(r model.DataStreamRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Name)
+	case 1:
+		if r.Template == "" {
+			return "---"
+		}
+		return sanitize(r.Template)
+	case 2:
+		return strconv.Itoa(r.Generation)
+	case 3:
+		return strconv.Itoa(r.IndexCount)
+	case 4:
+		if r.Status == "" {
+			return "---"
+		}
+		return sanitize(r.Status)
+	case 5:
+		return format.FormatBytes(r.TotalSizeBytes)
+	case 6:
+		return format.FormatNumber(r.DocCount)
+	case 7:
+		return format.FormatRate(r.IndexingRate)
+	case 8:
+		return format.FormatRate(r.SearchRate)
+	default:
+		return ""
+	}
+}
+
+// healthColor returns the color for a GREEN/YELLOW/RED health string.
+func healthColor(status string) lipgloss.TerminalColor {
+	switch strings.ToUpper(status) {
+	case "GREEN":
+		return colorGreen
+	case "YELLOW":
+		return colorYellow
+	case "RED":
+		return colorRed
+	default:
+		return colorWhite
+	}
+}
+
+// renderDataStreamsTitle renders the title bar for the data streams list.
+func renderDataStreamsTitle(width int) string {
+	return renderTitleBar("Data Streams", "[D/esc: back  enter: backing indices  r: refresh]", width)
+}
+
+// fitDataStreamTable sizes the data streams table page to the screen height.
+func (app *App) fitDataStreamTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderDataStreamsTitle(width))
+	app.dataStreamTable.fitHeight(availH, len(app.dataStreamTable.displayRows))
+}
+
+// renderDataStreams renders the data streams screen: title bar and the
+// current page of streams with their aggregated totals.
+func renderDataStreams(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderDataStreamsTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.dataStreamTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case app.current.DataStreams == nil:
+		body = "\n  " + StyleDim.Render("Data streams unavailable (_data_stream failed; requires ES 7.9+)")
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleDim.Render("No data streams")
+	default:
+		title := fmt.Sprintf("%d data stream(s)", len(m.allRows))
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching data streams)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = dataStreamCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				switch col {
+				case 4:
+					return healthColor(m.displayRows[i].Status)
+				case 7:
+					return colorGreen
+				case 8:
+					return colorCyan
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "  [enter: expand]") + "\n" + tbl
+		if r, ok := m.cursorRow(); ok {
+			detail := sanitize(r.Name)
+			if r.ILMPolicy != "" {
+				detail += "  ILM policy: " + sanitize(r.ILMPolicy)
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
+
+// buildDataStreamDetailLines returns the rendered lines listing the backing
+// indices of row, newest generation first with the write index marked.
+func buildDataStreamDetailLines(row model.DataStreamRow, found bool, width int) []string {
+	lines := []string{""}
+	if !found {
+		return append(lines, "  "+StyleDim.Render("Data stream no longer exists"))
+	}
+	lines = append(lines, "  "+StyleDim.Render(fmt.Sprintf("%d backing indices  generation %d  %s  %s docs",
+		row.IndexCount, row.Generation, format.FormatBytes(row.TotalSizeBytes), format.FormatNumber(row.DocCount))))
+	if row.IndexCount > len(row.BackingIndices) {
+		lines = append(lines, "  "+StyleYellow.Render(fmt.Sprintf("%d backing index(es) missing from _cat/indices (closed?)", row.IndexCount-len(row.BackingIndices))))
+	}
+	lines = append(lines, "")
+	if len(row.BackingIndices) == 0 {
+		return append(lines, "  "+StyleDim.Render("(no backing indices)"))
+	}
+
+	nameW := width - 2 - (7 + 1 + 10 + 1 + 12 + 1 + 8 + 1 + 8 + 1 + 8)
+	if nameW < 20 {
+		nameW = 20
+	}
+	lines = append(lines, StyleDim.Bold(true).Render(fmt.Sprintf("  %-*s %-7s %10s %12s %8s %8s %-8s",
+		nameW, "Backing Index", "", "Size", "Doc Count", "Idx/s", "Srch/s", "Phase")))
+	for i, idx := range row.BackingIndices {
+		marker := ""
+		if i == 0 {
+			marker = "write"
+		}
+		phase := "---"
+		if idx.LifecyclePhase != "" {
+			phase = sanitize(idx.LifecyclePhase)
+		}
+		line := fmt.Sprintf("  %-*s %-7s %10s %12s %8s %8s %-8s",
+			nameW, truncateName(sanitize(idx.Name), nameW), marker,
+			format.FormatBytes(idx.TotalSizeBytes), format.FormatNumber(idx.DocCount),
+			format.FormatRate(idx.IndexingRate), format.FormatRate(idx.SearchRate), phase)
+		if i == 0 {
+			line = StyleGreen.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderDataStreamDetailTitle renders the title bar for one stream's
+// backing index list.
+func renderDataStreamDetailTitle(name string, width int) string {
+	return renderTitleBar("Data Stream — "+sanitize(name), "[esc: back to list  ↑↓: scroll]", width)
+}
+
+// dataStreamDetailLines builds the backing index lines for the expanded
+// stream from the latest poll.
+func (app *App) dataStreamDetailLines(width int) []string {
+	row, ok := app.dataStreamTable.row(app.dataStreamTarget)
+	return buildDataStreamDetailLines(row, ok, width)
+}
+
+// dataStreamMaxOffset returns the maximum valid dataStreamScrollOffset for
+// the current app state.
+func dataStreamMaxOffset(app *App) int {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderDataStreamDetailTitle(app.dataStreamTarget, width))
+	return scrollMaxOffset(len(app.dataStreamDetailLines(width)), availH)
+}
+
+// renderDataStreamDetail renders the expanded stream's title bar followed by
+// the scrollable list of its backing indices.
+func renderDataStreamDetail(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderDataStreamDetailTitle(app.dataStreamTarget, width)
+	availH := screenAvailHeight(app, titleBar)
+	return titleBar + "\n" + renderScrollLines(app.dataStreamDetailLines(width), app.dataStreamScrollOffset, availH)
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleDataStreamRows() []model.DataStreamRow {
+	return []model.DataStreamRow{
+		{
+			Name: "logs-app", Template: "logs", Status: "GREEN", Generation: 2, IndexCount: 3,
+			TotalSizeBytes: 2048, DocCount: 20, IndexingRate: 12.5, SearchRate: model.MetricNotAvailable,
+			BackingIndices: []model.IndexRow{
+				{Name: ".ds-logs-app-000002", TotalSizeBytes: 1024, DocCount: 10, IndexingRate: 12.5, SearchRate: model.MetricNotAvailable, LifecyclePhase: "hot"},
+				{Name: ".ds-logs-app-000001", TotalSizeBytes: 1024, DocCount: 10, IndexingRate: model.MetricNotAvailable, SearchRate: model.MetricNotAvailable},
+			},
+		},
+		{Name: "metrics-db", Status: "YELLOW", Generation: 1, IndexCount: 1, IndexingRate: model.MetricNotAvailable, SearchRate: 3},
+	}
+}
+
+func TestSortDataStreamRows_RateSentinelLast(t *testing.T) {
+	for _, desc := range []bool{false, true} {
+		out := sortDataStreamRows(sampleDataStreamRows(), 7, desc)
+		assert.Equal(t, "metrics-db", out[1].Name, "desc=%v", desc)
+	}
+	out := sortDataStreamRows(sampleDataStreamRows(), 0, true)
+	assert.Equal(t, "metrics-db", out[0].Name)
+}
+
+func TestFilterDataStreamRows_MatchesBackingIndex(t *testing.T) {
+	out := filterDataStreamRows(sampleDataStreamRows(), "000001")
+	require.Len(t, out, 1)
+	assert.Equal(t, "logs-app", out[0].Name)
+	assert.Len(t, filterDataStreamRows(sampleDataStreamRows(), ""), 2)
+}
+
+func TestDataStreamCellValue(t *testing.T) {
+	rows := sampleDataStreamRows()
+	assert.Equal(t, "logs", dataStreamCellValue(rows[0], 1))
+	assert.Equal(t, "---", dataStreamCellValue(rows[1], 1))
+	assert.Equal(t, "3", dataStreamCellValue(rows[0], 3))
+	assert.Equal(t, "2.0 KB", dataStreamCellValue(rows[0], 5))
+}
+
+func TestBuildDataStreamDetailLines(t *testing.T) {
+	row := sampleDataStreamRows()[0]
+	out := stripANSI(strings.Join(buildDataStreamDetailLines(row, true, 120), "\n"))
+	assert.Contains(t, out, "3 backing indices  generation 2")
+	assert.Contains(t, out, "1 backing index(es) missing")
+	assert.Less(t, strings.Index(out, "000002"), strings.Index(out, "000001"), "newest first")
+	assert.Contains(t, out, "write")
+	assert.Contains(t, out, "hot")
+
+	gone := stripANSI(strings.Join(buildDataStreamDetailLines(model.DataStreamRow{}, false, 120), "\n"))
+	assert.Contains(t, gone, "no longer exists")
+}
+
+func TestApp_DataStreamsModeExpand(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 140
+	app.height = 40
+	snap := makeFixtureSnapshot()
+	snap.DataStreams = []client.DataStream{}
+	app.current = snap
+	app.dataStreamTable.SetData(sampleDataStreamRows())
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	require.True(t, app.dataStreamsMode)
+	assert.Contains(t, stripANSI(app.View()), "2 data stream(s)")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, app.dataStreamDetailMode)
+	assert.Equal(t, "logs-app", app.dataStreamTarget, "default sort puts the busiest stream first")
+	assert.Contains(t, stripANSI(app.View()), ".ds-logs-app-000002")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.dataStreamDetailMode)
+	assert.True(t, app.dataStreamsMode)
+
+	// Enter ends a search instead of expanding the stream under the cursor.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("metrics")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, app.dataStreamDetailMode)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, `filter="metrics"`)
+	assert.NotContains(t, out, "logs-app")
+}
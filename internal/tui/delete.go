@@ -69,13 +69,25 @@ func renderDeleteConfirm(app *App) string {
 		"",
 		"  " + StyleYellow.Render("Press y to confirm, n or esc to cancel."),
 	}
+	writeAliases := pendingDeleteWriteAliases(app)
+	if n := len(writeAliases); n > 0 {
+		warning := fmt.Sprintf("  %d index(es) are the current write index of an alias; writes through it will fail after deletion.", n)
+		footerLines = append([]string{"", StyleRed.Bold(true).Render(warning)}, footerLines...)
+	}
 
 	// Build the name list respecting available height.
 	// footerLines (confirmation prompt) takes priority: trim nameLines first,
 	// then headerLines from the bottom if needed. footerLines are never trimmed.
 	nameLines := make([]string, 0, len(app.pendingDeleteNames))
 	for _, name := range app.pendingDeleteNames {
-		nameLines = append(nameLines, "    • "+sanitize(name))
+		line := "    • " + sanitize(name)
+		if aliases := writeAliases[name]; len(aliases) > 0 {
+			for i, a := range aliases {
+				aliases[i] = sanitize(a)
+			}
+			line += "  " + StyleRed.Render("(write index of "+strings.Join(aliases, ", ")+")")
+		}
+		nameLines = append(nameLines, line)
 	}
 
 	fLen := len(footerLines)
@@ -135,3 +147,20 @@ func renderDeleteConfirm(app *App) string {
 	content := strings.Join(lines, "\n")
 	return titleBar + "\n" + content
 }
+
+// pendingDeleteWriteAliases returns, for each index pending deletion that is
+// the write index of an alias, the aliases it serves. Alias data comes from
+// the latest poll; indices not in it are omitted.
+func pendingDeleteWriteAliases(app *App) map[string][]string {
+	pending := make(map[string]struct{}, len(app.pendingDeleteNames))
+	for _, name := range app.pendingDeleteNames {
+		pending[name] = struct{}{}
+	}
+	result := make(map[string][]string)
+	for _, r := range app.indexRows {
+		if _, ok := pending[r.Name]; ok && len(r.WriteAliases) > 0 {
+			result[r.Name] = append([]string(nil), r.WriteAliases...)
+		}
+	}
+	return result
+}
@@ -11,56 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/jtsunne/epm-go/internal/client"
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
-// tuiMockClient is a minimal ESClient implementation for tui-package tests.
-type tuiMockClient struct {
-	deleteIndexFn         func(ctx context.Context, names []string) error
-	getIndexSettingsFn    func(ctx context.Context, name string) (*client.IndexSettingsValues, error)
-	updateIndexSettingsFn func(ctx context.Context, names []string, settings map[string]any) error
-}
-
-func (m *tuiMockClient) GetClusterHealth(ctx context.Context) (*client.ClusterHealth, error) {
-	return &client.ClusterHealth{}, nil
-}
-func (m *tuiMockClient) GetNodes(ctx context.Context) ([]client.NodeInfo, error) {
-	return nil, nil
-}
-func (m *tuiMockClient) GetNodeStats(ctx context.Context) (*client.NodeStatsResponse, error) {
-	return &client.NodeStatsResponse{Nodes: map[string]client.NodePerformanceStats{}}, nil
-}
-func (m *tuiMockClient) GetIndices(ctx context.Context) ([]client.IndexInfo, error) {
-	return nil, nil
-}
-func (m *tuiMockClient) GetIndexStats(ctx context.Context) (*client.IndexStatsResponse, error) {
-	return &client.IndexStatsResponse{Indices: map[string]client.IndexStatEntry{}}, nil
-}
-func (m *tuiMockClient) GetAllocation(ctx context.Context) ([]client.AllocationInfo, error) {
-	return []client.AllocationInfo{}, nil
-}
-func (m *tuiMockClient) DeleteIndex(ctx context.Context, names []string) error {
-	if m.deleteIndexFn != nil {
-		return m.deleteIndexFn(ctx, names)
-	}
-	return nil
-}
-func (m *tuiMockClient) GetIndexSettings(ctx context.Context, name string) (*client.IndexSettingsValues, error) {
-	if m.getIndexSettingsFn != nil {
-		return m.getIndexSettingsFn(ctx, name)
-	}
-	return &client.IndexSettingsValues{NumberOfReplicas: "1", RefreshInterval: "1s"}, nil
-}
-func (m *tuiMockClient) UpdateIndexSettings(ctx context.Context, names []string, settings map[string]any) error {
-	if m.updateIndexSettingsFn != nil {
-		return m.updateIndexSettingsFn(ctx, names, settings)
-	}
-	return nil
-}
-func (m *tuiMockClient) Ping(ctx context.Context) error { return nil }
-func (m *tuiMockClient) BaseURL() string                { return "http://mock:9200" }
-
 // TestDeleteCmd_Success verifies that deleteCmd returns a DeleteResultMsg with
 // nil Err and the correct Names on a successful DeleteIndex call.
 func TestDeleteCmd_Success(t *testing.T) {
@@ -393,8 +346,9 @@ func TestApp_DeleteResultMsg_Success_WhileFetching(t *testing.T) {
 }
 
 // TestApp_SnapshotMsg_PendingRefresh_TriggersNewFetch verifies that when
-// pendingRefresh is true, a SnapshotMsg: (a) does NOT clear deleteStatus,
-// (b) clears pendingRefresh, and (c) issues an immediate fetchCmd.
+// pendingRefresh is true, a SnapshotMsg from a poll that started before the
+// delete: (a) does NOT clear deleteStatus, (b) is not shown, and (c) issues
+// an immediate refresh. The next result clears pendingRefresh.
 func TestApp_SnapshotMsg_PendingRefresh_TriggersNewFetch(t *testing.T) {
 	mc := &tuiMockClient{}
 	app := NewApp(mc, 10*time.Second)
@@ -405,9 +359,45 @@ func TestApp_SnapshotMsg_PendingRefresh_TriggersNewFetch(t *testing.T) {
 	newModel, cmd := app.Update(makeFixtureMsg(snap))
 	updated := newModel.(*App)
 
-	assert.False(t, updated.pendingRefresh, "pendingRefresh must be cleared after SnapshotMsg")
+	assert.True(t, updated.pendingRefresh, "pendingRefresh stays set until a poll started after the delete lands")
 	assert.Equal(t, "Deleted 1 index(es)", updated.deleteStatus, "deleteStatus must not be cleared by stale SnapshotMsg")
+	assert.Nil(t, updated.current, "the stale snapshot must not be shown")
 	require.NotNil(t, cmd, "an immediate fetch cmd must be issued after the stale snapshot lands")
+
+	fresh := makeFixtureMsg(snap)
+	fresh.Seq = 1
+	newModel, _ = updated.Update(fresh)
+	updated = newModel.(*App)
+	assert.False(t, updated.pendingRefresh, "pendingRefresh must be cleared by the fresh SnapshotMsg")
+	assert.Same(t, snap, updated.current)
+}
+
+// TestApp_DeleteResultMsg_DuringScheduledPoll verifies that the result of a
+// scheduled poll already in flight when the delete succeeded is dropped even
+// though no refresh was requested, so it cannot bring the deleted index back.
+func TestApp_DeleteResultMsg_DuringScheduledPoll(t *testing.T) {
+	mc := &tuiMockClient{}
+	app := NewApp(mc, 10*time.Second)
+	app.fetching = false
+	before := makeFixtureSnapshot()
+	newModel, _ := app.Update(makeFixtureMsg(before))
+	app = newModel.(*App)
+
+	newModel, _ = app.Update(DeleteResultMsg{Names: []string{"my-index"}})
+	app = newModel.(*App)
+
+	// The scheduled poll started before the delete (Seq 0 <= Started()).
+	newModel, _ = app.Update(makeFixtureMsg(makeFixtureSnapshot()))
+	app = newModel.(*App)
+	assert.Same(t, before, app.current)
+	assert.Equal(t, "Deleted 1 index(es)", app.deleteStatus)
+
+	after := makeFixtureMsg(makeFixtureSnapshot())
+	after.Seq = 1
+	newModel, _ = app.Update(after)
+	app = newModel.(*App)
+	assert.Same(t, after.Snapshot, app.current)
+	assert.Empty(t, app.deleteStatus)
 }
 
 // TestRenderDeleteConfirm_UltraSmallHeight_NoOverflow verifies that at
@@ -0,0 +1,426 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// UnassignedTableModel is a sortable, paginated, searchable table of
+// unassigned shard copies.
+type UnassignedTableModel struct {
+	tableModel
+	allRows     []model.ShardRow // unfiltered source data
+	displayRows []model.ShardRow // after filter + sort applied
+}
+
+// NewUnassignedTable returns an UnassignedTableModel with a 4-column layout.
+// Rows are left unsorted so the engine order (primaries first) is kept.
+func NewUnassignedTable() UnassignedTableModel {
+	cols := []columnDef{
+		{Title: "Index", Width: 36, SortDesc: false},
+		{Title: "Shard", Width: 6, SortDesc: false},
+		{Title: "Pri/Rep", Width: 8, SortDesc: false},
+		{Title: "Reason", Width: 24, SortDesc: false},
+	}
+	m := UnassignedTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *UnassignedTableModel) SetData(rows []model.ShardRow) {
+	m.allRows = rows
+	m.displayRows = sortUnassignedRows(filterShardRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m UnassignedTableModel) Update(msg tea.Msg) (UnassignedTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortUnassignedRows(filterShardRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// cursorRow returns the shard under the cursor, or false when the table is empty.
+func (m *UnassignedTableModel) cursorRow() (model.ShardRow, bool) {
+	idx := m.cursorIndex(len(m.displayRows))
+	if idx < 0 {
+		return model.ShardRow{}, false
+	}
+	return m.displayRows[idx], true
+}
+
+// sortUnassignedRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Index, 1=Shard, 2=Primary, 3=UnassignedReason
+//
+// col -1 means no sort (preserve order). Ties are broken by index, then shard.
+func sortUnassignedRows(rows []model.ShardRow, col int, desc bool) []model.ShardRow {
+	out := make([]model.ShardRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Index), strings.ToLower(b.Index))
+		case 1:
+			cmp = compareInt64(int64(a.Shard), int64(b.Shard))
+		case 2:
+			// Primaries sort before replicas in ascending order.
+			cmp = compareInt64(boolToInt64(b.Primary), boolToInt64(a.Primary))
+		case 3:
+			cmp = strings.Compare(a.UnassignedReason, b.UnassignedReason)
+		}
+		if cmp == 0 {
+			if a.Index != b.Index {
+				return a.Index < b.Index
+			}
+			return a.Shard < b.Shard
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// unassignedCellValue formats a ShardRow field for a given column index.
+func unassignedCellValue(r model.ShardRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Index)
+	case 1:
+		if r.Shard < 0 {
+			return "---"
+		}
+		return strconv.Itoa(r.Shard)
+	case 2:
+		return priRepLabel(r.Primary)
+	case 3:
+		return sanitize(r.UnassignedReason)
+	default:
+		return ""
+	}
+}
+
+// deciderLabel returns a readable name for an allocation decider. Unknown
+// deciders are shown by their ES name.
+func deciderLabel(decider string) string {
+	switch decider {
+	case "disk_threshold":
+		return "Disk watermark"
+	case "same_shard":
+		return "Same shard on node"
+	case "filter":
+		return "Allocation filter rules"
+	case "awareness":
+		return "Allocation awareness"
+	case "shards_limit":
+		return "Shards per node limit"
+	case "max_retry":
+		return "Max allocation retries"
+	case "throttling":
+		return "Recovery throttling"
+	case "enable":
+		return "Allocation disabled"
+	case "node_version":
+		return "Node version"
+	case "data_tier":
+		return "Data tier preference"
+	case "replica_after_primary_active":
+		return "Primary not yet active"
+	case "snapshot_in_progress":
+		return "Snapshot in progress"
+	case "restore_in_progress":
+		return "Restore in progress"
+	case "node_shutdown":
+		return "Node shutting down"
+	case "cluster_rebalance":
+		return "Cluster rebalance"
+	default:
+		return decider
+	}
+}
+
+// unassignedLoadCmd fetches _cat/shards and returns an UnassignedLoadedMsg.
+// nonce is embedded in the message so the App can discard stale responses.
+func unassignedLoadCmd(c client.ESClient, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shards, err := c.GetShards(ctx)
+		return UnassignedLoadedMsg{Shards: shards, Err: err, Nonce: nonce}
+	}
+}
+
+// explainLoadCmd calls _cluster/allocation/explain for one shard copy and
+// returns an ExplainLoadedMsg.
+func explainLoadCmd(c client.ESClient, shard model.ShardRow, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		exp, err := c.GetAllocationExplain(ctx, shard.Index, shard.Shard, shard.Primary)
+		return ExplainLoadedMsg{Explain: exp, Err: err, Nonce: nonce}
+	}
+}
+
+// renderExplainTitle renders the title bar for the unassigned shard list.
+func renderExplainTitle(width int) string {
+	return renderTitleBar("Unassigned Shards — Allocation Explain", "[enter: explain  r: reload  x/esc: back]", width)
+}
+
+// fitExplainTable sizes the unassigned shard table page to the screen height.
+func (app *App) fitExplainTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderExplainTitle(width))
+	app.explainTable.fitHeight(availH, len(app.explainTable.displayRows))
+}
+
+// renderExplainList renders the unassigned shard list screen.
+func renderExplainList(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderExplainTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.explainTable
+	var body string
+	switch {
+	case app.explainLoading && m.allRows == nil:
+		body = "\n  " + StyleDim.Render("Loading shards...")
+	case app.explainErr != "" && m.allRows == nil:
+		body = "\n  " + StyleError.Render("Failed to load shards: "+app.explainErr)
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleGreen.Bold(true).Render("No unassigned shards — every shard copy is allocated")
+	default:
+		primaries := 0
+		for _, r := range m.allRows {
+			if r.Primary {
+				primaries++
+			}
+		}
+		title := fmt.Sprintf("%d unassigned (%d primary)", len(m.allRows), primaries)
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching shards)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = unassignedCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				if col == 2 {
+					if m.displayRows[i].Primary {
+						return colorRed
+					}
+					return colorYellow
+				}
+				return colorWhite
+			})
+		body = m.renderTitle(title, len(m.displayRows), "  [enter: explain]") + "\n" + tbl
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
+
+// buildExplainLines returns the full list of rendered content lines for the
+// allocation explain detail view. Extracted so the same logic can be used both
+// during rendering and when computing the maximum scroll offset in Update().
+func buildExplainLines(app *App, width int) []string {
+	lines := []string{""}
+	switch {
+	case app.explainDetailLoading:
+		return append(lines, "  "+StyleDim.Render("Asking the master to explain allocation..."))
+	case app.explainDetailErr != "":
+		return append(lines, "  "+StyleError.Render("Allocation explain failed: "+app.explainDetailErr))
+	case app.explainDetail == nil:
+		return lines
+	}
+
+	exp := app.explainDetail
+	wrapW := width - 6
+	addWrapped := func(indent, text string) {
+		for _, l := range strings.Split(wrapText(sanitize(text), wrapW), "\n") {
+			lines = append(lines, indent+l)
+		}
+	}
+
+	lines = append(lines, "  "+StyleDim.Bold(true).Render(fmt.Sprintf("%s[%d] %s — %s",
+		sanitize(exp.Index), exp.Shard, priRepLabel(exp.Primary), sanitize(exp.CurrentState))))
+	if ui := exp.UnassignedInfo; ui != nil {
+		info := "Reason: " + sanitize(ui.Reason)
+		if ui.At != "" {
+			info += "  since " + sanitize(ui.At)
+		}
+		if ui.LastAllocationStatus != "" {
+			info += "  last attempt: " + sanitize(ui.LastAllocationStatus)
+		}
+		lines = append(lines, "  "+info)
+		if ui.Details != "" {
+			addWrapped("  ", "Details: "+ui.Details)
+		}
+	}
+
+	lines = append(lines, "")
+	verdict := strings.ToUpper(sanitize(exp.CanAllocate))
+	switch exp.CanAllocate {
+	case "yes":
+		verdict = StyleGreen.Bold(true).Render(verdict)
+	case "no":
+		verdict = StyleRed.Bold(true).Render(verdict)
+	default:
+		verdict = StyleYellow.Bold(true).Render(verdict)
+	}
+	lines = append(lines, "  Can allocate: "+verdict)
+	if exp.AllocateExplanation != "" {
+		addWrapped("  ", exp.AllocateExplanation)
+	}
+
+	if summaries := engine.SummarizeDeciders(exp); len(summaries) > 0 {
+		lines = append(lines, "", "  "+StyleDim.Bold(true).Underline(true).Render("Blocking deciders"))
+		for _, s := range summaries {
+			badge := StyleRed.Bold(true).Render("[NO]      ")
+			if s.Decision != "NO" {
+				badge = StyleYellow.Bold(true).Render("[THROTTLE]")
+			}
+			nodes := make([]string, len(s.Nodes))
+			for i, n := range s.Nodes {
+				nodes[i] = sanitize(n)
+			}
+			head := fmt.Sprintf("%s (%s) — %d/%d node(s): %s", deciderLabel(s.Decider), sanitize(s.Decider),
+				len(s.Nodes), len(exp.NodeAllocationDecisions), strings.Join(nodes, ", "))
+			lines = append(lines, "  "+badge+" "+truncateName(head, width-14))
+			if s.Explanation != "" {
+				addWrapped("      ", s.Explanation)
+			}
+		}
+	}
+
+	if len(exp.NodeAllocationDecisions) > 0 {
+		lines = append(lines, "", "  "+StyleDim.Bold(true).Underline(true).Render("Per-node decisions"))
+		for _, nd := range exp.NodeAllocationDecisions {
+			name := nd.NodeName
+			if name == "" {
+				name = nd.NodeID
+			}
+			decision := sanitize(nd.NodeDecision)
+			if nd.NodeDecision == "yes" {
+				decision = StyleGreen.Render(decision)
+			} else {
+				decision = StyleYellow.Render(decision)
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %s", sanitize(name), decision))
+			for _, d := range nd.Deciders {
+				if strings.EqualFold(d.Decision, "YES") {
+					continue
+				}
+				addWrapped("      ", fmt.Sprintf("%s %s: %s", strings.ToUpper(d.Decision), deciderLabel(d.Decider), d.Explanation))
+			}
+		}
+	}
+	return lines
+}
+
+// renderExplainDetailTitle renders the title bar for the explain detail view.
+func renderExplainDetailTitle(app *App, width int) string {
+	t := app.explainTarget
+	title := fmt.Sprintf("Allocation Explain — %s[%d] %s", sanitize(t.Index), t.Shard, priRepLabel(t.Primary))
+	return renderTitleBar(title, "[esc: back to list  r: reload  ↑↓: scroll]", width)
+}
+
+// explainMaxOffset returns the maximum valid explainScrollOffset for the
+// current app state.
+func explainMaxOffset(app *App) int {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderExplainDetailTitle(app, width))
+	return scrollMaxOffset(len(buildExplainLines(app, width)), availH)
+}
+
+// renderExplainDetail renders the explain detail title bar followed by the
+// scrollable decider breakdown.
+func renderExplainDetail(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderExplainDetailTitle(app, width)
+	availH := screenAvailHeight(app, titleBar)
+	lines := buildExplainLines(app, width)
+	return titleBar + "\n" + renderScrollLines(lines, app.explainScrollOffset, availH)
+}
+
+// openExplain switches to the unassigned shard list and starts loading it.
+// fromAnalytics records whether closing the list returns to Analytics.
+func (app *App) openExplain(fromAnalytics bool) tea.Cmd {
+	app.explainNonce++
+	app.explainMode = true
+	app.explainFromAnalytics = fromAnalytics
+	app.explainLoading = true
+	app.explainErr = ""
+	app.explainDetailMode = false
+	app.explainDetail = nil
+	app.explainTable = NewUnassignedTable()
+	app.fitExplainTable()
+	return unassignedLoadCmd(app.client, app.explainNonce)
+}
+
+// reloadUnassigned reloads the unassigned shard list unless a load is
+// already in flight.
+func (app *App) reloadUnassigned() tea.Cmd {
+	if app.explainLoading {
+		return nil
+	}
+	app.explainNonce++
+	app.explainLoading = true
+	return unassignedLoadCmd(app.client, app.explainNonce)
+}
+
+// closeExplain leaves the explain view, returning to Analytics when it was
+// opened from there.
+func (app *App) closeExplain() {
+	app.explainMode = false
+	app.explainDetailMode = false
+	app.explainDetail = nil
+	if app.explainFromAnalytics {
+		app.analyticsMode = true
+	}
+	app.explainFromAnalytics = false
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleUnassignedShards() []client.ShardInfo {
+	return []client.ShardInfo{
+		{Index: "logs", Shard: "0", PriRep: "p", State: "STARTED"},
+		{Index: "logs", Shard: "0", PriRep: "r", State: "UNASSIGNED", UnassignedReason: "NODE_LEFT"},
+		{Index: "metrics", Shard: "2", PriRep: "p", State: "UNASSIGNED", UnassignedReason: "ALLOCATION_FAILED"},
+	}
+}
+
+func sampleExplain() *client.AllocationExplain {
+	return &client.AllocationExplain{
+		Index: "metrics", Shard: 2, Primary: true, CurrentState: "unassigned",
+		UnassignedInfo:      &client.UnassignedInfo{Reason: "ALLOCATION_FAILED", LastAllocationStatus: "no"},
+		CanAllocate:         "no",
+		AllocateExplanation: "cannot allocate because allocation is not permitted to any of the nodes",
+		NodeAllocationDecisions: []client.NodeAllocationDecision{
+			{NodeName: "es-1", NodeDecision: "no", Deciders: []client.AllocationDecider{
+				{Decider: "disk_threshold", Decision: "NO", Explanation: "the node is above the high watermark"},
+			}},
+			{NodeName: "es-2", NodeDecision: "no", Deciders: []client.AllocationDecider{
+				{Decider: "disk_threshold", Decision: "NO", Explanation: "the node is above the high watermark"},
+				{Decider: "filter", Decision: "YES", Explanation: "node passes include/exclude/require filters"},
+			}},
+		},
+	}
+}
+
+// openExplainWithShards opens the explain list and delivers sample shards.
+func openExplainWithShards(t *testing.T, app *App) {
+	t.Helper()
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.NotNil(t, cmd, "x should return a shard load command")
+	require.True(t, app.explainMode)
+	app.Update(UnassignedLoadedMsg{Shards: sampleUnassignedShards(), Nonce: app.explainNonce})
+}
+
+func TestUnassignedLoadCmd(t *testing.T) {
+	mc := &tuiMockClient{
+		getShardsFn: func(context.Context) ([]client.ShardInfo, error) {
+			return sampleUnassignedShards(), nil
+		},
+	}
+	msg := unassignedLoadCmd(mc, 2)()
+	result, ok := msg.(UnassignedLoadedMsg)
+	require.True(t, ok, "expected UnassignedLoadedMsg, got %T", msg)
+	assert.Len(t, result.Shards, 3)
+	assert.Equal(t, 2, result.Nonce)
+}
+
+func TestExplainLoadCmd_PassesShardIdentity(t *testing.T) {
+	var gotIndex string
+	var gotShard int
+	var gotPrimary bool
+	mc := &tuiMockClient{
+		allocExplainFn: func(_ context.Context, index string, shard int, primary bool) (*client.AllocationExplain, error) {
+			gotIndex, gotShard, gotPrimary = index, shard, primary
+			return sampleExplain(), nil
+		},
+	}
+	msg := explainLoadCmd(mc, model.ShardRow{Index: "metrics", Shard: 2, Primary: true}, 1)()
+	result, ok := msg.(ExplainLoadedMsg)
+	require.True(t, ok, "expected ExplainLoadedMsg, got %T", msg)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "metrics", gotIndex)
+	assert.Equal(t, 2, gotShard)
+	assert.True(t, gotPrimary)
+}
+
+func TestApp_Explain_ListsUnassignedPrimariesFirst(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openExplainWithShards(t, app)
+
+	require.Len(t, app.explainTable.displayRows, 2, "only UNASSIGNED shards are listed")
+	assert.Equal(t, "metrics", app.explainTable.displayRows[0].Index, "primaries sort first")
+	view := renderExplainList(app)
+	assert.Contains(t, view, "2 unassigned (1 primary)")
+	assert.Contains(t, view, "ALLOCATION_FAILED")
+}
+
+func TestApp_Explain_SearchAndEscape(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openExplainWithShards(t, app)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	require.True(t, app.explainTable.searching)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.True(t, app.explainMode, "x types into the open search input")
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "x", app.explainTable.search)
+	assert.False(t, app.explainDetailMode, "enter applies the filter, not the detail view")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, app.explainMode, "esc clears the filter first")
+	assert.Empty(t, app.explainTable.search)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.explainMode)
+}
+
+func TestApp_Explain_NoUnassigned(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	app.Update(UnassignedLoadedMsg{Shards: []client.ShardInfo{{Index: "a", Shard: "0", PriRep: "p", State: "STARTED"}}, Nonce: app.explainNonce})
+	assert.Contains(t, renderExplainList(app), "No unassigned shards")
+}
+
+func TestApp_Explain_LoadError(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	app.Update(UnassignedLoadedMsg{Err: errors.New("forbidden"), Nonce: app.explainNonce})
+	assert.Contains(t, renderExplainList(app), "Failed to load shards: forbidden")
+}
+
+func TestApp_Explain_EnterOpensDetail(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openExplainWithShards(t, app)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd, "enter should return an explain command")
+	assert.True(t, app.explainDetailMode)
+	assert.Equal(t, "metrics", app.explainTarget.Index)
+	assert.Contains(t, renderExplainDetail(app), "Asking the master")
+
+	// Stale detail responses are dropped.
+	app.Update(ExplainLoadedMsg{Explain: sampleExplain(), Nonce: app.explainDetailNonce - 1})
+	assert.Nil(t, app.explainDetail)
+
+	app.Update(ExplainLoadedMsg{Explain: sampleExplain(), Nonce: app.explainDetailNonce})
+	view := renderExplainDetail(app)
+	assert.Contains(t, view, "Can allocate")
+	assert.Contains(t, view, "Disk watermark (disk_threshold) — 2/2 node(s): es-1, es-2")
+	assert.Contains(t, view, "the node is above the high watermark")
+	assert.NotContains(t, view, "node passes include/exclude/require filters", "YES deciders are omitted")
+
+	// esc returns to the list, a second esc closes the view.
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.explainDetailMode)
+	assert.True(t, app.explainMode)
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.explainMode)
+}
+
+func TestApp_Explain_DetailError(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openExplainWithShards(t, app)
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(ExplainLoadedMsg{Err: errors.New("no such shard"), Nonce: app.explainDetailNonce})
+	assert.Contains(t, renderExplainDetail(app), "Allocation explain failed: no such shard")
+}
+
+func TestApp_Explain_FromAnalyticsReturnsToAnalytics(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.analyticsMode = true
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.NotNil(t, cmd)
+	assert.True(t, app.explainMode)
+	assert.False(t, app.analyticsMode)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.explainMode)
+	assert.True(t, app.analyticsMode, "closing explain should return to analytics")
+}
+
+func TestBuildAnalyticsLines_ShowsExplainHint(t *testing.T) {
+	recs := []model.Recommendation{{
+		Severity: model.SeverityCritical,
+		Category: model.CategoryShardHealth,
+		Title:    "Cluster status RED",
+		Link:     model.LinkUnassignedShards,
+	}}
+	joined := ""
+	for _, l := range buildAnalyticsLines(recs, 120) {
+		joined += l + "\n"
+	}
+	assert.Contains(t, joined, "press x to explain unassigned shards")
+}
+
+func TestRecommendationLinkHint(t *testing.T) {
+	assert.Empty(t, recommendationLinkHint(model.LinkNone))
+	assert.Contains(t, recommendationLinkHint(model.LinkMapping), "press m")
+	assert.Contains(t, recommendationLinkHint(model.LinkZones), "press z")
+	assert.Contains(t, recommendationLinkHint(model.LinkPipelines), "press i")
+	assert.Contains(t, recommendationLinkHint(model.LinkReplication), "press X")
+	assert.Contains(t, recommendationLinkHint(model.LinkNodeResources), "press n")
+}
+
+func TestDeciderLabel(t *testing.T) {
+	assert.Equal(t, "Disk watermark", deciderLabel("disk_threshold"))
+	assert.Equal(t, "Same shard on node", deciderLabel("same_shard"))
+	assert.Equal(t, "some_new_decider", deciderLabel("some_new_decider"))
+}
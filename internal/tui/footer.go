@@ -1,7 +1,7 @@
 package tui
 
 // renderFooter renders the key binding help footer at full terminal width.
-// When app.deleteStatus or app.settingsStatus is set, it is shown instead of
+// When app.deleteStatus, app.settingsStatus or app.tasksStatus is set, it is shown instead of
 // the normal help text. When app.showHelp is true, shows all key bindings.
 func renderFooter(app *App) string {
 	width := app.width
@@ -20,6 +20,12 @@ func renderFooter(app *App) string {
 		}
 		return StyleGreen.Width(width).Render(app.settingsStatus)
 	}
+	if app.tasksStatus != "" {
+		if app.tasksStatusErr {
+			return StyleError.Width(width).Render(app.tasksStatus)
+		}
+		return StyleGreen.Width(width).Render(app.tasksStatus)
+	}
 	text := "? for help"
 	if app.showHelp {
 		text = helpText
@@ -6,6 +6,10 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
 )
 
 // sanitize removes ANSI escape sequences and ASCII control characters from a
@@ -128,11 +132,88 @@ func retryCountdown(nextRetryAt time.Time) string {
 	return fmt.Sprintf("Retrying in %ds... (r: retry now)", secs)
 }
 
+// restartBadge returns the header badge for node restarts detected within
+// engine.RestartRetention: the node name and how long ago it started for a
+// single restart, or the count otherwise. Returns "" when there are none.
+func restartBadge(restarts []model.NodeRestart, now time.Time) string {
+	switch len(restarts) {
+	case 0:
+		return ""
+	case 1:
+		ago := now.Sub(restarts[0].StartedAt).Truncate(time.Second)
+		return StyleYellow.Render(fmt.Sprintf("↻ %s restarted %s ago", sanitize(restarts[0].Name), format.FormatAge(ago)))
+	default:
+		return StyleYellow.Render(fmt.Sprintf("↻ %d nodes restarted", len(restarts)))
+	}
+}
+
+// mixedVersionBadge returns the header warning shown while the cluster runs
+// more than one Elasticsearch version, listing the versions oldest first.
+// Returns "" when all nodes report the same version.
+func mixedVersionBadge(nodeRows []model.NodeRow) string {
+	groups := engine.VersionGroups(nodeRows)
+	if len(groups) < 2 {
+		return ""
+	}
+	versions := make([]string, len(groups))
+	for i, g := range groups {
+		versions[i] = sanitize(g.Version)
+	}
+	return StyleYellow.Render("⚠ mixed versions " + strings.Join(versions, "/"))
+}
+
+// degradedBadge returns the header warning listing the core sections whose
+// request failed this poll, each with the age of the data still shown for
+// it, or "no data" when it was never fetched. Returns "" when every section
+// is fresh.
+func degradedBadge(snap *model.Snapshot, now time.Time) string {
+	secs := snap.Degraded()
+	if len(secs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(secs))
+	for i, sec := range secs {
+		parts[i] = sec.String() + " (" + staleAge(snap, sec, now) + ")"
+	}
+	return StyleYellow.Render("⚠ stale: " + strings.Join(parts, ", "))
+}
+
+// staleAge returns how old the data held for sec is, or "no data" when the
+// section has never been fetched.
+func staleAge(snap *model.Snapshot, sec model.Section, now time.Time) string {
+	fetched := snap.Sections[sec].FetchedAt
+	if fetched.IsZero() {
+		return "no data"
+	}
+	return format.FormatAge(now.Sub(fetched).Truncate(time.Second))
+}
+
+// staleLabel returns the table title suffix shown while any of secs is
+// stale, with the age of the oldest data. Returns "" when all are fresh.
+func staleLabel(snap *model.Snapshot, now time.Time, secs ...model.Section) string {
+	var oldest model.Section
+	found := false
+	for _, sec := range secs {
+		if !snap.Stale(sec) {
+			continue
+		}
+		if !found || snap.Sections[sec].FetchedAt.Before(snap.Sections[oldest].FetchedAt) {
+			oldest = sec
+		}
+		found = true
+	}
+	if !found {
+		return ""
+	}
+	return " (stale " + staleAge(snap, oldest, now) + ")"
+}
+
 // renderHeader renders the top header bar with cluster name, status, and timing info.
 //
 // Layout:
 //   left:   cluster name (or "Connecting to <URL>..." on first connect)
-//   center: colored "● STATUS" indicator (or "● DISCONNECTED  <error>" when offline)
+//   center: colored "● STATUS" indicator plus the master queue, recovery
+//           node restart, mixed version and stale section badges (or "● DISCONNECTED  <error>" when offline)
 //   right:  "Last: HH:MM:SS  Poll: Ns" (or "Press r to retry" when offline)
 func renderHeader(app *App) string {
 	width := app.width
@@ -187,6 +268,21 @@ func renderHeader(app *App) string {
 				status = "UNKNOWN"
 			}
 			center = StatusStyle(app.current.Health.Status).Render("● " + status)
+			if badge := pendingTasksBadge(app.current.PendingTasks); badge != "" {
+				center += "  " + badge
+			}
+			if badge := recoveryBadge(app.recoveryRows); badge != "" {
+				center += "  " + badge
+			}
+			if badge := restartBadge(app.current.Restarts, app.current.FetchedAt); badge != "" {
+				center += "  " + badge
+			}
+			if badge := mixedVersionBadge(app.nodeRows); badge != "" {
+				center += "  " + badge
+			}
+			if badge := degradedBadge(app.current, app.current.FetchedAt); badge != "" {
+				center += "  " + badge
+			}
 
 			lastStr := app.lastUpdated.Format("15:04:05")
 			right = StyleDim.Render(fmt.Sprintf("Last: %s  Poll: %s", lastStr, formatDuration(app.pollInterval)))
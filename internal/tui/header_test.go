@@ -8,6 +8,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/jtsunne/epm-go/internal/model"
 )
 
 func TestClassifyError(t *testing.T) {
@@ -77,7 +79,7 @@ func TestApp_FetchErrorSetsNextRetryAt(t *testing.T) {
 	app := NewApp(nil, 10*time.Second)
 
 	before := time.Now()
-	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("connection refused")})
+	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("connection refused"), Fails: 1})
 	after := time.Now()
 	updated := newModel.(*App)
 
@@ -90,7 +92,7 @@ func TestApp_SnapshotMsgClearsNextRetryAt(t *testing.T) {
 	app := NewApp(nil, 10*time.Second)
 
 	// First put app into disconnected state with a retry scheduled.
-	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("timeout")})
+	newModel, _ := app.Update(FetchErrorMsg{Err: errors.New("timeout"), Fails: 1})
 	app = newModel.(*App)
 	assert.False(t, app.nextRetryAt.IsZero())
 
@@ -212,6 +214,32 @@ func TestRenderHeader_DisconnectedWidth60(t *testing.T) {
 	assert.Equal(t, 60, lipgloss.Width(result), "disconnected header must fill terminal width exactly")
 }
 
+func TestRestartBadge(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	one := []model.NodeRestart{{NodeID: "n1", Name: "es-1", StartedAt: now.Add(-90 * time.Second)}}
+	two := append(one, model.NodeRestart{NodeID: "n2", Name: "es-2", StartedAt: now.Add(-5 * time.Minute)})
+
+	assert.Empty(t, restartBadge(nil, now))
+	assert.Equal(t, "↻ es-1 restarted 1m30s ago", stripANSI(restartBadge(one, now)))
+	assert.Equal(t, "↻ 2 nodes restarted", stripANSI(restartBadge(two, now)))
+}
+
+func TestRenderHeader_ShowsRestartBadge(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 120
+	app.connState = stateConnected
+	snap := makeFixtureSnapshot()
+	snap.Health.ClusterName = "prod"
+	snap.Health.Status = "green"
+	snap.FetchedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	snap.Restarts = []model.NodeRestart{{NodeID: "n1", Name: "es-1", StartedAt: snap.FetchedAt.Add(-30 * time.Second)}}
+	app.current = snap
+
+	result := renderHeader(app)
+	assert.Contains(t, stripANSI(result), "↻ es-1 restarted 30.0s ago")
+	assert.Equal(t, 120, lipgloss.Width(result))
+}
+
 func TestFormatDuration(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -233,3 +261,50 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestMixedVersionBadge(t *testing.T) {
+	assert.Empty(t, mixedVersionBadge(nil))
+	assert.Empty(t, mixedVersionBadge([]model.NodeRow{{Name: "a", Version: "8.12.0"}, {Name: "b", Version: "8.12.0"}, {Name: "c"}}))
+	assert.Equal(t, "⚠ mixed versions 7.17.9/8.12.0", stripANSI(mixedVersionBadge([]model.NodeRow{
+		{Name: "a", Version: "8.12.0"},
+		{Name: "b", Version: "7.17.9"},
+	})))
+}
+
+func TestDegradedBadge(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	failed := errors.New("timeout")
+	snap := &model.Snapshot{Sections: map[model.Section]model.SectionState{
+		model.SectionHealth:     {FetchedAt: now},
+		model.SectionNodes:      {Err: failed},
+		model.SectionIndexStats: {Err: failed, FetchedAt: now.Add(-90 * time.Second)},
+	}}
+
+	assert.Empty(t, degradedBadge(nil, now))
+	assert.Empty(t, degradedBadge(&model.Snapshot{}, now))
+	assert.Equal(t, "⚠ stale: nodes (no data), index stats (1m30s)", stripANSI(degradedBadge(snap, now)))
+
+	assert.Empty(t, staleLabel(snap, now, model.SectionHealth, model.SectionNodeStats))
+	assert.Equal(t, " (stale 1m30s)", staleLabel(snap, now, model.SectionIndices, model.SectionIndexStats))
+	assert.Equal(t, " (stale no data)", staleLabel(snap, now, model.SectionNodes, model.SectionIndexStats))
+}
+
+func TestRenderHeader_ShowsDegradedBadge(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 120
+	app.connState = stateConnected
+	snap := makeFixtureSnapshot()
+	snap.Health.ClusterName = "prod"
+	snap.Health.Status = "green"
+	snap.FetchedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	snap.Sections = map[model.Section]model.SectionState{
+		model.SectionNodeStats: {Err: errors.New("timeout"), FetchedAt: snap.FetchedAt.Add(-20 * time.Second)},
+	}
+	app.current = snap
+
+	result := renderHeader(app)
+	assert.Contains(t, stripANSI(result), "⚠ stale: node stats (20.0s)")
+	assert.Equal(t, 120, lipgloss.Width(result))
+	assert.Contains(t, stripANSI(app.nodeTable.renderTable(app)), "Node Statistics (stale 20.0s)")
+	assert.NotContains(t, stripANSI(app.indexTable.renderTable(app)), "stale")
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
+)
+
+// hotThreadsLoadCmd fetches the hot threads report for nodeID and returns a
+// HotThreadsLoadedMsg. nonce is embedded in the message so the App can
+// discard stale responses.
+func hotThreadsLoadCmd(c client.ESClient, nodeID string, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		text, err := c.GetHotThreads(ctx, nodeID)
+		return HotThreadsLoadedMsg{NodeID: nodeID, Text: text, Err: err, Nonce: nonce}
+	}
+}
+
+// buildHotThreadsLines returns the full list of rendered content lines for
+// the hot threads pane: the raw report, or threads grouped by top stack
+// frame when grouped is true. Extracted so the same logic can be used both
+// during rendering and when computing the maximum scroll offset in Update().
+func buildHotThreadsLines(app *App, width int) []string {
+	lines := []string{""}
+	switch {
+	case app.hotThreadsLoading && app.hotThreadsText == "":
+		return append(lines, "  "+StyleDim.Render("Loading hot threads..."))
+	case app.hotThreadsErr != "":
+		return append(lines, "  "+StyleError.Render("Failed to load hot threads: "+app.hotThreadsErr))
+	}
+
+	if app.hotThreadsGrouped {
+		groups := engine.GroupHotThreads(engine.ParseHotThreads(app.hotThreadsText))
+		if len(groups) == 0 {
+			return append(lines, "  "+StyleDim.Render("No busy threads to group"))
+		}
+		const cpuW, thrW = 8, 8
+		lines = append(lines, StyleDim.Bold(true).Render(fmt.Sprintf("  %*s %*s  %s", cpuW, "CPU%", thrW, "Threads", "Top Frame")))
+		frameW := width - 2 - cpuW - 1 - thrW - 2
+		for _, g := range groups {
+			row := fmt.Sprintf("  %*s %*d  %s", cpuW, fmt.Sprintf("%.1f%%", g.CPUPercent), thrW, g.Threads, truncateName(sanitize(g.TopFrame), frameW))
+			if g.CPUPercent >= 50 {
+				row = StyleYellow.Render(row)
+			}
+			lines = append(lines, row)
+		}
+		return lines
+	}
+
+	text := strings.TrimRight(app.hotThreadsText, "\n")
+	if strings.TrimSpace(text) == "" {
+		return append(lines, "  "+StyleGreen.Render("No hot threads reported"))
+	}
+	for _, l := range strings.Split(text, "\n") {
+		l = sanitize(strings.ReplaceAll(l, "\t", "    "))
+		lines = append(lines, truncateName(" "+l, width))
+	}
+	return lines
+}
+
+// renderHotThreadsTitle renders the title bar for the hot threads pane.
+func renderHotThreadsTitle(app *App, width int) string {
+	title := "Hot Threads — " + sanitize(app.hotThreadsNode.Name)
+	if app.hotThreadsGrouped {
+		title += " (grouped by top frame)"
+	}
+	if app.hotThreadsLoading && app.hotThreadsText != "" {
+		title += "  refreshing..."
+	}
+	return renderTitleBar(title, "[h/esc: back  r: refresh  g: group  ↑↓: scroll]", width)
+}
+
+// hotThreadsMaxOffset returns the maximum valid hotThreadsScrollOffset for
+// the current app state.
+func hotThreadsMaxOffset(app *App) int {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderHotThreadsTitle(app, width))
+	return scrollMaxOffset(len(buildHotThreadsLines(app, width)), availH)
+}
+
+// renderHotThreads renders the hot threads title bar followed by the
+// scrollable report.
+func renderHotThreads(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderHotThreadsTitle(app, width)
+	availH := screenAvailHeight(app, titleBar)
+	lines := buildHotThreadsLines(app, width)
+	return titleBar + "\n" + renderScrollLines(lines, app.hotThreadsScrollOffset, availH)
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const sampleHotThreads = `::: {es-1}{n1}
+   Hot threads at 2026-10-18T10:00:00.000Z, interval=500ms, busiestThreads=3, ignoreIdleThreads=true:
+
+   61.2% [cpu=61.2%, other=0.0%] (306ms out of 500ms) cpu usage by thread 'elasticsearch[es-1][search][T#3]'
+     10/10 snapshots sharing following 29 elements
+       app//org.apache.lucene.search.BooleanScorer.score(BooleanScorer.java:123)
+`
+
+// newHotThreadsApp returns an App with the node table focused on a single node.
+func newHotThreadsApp(mc *tuiMockClient) *App {
+	app := NewApp(mc, 10*time.Second)
+	app.activeTable = 1
+	app.indexTable.focused = false
+	app.nodeTable.focused = true
+	app.nodeTable.SetData([]model.NodeRow{{ID: "n1", Name: "es-1"}})
+	return app
+}
+
+func TestHotThreadsLoadCmd(t *testing.T) {
+	var gotID string
+	mc := &tuiMockClient{
+		getHotThreadsFn: func(_ context.Context, id string) (string, error) {
+			gotID = id
+			return sampleHotThreads, nil
+		},
+	}
+	msg := hotThreadsLoadCmd(mc, "n1", 4)()
+	result, ok := msg.(HotThreadsLoadedMsg)
+	require.True(t, ok, "expected HotThreadsLoadedMsg, got %T", msg)
+	assert.Equal(t, "n1", gotID)
+	assert.Equal(t, sampleHotThreads, result.Text)
+	assert.Equal(t, 4, result.Nonce)
+}
+
+func TestApp_HotThreadsKey_OpensForCursorNode(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	require.NotNil(t, cmd, "h should return a hot threads load command")
+	assert.True(t, app.hotThreadsMode)
+	assert.True(t, app.hotThreadsLoading)
+	assert.Equal(t, "n1", app.hotThreadsNode.ID)
+	assert.Contains(t, renderHotThreads(app), "Loading hot threads")
+}
+
+func TestApp_HotThreadsKey_NoopOnIndexTable(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.activeTable = 0
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	assert.Nil(t, cmd)
+	assert.False(t, app.hotThreadsMode)
+}
+
+func TestApp_HotThreadsLoadedMsg(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+
+	// Stale nonce is dropped.
+	app.Update(HotThreadsLoadedMsg{NodeID: "n1", Text: "stale", Nonce: app.hotThreadsNonce - 1})
+	assert.Empty(t, app.hotThreadsText)
+
+	app.Update(HotThreadsLoadedMsg{NodeID: "n1", Text: sampleHotThreads, Nonce: app.hotThreadsNonce})
+	assert.False(t, app.hotThreadsLoading)
+	assert.Contains(t, renderHotThreads(app), "cpu usage by thread")
+}
+
+func TestApp_HotThreadsLoadedMsg_Error(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	app.Update(HotThreadsLoadedMsg{NodeID: "n1", Err: errors.New("timeout"), Nonce: app.hotThreadsNonce})
+	assert.Contains(t, renderHotThreads(app), "Failed to load hot threads: timeout")
+}
+
+func TestApp_HotThreads_GroupRefreshAndClose(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	app.Update(HotThreadsLoadedMsg{NodeID: "n1", Text: sampleHotThreads, Nonce: app.hotThreadsNonce})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	assert.True(t, app.hotThreadsGrouped)
+	view := renderHotThreads(app)
+	assert.Contains(t, view, "grouped by top frame")
+	assert.Contains(t, view, "BooleanScorer.score")
+	assert.Contains(t, view, "61.2%")
+
+	nonce := app.hotThreadsNonce
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd, "r should refresh hot threads")
+	assert.Equal(t, nonce+1, app.hotThreadsNonce)
+	assert.True(t, app.hotThreadsLoading)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.hotThreadsMode)
+}
+
+func TestApp_HotThreads_Scroll(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.width = 100
+	app.height = 10
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	long := sampleHotThreads
+	for i := 0; i < 50; i++ {
+		long += "       app//frame.line\n"
+	}
+	app.Update(HotThreadsLoadedMsg{NodeID: "n1", Text: long, Nonce: app.hotThreadsNonce})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, app.hotThreadsScrollOffset)
+	for i := 0; i < 200; i++ {
+		app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	assert.Equal(t, hotThreadsMaxOffset(app), app.hotThreadsScrollOffset)
+}
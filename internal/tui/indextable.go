@@ -10,6 +10,7 @@ import (
 	ltable "github.com/charmbracelet/lipgloss/table"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/jtsunne/epm-go/internal/engine"
 	"github.com/jtsunne/epm-go/internal/format"
 	"github.com/jtsunne/epm-go/internal/model"
 )
@@ -20,12 +21,24 @@ type IndexTableModel struct {
 	allRows     []model.IndexRow    // unfiltered source data
 	displayRows []model.IndexRow    // after filter + sort applied
 	selected    map[string]struct{} // set of selected index names
+	lifecycle   bool                // true when the ILM/ISM column set is shown
 }
 
-// NewIndexTable returns an IndexTableModel with 9-column layout and
+// NewIndexTable returns an IndexTableModel with 12-column layout and
 // default sort by IndexingRate (col 5) descending.
 func NewIndexTable() IndexTableModel {
-	cols := []columnDef{
+	m := IndexTableModel{
+		tableModel: newTableModel(indexPerfColumns()),
+		selected:   make(map[string]struct{}),
+	}
+	m.sortCol = 5  // IndexingRate
+	m.sortDesc = true
+	return m
+}
+
+// indexPerfColumns returns the default size and performance column set.
+func indexPerfColumns() []columnDef {
+	return []columnDef{
 		{Title: "Index Name", Width: 25, SortDesc: false},
 		{Title: "P/T",        Width: 7,  SortDesc: true},
 		{Title: "Total Size", Width: 10, SortDesc: true},
@@ -35,14 +48,10 @@ func NewIndexTable() IndexTableModel {
 		{Title: "Srch/s",     Width: 8,  SortDesc: true},
 		{Title: "Idx Lat",    Width: 9,  SortDesc: true},
 		{Title: "Srch Lat",   Width: 9,  SortDesc: true},
+		{Title: "Fields",     Width: 11, SortDesc: true},
+		{Title: "Del%",       Width: 6,  SortDesc: true},
+		{Title: "Aliases",    Width: 16, SortDesc: false},
 	}
-	m := IndexTableModel{
-		tableModel: newTableModel(cols),
-		selected:   make(map[string]struct{}),
-	}
-	m.sortCol = 5  // IndexingRate
-	m.sortDesc = true
-	return m
 }
 
 // toggleSelect adds the given index name to the selection set if absent,
@@ -94,12 +103,22 @@ func (m *IndexTableModel) SetData(rows []model.IndexRow) {
 			delete(m.selected, name)
 		}
 	}
-	filtered := filterIndexRows(m.allRows, m.search)
-	m.displayRows = sortIndexRows(filtered, m.sortCol, m.sortDesc)
+	m.applyFilterSort()
 	m.clampPage(len(m.displayRows))
 	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
 }
 
+// applyFilterSort rebuilds displayRows from allRows using the current search
+// term and the sort function of the active column set.
+func (m *IndexTableModel) applyFilterSort() {
+	filtered := filterIndexRows(m.allRows, m.search)
+	if m.lifecycle {
+		m.displayRows = sortIndexLifecycleRows(filtered, m.sortCol, m.sortDesc)
+	} else {
+		m.displayRows = sortIndexRows(filtered, m.sortCol, m.sortDesc)
+	}
+}
+
 // Update handles keyboard events for sorting, pagination, and search. It
 // intercepts the space key to toggle row selection, then delegates remaining
 // keys to the embedded tableModel and re-applies filter/sort when needed.
@@ -122,19 +141,26 @@ func (m IndexTableModel) Update(msg tea.Msg) (IndexTableModel, tea.Cmd) {
 	m.tableModel = base
 
 	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
-		filtered := filterIndexRows(m.allRows, m.search)
-		m.displayRows = sortIndexRows(filtered, m.sortCol, m.sortDesc)
+		m.applyFilterSort()
 	}
 	m.clampPage(len(m.displayRows)) // always clamp after any key (e.g. NextPage)
 	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
 	return m, cmd
 }
 
-// renderTable renders the complete "Index Statistics" section: a header bar
-// followed by the lipgloss table body for the current page.
+// renderTable renders the complete "Index Statistics" section (or "Index
+// Lifecycle" when the lifecycle column set is active): a header bar followed
+// by the lipgloss table body for the current page.
 func (m *IndexTableModel) renderTable(app *App) string {
 	pc := pageCount(len(m.displayRows), m.pageSize)
-	hdr := m.renderHeader("Index Statistics", m.page+1, pc, m.searching, m.search)
+	title := "Index Statistics"
+	if m.lifecycle {
+		title = "Index Lifecycle"
+	}
+	if app != nil && app.current != nil {
+		title += staleLabel(app.current, app.current.FetchedAt, model.SectionIndices, model.SectionIndexStats)
+	}
+	hdr := m.renderHeader(title, m.page+1, pc, m.searching, m.search)
 
 	// Compute proportional column widths for the current terminal width.
 	// Padding headers to these widths guides the table's natural column layout
@@ -188,7 +214,34 @@ func (m *IndexTableModel) renderTable(app *App) string {
 		}
 	}
 
+	// Rows whose mapped field count is within the recommendation margin of
+	// total_fields.limit are highlighted in the Fields column.
+	margin := engine.DefaultRecommendationConfig().FieldLimitMargin
+	if app != nil {
+		margin = app.recConfig.FieldLimitMargin
+	}
+	nearLimitRows := make(map[int]bool)
+	for i, idx := range pageIdx {
+		if u := engine.FieldLimitUsage(m.displayRows[idx]); u >= 0 && u >= 100-margin {
+			nearLimitRows[i] = true
+		}
+	}
+
+	// Rows above the deleted documents threshold are highlighted in the Del%
+	// column, whether or not they are large enough to be recommended.
+	deletedThreshold := engine.DefaultRecommendationConfig().DeletedDocsThreshold
+	if app != nil {
+		deletedThreshold = app.recConfig.DeletedDocsThreshold
+	}
+	bloatedRows := make(map[int]bool)
+	for i, idx := range pageIdx {
+		if deletedThreshold > 0 && engine.DeletedDocsPercent(m.displayRows[idx]) > deletedThreshold {
+			bloatedRows[i] = true
+		}
+	}
+
 	sortCol := m.sortCol
+	lifecycle := m.lifecycle
 	focused := m.focused
 	cursor := m.cursor
 	t := ltable.New().
@@ -208,6 +261,9 @@ func (m *IndexTableModel) renderTable(app *App) string {
 			} else if row%2 == 0 {
 				base = base.Background(colorAlt)
 			}
+			if lifecycle {
+				return base.Foreground(indexLifecycleColumnColor(col))
+			}
 			switch col {
 			case 5:
 				return base.Foreground(colorGreen)
@@ -217,6 +273,18 @@ func (m *IndexTableModel) renderTable(app *App) string {
 				return base.Foreground(colorPurple)
 			case 8:
 				return base.Foreground(colorOrange)
+			case 9:
+				if nearLimitRows[row] {
+					return base.Foreground(colorRed)
+				}
+				return base.Foreground(colorWhite)
+			case 10:
+				if bloatedRows[row] {
+					return base.Foreground(colorYellow)
+				}
+				return base.Foreground(colorWhite)
+			case 11:
+				return base.Foreground(colorBlue)
 			default:
 				return base.Foreground(colorWhite)
 			}
@@ -237,7 +305,11 @@ func (m *IndexTableModel) renderTable(app *App) string {
 		r := m.displayRows[idx]
 		cells := make([]string, len(m.columns))
 		for col := range m.columns {
-			cells[col] = indexCellValue(r, col)
+			if m.lifecycle {
+				cells[col] = indexLifecycleCellValue(r, col)
+			} else {
+				cells[col] = indexCellValue(r, col)
+			}
 		}
 		// Prevent cell wrapping: truncate name to allocated column width.
 		// For selected rows the "✓ " prefix (2 display chars) is added after
@@ -259,7 +331,21 @@ func (m *IndexTableModel) renderTable(app *App) string {
 	// Detail line: show the full untruncated name of the selected row when focused.
 	var detailLine string
 	if m.focused && len(pageIdx) > 0 && m.cursor < len(pageIdx) {
-		detailLine = StyleDim.Render("  " + sanitize(m.displayRows[pageIdx[m.cursor]].Name))
+		r := m.displayRows[pageIdx[m.cursor]]
+		detail := "  " + sanitize(r.Name)
+		if m.lifecycle && r.LifecycleError != "" {
+			detail += "  " + sanitize(r.LifecycleError)
+		}
+		if r.FieldLimit > 0 {
+			detail += fmt.Sprintf("  fields: %d/%d (%.0f%%)", r.FieldCount, r.FieldLimit, engine.FieldLimitUsage(r))
+		}
+		if r.DocsDeleted > 0 {
+			detail += "  deleted: " + format.FormatNumber(r.DocsDeleted) + " docs (~" + format.FormatBytes(engine.ReclaimableBytes(r)) + " reclaimable)"
+		}
+		if len(r.Aliases) > 0 {
+			detail += "  aliases: " + aliasList(r)
+		}
+		detailLine = StyleDim.Render(detail)
 	}
 	if detailLine != "" {
 		return lipgloss.JoinVertical(lipgloss.Left, hdr, t.String(), detailLine)
@@ -307,7 +393,39 @@ func indexCellValue(r model.IndexRow, col int) string {
 		return format.FormatLatency(r.IndexLatency)
 	case 8:
 		return format.FormatLatency(r.SearchLatency)
+	case 9:
+		if r.FieldLimit <= 0 {
+			return "---"
+		}
+		return fmt.Sprintf("%d/%d", r.FieldCount, r.FieldLimit)
+	case 10:
+		pct := engine.DeletedDocsPercent(r)
+		if pct < 0 {
+			return "---"
+		}
+		return format.FormatPercent(pct)
+	case 11:
+		if len(r.Aliases) == 0 {
+			return "---"
+		}
+		return aliasList(r)
 	default:
 		return ""
 	}
 }
+
+// aliasList joins the aliases of r, marking those for which r is the write
+// index with a trailing "*".
+func aliasList(r model.IndexRow) string {
+	parts := make([]string, len(r.Aliases))
+	for i, a := range r.Aliases {
+		parts[i] = sanitize(a)
+		for _, w := range r.WriteAliases {
+			if w == a {
+				parts[i] += "*"
+				break
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -360,3 +360,24 @@ func TestIndexTableSpaceKey_DuringSearch(t *testing.T) {
 
 	assert.Len(t, m.selectedNames(), 0, "space during search must not select anything")
 }
+
+func TestIndexTable_DeletedDocs(t *testing.T) {
+	unknown := model.IndexRow{Name: "closed", DocCountKnown: true}
+	clean := model.IndexRow{Name: "logs", DocCount: 900, DocsDeleted: 100, DocCountKnown: true, DeletedKnown: true}
+	bloated := model.IndexRow{Name: "users", DocCount: 600, DocsDeleted: 400, DocCountKnown: true, DeletedKnown: true, TotalSizeBytes: 10 << 30}
+
+	assert.Equal(t, "---", indexCellValue(unknown, 10))
+	assert.Equal(t, "10.0%", indexCellValue(clean, 10))
+	assert.Equal(t, "40.0%", indexCellValue(bloated, 10))
+
+	sorted := sortIndexRows([]model.IndexRow{unknown, clean, bloated}, 10, true)
+	assert.Equal(t, []string{"users", "logs", "closed"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name})
+	sorted = sortIndexRows([]model.IndexRow{unknown, bloated, clean}, 10, false)
+	assert.Equal(t, []string{"logs", "users", "closed"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name},
+		"unavailable deleted counts sort last in either direction")
+
+	m := NewIndexTable()
+	m.focused = true
+	m.SetData([]model.IndexRow{bloated})
+	assert.Contains(t, stripANSI(m.renderTable(nil)), "deleted: 400 docs (~4.0 GB reclaimable)")
+}
@@ -19,6 +19,27 @@ type keyMap struct {
 	ToggleSelect key.Binding
 	DeleteKey    key.Binding
 	EditSettings key.Binding
+	PendingTasks key.Binding
+	Tasks        key.Binding
+	CancelTask   key.Binding
+	HotThreads   key.Binding
+	Resources    key.Binding
+	GroupThreads key.Binding
+	Explain      key.Binding
+	Select       key.Binding
+	Shards       key.Binding
+	Recovery     key.Binding
+	Backups      key.Binding
+	Lifecycle    key.Binding
+	DataStreams  key.Binding
+	Aliases      key.Binding
+	Templates    key.Binding
+	Mapping      key.Binding
+	ClusterSet   key.Binding
+	Zones        key.Binding
+	Tiers        key.Binding
+	Pipelines    key.Binding
+	CCR          key.Binding
 }
 
 // keys is the global key map.
@@ -83,7 +104,103 @@ var keys = keyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit settings"),
 	),
+	PendingTasks: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pending tasks"),
+	),
+	Tasks: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "running tasks"),
+	),
+	CancelTask: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "cancel task"),
+	),
+	HotThreads: key.NewBinding(
+		key.WithKeys("h"),
+		key.WithHelp("h", "hot threads"),
+	),
+	Resources: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "node resources"),
+	),
+	GroupThreads: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "group threads"),
+	),
+	Explain: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "explain unassigned"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open"),
+	),
+	Shards: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "shards"),
+	),
+	Recovery: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "recoveries"),
+	),
+	Backups: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "backups"),
+	),
+	Lifecycle: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "lifecycle columns"),
+	),
+	DataStreams: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "data streams"),
+	),
+	Aliases: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "aliases"),
+	),
+	Templates: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "templates"),
+	),
+	Mapping: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "mapping tree"),
+	),
+	ClusterSet: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "cluster settings"),
+	),
+	Zones: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "zones"),
+	),
+	Tiers: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "data tiers"),
+	),
+	Pipelines: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "ingest pipelines"),
+	),
+	CCR: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "replication"),
+	),
 }
 
-// helpText is the full help string displayed in the footer when help is toggled on.
-const helpText = "tab: switch table  /: search  1-9: sort col  ←→: pages  ↑↓: select row  space: select  d: delete  e: edit settings  r: refresh  a: analytics  q: quit  ?: close help"
+// helpText is the full help shown in the footer when help is toggled on,
+// grouped by what the keys act on. Each line fits an 80-column terminal.
+// Keys that only work inside one view name that view's key in parentheses.
+const helpText = "" +
+	"Navigation  tab: switch table  ↑↓: move  ←→: pages  /: search  1-9/0: sort col\n" +
+	"            space: select  esc: close  r: refresh  ?: close help  q: quit\n" +
+	"Indices     d: delete  e: edit settings  l: lifecycle columns  m: mapping tree\n" +
+	"            D: data streams  A: aliases  T: templates\n" +
+	"Nodes       h: hot threads  n: node resources\n" +
+	"Cluster     a: analytics  p: pending tasks  t: tasks  s: shards  b: backups\n" +
+	"            x: explain unassigned  R: recoveries  C: cluster settings\n" +
+	"Topology    z: zones  w: data tiers  i: ingest pipelines  X: replication\n" +
+	"In views    enter: open  g: group threads (h) / zone attribute (z)\n" +
+	"            c: cancel task (t)  e: edit (C) / expand all (m)  y/n: confirm"
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// indexLifecycleColumns returns the ILM/ISM column set of the index table.
+func indexLifecycleColumns() []columnDef {
+	return []columnDef{
+		{Title: "Index Name", Width: 25, SortDesc: false},
+		{Title: "Policy", Width: 16, SortDesc: false},
+		{Title: "Phase", Width: 8, SortDesc: false},
+		{Title: "Action", Width: 12, SortDesc: false},
+		{Title: "Step", Width: 16, SortDesc: false},
+		{Title: "Age", Width: 8, SortDesc: true},
+		{Title: "Step Error", Width: 30, SortDesc: true},
+	}
+}
+
+// toggleLifecycle switches the index table between the performance and the
+// lifecycle column sets. Each set starts from its own default sort: indexing
+// rate descending for performance, failed steps first for lifecycle.
+func (m *IndexTableModel) toggleLifecycle() {
+	m.lifecycle = !m.lifecycle
+	if m.lifecycle {
+		m.columns = indexLifecycleColumns()
+		m.sortCol = 6 // Step Error
+	} else {
+		m.columns = indexPerfColumns()
+		m.sortCol = 5 // IndexingRate
+	}
+	m.sortDesc = true
+	m.page = 0
+	m.cursor = 0
+	m.applyFilterSort()
+}
+
+// sortIndexLifecycleRows returns a sorted copy of rows for the lifecycle
+// column set.
+// Column mapping:
+//
+//	0=Name, 1=LifecyclePolicy, 2=LifecyclePhase, 3=LifecycleAction,
+//	4=LifecycleStep, 5=LifecycleAge, 6=LifecycleError
+//
+// col -1 means no sort (preserve order). Unmanaged indices always sort after
+// managed ones; ties are broken by Name ascending.
+func sortIndexLifecycleRows(rows []model.IndexRow, col int, desc bool) []model.IndexRow {
+	out := make([]model.IndexRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if col > 0 && a.LifecycleManaged != b.LifecycleManaged {
+			return a.LifecycleManaged // unmanaged always last regardless of direction
+		}
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case 1:
+			cmp = strings.Compare(a.LifecyclePolicy, b.LifecyclePolicy)
+		case 2:
+			cmp = strings.Compare(a.LifecyclePhase, b.LifecyclePhase)
+		case 3:
+			cmp = strings.Compare(a.LifecycleAction, b.LifecycleAction)
+		case 4:
+			cmp = strings.Compare(a.LifecycleStep, b.LifecycleStep)
+		case 5:
+			cmp = compareInt64(int64(a.LifecycleAge), int64(b.LifecycleAge))
+		case 6:
+			cmp = strings.Compare(a.LifecycleError, b.LifecycleError)
+		}
+		if cmp == 0 {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// indexLifecycleCellValue formats an IndexRow lifecycle field for a given
+// column index. Unmanaged indices show "---" in every lifecycle column.
+func indexLifecycleCellValue(r model.IndexRow, col int) string {
+	if col == 0 {
+		return sanitize(r.Name)
+	}
+	if !r.LifecycleManaged {
+		return "---"
+	}
+	var v string
+	switch col {
+	case 1:
+		v = r.LifecyclePolicy
+	case 2:
+		v = r.LifecyclePhase
+	case 3:
+		v = r.LifecycleAction
+	case 4:
+		v = r.LifecycleStep
+	case 5:
+		if r.LifecycleAge <= 0 {
+			return "---"
+		}
+		return format.FormatAge(r.LifecycleAge)
+	case 6:
+		v = r.LifecycleError
+	}
+	if v == "" {
+		return "---"
+	}
+	return sanitize(v)
+}
+
+// indexLifecycleColumnColor returns the foreground color of a lifecycle
+// column; step errors are red.
+func indexLifecycleColumnColor(col int) lipgloss.TerminalColor {
+	switch col {
+	case 1:
+		return colorBlue
+	case 2:
+		return colorCyan
+	case 5:
+		return colorPurple
+	case 6:
+		return colorRed
+	default:
+		return colorWhite
+	}
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func lifecycleRows() []model.IndexRow {
+	return []model.IndexRow{
+		{Name: "plain"},
+		{Name: "logs-000002", LifecycleManaged: true, LifecyclePolicy: "logs", LifecyclePhase: "hot", LifecycleAction: "rollover", LifecycleAge: 2 * time.Hour},
+		{Name: "logs-000001", LifecycleManaged: true, LifecyclePolicy: "logs", LifecyclePhase: "warm", LifecycleAction: "shrink", LifecycleStep: "ERROR", LifecycleAge: 50 * time.Hour, LifecycleError: "no node can hold all shards"},
+	}
+}
+
+func TestIndexTable_ToggleLifecycle(t *testing.T) {
+	m := NewIndexTable()
+	m.SetData(lifecycleRows())
+
+	m.toggleLifecycle()
+	require.True(t, m.lifecycle)
+	assert.Equal(t, "Policy", m.columns[1].Title)
+	assert.Equal(t, 6, m.sortCol)
+	assert.Equal(t, "logs-000001", m.displayRows[0].Name, "failed steps sort first")
+	assert.Equal(t, "plain", m.displayRows[2].Name, "unmanaged indices sort last")
+
+	m.toggleLifecycle()
+	assert.False(t, m.lifecycle)
+	assert.Equal(t, indexPerfColumns(), m.columns)
+	assert.Equal(t, 5, m.sortCol)
+	assert.True(t, m.sortDesc)
+}
+
+func TestSortIndexLifecycleRows_UnmanagedLastBothDirections(t *testing.T) {
+	for _, desc := range []bool{false, true} {
+		out := sortIndexLifecycleRows(lifecycleRows(), 5, desc)
+		assert.Equal(t, "plain", out[2].Name, "desc=%v", desc)
+	}
+	out := sortIndexLifecycleRows(lifecycleRows(), 5, false)
+	assert.Equal(t, "logs-000002", out[0].Name, "youngest first when ascending")
+}
+
+func TestIndexLifecycleCellValue(t *testing.T) {
+	rows := lifecycleRows()
+	assert.Equal(t, "plain", indexLifecycleCellValue(rows[0], 0))
+	assert.Equal(t, "---", indexLifecycleCellValue(rows[0], 1))
+	assert.Equal(t, "logs", indexLifecycleCellValue(rows[2], 1))
+	assert.Equal(t, "ERROR", indexLifecycleCellValue(rows[2], 4))
+	assert.Equal(t, "2d02h", indexLifecycleCellValue(rows[2], 5))
+	assert.Equal(t, "no node can hold all shards", indexLifecycleCellValue(rows[2], 6))
+	assert.Equal(t, "---", indexLifecycleCellValue(rows[1], 4), "empty step shows placeholder")
+	assert.Equal(t, "---", indexLifecycleCellValue(rows[1], 6))
+}
+
+func TestApp_LifecycleKeyTogglesColumns(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	app.indexTable.SetData(lifecycleRows())
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	require.True(t, app.indexTable.lifecycle)
+	out := stripANSI(app.indexTable.renderTable(app))
+	assert.Contains(t, out, "Index Lifecycle")
+	assert.Contains(t, out, "Policy")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	assert.False(t, app.indexTable.lifecycle)
+}
@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+// mappingNode is one field of an index mapping. Object and nested fields
+// hold their sub-properties as children; multi-fields are children of the
+// field they index.
+type mappingNode struct {
+	Name     string
+	Path     string // dotted path; unique key for the expansion state
+	Type     string // field type, "object" for untyped objects
+	Target   string // path a field alias points at
+	Multi    bool   // defined under the parent's "fields"
+	Runtime  bool   // defined under "runtime"
+	Children []*mappingNode
+	Count    int // fields in this subtree, counted as for total_fields.limit
+}
+
+// mappingLine is a visible row of the mapping tree at a given depth.
+type mappingLine struct {
+	node  *mappingNode
+	depth int
+}
+
+// buildMappingTree converts a mappings object ({"properties": ...,
+// "runtime": ...}) into a tree sorted by field name, runtime fields last.
+func buildMappingTree(mappings map[string]any) []*mappingNode {
+	var roots []*mappingNode
+	if props, ok := mappings["properties"].(map[string]any); ok {
+		roots = mappingChildren("", props, false)
+	}
+	if runtime, ok := mappings["runtime"].(map[string]any); ok {
+		names := sortedKeys(runtime)
+		for _, name := range names {
+			n := &mappingNode{Name: name, Path: "runtime:" + name, Runtime: true, Count: 1}
+			if def, ok := runtime[name].(map[string]any); ok {
+				n.Type, _ = def["type"].(string)
+			}
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
+
+// mappingChildren builds the nodes of one "properties" or "fields" object.
+func mappingChildren(prefix string, props map[string]any, multi bool) []*mappingNode {
+	names := sortedKeys(props)
+	nodes := make([]*mappingNode, 0, len(names))
+	for _, name := range names {
+		n := &mappingNode{Name: name, Path: prefix + name, Multi: multi, Count: 1}
+		if def, ok := props[name].(map[string]any); ok {
+			n.Type, _ = def["type"].(string)
+			n.Target, _ = def["path"].(string)
+			if sub, ok := def["properties"].(map[string]any); ok {
+				if n.Type == "" {
+					n.Type = "object"
+				}
+				n.Children = append(n.Children, mappingChildren(n.Path+".", sub, false)...)
+			}
+			if fields, ok := def["fields"].(map[string]any); ok {
+				n.Children = append(n.Children, mappingChildren(n.Path+".", fields, true)...)
+			}
+		}
+		if n.Type == "" {
+			n.Type = "object"
+		}
+		for _, c := range n.Children {
+			n.Count += c.Count
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// visibleMappingLines flattens the tree into the rows currently shown:
+// every root, plus the children of each expanded node.
+func visibleMappingLines(roots []*mappingNode, expanded map[string]bool) []mappingLine {
+	var out []mappingLine
+	var walk func(nodes []*mappingNode, depth int)
+	walk = func(nodes []*mappingNode, depth int) {
+		for _, n := range nodes {
+			out = append(out, mappingLine{node: n, depth: depth})
+			if len(n.Children) > 0 && expanded[n.Path] {
+				walk(n.Children, depth+1)
+			}
+		}
+	}
+	walk(roots, 0)
+	return out
+}
+
+// setMappingExpanded expands (or, when expand is false, collapses) every
+// node with children.
+func (app *App) setMappingExpanded(expand bool) {
+	app.mappingExpanded = make(map[string]bool)
+	if !expand {
+		return
+	}
+	var walk func(nodes []*mappingNode)
+	walk = func(nodes []*mappingNode) {
+		for _, n := range nodes {
+			if len(n.Children) > 0 {
+				app.mappingExpanded[n.Path] = true
+				walk(n.Children)
+			}
+		}
+	}
+	walk(app.mappingRoots)
+}
+
+// anyMappingExpanded reports whether any node is currently expanded.
+func anyMappingExpanded(expanded map[string]bool) bool {
+	for _, v := range expanded {
+		if v {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingLoadCmd fetches the mapping of index and returns a
+// MappingLoadedMsg. nonce is embedded in the message so the App can discard
+// stale responses.
+func mappingLoadCmd(c client.ESClient, index string, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		mapping, err := c.GetIndexMapping(ctx, index)
+		return MappingLoadedMsg{Index: index, Mapping: mapping, Err: err, Nonce: nonce}
+	}
+}
+
+// openMapping switches to the mapping tree of the index under the cursor.
+// It is a no-op unless the index table is focused on a row.
+func (app *App) openMapping() tea.Cmd {
+	if app.activeTable != 0 {
+		return nil
+	}
+	name := app.indexTable.cursorRowName()
+	if name == "" {
+		return nil
+	}
+	app.mappingNonce++
+	app.mappingMode = true
+	app.mappingIndex = name
+	app.mappingLoading = true
+	app.mappingErr = ""
+	app.mappingRoots = nil
+	app.mappingExpanded = make(map[string]bool)
+	app.mappingCursor = 0
+	app.mappingScrollOffset = 0
+	return mappingLoadCmd(app.client, name, app.mappingNonce)
+}
+
+// moveMappingCursor moves the tree cursor by delta rows and scrolls so that
+// it stays visible.
+func (app *App) moveMappingCursor(delta int) {
+	lines := visibleMappingLines(app.mappingRoots, app.mappingExpanded)
+	app.mappingCursor += delta
+	if app.mappingCursor >= len(lines) {
+		app.mappingCursor = len(lines) - 1
+	}
+	if app.mappingCursor < 0 {
+		app.mappingCursor = 0
+	}
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderMappingTitle(app, width))
+	contentH := scrollContentHeight(len(lines), availH)
+	if app.mappingCursor < app.mappingScrollOffset {
+		app.mappingScrollOffset = app.mappingCursor
+	}
+	if app.mappingCursor >= app.mappingScrollOffset+contentH {
+		app.mappingScrollOffset = app.mappingCursor - contentH + 1
+	}
+	if max := scrollMaxOffset(len(lines), availH); app.mappingScrollOffset > max {
+		app.mappingScrollOffset = max
+	}
+}
+
+// setMappingNodeExpanded expands or collapses the node under the cursor.
+// Collapsing a leaf or an already collapsed node moves the cursor to its
+// parent instead.
+func (app *App) setMappingNodeExpanded(expand bool) {
+	lines := visibleMappingLines(app.mappingRoots, app.mappingExpanded)
+	if app.mappingCursor >= len(lines) {
+		return
+	}
+	line := lines[app.mappingCursor]
+	if len(line.node.Children) > 0 && app.mappingExpanded[line.node.Path] != expand {
+		app.mappingExpanded[line.node.Path] = expand
+		app.moveMappingCursor(0)
+		return
+	}
+	if !expand && line.depth > 0 {
+		for i := app.mappingCursor - 1; i >= 0; i-- {
+			if lines[i].depth == line.depth-1 {
+				app.moveMappingCursor(i - app.mappingCursor)
+				return
+			}
+		}
+	}
+}
+
+// mappingFieldSummary describes the focused index's field count against its
+// limit from the latest poll, or "" when unknown.
+func mappingFieldSummary(app *App) string {
+	for _, r := range app.indexTable.allRows {
+		if r.Name == app.mappingIndex && r.FieldLimit > 0 {
+			return fmt.Sprintf("%d/%d fields", r.FieldCount, r.FieldLimit)
+		}
+	}
+	return ""
+}
+
+// renderMappingTitle renders the title bar for the mapping tree.
+func renderMappingTitle(app *App, width int) string {
+	title := "Mapping — " + sanitize(app.mappingIndex)
+	if s := mappingFieldSummary(app); s != "" {
+		title += "  " + s
+	}
+	return renderTitleBar(title, "[m/esc: back  ↑↓: move  enter/→: expand  ←: collapse  e: expand/collapse all  r: reload]", width)
+}
+
+// buildMappingLines returns the rendered rows of the mapping tree, with the
+// cursor row highlighted.
+func buildMappingLines(app *App, width int) []string {
+	switch {
+	case app.mappingLoading:
+		return []string{"", "  " + StyleDim.Render("Loading mapping...")}
+	case app.mappingErr != "":
+		return []string{"", "  " + StyleError.Render("Failed to load mapping: "+app.mappingErr)}
+	case len(app.mappingRoots) == 0:
+		return []string{"", "  " + StyleDim.Render("No mapped fields")}
+	}
+
+	visible := visibleMappingLines(app.mappingRoots, app.mappingExpanded)
+	lines := make([]string, len(visible))
+	for i, l := range visible {
+		n := l.node
+		marker := "  "
+		if len(n.Children) > 0 {
+			marker = "▸ "
+			if app.mappingExpanded[n.Path] {
+				marker = "▾ "
+			}
+		}
+		typ := n.Type
+		switch {
+		case n.Runtime:
+			typ = "runtime " + typ
+		case n.Multi:
+			typ = "multi-field " + typ
+		}
+		if n.Target != "" {
+			typ += " → " + n.Target
+		}
+		if len(n.Children) > 0 {
+			typ += fmt.Sprintf(" (%d fields)", n.Count-1)
+		}
+		text := truncateName("  "+strings.Repeat("  ", l.depth)+marker+sanitize(n.Name)+"  "+sanitize(typ), width)
+		if i == app.mappingCursor {
+			lines[i] = lipgloss.NewStyle().Background(colorSelectedBg).Foreground(colorWhite).Render(text)
+		} else if len(n.Children) > 0 {
+			lines[i] = StyleCyan.Render(text)
+		} else {
+			lines[i] = text
+		}
+	}
+	return lines
+}
+
+// renderMapping renders the mapping tree title bar followed by the visible
+// part of the tree.
+func renderMapping(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderMappingTitle(app, width)
+	availH := screenAvailHeight(app, titleBar)
+	return titleBar + "\n" + renderScrollLines(buildMappingLines(app, width), app.mappingScrollOffset, availH)
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleMapping() map[string]any {
+	return map[string]any{
+		"runtime": map[string]any{"day": map[string]any{"type": "keyword"}},
+		"properties": map[string]any{
+			"message": map[string]any{"type": "text", "fields": map[string]any{"raw": map[string]any{"type": "keyword"}}},
+			"host": map[string]any{"properties": map[string]any{
+				"name": map[string]any{"type": "keyword"},
+				"geo":  map[string]any{"properties": map[string]any{"city": map[string]any{"type": "keyword"}}},
+			}},
+			"hostname": map[string]any{"type": "alias", "path": "host.name"},
+		},
+	}
+}
+
+func TestBuildMappingTree(t *testing.T) {
+	roots := buildMappingTree(sampleMapping())
+	require.Len(t, roots, 4)
+	assert.Equal(t, []string{"host", "hostname", "message", "day"},
+		[]string{roots[0].Name, roots[1].Name, roots[2].Name, roots[3].Name}, "sorted, runtime fields last")
+
+	host := roots[0]
+	assert.Equal(t, "object", host.Type)
+	assert.Equal(t, 4, host.Count, "host, host.name, host.geo, host.geo.city")
+	assert.Equal(t, "host.geo.city", host.Children[0].Children[0].Path)
+	assert.Equal(t, "host.name", roots[1].Target)
+	assert.True(t, roots[2].Children[0].Multi)
+	assert.True(t, roots[3].Runtime)
+
+	assert.Len(t, visibleMappingLines(roots, nil), 4, "collapsed by default")
+	assert.Len(t, visibleMappingLines(roots, map[string]bool{"host": true}), 6)
+	assert.Len(t, visibleMappingLines(roots, map[string]bool{"host.geo": true}), 4, "children of a collapsed parent stay hidden")
+}
+
+func TestApp_MappingKey_LoadsFocusedIndex(t *testing.T) {
+	var requested string
+	mc := &tuiMockClient{getIndexMappingFn: func(_ context.Context, index string) (map[string]any, error) {
+		requested = index
+		return sampleMapping(), nil
+	}}
+	app := NewApp(mc, 10*time.Second)
+	app.width = 140
+	app.height = 40
+	app.indexTable.SetData([]model.IndexRow{{Name: "logs", FieldCount: 7, FieldLimit: 1000}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	require.NotNil(t, cmd)
+	require.True(t, app.mappingMode)
+	app.Update(cmd())
+	assert.Equal(t, "logs", requested)
+
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "Mapping — logs  7/1000 fields")
+	assert.Contains(t, out, "▸ host  object (3 fields)")
+	assert.Contains(t, out, "hostname  alias → host.name")
+	assert.Contains(t, out, "day  runtime keyword")
+	assert.NotContains(t, out, "city")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, app.mappingExpanded["host"])
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Contains(t, stripANSI(app.View()), "city  keyword")
+
+	// ← on a leaf moves to its parent; ← again collapses it.
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, 1, app.mappingCursor)
+	app.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.False(t, app.mappingExpanded["host.geo"])
+
+	// e collapses everything while anything is expanded, then expands all.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.Empty(t, app.mappingExpanded)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.Len(t, visibleMappingLines(app.mappingRoots, app.mappingExpanded), 8)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	assert.False(t, app.mappingMode)
+}
+
+func TestApp_Mapping_NodeFocusAndErrors(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.activeTable = 1
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	assert.Nil(t, cmd)
+	assert.False(t, app.mappingMode, "the mapping tree needs a focused index")
+
+	app.activeTable = 0
+	app.indexTable.SetData([]model.IndexRow{{Name: "logs"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	app.Update(MappingLoadedMsg{Index: "logs", Mapping: sampleMapping(), Nonce: app.mappingNonce - 1})
+	assert.Nil(t, app.mappingRoots, "stale responses are dropped")
+	app.Update(MappingLoadedMsg{Index: "logs", Err: errors.New("index_not_found"), Nonce: app.mappingNonce})
+	assert.Contains(t, stripANSI(renderMapping(app)), "Failed to load mapping: index_not_found")
+}
+
+func TestIndexTable_FieldsColumn(t *testing.T) {
+	rows := []model.IndexRow{
+		{Name: "a", FieldCount: 100, FieldLimit: 1000},
+		{Name: "b", FieldCount: 990, FieldLimit: 1000},
+		{Name: "c"},
+	}
+	assert.Equal(t, "990/1000", indexCellValue(rows[1], 9))
+	assert.Equal(t, "---", indexCellValue(rows[2], 9))
+
+	m := NewIndexTable()
+	m.focused = true
+	m.SetData(rows)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0")})
+	assert.Equal(t, 9, m.sortCol)
+	assert.Equal(t, []string{"b", "a", "c"}, []string{m.displayRows[0].Name, m.displayRows[1].Name, m.displayRows[2].Name},
+		"closest to the limit first, unknown last")
+}
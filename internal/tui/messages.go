@@ -1,8 +1,6 @@
 package tui
 
 import (
-	"time"
-
 	"github.com/jtsunne/epm-go/internal/client"
 	"github.com/jtsunne/epm-go/internal/model"
 )
@@ -14,17 +12,21 @@ type SnapshotMsg struct {
 	Resources       model.ClusterResources
 	NodeRows        []model.NodeRow
 	IndexRows       []model.IndexRow
+	Recoveries      []model.RecoveryRow
+	DataStreams     []model.DataStreamRow
+	Aliases         []model.AliasRow
+	Pipelines       []model.PipelineRow
+	Followers       []model.FollowerRow
 	Recommendations []model.Recommendation
+	// Seq is the poll's number; see engine.PollResult.Seq.
+	Seq uint64
 }
 
-// FetchErrorMsg signals a poll failure.
-type FetchErrorMsg struct{ Err error }
-
-// TickMsg triggers the next scheduled poll.
-// Gen must match App.tickGen; stale ticks from superseded schedules are dropped.
-type TickMsg struct {
-	Time time.Time
-	Gen  int
+// FetchErrorMsg signals a poll failure. Fails is the poller's count of
+// consecutive failed polls, which sets its backoff delay.
+type FetchErrorMsg struct {
+	Err   error
+	Fails int
 }
 
 // CountdownTickMsg triggers a 1-second display refresh while disconnected so
@@ -53,3 +55,97 @@ type SettingsResultMsg struct {
 	Err   error
 	Nonce int
 }
+
+// TasksLoadedMsg delivers the running task list from _tasks.
+// Nonce must match App.tasksNonce; stale responses are dropped.
+type TasksLoadedMsg struct {
+	Tasks []client.TaskInfo
+	Err   error
+	Nonce int
+}
+
+// TaskCancelResultMsg reports the outcome of a CancelTask operation.
+// Nonce must match App.tasksNonce; stale responses are dropped.
+type TaskCancelResultMsg struct {
+	TaskID string
+	Err    error
+	Nonce  int
+}
+
+// HotThreadsLoadedMsg delivers the hot threads report for a node.
+// Nonce must match App.hotThreadsNonce; stale responses are dropped.
+type HotThreadsLoadedMsg struct {
+	NodeID string
+	Text   string
+	Err    error
+	Nonce  int
+}
+
+// UnassignedLoadedMsg delivers the _cat/shards listing for the explain view.
+// Nonce must match App.explainNonce; stale responses are dropped.
+type UnassignedLoadedMsg struct {
+	Shards []client.ShardInfo
+	Err    error
+	Nonce  int
+}
+
+// ExplainLoadedMsg delivers an allocation explain response for one shard.
+// Nonce must match App.explainDetailNonce; stale responses are dropped.
+type ExplainLoadedMsg struct {
+	Explain *client.AllocationExplain
+	Err     error
+	Nonce   int
+}
+
+// ShardsLoadedMsg delivers the _cat/shards listing for the shard view.
+// Nonce must match App.shardsNonce; stale responses are dropped.
+type ShardsLoadedMsg struct {
+	Shards []client.ShardInfo
+	Err    error
+	Nonce  int
+}
+
+// TemplatesLoadedMsg delivers composable, component and legacy templates for
+// the template screen. Nonce must match App.templatesNonce; stale responses
+// are dropped.
+type TemplatesLoadedMsg struct {
+	Templates []client.IndexTemplate
+	Err       error
+	Nonce     int
+}
+
+// SimulateIndexLoadedMsg delivers the _simulate_index result for the index
+// the template screen is scoped to. Nonce must match App.templatesNonce;
+// stale responses are dropped.
+type SimulateIndexLoadedMsg struct {
+	Index  string
+	Result *client.SimulatedIndex
+	Err    error
+	Nonce  int
+}
+
+// MappingLoadedMsg delivers the mapping of one index for the mapping tree.
+// Nonce must match App.mappingNonce; stale responses are dropped.
+type MappingLoadedMsg struct {
+	Index   string
+	Mapping map[string]any
+	Err     error
+	Nonce   int
+}
+
+// ClusterSettingsLoadedMsg delivers the persistent, transient and default
+// cluster settings for the cluster settings screen. Nonce must match
+// App.clusterSettingsNonce; stale responses are dropped.
+type ClusterSettingsLoadedMsg struct {
+	Settings *client.ClusterSettings
+	Err      error
+	Nonce    int
+}
+
+// ClusterSettingsResultMsg is returned after a PUT /_cluster/settings
+// attempt. Changed is the number of persistent keys sent.
+type ClusterSettingsResultMsg struct {
+	Changed int
+	Err     error
+	Nonce   int
+}
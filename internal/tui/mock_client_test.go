@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+// tuiMockClient is a minimal ESClient implementation for tui-package tests.
+type tuiMockClient struct {
+	deleteIndexFn         func(ctx context.Context, names []string) error
+	getIndexSettingsFn    func(ctx context.Context, name string) (*client.IndexSettingsValues, error)
+	updateIndexSettingsFn func(ctx context.Context, names []string, settings map[string]any) error
+	getTasksFn            func(ctx context.Context) ([]client.TaskInfo, error)
+	cancelTaskFn          func(ctx context.Context, taskID string) error
+	getHotThreadsFn       func(ctx context.Context, nodeID string) (string, error)
+	getShardsFn           func(ctx context.Context) ([]client.ShardInfo, error)
+	allocExplainFn        func(ctx context.Context, index string, shard int, primary bool) (*client.AllocationExplain, error)
+	getRecoveryFn         func(ctx context.Context) ([]client.RecoveryInfo, error)
+	getIndexMappingFn     func(ctx context.Context, index string) (map[string]any, error)
+	getClusterSettingsFn  func(ctx context.Context) (*client.ClusterSettings, error)
+	updateClusterSetFn    func(ctx context.Context, persistent, transient map[string]any) error
+	diskWatermarksFn      func(ctx context.Context) (*client.DiskWatermarks, error)
+}
+
+func (m *tuiMockClient) GetClusterHealth(ctx context.Context) (*client.ClusterHealth, error) {
+	return &client.ClusterHealth{}, nil
+}
+func (m *tuiMockClient) GetNodes(ctx context.Context) ([]client.NodeInfo, error) {
+	return nil, nil
+}
+func (m *tuiMockClient) GetNodeStats(ctx context.Context) (*client.NodeStatsResponse, error) {
+	return &client.NodeStatsResponse{Nodes: map[string]client.NodePerformanceStats{}}, nil
+}
+func (m *tuiMockClient) GetIndices(ctx context.Context) ([]client.IndexInfo, error) {
+	return nil, nil
+}
+func (m *tuiMockClient) GetIndexStats(ctx context.Context) (*client.IndexStatsResponse, error) {
+	return &client.IndexStatsResponse{Indices: map[string]client.IndexStatEntry{}}, nil
+}
+func (m *tuiMockClient) GetAllocation(ctx context.Context) ([]client.AllocationInfo, error) {
+	return []client.AllocationInfo{}, nil
+}
+func (m *tuiMockClient) GetPendingTasks(ctx context.Context) ([]client.PendingTask, error) {
+	return []client.PendingTask{}, nil
+}
+func (m *tuiMockClient) GetTasks(ctx context.Context) ([]client.TaskInfo, error) {
+	if m.getTasksFn != nil {
+		return m.getTasksFn(ctx)
+	}
+	return []client.TaskInfo{}, nil
+}
+func (m *tuiMockClient) CancelTask(ctx context.Context, taskID string) error {
+	if m.cancelTaskFn != nil {
+		return m.cancelTaskFn(ctx, taskID)
+	}
+	return nil
+}
+func (m *tuiMockClient) GetHotThreads(ctx context.Context, nodeID string) (string, error) {
+	if m.getHotThreadsFn != nil {
+		return m.getHotThreadsFn(ctx, nodeID)
+	}
+	return "", nil
+}
+func (m *tuiMockClient) GetShards(ctx context.Context) ([]client.ShardInfo, error) {
+	if m.getShardsFn != nil {
+		return m.getShardsFn(ctx)
+	}
+	return []client.ShardInfo{}, nil
+}
+func (m *tuiMockClient) GetAllocationExplain(ctx context.Context, index string, shard int, primary bool) (*client.AllocationExplain, error) {
+	if m.allocExplainFn != nil {
+		return m.allocExplainFn(ctx, index, shard, primary)
+	}
+	return &client.AllocationExplain{}, nil
+}
+func (m *tuiMockClient) GetRecovery(ctx context.Context) ([]client.RecoveryInfo, error) {
+	if m.getRecoveryFn != nil {
+		return m.getRecoveryFn(ctx)
+	}
+	return []client.RecoveryInfo{}, nil
+}
+func (m *tuiMockClient) GetSnapshotRepositories(ctx context.Context) ([]client.SnapshotRepository, error) {
+	return []client.SnapshotRepository{}, nil
+}
+func (m *tuiMockClient) GetSnapshots(ctx context.Context, repo string) ([]client.SnapshotInfo, error) {
+	return []client.SnapshotInfo{}, nil
+}
+func (m *tuiMockClient) GetLifecycleExplain(ctx context.Context) (map[string]client.LifecycleExplain, error) {
+	return map[string]client.LifecycleExplain{}, nil
+}
+func (m *tuiMockClient) GetDataStreams(ctx context.Context) ([]client.DataStream, error) {
+	return []client.DataStream{}, nil
+}
+func (m *tuiMockClient) GetAliases(ctx context.Context) ([]client.AliasInfo, error) {
+	return []client.AliasInfo{}, nil
+}
+func (m *tuiMockClient) GetNodeAttributes(ctx context.Context) ([]client.NodeAttribute, error) {
+	return []client.NodeAttribute{}, nil
+}
+func (m *tuiMockClient) GetIngestStats(ctx context.Context) (*client.IngestStatsResponse, error) {
+	return &client.IngestStatsResponse{Nodes: map[string]client.NodeIngestStats{}}, nil
+}
+func (m *tuiMockClient) GetRemoteInfo(ctx context.Context) (map[string]client.RemoteInfo, error) {
+	return map[string]client.RemoteInfo{}, nil
+}
+func (m *tuiMockClient) GetCCRStats(ctx context.Context) (*client.CCRStatsResponse, error) {
+	return &client.CCRStatsResponse{}, nil
+}
+func (m *tuiMockClient) GetIndexingPressure(ctx context.Context) (*client.IndexingPressureResponse, error) {
+	return &client.IndexingPressureResponse{Nodes: map[string]client.NodeIndexingPressure{}}, nil
+}
+func (m *tuiMockClient) GetNodeVersions(ctx context.Context) (map[string]client.NodeVersion, error) {
+	return map[string]client.NodeVersion{}, nil
+}
+func (m *tuiMockClient) GetTemplates(ctx context.Context) ([]client.IndexTemplate, error) {
+	return []client.IndexTemplate{}, nil
+}
+func (m *tuiMockClient) SimulateIndex(ctx context.Context, name string) (*client.SimulatedIndex, error) {
+	return &client.SimulatedIndex{}, nil
+}
+func (m *tuiMockClient) GetMappingStats(ctx context.Context) (map[string]client.MappingStats, error) {
+	return map[string]client.MappingStats{}, nil
+}
+func (m *tuiMockClient) GetIndexMapping(ctx context.Context, index string) (map[string]any, error) {
+	if m.getIndexMappingFn != nil {
+		return m.getIndexMappingFn(ctx, index)
+	}
+	return map[string]any{}, nil
+}
+func (m *tuiMockClient) GetClusterSettings(ctx context.Context) (*client.ClusterSettings, error) {
+	if m.getClusterSettingsFn != nil {
+		return m.getClusterSettingsFn(ctx)
+	}
+	return &client.ClusterSettings{}, nil
+}
+func (m *tuiMockClient) GetDiskWatermarks(ctx context.Context) (*client.DiskWatermarks, error) {
+	if m.diskWatermarksFn != nil {
+		return m.diskWatermarksFn(ctx)
+	}
+	wm := client.DefaultDiskWatermarks()
+	return &wm, nil
+}
+func (m *tuiMockClient) UpdateClusterSettings(ctx context.Context, persistent, transient map[string]any) error {
+	if m.updateClusterSetFn != nil {
+		return m.updateClusterSetFn(ctx, persistent, transient)
+	}
+	return nil
+}
+func (m *tuiMockClient) DeleteIndex(ctx context.Context, names []string) error {
+	if m.deleteIndexFn != nil {
+		return m.deleteIndexFn(ctx, names)
+	}
+	return nil
+}
+func (m *tuiMockClient) GetIndexSettings(ctx context.Context, name string) (*client.IndexSettingsValues, error) {
+	if m.getIndexSettingsFn != nil {
+		return m.getIndexSettingsFn(ctx, name)
+	}
+	return &client.IndexSettingsValues{NumberOfReplicas: "1", RefreshInterval: "1s"}, nil
+}
+func (m *tuiMockClient) UpdateIndexSettings(ctx context.Context, names []string, settings map[string]any) error {
+	if m.updateIndexSettingsFn != nil {
+		return m.updateIndexSettingsFn(ctx, names, settings)
+	}
+	return nil
+}
+func (m *tuiMockClient) Ping(ctx context.Context) error { return nil }
+func (m *tuiMockClient) BaseURL() string                { return "http://mock:9200" }
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+const (
+	// resourcesBarWidth is the width of the usage bars on the node
+	// resources screen.
+	resourcesBarWidth = 20
+
+	// fdCritPct is the share of the file descriptor limit shown in red; it
+	// matches the engine's Critical threshold.
+	fdCritPct = 95.0
+)
+
+// resourcesNode returns the latest row of the node shown on the resources
+// screen, or false when the node is no longer reported.
+func (app *App) resourcesNode() (model.NodeRow, bool) {
+	for _, r := range app.nodeRows {
+		if r.ID == app.resourcesNodeID {
+			return r, true
+		}
+	}
+	return model.NodeRow{}, false
+}
+
+// resourceUsageLine renders one "label  bar  pct  detail" line with the bar
+// colored by sev, or a dim "not available" when pct is negative.
+func resourceUsageLine(label string, pct float64, detail string, sev severity) string {
+	if pct < 0 {
+		return fmt.Sprintf("  %-18s %s", label, StyleDim.Render("not available"))
+	}
+	bar := renderMiniBar(pct, resourcesBarWidth)
+	style := StyleGreen
+	switch sev {
+	case severityWarning:
+		style = StyleYellow
+	case severityCritical:
+		style = StyleRed
+	}
+	return fmt.Sprintf("  %-18s %s %6s  %s", label, style.Render(bar), format.FormatPercent(pct), detail)
+}
+
+// percentOf returns used as a percentage of total, or -1 when total is 0.
+func percentOf(used, total int64) float64 {
+	if total <= 0 {
+		return -1
+	}
+	return float64(used) / float64(total) * 100
+}
+
+// bytesOf formats "used of total" in bytes.
+func bytesOf(used, total int64) string {
+	return format.FormatBytes(used) + " of " + format.FormatBytes(total)
+}
+
+// buildResourcesLines returns the content lines of the node resources
+// screen for r: CPU and load, host memory and swap, heap, disk, file
+// descriptors and indexing pressure.
+func buildResourcesLines(app *App, r model.NodeRow) []string {
+	lines := []string{
+		"",
+		"  " + sanitize(r.Name) + "  " + StyleDim.Render(sanitize(r.Role)+"  "+sanitize(r.IP)),
+		"",
+	}
+
+	lines = append(lines, resourceUsageLine("CPU", r.CPUPercent, "", cpuSeverity(r.CPUPercent)))
+	if r.LoadAverage[0] >= 0 {
+		lines = append(lines, fmt.Sprintf("  %-18s %.2f  %.2f  %.2f  %s", "Load average", r.LoadAverage[0], r.LoadAverage[1], r.LoadAverage[2], StyleDim.Render("(1m 5m 15m)")))
+	} else {
+		lines = append(lines, fmt.Sprintf("  %-18s %s", "Load average", StyleDim.Render("not available")))
+	}
+
+	memPct := percentOf(r.MemUsedBytes, r.MemTotalBytes)
+	// Used host memory includes the page cache, which Elasticsearch relies
+	// on, so it is never colored as a problem.
+	lines = append(lines, resourceUsageLine("Memory", memPct, bytesOf(r.MemUsedBytes, r.MemTotalBytes), severityNormal))
+	if r.SwapTotalBytes > 0 {
+		sev := severityNormal
+		if r.SwapUsedBytes > 0 {
+			sev = severityWarning
+		}
+		lines = append(lines, resourceUsageLine("Swap", percentOf(r.SwapUsedBytes, r.SwapTotalBytes), bytesOf(r.SwapUsedBytes, r.SwapTotalBytes), sev))
+	} else {
+		lines = append(lines, fmt.Sprintf("  %-18s %s", "Swap", StyleGreen.Render("disabled")))
+	}
+
+	heapPct := percentOf(r.HeapUsedBytes, r.HeapMaxBytes)
+	lines = append(lines, resourceUsageLine("JVM heap", heapPct, bytesOf(r.HeapUsedBytes, r.HeapMaxBytes), jvmSeverity(heapPct)))
+	diskUsed := r.DiskTotalBytes - r.DiskAvailBytes
+	diskSev := severityNormal
+	switch r.DiskWatermark {
+	case model.WatermarkHigh, model.WatermarkLow:
+		diskSev = severityWarning
+	case model.WatermarkFloodStage:
+		diskSev = severityCritical
+	}
+	lines = append(lines, resourceUsageLine("Disk", percentOf(diskUsed, r.DiskTotalBytes), bytesOf(diskUsed, r.DiskTotalBytes), diskSev))
+
+	if r.MaxFDs < 0 && r.OpenFDs >= 0 {
+		lines = append(lines, fmt.Sprintf("  %-18s %s open", "File descriptors", format.FormatNumber(r.OpenFDs)))
+	} else {
+		sev := storageSeverityAt(r.FDPercent, app.fdThreshold(), fdCritPct)
+		detail := format.FormatNumber(r.OpenFDs) + " of " + format.FormatNumber(r.MaxFDs)
+		lines = append(lines, resourceUsageLine("File descriptors", r.FDPercent, detail, sev))
+	}
+
+	if r.IndexingPressurePercent >= 0 {
+		sev := storageSeverityAt(r.IndexingPressurePercent, indexingPressureWarnPct, indexingPressureCritPct)
+		lines = append(lines, resourceUsageLine("Indexing pressure", r.IndexingPressurePercent,
+			format.FormatBytes(r.IndexingPressureBytes)+" in flight, limit "+format.FormatBytes(r.IndexingPressureLimit), sev))
+	}
+	return lines
+}
+
+// fdThreshold returns the configured file descriptor warning threshold, or
+// 100 when the check is disabled.
+func (app *App) fdThreshold() float64 {
+	if app.recConfig.FDThreshold > 0 {
+		return app.recConfig.FDThreshold
+	}
+	return 100
+}
+
+// renderResourcesTitle renders the title bar for the node resources screen.
+func renderResourcesTitle(app *App, width int) string {
+	return renderTitleBar("Node Resources — "+sanitize(app.resourcesNodeName), "[n/esc: back  r: refresh]", width)
+}
+
+// renderResources renders the node resources screen for the node selected
+// when it was opened, refreshed with every poll.
+func renderResources(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderResourcesTitle(app, width)
+	availH := screenAvailHeight(app, titleBar)
+
+	var lines []string
+	if r, ok := app.resourcesNode(); ok {
+		lines = buildResourcesLines(app, r)
+	} else {
+		lines = []string{"", "  " + StyleDim.Render("Node is no longer reported by the cluster")}
+	}
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
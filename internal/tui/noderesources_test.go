@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleResourcesRow() model.NodeRow {
+	return model.NodeRow{
+		ID: "n1", Name: "es-1", Role: "dim", IP: "10.0.0.1",
+		CPUPercent:    45,
+		LoadAverage:   [3]float64{1.5, 1.25, 0.75},
+		MemTotalBytes: 16 << 30, MemUsedBytes: 12 << 30,
+		SwapTotalBytes: 2 << 30, SwapUsedBytes: 512 << 20,
+		HeapMaxBytes: 8 << 30, HeapUsedBytes: 4 << 30,
+		DiskTotalBytes: 100 << 30, DiskAvailBytes: 60 << 30,
+		OpenFDs: 900, MaxFDs: 1000, FDPercent: 90,
+		IndexingPressurePercent: -1,
+	}
+}
+
+func TestBuildResourcesLines(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	out := stripANSI(strings.Join(buildResourcesLines(app, sampleResourcesRow()), "\n"))
+
+	assert.Contains(t, out, "es-1  dim  10.0.0.1")
+	assert.Contains(t, out, "1.50  1.25  0.75")
+	assert.Contains(t, out, "12.0 GB of 16.0 GB")
+	assert.Contains(t, out, "512.0 MB of 2.0 GB")
+	assert.Contains(t, out, "4.0 GB of 8.0 GB")
+	assert.Contains(t, out, "40.0 GB of 100.0 GB")
+	assert.Contains(t, out, "900 of 1,000")
+	assert.NotContains(t, out, "Indexing pressure", "no limit reported")
+
+	r := sampleResourcesRow()
+	r.LoadAverage = [3]float64{-1, -1, -1}
+	r.SwapTotalBytes, r.SwapUsedBytes = 0, 0
+	r.OpenFDs, r.MaxFDs, r.FDPercent = -1, -1, -1
+	out = stripANSI(strings.Join(buildResourcesLines(app, r), "\n"))
+	assert.Regexp(t, `Load average\s+not available`, out)
+	assert.Regexp(t, `Swap\s+disabled`, out)
+	assert.Regexp(t, `File descriptors\s+not available`, out)
+}
+
+func TestApp_ResourcesKey_OpensForCursorNode(t *testing.T) {
+	app := newHotThreadsApp(&tuiMockClient{})
+	app.width = 120
+	app.height = 30
+	app.nodeRows = []model.NodeRow{sampleResourcesRow()}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.True(t, app.resourcesMode)
+	assert.Equal(t, "n1", app.resourcesNodeID)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "Node Resources — es-1")
+	assert.Contains(t, out, "900 of 1,000")
+
+	// The screen follows the node across polls and notices when it leaves.
+	app.nodeRows = nil
+	assert.Contains(t, renderResources(app), "no longer reported")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.resourcesMode)
+}
+
+func TestApp_ResourcesKey_NoopOnIndexTable(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.nodeTable.SetData([]model.NodeRow{{ID: "n1", Name: "es-1"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.False(t, app.resourcesMode)
+}
@@ -20,7 +20,14 @@ type NodeTableModel struct {
 	displayRows []model.NodeRow // after filter + sort applied
 }
 
-// NewNodeTable returns a NodeTableModel with 9-column layout and
+// Indexing pressure cell thresholds: percent of indexing_pressure.memory.limit
+// held by in-flight coordinating and primary bytes.
+const (
+	indexingPressureWarnPct = 60.0
+	indexingPressureCritPct = 85.0
+)
+
+// NewNodeTable returns a NodeTableModel with 12-column layout and
 // default sort by IndexingRate (col 3) descending.
 func NewNodeTable() NodeTableModel {
 	cols := []columnDef{
@@ -33,6 +40,9 @@ func NewNodeTable() NodeTableModel {
 		{Title: "Srch Lat",  Width: 9,  SortDesc: true},
 		{Title: "Shards",    Width: 7,  SortDesc: true},
 		{Title: "Disk%",     Width: 7,  SortDesc: true},
+		{Title: "Press%",    Width: 7,  SortDesc: true},
+		{Title: "Rej",       Width: 6,  SortDesc: true},
+		{Title: "Version",   Width: 8,  SortDesc: false},
 	}
 	m := NodeTableModel{
 		tableModel: newTableModel(cols),
@@ -52,6 +62,16 @@ func (m *NodeTableModel) SetData(rows []model.NodeRow) {
 	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
 }
 
+// cursorRow returns the node under the cursor, or false when the table is
+// empty.
+func (m *NodeTableModel) cursorRow() (model.NodeRow, bool) {
+	idx := m.cursorIndex(len(m.displayRows))
+	if idx < 0 {
+		return model.NodeRow{}, false
+	}
+	return m.displayRows[idx], true
+}
+
 // Update handles keyboard events for sorting, pagination, and search. It
 // delegates to the embedded tableModel and re-applies filter/sort when the
 // sort column, direction, or search term changes.
@@ -76,7 +96,11 @@ func (m NodeTableModel) Update(msg tea.Msg) (NodeTableModel, tea.Cmd) {
 // followed by the lipgloss table body for the current page.
 func (m *NodeTableModel) renderTable(app *App) string {
 	pc := pageCount(len(m.displayRows), m.pageSize)
-	hdr := m.renderHeader("Node Statistics", m.page+1, pc, m.searching, m.search)
+	title := "Node Statistics"
+	if app != nil && app.current != nil {
+		title += staleLabel(app.current, app.current.FetchedAt, model.SectionNodes, model.SectionNodeStats)
+	}
+	hdr := m.renderHeader(title, m.page+1, pc, m.searching, m.search)
 
 	// Compute proportional column widths for the current terminal width.
 	// Padding headers to these widths guides the table's natural column layout
@@ -154,7 +178,21 @@ func (m *NodeTableModel) renderTable(app *App) string {
 			case 7:
 				return base.Foreground(colorWhite)
 			case 8:
+				if row >= 0 && row < len(pageIdx) {
+					return watermarkStyle(base, m.displayRows[pageIdx[row]].DiskWatermark)
+				}
 				return base.Foreground(colorDiskYellow)
+			case 9:
+				if row >= 0 && row < len(pageIdx) {
+					pct := m.displayRows[pageIdx[row]].IndexingPressurePercent
+					return base.Foreground(severityFg(storageSeverityAt(pct, indexingPressureWarnPct, indexingPressureCritPct)))
+				}
+				return base.Foreground(colorWhite)
+			case 10:
+				if row >= 0 && row < len(pageIdx) && m.displayRows[pageIdx[row]].IndexingRejections > 0 {
+					return base.Foreground(colorRed)
+				}
+				return base.Foreground(colorWhite)
 			default:
 				return base.Foreground(colorWhite)
 			}
@@ -188,7 +226,29 @@ func (m *NodeTableModel) renderTable(app *App) string {
 	var detailLine string
 	if m.focused && len(pageIdx) > 0 && m.cursor < len(pageIdx) {
 		r := m.displayRows[pageIdx[m.cursor]]
-		detailLine = StyleDim.Render("  " + sanitize(r.Name) + "  " + sanitize(r.Role) + "  " + sanitize(r.IP))
+		detail := "  " + sanitize(r.Name) + "  " + sanitize(r.Role) + "  " + sanitize(r.IP)
+		if r.DiskTotalBytes > 0 {
+			detail += "  disk: " + format.FormatBytes(r.DiskAvailBytes) + " free of " + format.FormatBytes(r.DiskTotalBytes)
+		}
+		if r.DiskWatermark != model.WatermarkNone {
+			detail += " (" + r.DiskWatermark.String() + " watermark exceeded)"
+		}
+		if r.IndexingPressureBytes >= 0 {
+			detail += "  indexing pressure: " + format.FormatBytes(r.IndexingPressureBytes) + " in flight"
+			if r.IndexingPressureLimit > 0 {
+				detail += " (limit " + format.FormatBytes(r.IndexingPressureLimit) + ")"
+			}
+		}
+		if r.Version != "" {
+			detail += "  version " + sanitize(r.Version)
+			if r.BuildFlavor != "" {
+				detail += " (" + sanitize(r.BuildFlavor) + ")"
+			}
+			if r.JVMVersion != "" {
+				detail += ", JVM " + sanitize(r.JVMVersion)
+			}
+		}
+		detailLine = StyleDim.Render(detail)
 	}
 	if detailLine != "" {
 		return lipgloss.JoinVertical(lipgloss.Left, hdr, t.String(), detailLine)
@@ -241,7 +301,30 @@ func nodeCellValue(r model.NodeRow, col int) string {
 		if r.DiskPercent < 0 {
 			return "---"
 		}
+		if r.DiskWatermark == model.WatermarkFloodStage {
+			return format.FormatPercent(r.DiskPercent) + "!"
+		}
 		return format.FormatPercent(r.DiskPercent)
+	case 9:
+		switch {
+		case r.IndexingPressurePercent >= 0:
+			return format.FormatPercent(r.IndexingPressurePercent)
+		case r.IndexingPressureBytes >= 0:
+			// No limit reported (ES 7.9): show the bytes in flight instead.
+			return format.FormatBytes(r.IndexingPressureBytes)
+		default:
+			return "---"
+		}
+	case 10:
+		if r.IndexingRejections < 0 {
+			return "---"
+		}
+		return format.FormatNumber(r.IndexingRejections)
+	case 11:
+		if r.Version == "" {
+			return "---"
+		}
+		return sanitize(r.Version)
 	default:
 		return ""
 	}
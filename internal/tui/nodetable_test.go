@@ -101,6 +101,62 @@ func TestNodeTableDetailLine_FocusedShowsFullName(t *testing.T) {
 		"detail line should contain the node IP when focused")
 }
 
+func TestNodeTable_DiskWatermark(t *testing.T) {
+	m := NewNodeTable()
+	m.focused = true
+	m.SetData([]model.NodeRow{{
+		Name: "node-1", DiskPercent: 96, DiskTotalBytes: 100 << 30, DiskAvailBytes: 4 << 30,
+		DiskWatermark: model.WatermarkFloodStage,
+	}})
+
+	out := stripANSI(m.renderTable(nil))
+	assert.Contains(t, out, "96.0%!", "flood-stage nodes are marked like critical cards")
+	assert.Contains(t, out, "disk: 4.0 GB free of 100.0 GB (flood_stage watermark exceeded)")
+	assert.Equal(t, "42.0%", nodeCellValue(model.NodeRow{DiskPercent: 42}, 8))
+}
+
+func TestNodeTable_IndexingPressure(t *testing.T) {
+	unavailable := model.NodeRow{Name: "old", IndexingPressureBytes: -1, IndexingPressurePercent: -1, IndexingRejections: -1}
+	bytesOnly := model.NodeRow{Name: "es-7.9", IndexingPressureBytes: 2048, IndexingPressurePercent: -1, IndexingRejections: 0}
+	full := model.NodeRow{Name: "es-1", IndexingPressureBytes: 80 << 20, IndexingPressureLimit: 100 << 20, IndexingPressurePercent: 72.5, IndexingRejections: 1234}
+
+	assert.Equal(t, "---", nodeCellValue(unavailable, 9))
+	assert.Equal(t, "---", nodeCellValue(unavailable, 10))
+	assert.Equal(t, "2.0 KB", nodeCellValue(bytesOnly, 9), "bytes in flight when no limit is reported")
+	assert.Equal(t, "0", nodeCellValue(bytesOnly, 10))
+	assert.Equal(t, "72.5%", nodeCellValue(full, 9))
+	assert.Equal(t, "1,234", nodeCellValue(full, 10))
+
+	sorted := sortNodeRows([]model.NodeRow{unavailable, bytesOnly, full}, 10, true)
+	assert.Equal(t, []string{"es-1", "es-7.9", "old"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name})
+	sorted = sortNodeRows([]model.NodeRow{unavailable, full, bytesOnly}, 9, false)
+	assert.Equal(t, "es-1", sorted[0].Name, "unavailable pressure sorts last in either direction")
+
+	m := NewNodeTable()
+	m.focused = true
+	m.SetData([]model.NodeRow{full})
+	assert.Contains(t, stripANSI(m.renderTable(nil)), "indexing pressure: 80.0 MB in flight (limit 100.0 MB)")
+}
+
+func TestNodeTable_Version(t *testing.T) {
+	unknown := model.NodeRow{Name: "es-0"}
+	old := model.NodeRow{Name: "es-1", Version: "7.17.9", BuildFlavor: "default", JVMVersion: "17.0.6"}
+	upgraded := model.NodeRow{Name: "es-2", Version: "8.12.0"}
+
+	assert.Equal(t, "---", nodeCellValue(unknown, 11))
+	assert.Equal(t, "7.17.9", nodeCellValue(old, 11))
+
+	sorted := sortNodeRows([]model.NodeRow{unknown, upgraded, old}, 11, false)
+	assert.Equal(t, []string{"es-1", "es-2", "es-0"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name})
+	sorted = sortNodeRows([]model.NodeRow{unknown, old, upgraded}, 11, true)
+	assert.Equal(t, []string{"es-2", "es-1", "es-0"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name})
+
+	m := NewNodeTable()
+	m.focused = true
+	m.SetData([]model.NodeRow{old})
+	assert.Contains(t, stripANSI(m.renderTable(nil)), "version 7.17.9 (default), JVM 17.0.6")
+}
+
 // TestNodeTableDetailLine_UnfocusedAbsent verifies that the focused table
 // output is longer than the unfocused output, confirming the detail line is
 // only rendered when the table is focused.
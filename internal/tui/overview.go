@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jtsunne/epm-go/internal/engine"
 	"github.com/jtsunne/epm-go/internal/format"
 )
 
@@ -179,9 +180,11 @@ func renderOverview(app *App) string {
 		Width(cardWidths[5])).
 		Render(jvmVal + "\n" + jvmBar + "\nJVM Heap")
 
-	// Card 7: Storage% with mini bar — threshold-colored via storageSeverity.
+	// Card 7: Storage% with mini bar — colored against the low and high disk
+	// watermarks when known.
 	storagePct := res.StoragePercent
-	storageSev := storageSeverity(storagePct)
+	storageWarn, storageCrit := engine.StorageThresholds(app.current)
+	storageSev := storageSeverityAt(storagePct, storageWarn, storageCrit)
 	storageVal := fmt.Sprintf("%.1f%%", storagePct)
 	if storageSev == severityCritical {
 		storageVal += "!"
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+)
+
+// pendingPriorityRank orders ES cluster task priorities from most to least
+// urgent. Unknown priorities sort after LANGUID.
+func pendingPriorityRank(priority string) int {
+	switch strings.ToUpper(priority) {
+	case "IMMEDIATE":
+		return 0
+	case "URGENT":
+		return 1
+	case "HIGH":
+		return 2
+	case "NORMAL":
+		return 3
+	case "LOW":
+		return 4
+	case "LANGUID":
+		return 5
+	default:
+		return 6
+	}
+}
+
+// sortPendingTasks returns a copy of tasks ordered by priority (most urgent
+// first), then by insert order (oldest first) — the order the master
+// processes them in.
+func sortPendingTasks(tasks []client.PendingTask) []client.PendingTask {
+	out := make([]client.PendingTask, len(tasks))
+	copy(out, tasks)
+	sort.SliceStable(out, func(i, j int) bool {
+		ri, rj := pendingPriorityRank(out[i].Priority), pendingPriorityRank(out[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return out[i].InsertOrder < out[j].InsertOrder
+	})
+	return out
+}
+
+// pendingTasksBadge returns the header badge summarising the master queue:
+// dim "Queue 0" when empty, yellow depth + oldest age when non-empty.
+// Returns "" when the pending tasks endpoint was unavailable.
+func pendingTasksBadge(tasks []client.PendingTask) string {
+	if tasks == nil {
+		return ""
+	}
+	if len(tasks) == 0 {
+		return StyleDim.Render("Queue 0")
+	}
+	oldest := engine.OldestPendingTask(tasks)
+	return StyleYellow.Render(fmt.Sprintf("Queue %d (oldest %s)", len(tasks), format.FormatAge(oldest)))
+}
+
+// buildPendingTasksLines returns the full list of rendered content lines for
+// the pending tasks panel. Extracted so the same logic can be used both during
+// rendering and when computing the maximum scroll offset in Update().
+func buildPendingTasksLines(tasks []client.PendingTask, width int) []string {
+	lines := []string{""}
+	if tasks == nil {
+		lines = append(lines, "  "+StyleDim.Render("Pending tasks unavailable (requires cluster:monitor privilege)"))
+		return lines
+	}
+	if len(tasks) == 0 {
+		lines = append(lines, "  "+StyleGreen.Bold(true).Render("Master task queue is empty"))
+		return lines
+	}
+
+	sorted := sortPendingTasks(tasks)
+	oldest := engine.OldestPendingTask(tasks)
+	lines = append(lines, fmt.Sprintf("  %d task(s) queued, oldest %s", len(tasks), format.FormatAge(oldest)))
+
+	// Per-priority counts in processing order.
+	var counts []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && strings.EqualFold(sorted[j].Priority, sorted[i].Priority) {
+			j++
+		}
+		counts = append(counts, fmt.Sprintf("%s %d", strings.ToUpper(sanitize(sorted[i].Priority)), j-i))
+		i = j
+	}
+	lines = append(lines, "  "+StyleDim.Render(strings.Join(counts, "  ")))
+	lines = append(lines, "")
+
+	const prioW, ageW, execW = 10, 9, 5
+	lines = append(lines, StyleDim.Bold(true).Render(fmt.Sprintf("  %-*s %*s %-*s %s", prioW, "Priority", ageW, "In Queue", execW, "Exec", "Source")))
+	sourceW := width - 2 - prioW - 1 - ageW - 1 - execW - 1
+	for _, t := range sorted {
+		exec := ""
+		if t.Executing {
+			exec = "yes"
+		}
+		age := format.FormatAge(time.Duration(t.TimeInQueueMillis) * time.Millisecond)
+		prio := strings.ToUpper(sanitize(t.Priority))
+		row := fmt.Sprintf("  %-*s %*s %-*s %s", prioW, prio, ageW, age, execW, exec, truncateName(sanitize(t.Source), sourceW))
+		if pendingPriorityRank(t.Priority) <= 1 {
+			row = StyleYellow.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	return lines
+}
+
+// renderPendingTasksTitle renders the title bar for the pending tasks panel.
+func renderPendingTasksTitle(width int) string {
+	return renderTitleBar("Pending Cluster Tasks — Master Queue", "[p/esc: back  ↑↓: scroll]", width)
+}
+
+// pendingTasksMaxOffset returns the maximum valid pendingTasksScrollOffset for
+// the current app state.
+func pendingTasksMaxOffset(app *App) int {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderPendingTasksTitle(width))
+	return scrollMaxOffset(len(buildPendingTasksLines(app.pendingTasks(), width)), availH)
+}
+
+// renderPendingTasks renders the pending tasks title bar followed by the
+// scrollable task list.
+func renderPendingTasks(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderPendingTasksTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+	lines := buildPendingTasksLines(app.pendingTasks(), width)
+	return titleBar + "\n" + renderScrollLines(lines, app.pendingTasksScrollOffset, availH)
+}
+
+// pendingTasks returns the pending tasks from the current snapshot, or nil
+// before the first successful poll.
+func (app *App) pendingTasks() []client.PendingTask {
+	if app.current == nil {
+		return nil
+	}
+	return app.current.PendingTasks
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jtsunne/epm-go/internal/client"
+)
+
+func TestSortPendingTasks_PriorityThenInsertOrder(t *testing.T) {
+	tasks := []client.PendingTask{
+		{InsertOrder: 5, Priority: "NORMAL"},
+		{InsertOrder: 9, Priority: "URGENT"},
+		{InsertOrder: 3, Priority: "URGENT"},
+		{InsertOrder: 1, Priority: "LANGUID"},
+		{InsertOrder: 2, Priority: "IMMEDIATE"},
+	}
+	sorted := sortPendingTasks(tasks)
+	var order []int64
+	for _, task := range sorted {
+		order = append(order, task.InsertOrder)
+	}
+	assert.Equal(t, []int64{2, 3, 9, 5, 1}, order)
+	assert.Equal(t, int64(5), tasks[0].InsertOrder, "input slice must not be reordered")
+}
+
+func TestPendingTasksBadge(t *testing.T) {
+	assert.Empty(t, pendingTasksBadge(nil), "unavailable endpoint shows no badge")
+	assert.Equal(t, "Queue 0", stripANSI(pendingTasksBadge([]client.PendingTask{})))
+	badge := stripANSI(pendingTasksBadge([]client.PendingTask{
+		{TimeInQueueMillis: 200},
+		{TimeInQueueMillis: 12_500},
+	}))
+	assert.Equal(t, "Queue 2 (oldest 12.5s)", badge)
+}
+
+func TestRenderHeader_ShowsPendingTasksBadge(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 120
+	app.connState = stateConnected
+	snap := makeFixtureSnapshot()
+	snap.Health.ClusterName = "prod"
+	snap.Health.Status = "green"
+	snap.PendingTasks = []client.PendingTask{{Priority: "URGENT", TimeInQueueMillis: 3000}}
+	app.current = snap
+
+	result := renderHeader(app)
+	assert.Contains(t, stripANSI(result), "Queue 1 (oldest 3.0s)")
+	assert.Equal(t, 120, lipgloss.Width(result))
+}
+
+func TestBuildPendingTasksLines(t *testing.T) {
+	unavailable := strings.Join(buildPendingTasksLines(nil, 80), "\n")
+	assert.Contains(t, stripANSI(unavailable), "unavailable")
+
+	empty := strings.Join(buildPendingTasksLines([]client.PendingTask{}, 80), "\n")
+	assert.Contains(t, stripANSI(empty), "queue is empty")
+
+	lines := buildPendingTasksLines([]client.PendingTask{
+		{InsertOrder: 2, Priority: "HIGH", Source: "shard-started", TimeInQueueMillis: 800},
+		{InsertOrder: 1, Priority: "URGENT", Source: "put-mapping [logs]", Executing: true, TimeInQueueMillis: 61_000},
+	}, 80)
+	out := stripANSI(strings.Join(lines, "\n"))
+	assert.Contains(t, out, "2 task(s) queued, oldest 1m01s")
+	assert.Contains(t, out, "URGENT 1  HIGH 1")
+	assert.Less(t, strings.Index(out, "put-mapping"), strings.Index(out, "shard-started"),
+		"URGENT task must be listed before HIGH")
+}
+
+func TestApp_PendingTasksModeScrolling(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 80
+	app.height = 12
+	snap := makeFixtureSnapshot()
+	for i := 0; i < 30; i++ {
+		snap.PendingTasks = append(snap.PendingTasks, client.PendingTask{InsertOrder: int64(i), Priority: "NORMAL"})
+	}
+	app.current = snap
+	app.pendingTasksMode = true
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	newModel, _ := app.Update(down)
+	app = newModel.(*App)
+	assert.Equal(t, 1, app.pendingTasksScrollOffset)
+
+	max := pendingTasksMaxOffset(app)
+	for i := 0; i < max+10; i++ {
+		newModel, _ = app.Update(down)
+		app = newModel.(*App)
+	}
+	assert.Equal(t, max, app.pendingTasksScrollOffset, "scroll offset must be capped at max")
+
+	newModel, _ = app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	app = newModel.(*App)
+	assert.Equal(t, max-1, app.pendingTasksScrollOffset)
+}
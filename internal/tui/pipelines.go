@@ -0,0 +1,302 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// pipelineProcessorLimit caps the number of processors listed under the
+// pipeline table.
+const pipelineProcessorLimit = 10
+
+// PipelineTableModel is a sortable, paginated, searchable table of ingest
+// pipelines, refreshed on every poll.
+type PipelineTableModel struct {
+	tableModel
+	allRows     []model.PipelineRow // unfiltered source data
+	displayRows []model.PipelineRow // after filter + sort applied
+}
+
+// NewPipelineTable returns a PipelineTableModel with an 8-column layout and
+// default sort by Docs/s (col 1) descending.
+func NewPipelineTable() PipelineTableModel {
+	cols := []columnDef{
+		{Title: "Pipeline", Width: 30, SortDesc: false},
+		{Title: "Docs/s", Width: 10, SortDesc: true},
+		{Title: "Time/Doc", Width: 10, SortDesc: true},
+		{Title: "Fail/s", Width: 8, SortDesc: true},
+		{Title: "Fail%", Width: 7, SortDesc: true},
+		{Title: "Current", Width: 8, SortDesc: true},
+		{Title: "Total", Width: 14, SortDesc: true},
+		{Title: "Failed", Width: 10, SortDesc: true},
+	}
+	m := PipelineTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 1
+	m.sortDesc = true
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *PipelineTableModel) SetData(rows []model.PipelineRow) {
+	m.allRows = rows
+	m.displayRows = sortPipelineRows(filterPipelineRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m PipelineTableModel) Update(msg tea.Msg) (PipelineTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortPipelineRows(filterPipelineRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortPipelineRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Name, 1=DocsPerSec, 2=TimePerDocMs, 3=FailedPerSec, 4=FailurePercent,
+//	5=Current, 6=TotalCount, 7=TotalFailed
+//
+// col -1 means no sort (preserve order). Unknown values sort last; ties are
+// broken by name.
+func sortPipelineRows(rows []model.PipelineRow, col int, desc bool) []model.PipelineRow {
+	out := make([]model.PipelineRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var av, bv float64
+		switch col {
+		case 0:
+			cmp := strings.Compare(a.Name, b.Name)
+			if desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		case 1:
+			av, bv = a.DocsPerSec, b.DocsPerSec
+		case 2:
+			av, bv = a.TimePerDocMs, b.TimePerDocMs
+		case 3:
+			av, bv = a.FailedPerSec, b.FailedPerSec
+		case 4:
+			av, bv = a.FailurePercent, b.FailurePercent
+		case 5:
+			av, bv = float64(a.Current), float64(b.Current)
+		case 6:
+			av, bv = float64(a.TotalCount), float64(b.TotalCount)
+		case 7:
+			av, bv = float64(a.TotalFailed), float64(b.TotalFailed)
+		}
+		if aSentinel, bSentinel := av < 0, bv < 0; aSentinel != bSentinel {
+			return bSentinel // sentinel always last regardless of direction
+		}
+		cmp := compareFloat64(av, bv)
+		if cmp == 0 {
+			return a.Name < b.Name
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterPipelineRows returns rows whose name contains search
+// (case-insensitive). Returns all rows when search is empty.
+func filterPipelineRows(rows []model.PipelineRow, search string) []model.PipelineRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Name), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// pipelineCellValue formats a PipelineRow field for a given column index.
+func pipelineCellValue(r model.PipelineRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Name)
+	case 1:
+		return format.FormatRate(r.DocsPerSec)
+	case 2:
+		return format.FormatLatency(r.TimePerDocMs)
+	case 3:
+		return format.FormatRate(r.FailedPerSec)
+	case 4:
+		if r.FailurePercent < 0 {
+			return "---"
+		}
+		return format.FormatPercent(r.FailurePercent)
+	case 5:
+		return format.FormatNumber(r.Current)
+	case 6:
+		return format.FormatNumber(r.TotalCount)
+	case 7:
+		return format.FormatNumber(r.TotalFailed)
+	default:
+		return ""
+	}
+}
+
+// pipelinePanelHeight returns the number of lines reserved for the
+// processor panel: a heading plus the longest processor list, capped at
+// pipelineProcessorLimit with one line for the overflow note. It depends on
+// all rows, not the focused one, so the table does not resize as the cursor
+// moves.
+func pipelinePanelHeight(rows []model.PipelineRow) int {
+	most := 0
+	for _, r := range rows {
+		if len(r.Processors) > most {
+			most = len(r.Processors)
+		}
+	}
+	if most == 0 {
+		return 0
+	}
+	if most > pipelineProcessorLimit {
+		most = pipelineProcessorLimit + 1
+	}
+	return most + 1
+}
+
+// buildPipelineProcessorLines renders the processors of pipeline r with
+// their share of the pipeline's processing time.
+func buildPipelineProcessorLines(r model.PipelineRow, width int) []string {
+	if len(r.Processors) == 0 {
+		return nil
+	}
+	lines := []string{StyleDim.Render("  Processors of " + truncateName(sanitize(r.Name), width-20) + " (time share):")}
+	for i, p := range r.Processors {
+		if i == pipelineProcessorLimit {
+			lines = append(lines, StyleDim.Render(fmt.Sprintf("    ... and %d more", len(r.Processors)-pipelineProcessorLimit)))
+			break
+		}
+		share := "  ---"
+		bar := renderMiniBar(0, 10)
+		if p.TimePercent >= 0 {
+			share = fmt.Sprintf("%5.1f%%", p.TimePercent)
+			bar = renderMiniBar(p.TimePercent, 10)
+		}
+		line := fmt.Sprintf("  %2d. %-30s %s %s  %10s", i+1, truncateName(sanitize(p.Name), 30), bar, share, format.FormatLatency(p.TimePerDocMs))
+		if p.Failed > 0 {
+			line += "  " + StyleRed.Render(format.FormatNumber(p.Failed)+" failed")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderPipelinesTitle renders the title bar for the pipelines screen.
+func renderPipelinesTitle(width int) string {
+	return renderTitleBar("Ingest Pipelines", "[i/esc: back  r: refresh]", width)
+}
+
+// fitPipelineTable sizes the pipeline table page to the screen height, less
+// the processor panel.
+func (app *App) fitPipelineTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderPipelinesTitle(width)) - pipelinePanelHeight(app.pipelineTable.allRows)
+	app.pipelineTable.fitHeight(availH, len(app.pipelineTable.displayRows))
+}
+
+// renderPipelines renders the pipelines screen: title bar, the current page
+// of pipelines, and the processors of the pipeline under the cursor.
+func renderPipelines(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderPipelinesTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.pipelineTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case app.current.IngestStats == nil:
+		body = "\n  " + StyleDim.Render("Ingest stats unavailable (_nodes/stats/ingest failed)")
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleDim.Render("No ingest pipelines")
+	default:
+		title := fmt.Sprintf("%d pipeline(s)", len(m.allRows))
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching pipelines)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = pipelineCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch col {
+				case 1:
+					return colorGreen
+				case 2:
+					return colorCyan
+				case 3, 4:
+					if r.FailedPerSec > 0 {
+						return colorRed
+					}
+					return colorWhite
+				case 7:
+					if r.TotalFailed > 0 {
+						return colorYellow
+					}
+					return colorWhite
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			r := m.displayRows[idx]
+			body += "\n" + StyleDim.Render(fmt.Sprintf("  %s: %d processor(s), %s docs in flight", truncateName(sanitize(r.Name), width/2), len(r.Processors), format.FormatNumber(r.Current)))
+			if lines := buildPipelineProcessorLines(r, width); len(lines) > 0 {
+				body += "\n" + strings.Join(lines, "\n")
+			}
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestSortPipelineRows(t *testing.T) {
+	rows := []model.PipelineRow{
+		{Name: "a", DocsPerSec: model.MetricNotAvailable, FailurePercent: 1},
+		{Name: "b", DocsPerSec: 50, FailurePercent: model.MetricNotAvailable},
+		{Name: "c", DocsPerSec: 10, FailurePercent: 3},
+	}
+	out := sortPipelineRows(rows, 1, true)
+	assert.Equal(t, []string{"b", "c", "a"}, []string{out[0].Name, out[1].Name, out[2].Name}, "unknown rate sorts last")
+	out = sortPipelineRows(rows, 4, true)
+	assert.Equal(t, []string{"c", "a", "b"}, []string{out[0].Name, out[1].Name, out[2].Name})
+	assert.Equal(t, "---", pipelineCellValue(model.PipelineRow{FailurePercent: -1}, 4))
+	assert.Equal(t, "1,234", pipelineCellValue(model.PipelineRow{TotalCount: 1234}, 6))
+}
+
+func TestPipelinePanelHeight(t *testing.T) {
+	assert.Equal(t, 0, pipelinePanelHeight([]model.PipelineRow{{Name: "empty"}}))
+	assert.Equal(t, 3, pipelinePanelHeight([]model.PipelineRow{{Processors: make([]model.ProcessorRow, 2)}}))
+	assert.Equal(t, pipelineProcessorLimit+2, pipelinePanelHeight([]model.PipelineRow{{Processors: make([]model.ProcessorRow, 30)}}))
+}
+
+func TestApp_Pipelines_FollowPolls(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	snap := &model.Snapshot{IngestStats: &client.IngestStatsResponse{}}
+	app.Update(SnapshotMsg{Snapshot: snap, Pipelines: []model.PipelineRow{{
+		Name: "logs", DocsPerSec: 120, TimePerDocMs: 0.5, FailedPerSec: 2, FailurePercent: 1.7,
+		TotalCount: 5000, TotalFailed: 40,
+		Processors: []model.ProcessorRow{
+			{Name: "grok:parse", Type: "grok", TimePercent: 80, TimePerDocMs: 0.4, Failed: 40},
+			{Name: "set", Type: "set", TimePercent: 20, TimePerDocMs: 0.1},
+		},
+	}}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	require.True(t, app.pipelinesMode)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "1 pipeline(s)")
+	assert.Contains(t, out, "Processors of logs")
+	assert.Contains(t, out, "grok:parse")
+	assert.Contains(t, out, "80.0%")
+	assert.Contains(t, out, "40 failed")
+
+	// The open screen picks up the rows of the next poll.
+	app.Update(SnapshotMsg{Snapshot: snap, Pipelines: []model.PipelineRow{
+		{Name: "logs", DocsPerSec: 100, FailurePercent: model.MetricNotAvailable},
+		{Name: "metrics", DocsPerSec: 10, FailurePercent: model.MetricNotAvailable},
+	}})
+	require.True(t, app.pipelinesMode)
+	assert.Contains(t, stripANSI(app.View()), "2 pipeline(s)")
+}
@@ -0,0 +1,303 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// RecoveryTableModel is a sortable, paginated, searchable table of active
+// shard recoveries from _cat/recovery, refreshed on every poll.
+type RecoveryTableModel struct {
+	tableModel
+	allRows     []model.RecoveryRow // unfiltered source data
+	displayRows []model.RecoveryRow // after filter + sort applied
+}
+
+// NewRecoveryTable returns a RecoveryTableModel with a 10-column layout and
+// default sort by bytes percent (col 7) ascending, least complete first.
+func NewRecoveryTable() RecoveryTableModel {
+	cols := []columnDef{
+		{Title: "Index", Width: 24, SortDesc: false},
+		{Title: "Shard", Width: 5, SortDesc: false},
+		{Title: "Type", Width: 14, SortDesc: false},
+		{Title: "Stage", Width: 12, SortDesc: false},
+		{Title: "Source", Width: 16, SortDesc: false},
+		{Title: "Target", Width: 16, SortDesc: false},
+		{Title: "Files%", Width: 7, SortDesc: false},
+		{Title: "Bytes%", Width: 7, SortDesc: false},
+		{Title: "Rate", Width: 10, SortDesc: true},
+		{Title: "ETA", Width: 8, SortDesc: true},
+	}
+	m := RecoveryTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 7
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *RecoveryTableModel) SetData(rows []model.RecoveryRow) {
+	m.allRows = rows
+	m.displayRows = sortRecoveryRows(filterRecoveryRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m RecoveryTableModel) Update(msg tea.Msg) (RecoveryTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortRecoveryRows(filterRecoveryRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortRecoveryRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Index, 1=Shard, 2=Type, 3=Stage, 4=SourceNode, 5=TargetNode,
+//	6=FilesPercent, 7=BytesPercent, 8=Throughput, 9=ETA
+//
+// col -1 means no sort (preserve order). Ties are broken by index, then shard.
+func sortRecoveryRows(rows []model.RecoveryRow, col int, desc bool) []model.RecoveryRow {
+	out := make([]model.RecoveryRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Index), strings.ToLower(b.Index))
+		case 1:
+			cmp = compareInt64(int64(a.Shard), int64(b.Shard))
+		case 2:
+			cmp = strings.Compare(a.Type, b.Type)
+		case 3:
+			cmp = strings.Compare(a.Stage, b.Stage)
+		case 4:
+			cmp = strings.Compare(strings.ToLower(a.SourceNode), strings.ToLower(b.SourceNode))
+		case 5:
+			cmp = strings.Compare(strings.ToLower(a.TargetNode), strings.ToLower(b.TargetNode))
+		case 6:
+			cmp = compareFloat64(a.FilesPercent, b.FilesPercent)
+		case 7:
+			cmp = compareFloat64(a.BytesPercent, b.BytesPercent)
+		case 8:
+			cmp = compareFloat64(a.Throughput, b.Throughput)
+		case 9:
+			cmp = compareInt64(int64(a.ETA), int64(b.ETA))
+		}
+		if cmp == 0 {
+			if a.Index != b.Index {
+				return a.Index < b.Index
+			}
+			return a.Shard < b.Shard
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// compareFloat64 returns -1, 0 or 1 as a is less than, equal to, or greater than b.
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// filterRecoveryRows returns rows whose index, type, stage or source/target
+// node contains search (case-insensitive). Returns all rows when search is
+// empty.
+func filterRecoveryRows(rows []model.RecoveryRow, search string) []model.RecoveryRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Index), lower) ||
+			strings.Contains(strings.ToLower(r.Type), lower) ||
+			strings.Contains(strings.ToLower(r.Stage), lower) ||
+			strings.Contains(strings.ToLower(r.SourceNode), lower) ||
+			strings.Contains(strings.ToLower(r.TargetNode), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// recoveryCellValue formats a RecoveryRow field for a given column index.
+func recoveryCellValue(r model.RecoveryRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Index)
+	case 1:
+		if r.Shard < 0 {
+			return "---"
+		}
+		return strconv.Itoa(r.Shard)
+	case 2:
+		return sanitize(r.Type)
+	case 3:
+		return sanitize(r.Stage)
+	case 4:
+		if r.SourceNode == "" {
+			return "---"
+		}
+		return sanitize(r.SourceNode)
+	case 5:
+		return sanitize(r.TargetNode)
+	case 6:
+		return recoveryPercent(r.FilesPercent)
+	case 7:
+		return recoveryPercent(r.BytesPercent)
+	case 8:
+		if r.Throughput < 0 {
+			return "---"
+		}
+		return format.FormatBytes(int64(r.Throughput)) + "/s"
+	case 9:
+		if r.ETA < 0 {
+			return "---"
+		}
+		return format.FormatAge(r.ETA)
+	default:
+		return ""
+	}
+}
+
+// recoveryPercent formats a recovery percentage, "---" when not reported.
+func recoveryPercent(p float64) string {
+	if p < 0 {
+		return "---"
+	}
+	return fmt.Sprintf("%.1f%%", p)
+}
+
+// recoveryBadge returns the header badge for running recoveries, e.g.
+// "3 recoveries, 42% done". Returns "" when no recovery is active.
+func recoveryBadge(rows []model.RecoveryRow) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	noun := "recoveries"
+	if len(rows) == 1 {
+		noun = "recovery"
+	}
+	label := fmt.Sprintf("%d %s", len(rows), noun)
+	if pct := engine.RecoveryProgress(rows); pct >= 0 {
+		label += fmt.Sprintf(", %.0f%% done", pct)
+	}
+	return StyleCyan.Render(label)
+}
+
+// renderRecoveryTitle renders the title bar for the recovery screen.
+func renderRecoveryTitle(width int) string {
+	return renderTitleBar("Shard Recoveries", "[R/esc: back  r: refresh]", width)
+}
+
+// fitRecoveryTable sizes the recovery table page to the screen height.
+func (app *App) fitRecoveryTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderRecoveryTitle(width))
+	app.recoveryTable.fitHeight(availH, len(app.recoveryTable.displayRows))
+}
+
+// renderRecovery renders the recovery screen: title bar, overall progress
+// and the current page of active recoveries. Rate and ETA need two polls of
+// the same recovery and show "---" until then.
+func renderRecovery(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderRecoveryTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.recoveryTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case app.current.Recoveries == nil:
+		body = "\n  " + StyleDim.Render("Recovery status unavailable (_cat/recovery failed)")
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleGreen.Bold(true).Render("No active recoveries")
+	default:
+		title := fmt.Sprintf("%d active", len(m.allRows))
+		if pct := engine.RecoveryProgress(m.allRows); pct >= 0 {
+			title += fmt.Sprintf(", %.1f%% of bytes done", pct)
+		}
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching recoveries)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = recoveryCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch col {
+				case 8:
+					if r.Throughput == 0 {
+						return colorYellow
+					}
+					return colorCyan
+				case 4, 5:
+					return colorBlue
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			r := m.displayRows[idx]
+			detail := fmt.Sprintf("running %s", format.FormatAge(r.Elapsed))
+			if r.BytesTotal >= 0 && r.BytesRecovered >= 0 {
+				detail += fmt.Sprintf("  %s of %s", format.FormatBytes(r.BytesRecovered), format.FormatBytes(r.BytesTotal))
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
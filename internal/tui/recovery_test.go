@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleRecoveryRows() []model.RecoveryRow {
+	return []model.RecoveryRow{
+		{Index: "logs", Shard: 0, Type: "peer", Stage: "index", SourceNode: "es-1", TargetNode: "es-2",
+			FilesPercent: 50, BytesPercent: 75, BytesRecovered: 750, BytesTotal: 1000,
+			Elapsed: time.Minute, Throughput: 2048, ETA: 30 * time.Second},
+		{Index: "metrics", Shard: 3, Type: "existing_store", Stage: "translog", TargetNode: "es-3",
+			FilesPercent: 100, BytesPercent: 10, BytesRecovered: 100, BytesTotal: 1000,
+			Throughput: model.MetricNotAvailable, ETA: -1},
+	}
+}
+
+func TestSortRecoveryRows_BytesPercentAsc(t *testing.T) {
+	out := sortRecoveryRows(sampleRecoveryRows(), 7, false)
+	assert.Equal(t, "metrics", out[0].Index, "least complete first")
+	assert.Equal(t, "logs", out[1].Index)
+}
+
+func TestFilterRecoveryRows(t *testing.T) {
+	rows := sampleRecoveryRows()
+	assert.Len(t, filterRecoveryRows(rows, ""), 2)
+	assert.Len(t, filterRecoveryRows(rows, "PEER"), 1)
+	assert.Len(t, filterRecoveryRows(rows, "es-3"), 1)
+	assert.Empty(t, filterRecoveryRows(rows, "nomatch"))
+}
+
+func TestRecoveryCellValue(t *testing.T) {
+	rows := sampleRecoveryRows()
+	assert.Equal(t, "75.0%", recoveryCellValue(rows[0], 7))
+	assert.Equal(t, "2.0 KB/s", recoveryCellValue(rows[0], 8))
+	assert.Equal(t, "30.0s", recoveryCellValue(rows[0], 9))
+	assert.Equal(t, "---", recoveryCellValue(rows[1], 4), "store recoveries have no source node")
+	assert.Equal(t, "---", recoveryCellValue(rows[1], 8))
+	assert.Equal(t, "---", recoveryCellValue(rows[1], 9))
+}
+
+func TestRecoveryBadge(t *testing.T) {
+	assert.Empty(t, recoveryBadge(nil))
+	assert.Equal(t, "2 recoveries, 42% done", stripANSI(recoveryBadge(sampleRecoveryRows())))
+	assert.Equal(t, "1 recovery, 75% done", stripANSI(recoveryBadge(sampleRecoveryRows()[:1])))
+}
+
+func TestRenderHeader_ShowsRecoveryBadge(t *testing.T) {
+	app := NewApp(nil, 10*time.Second)
+	app.width = 120
+	app.connState = stateConnected
+	snap := makeFixtureSnapshot()
+	snap.Health.ClusterName = "prod"
+	snap.Health.Status = "yellow"
+	app.current = snap
+	app.recoveryRows = sampleRecoveryRows()
+
+	result := renderHeader(app)
+	assert.Contains(t, stripANSI(result), "2 recoveries, 42% done")
+	assert.Equal(t, 120, lipgloss.Width(result))
+}
+
+func TestApp_RecoveryScreen_UpdatesFromSnapshot(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 30
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	require.True(t, app.recoveryMode)
+	assert.Contains(t, renderRecovery(app), "Waiting for first poll")
+
+	snap := makeFixtureSnapshot()
+	snap.Recoveries = []client.RecoveryInfo{{Index: "logs"}, {Index: "metrics"}}
+	app.Update(SnapshotMsg{Snapshot: snap, Recoveries: sampleRecoveryRows()})
+	view := renderRecovery(app)
+	assert.Contains(t, view, "2 active, 42.5% of bytes done")
+	assert.Contains(t, view, "existing_store")
+}
+
+func TestApp_RecoveryScreen_EmptyAndUnavailable(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+
+	snap := makeFixtureSnapshot()
+	app.Update(SnapshotMsg{Snapshot: snap})
+	assert.Contains(t, renderRecovery(app), "Recovery status unavailable")
+
+	snap = makeFixtureSnapshot()
+	snap.Recoveries = []client.RecoveryInfo{}
+	app.Update(SnapshotMsg{Snapshot: snap})
+	assert.Contains(t, renderRecovery(app), "No active recoveries")
+}
+
+func TestApp_RecoveryScreen_RefreshForcesPoll(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.fetching = false
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.NotNil(t, cmd)
+	assert.True(t, app.fetching)
+	assert.True(t, app.recoveryMode, "r keeps the screen open")
+}
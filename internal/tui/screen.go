@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderTitleBar renders a full-width screen title bar with the title on the
+// left and a dimmed key hint on the right, styled like the cluster header.
+// Shared by the full-screen views (analytics, pending tasks, ...) so that
+// every screen measures and renders its title the same way.
+func renderTitleBar(title, hint string, width int) string {
+	hintText := StyleDim.Render(hint)
+	hintVW := lipgloss.Width(hintText)
+	titleVW := lipgloss.Width(title)
+	innerWidth := width - 2 // StyleHeader has Padding(0,1) -> 1 char per side
+	gap := innerWidth - titleVW - hintVW
+	if gap < 1 {
+		gap = 1
+	}
+	titleRow := title + strings.Repeat(" ", gap) + hintText
+	return StyleHeader.Width(width).MaxWidth(width).Render(titleRow)
+}
+
+// screenSize returns the terminal width and height, falling back to 80x24
+// before the first WindowSizeMsg arrives.
+func screenSize(app *App) (width, height int) {
+	width = app.width
+	if width <= 0 {
+		width = 80
+	}
+	height = app.height
+	if height <= 0 {
+		height = 24
+	}
+	return width, height
+}
+
+// screenAvailHeight returns the number of content lines available to a
+// full-screen view below titleBar. The caller (View) renders the cluster
+// header above and footer below, so both are subtracted here.
+func screenAvailHeight(app *App, titleBar string) int {
+	_, height := screenSize(app)
+	availH := height - renderedHeight(renderHeader(app)) - renderedHeight(titleBar) - renderedHeight(renderFooter(app))
+	if availH < 1 {
+		availH = 1
+	}
+	return availH
+}
+
+// scrollContentHeight returns the number of content lines visible in a
+// scroll view of availH lines. When content overflows, the last line is
+// reserved for the scroll hint.
+func scrollContentHeight(totalLines, availH int) int {
+	contentH := availH
+	if totalLines > availH && contentH > 1 {
+		contentH--
+	}
+	return contentH
+}
+
+// scrollMaxOffset returns the maximum valid scroll offset for totalLines of
+// content in a view of availH lines.
+func scrollMaxOffset(totalLines, availH int) int {
+	max := totalLines - scrollContentHeight(totalLines, availH)
+	if max < 0 {
+		max = 0
+	}
+	return max
+}
+
+// renderScrollLines renders exactly availH lines of a scrollable view: the
+// visible slice of lines starting at offset (clamped), padded with blanks,
+// plus a scroll hint on the last line when the content overflows.
+func renderScrollLines(lines []string, offset, availH int) string {
+	overflows := len(lines) > availH
+	contentH := scrollContentHeight(len(lines), availH)
+
+	// Clamp scroll offset to valid range (read-only; model state is not mutated in View).
+	maxOffset := scrollMaxOffset(len(lines), availH)
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	end := offset + contentH
+	if end > len(lines) {
+		end = len(lines)
+	}
+	var visibleLines []string
+	if offset < len(lines) {
+		visibleLines = append(visibleLines, lines[offset:end]...)
+	}
+
+	// Pad content area to contentH with empty lines.
+	for len(visibleLines) < contentH {
+		visibleLines = append(visibleLines, "")
+	}
+
+	// Append scroll hint as its own line (does not overwrite content).
+	if overflows {
+		var hint string
+		if offset == 0 {
+			hint = StyleDim.Render("  ↓ scroll for more")
+		} else if offset >= maxOffset {
+			hint = StyleDim.Render("  ↑ scroll up")
+		} else {
+			hint = StyleDim.Render("  ↑↓ scroll")
+		}
+		visibleLines = append(visibleLines, hint)
+	}
+
+	return strings.Join(visibleLines, "\n")
+}
+
+// scrollBy applies a CursorUp/CursorDown key to offset, clamping the result
+// to [0, max]. Returns offset unchanged for any other key.
+func scrollBy(offset int, up, down bool, max int) int {
+	switch {
+	case up:
+		if offset > 0 {
+			offset--
+		}
+	case down:
+		offset++
+	}
+	if offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApp_ScreenKeysOpenAndClose checks that every full-screen view opened
+// by a key from the dashboard renders its title and closes again on its own
+// key and on esc. Each view's own tests cover what it shows.
+func TestApp_ScreenKeysOpenAndClose(t *testing.T) {
+	tests := []struct {
+		key   string
+		title string
+		open  func(*App) bool
+	}{
+		{"p", "Pending Cluster Tasks", func(a *App) bool { return a.pendingTasksMode }},
+		{"t", "Running Tasks", func(a *App) bool { return a.tasksMode }},
+		{"s", "Shards — ", func(a *App) bool { return a.shardsMode }},
+		{"R", "Shard Recoveries", func(a *App) bool { return a.recoveryMode }},
+		{"b", "Snapshot Repositories", func(a *App) bool { return a.backupsMode }},
+		{"D", "Data Streams", func(a *App) bool { return a.dataStreamsMode }},
+		{"A", "Aliases", func(a *App) bool { return a.aliasesMode }},
+		{"T", "Index Templates", func(a *App) bool { return a.templatesMode }},
+		{"C", "Cluster Settings", func(a *App) bool { return a.clusterSettingsMode }},
+		{"z", "Zones", func(a *App) bool { return a.zonesMode }},
+		{"w", "Data Tiers", func(a *App) bool { return a.tiersMode }},
+		{"i", "Ingest Pipelines", func(a *App) bool { return a.pipelinesMode }},
+		{"X", "Cross-Cluster Replication", func(a *App) bool { return a.ccrMode }},
+	}
+	for _, tt := range tests {
+		for _, closeKey := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune(tt.key)}, {Type: tea.KeyEsc}} {
+			t.Run(tt.key+" closed by "+closeKey.String(), func(t *testing.T) {
+				app := NewApp(&tuiMockClient{}, 10*time.Second)
+				app.width = 120
+				app.height = 30
+				app.Update(SnapshotMsg{Snapshot: makeFixtureSnapshot()})
+
+				app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+				require.True(t, tt.open(app))
+				assert.Contains(t, stripANSI(app.View()), tt.title)
+
+				app.Update(closeKey)
+				assert.False(t, tt.open(app))
+			})
+		}
+	}
+}
@@ -245,6 +245,24 @@ func settingsUpdateCmd(c client.ESClient, names []string, settings map[string]an
 // renderSettingsForm renders the full-screen settings form overlay.
 // The caller (View) renders the cluster header above and footer below.
 func renderSettingsForm(app *App) string {
+	form := &app.settingsForm
+	nameLabel := strings.Join(form.names, ", ")
+	if len(form.names) > 1 {
+		nameLabel = fmt.Sprintf("%d indices", len(form.names))
+	}
+	// When editing multiple indices, show which index values were pre-filled from.
+	var subtitle string
+	if len(form.names) > 1 {
+		subtitle = fmt.Sprintf("  pre-filled from: %s", sanitize(form.names[0]))
+	}
+	return renderSettingsFormView(app, form,
+		fmt.Sprintf("Edit Index Settings: %s", sanitize(nameLabel)), "[ctrl+s: save  esc: cancel]", subtitle)
+}
+
+// renderSettingsFormView renders form under a title bar with the given
+// title and key hint, plus an optional dimmed subtitle line. Shared by the
+// index and cluster settings editors.
+func renderSettingsFormView(app *App, form *SettingsFormModel, titleText, hint, subtitle string) string {
 	width := app.width
 	if width <= 0 {
 		width = 80
@@ -254,15 +272,8 @@ func renderSettingsForm(app *App) string {
 		height = 24
 	}
 
-	form := &app.settingsForm
-
 	// Build title bar (same style as renderDeleteConfirm).
-	nameLabel := strings.Join(form.names, ", ")
-	if len(form.names) > 1 {
-		nameLabel = fmt.Sprintf("%d indices", len(form.names))
-	}
-	titleText := fmt.Sprintf("Edit Index Settings: %s", sanitize(nameLabel))
-	hintText := StyleDim.Render("[ctrl+s: save  esc: cancel]")
+	hintText := StyleDim.Render(hint)
 	hintVW := lipgloss.Width(hintText)
 	titleVW := lipgloss.Width(titleText)
 	innerWidth := width - 2 // StyleHeader has Padding(0,1)
@@ -274,11 +285,10 @@ func renderSettingsForm(app *App) string {
 	titleBar := StyleHeader.Width(width).MaxWidth(width).Render(titleRow)
 	titleH := lipgloss.Height(titleBar)
 
-	// When editing multiple indices, show which index values were pre-filled from.
 	var subtitleBar string
 	var subtitleH int
-	if len(form.names) > 1 {
-		subtitleText := StyleDim.Render(fmt.Sprintf("  pre-filled from: %s", sanitize(form.names[0])))
+	if subtitle != "" {
+		subtitleText := StyleDim.Render(subtitle)
 		subtitleBar = lipgloss.NewStyle().Width(width).Render(subtitleText)
 		subtitleH = lipgloss.Height(subtitleBar)
 	}
@@ -264,9 +264,16 @@ func TestApp_SnapshotMsg_SettingsPendingRefresh(t *testing.T) {
 	updated := newModel.(*App)
 
 	assert.NotEmpty(t, updated.settingsStatus, "settingsStatus must NOT be cleared by the stale SnapshotMsg")
-	assert.False(t, updated.settingsPendingRefresh, "settingsPendingRefresh must be cleared after triggering refresh")
+	assert.True(t, updated.settingsPendingRefresh, "settingsPendingRefresh stays set until a poll started after the update lands")
 	assert.True(t, updated.fetching, "a new fetch must be triggered after the stale snapshot lands")
 	require.NotNil(t, cmd)
+
+	fresh := makeFixtureMsg(snap)
+	fresh.Seq = 1
+	newModel, _ = updated.Update(fresh)
+	updated = newModel.(*App)
+	assert.False(t, updated.settingsPendingRefresh)
+	assert.Empty(t, updated.settingsStatus)
 }
 
 // TestApp_SettingsMode_EscExitsMode verifies that esc in settingsMode exits
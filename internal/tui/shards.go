@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// ShardsTableModel is a sortable, paginated, searchable table of shard
+// copies from _cat/shards.
+type ShardsTableModel struct {
+	tableModel
+	allRows     []model.ShardRow // unfiltered source data
+	displayRows []model.ShardRow // after filter + sort applied
+}
+
+// NewShardsTable returns a ShardsTableModel with a 7-column layout and
+// default sort by index (col 0) ascending.
+func NewShardsTable() ShardsTableModel {
+	cols := []columnDef{
+		{Title: "Index", Width: 30, SortDesc: false},
+		{Title: "Shard", Width: 6, SortDesc: false},
+		{Title: "P/R", Width: 4, SortDesc: false},
+		{Title: "State", Width: 13, SortDesc: false},
+		{Title: "Docs", Width: 12, SortDesc: true},
+		{Title: "Store", Width: 10, SortDesc: true},
+		{Title: "Node", Width: 24, SortDesc: false},
+	}
+	m := ShardsTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 0
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *ShardsTableModel) SetData(rows []model.ShardRow) {
+	m.allRows = rows
+	m.displayRows = sortShardRows(filterShardRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m ShardsTableModel) Update(msg tea.Msg) (ShardsTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortShardRows(filterShardRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortShardRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Index, 1=Shard, 2=Primary, 3=State, 4=Docs, 5=StoreBytes, 6=Node
+//
+// col -1 means no sort (preserve order). Ties are broken by index, shard,
+// then primary first.
+func sortShardRows(rows []model.ShardRow, col int, desc bool) []model.ShardRow {
+	out := make([]model.ShardRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Index), strings.ToLower(b.Index))
+		case 1:
+			cmp = compareInt64(int64(a.Shard), int64(b.Shard))
+		case 2:
+			// Primaries sort before replicas in ascending order.
+			cmp = compareInt64(boolToInt64(b.Primary), boolToInt64(a.Primary))
+		case 3:
+			cmp = strings.Compare(a.State, b.State)
+		case 4:
+			cmp = compareInt64(a.Docs, b.Docs)
+		case 5:
+			cmp = compareInt64(a.StoreBytes, b.StoreBytes)
+		case 6:
+			cmp = strings.Compare(strings.ToLower(a.Node), strings.ToLower(b.Node))
+		}
+		if cmp == 0 {
+			if a.Index != b.Index {
+				return a.Index < b.Index
+			}
+			if a.Shard != b.Shard {
+				return a.Shard < b.Shard
+			}
+			return a.Primary && !b.Primary
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterShardRows returns rows whose index name, node, state or unassigned
+// reason contains search (case-insensitive). Returns all rows when search is
+// empty.
+func filterShardRows(rows []model.ShardRow, search string) []model.ShardRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Index), lower) ||
+			strings.Contains(strings.ToLower(r.Node), lower) ||
+			strings.Contains(strings.ToLower(r.State), lower) ||
+			strings.Contains(strings.ToLower(r.UnassignedReason), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// priRepLabel returns "primary" or "replica".
+func priRepLabel(primary bool) string {
+	if primary {
+		return "primary"
+	}
+	return "replica"
+}
+
+// shardCellValue formats a ShardRow field for a given column index.
+func shardCellValue(r model.ShardRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Index)
+	case 1:
+		if r.Shard < 0 {
+			return "---"
+		}
+		return strconv.Itoa(r.Shard)
+	case 2:
+		if r.Primary {
+			return "p"
+		}
+		return "r"
+	case 3:
+		return sanitize(r.State)
+	case 4:
+		if r.Docs < 0 {
+			return "---"
+		}
+		return format.FormatNumber(r.Docs)
+	case 5:
+		if r.StoreBytes < 0 {
+			return "---"
+		}
+		return format.FormatBytes(r.StoreBytes)
+	case 6:
+		return sanitize(r.Node)
+	default:
+		return ""
+	}
+}
+
+// shardStateColor returns the row color for a shard state: moving and
+// missing copies stand out, started copies stay neutral.
+func shardStateColor(state string) lipgloss.TerminalColor {
+	switch state {
+	case "RELOCATING":
+		return colorCyan
+	case "INITIALIZING":
+		return colorYellow
+	case "UNASSIGNED":
+		return colorRed
+	default:
+		return colorWhite
+	}
+}
+
+// shardStateSummary returns per-state shard counts, e.g.
+// "10 STARTED  1 RELOCATING", with STARTED first and the rest alphabetical.
+func shardStateSummary(rows []model.ShardRow) string {
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.State]++
+	}
+	states := make([]string, 0, len(counts))
+	for s := range counts {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if (states[i] == "STARTED") != (states[j] == "STARTED") {
+			return states[i] == "STARTED"
+		}
+		return states[i] < states[j]
+	})
+	parts := make([]string, len(states))
+	for i, s := range states {
+		parts[i] = fmt.Sprintf("%d %s", counts[s], sanitize(s))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// shardsLoadCmd fetches _cat/shards and returns a ShardsLoadedMsg.
+// nonce is embedded in the message so the App can discard stale responses.
+func shardsLoadCmd(c client.ESClient, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shards, err := c.GetShards(ctx)
+		return ShardsLoadedMsg{Shards: shards, Err: err, Nonce: nonce}
+	}
+}
+
+// shardsScopeLabel describes the index or node the shard view is limited to.
+func (app *App) shardsScopeLabel() string {
+	switch {
+	case app.shardsIndex != "":
+		return "index " + sanitize(app.shardsIndex)
+	case app.shardsNode != "":
+		return "node " + sanitize(app.shardsNode)
+	default:
+		return "all indices"
+	}
+}
+
+// renderShardsTitle renders the title bar for the shard view.
+func renderShardsTitle(app *App, width int) string {
+	return renderTitleBar("Shards — "+app.shardsScopeLabel(), "[r: reload  s/esc: back]", width)
+}
+
+// fitShardsTable sizes the shard table page to the screen height.
+func (app *App) fitShardsTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderShardsTitle(app, width))
+	app.shardsTable.fitHeight(availH, len(app.shardsTable.displayRows))
+}
+
+// renderShards renders the shard view: title bar, table title with per-state
+// counts, and the current page of shard copies with non-STARTED states
+// highlighted.
+func renderShards(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderShardsTitle(app, width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.shardsTable
+	var body string
+	switch {
+	case app.shardsLoading && m.allRows == nil:
+		body = "\n  " + StyleDim.Render("Loading shards...")
+	case app.shardsErr != "" && m.allRows == nil:
+		body = "\n  " + StyleError.Render("Failed to load shards: "+app.shardsErr)
+	default:
+		title := fmt.Sprintf("%d shard(s)", len(m.allRows))
+		if len(m.allRows) > 0 {
+			title += ": " + shardStateSummary(m.allRows)
+		}
+		tbl := m.renderPage(width, len(m.displayRows), "(no shards)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = shardCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				return shardStateColor(m.displayRows[i].State)
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			r := m.displayRows[idx]
+			detail := sanitize(r.Node)
+			if r.UnassignedReason != "" {
+				detail = "unassigned: " + sanitize(r.UnassignedReason)
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
+
+// openShards switches to the shard view scoped to the focused dashboard row:
+// the cursor index when the index table is active, the cursor node when the
+// node table is active.
+func (app *App) openShards() tea.Cmd {
+	app.shardsIndex = ""
+	app.shardsNode = ""
+	if app.activeTable == 0 {
+		app.shardsIndex = app.indexTable.cursorRowName()
+	} else if r, ok := app.nodeTable.cursorRow(); ok {
+		app.shardsNode = r.Name
+	}
+	app.shardsNonce++
+	app.shardsMode = true
+	app.shardsLoading = true
+	app.shardsErr = ""
+	app.shardsTable = NewShardsTable()
+	app.fitShardsTable()
+	return shardsLoadCmd(app.client, app.shardsNonce)
+}
+
+// reloadShards reloads the shard list unless a load is already in flight.
+func (app *App) reloadShards() tea.Cmd {
+	if app.shardsLoading {
+		return nil
+	}
+	app.shardsNonce++
+	app.shardsLoading = true
+	return shardsLoadCmd(app.client, app.shardsNonce)
+}
+
+// applyShards scopes freshly loaded shards to the view's index or node.
+func (app *App) applyShards(shards []client.ShardInfo) {
+	rows := engine.FilterShardRows(engine.CalcShardRows(shards), app.shardsIndex, app.shardsNode)
+	app.shardsTable.SetData(rows)
+	app.fitShardsTable()
+}
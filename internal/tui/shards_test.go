@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleShardInfos() []client.ShardInfo {
+	return []client.ShardInfo{
+		{Index: "logs", Shard: "0", PriRep: "p", State: "STARTED", Docs: "100", Store: "2048", Node: "es-1"},
+		{Index: "logs", Shard: "0", PriRep: "r", State: "INITIALIZING", Node: "es-2"},
+		{Index: "metrics", Shard: "0", PriRep: "p", State: "RELOCATING", Docs: "5", Store: "10", Node: "es-1 -> 10.0.0.2 abc es-2"},
+		{Index: "metrics", Shard: "0", PriRep: "r", State: "UNASSIGNED", UnassignedReason: "NODE_LEFT"},
+	}
+}
+
+func TestSortShardRows_StoreDesc(t *testing.T) {
+	rows := []model.ShardRow{
+		{Index: "a", StoreBytes: 10},
+		{Index: "b", StoreBytes: 300},
+		{Index: "c", StoreBytes: -1},
+	}
+	out := sortShardRows(rows, 5, true)
+	assert.Equal(t, "b", out[0].Index)
+	assert.Equal(t, "a", out[1].Index)
+	assert.Equal(t, "c", out[2].Index)
+}
+
+func TestSortShardRows_PrimaryBeforeReplicaOnTie(t *testing.T) {
+	rows := []model.ShardRow{
+		{Index: "a", Shard: 0, Primary: false},
+		{Index: "a", Shard: 0, Primary: true},
+	}
+	out := sortShardRows(rows, 0, false)
+	assert.True(t, out[0].Primary)
+}
+
+func TestShardCellValue(t *testing.T) {
+	r := model.ShardRow{Index: "logs", Shard: 2, Primary: true, State: "STARTED", Docs: 1500, StoreBytes: -1, Node: "es-1"}
+	assert.Equal(t, "2", shardCellValue(r, 1))
+	assert.Equal(t, "p", shardCellValue(r, 2))
+	assert.Equal(t, "1,500", shardCellValue(r, 4))
+	assert.Equal(t, "---", shardCellValue(r, 5))
+	assert.Equal(t, "es-1", shardCellValue(r, 6))
+}
+
+func TestShardStateSummary(t *testing.T) {
+	rows := []model.ShardRow{{State: "UNASSIGNED"}, {State: "STARTED"}, {State: "STARTED"}, {State: "RELOCATING"}}
+	assert.Equal(t, "2 STARTED  1 RELOCATING  1 UNASSIGNED", shardStateSummary(rows))
+}
+
+func TestShardsLoadCmd(t *testing.T) {
+	mc := &tuiMockClient{
+		getShardsFn: func(context.Context) ([]client.ShardInfo, error) {
+			return sampleShardInfos(), nil
+		},
+	}
+	msg := shardsLoadCmd(mc, 5)()
+	result, ok := msg.(ShardsLoadedMsg)
+	require.True(t, ok, "expected ShardsLoadedMsg, got %T", msg)
+	assert.Len(t, result.Shards, 4)
+	assert.Equal(t, 5, result.Nonce)
+}
+
+func TestApp_ShardsKey_ScopesToFocusedIndex(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.indexTable.SetData([]model.IndexRow{{Name: "metrics"}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	require.NotNil(t, cmd, "s should return a shard load command")
+	assert.True(t, app.shardsMode)
+	assert.Equal(t, "metrics", app.shardsIndex)
+	assert.Empty(t, app.shardsNode)
+
+	app.Update(ShardsLoadedMsg{Shards: sampleShardInfos(), Nonce: app.shardsNonce})
+	require.Len(t, app.shardsTable.displayRows, 2)
+	view := renderShards(app)
+	assert.Contains(t, view, "Shards — index metrics")
+	assert.Contains(t, view, "1 RELOCATING  1 UNASSIGNED")
+}
+
+func TestApp_ShardsKey_ScopesToFocusedNode(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.activeTable = 1
+	app.nodeTable.SetData([]model.NodeRow{{ID: "n2", Name: "es-2"}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	assert.Equal(t, "es-2", app.shardsNode)
+	assert.Empty(t, app.shardsIndex)
+
+	app.Update(ShardsLoadedMsg{Shards: sampleShardInfos(), Nonce: app.shardsNonce})
+	// The INITIALIZING replica on es-2 and the shard relocating onto es-2.
+	assert.Len(t, app.shardsTable.displayRows, 2)
+}
+
+func TestApp_Shards_StaleAndError(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+
+	app.Update(ShardsLoadedMsg{Shards: sampleShardInfos(), Nonce: app.shardsNonce - 1})
+	assert.Nil(t, app.shardsTable.allRows, "stale responses are dropped")
+
+	app.Update(ShardsLoadedMsg{Err: errors.New("timeout"), Nonce: app.shardsNonce})
+	assert.Contains(t, renderShards(app), "Failed to load shards: timeout")
+}
+
+func TestApp_Shards_ReloadAndClose(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	app.Update(ShardsLoadedMsg{Shards: sampleShardInfos(), Nonce: app.shardsNonce})
+
+	nonce := app.shardsNonce
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, nonce+1, app.shardsNonce)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, app.shardsMode)
+}
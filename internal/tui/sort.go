@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jtsunne/epm-go/internal/engine"
 	"github.com/jtsunne/epm-go/internal/model"
 )
 
@@ -11,9 +12,11 @@ import (
 // Column mapping:
 //
 //	0=Name, 1=PrimaryShards, 2=TotalSizeBytes, 3=AvgShardSize, 4=DocCount,
-//	5=IndexingRate, 6=SearchRate, 7=IndexLatency, 8=SearchLatency
+//	5=IndexingRate, 6=SearchRate, 7=IndexLatency, 8=SearchLatency,
+//	9=FieldLimitUsage, 10=DeletedDocsPercent
 //
-// col -1 means no sort (preserve order).
+// col -1 means no sort (preserve order). Any other column (e.g. 11=Aliases)
+// sorts by Name.
 // Ties are broken by Name ascending.
 func sortIndexRows(rows []model.IndexRow, col int, desc bool) []model.IndexRow {
 	out := make([]model.IndexRow, len(rows))
@@ -89,6 +92,27 @@ func sortIndexRows(rows []model.IndexRow, col int, desc bool) []model.IndexRow {
 			} else {
 				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
 			}
+		case 9:
+			// Sort by share of the limit so the closest to a mapping explosion
+			// come first; indices without mapping stats always sort last.
+			ua, ub := engine.FieldLimitUsage(a), engine.FieldLimitUsage(b)
+			if aSentinel, bSentinel := ua < 0, ub < 0; aSentinel != bSentinel {
+				return bSentinel
+			} else if ua != ub {
+				less = ua < ub
+			} else {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		case 10:
+			// Indices without docs.deleted always sort last.
+			da, db := engine.DeletedDocsPercent(a), engine.DeletedDocsPercent(b)
+			if aSentinel, bSentinel := da < 0, db < 0; aSentinel != bSentinel {
+				return bSentinel
+			} else if da != db {
+				less = da < db
+			} else {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
 		default:
 			la, lb := strings.ToLower(a.Name), strings.ToLower(b.Name)
 			if la == lb {
@@ -108,7 +132,8 @@ func sortIndexRows(rows []model.IndexRow, col int, desc bool) []model.IndexRow {
 // Column mapping:
 //
 //	0=Name, 1=Role, 2=IP, 3=IndexingRate, 4=SearchRate, 5=IndexLatency, 6=SearchLatency,
-//	7=Shards, 8=DiskPercent
+//	7=Shards, 8=DiskPercent, 9=IndexingPressurePercent, 10=IndexingRejections,
+//	11=Version
 //
 // Ties are broken by Name ascending.
 func sortNodeRows(rows []model.NodeRow, col int, desc bool) []model.NodeRow {
@@ -189,6 +214,31 @@ func sortNodeRows(rows []model.NodeRow, col int, desc bool) []model.NodeRow {
 			} else {
 				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
 			}
+		case 9:
+			if aSentinel, bSentinel := a.IndexingPressurePercent < 0, b.IndexingPressurePercent < 0; aSentinel != bSentinel {
+				return bSentinel
+			} else if a.IndexingPressurePercent != b.IndexingPressurePercent {
+				less = a.IndexingPressurePercent < b.IndexingPressurePercent
+			} else {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		case 10:
+			if aSentinel, bSentinel := a.IndexingRejections < 0, b.IndexingRejections < 0; aSentinel != bSentinel {
+				return bSentinel
+			} else if a.IndexingRejections != b.IndexingRejections {
+				less = a.IndexingRejections < b.IndexingRejections
+			} else {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		case 11:
+			// Nodes without a reported version always sort last.
+			if aSentinel, bSentinel := a.Version == "", b.Version == ""; aSentinel != bSentinel {
+				return bSentinel
+			} else if c := engine.CompareVersions(a.Version, b.Version); c != 0 {
+				less = c < 0
+			} else {
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
 		default:
 			la, lb := strings.ToLower(a.Name), strings.ToLower(b.Name)
 			if la == lb {
@@ -204,8 +254,8 @@ func sortNodeRows(rows []model.NodeRow, col int, desc bool) []model.NodeRow {
 	return out
 }
 
-// filterIndexRows returns rows whose Name contains search (case-insensitive).
-// Returns all rows when search is empty.
+// filterIndexRows returns rows whose Name or any alias contains search
+// (case-insensitive). Returns all rows when search is empty.
 func filterIndexRows(rows []model.IndexRow, search string) []model.IndexRow {
 	if search == "" {
 		return rows
@@ -213,13 +263,23 @@ func filterIndexRows(rows []model.IndexRow, search string) []model.IndexRow {
 	lower := strings.ToLower(search)
 	out := rows[:0:0]
 	for _, r := range rows {
-		if strings.Contains(strings.ToLower(r.Name), lower) {
+		if strings.Contains(strings.ToLower(r.Name), lower) || aliasMatches(r.Aliases, lower) {
 			out = append(out, r)
 		}
 	}
 	return out
 }
 
+// aliasMatches reports whether any alias contains the lowercased search term.
+func aliasMatches(aliases []string, lower string) bool {
+	for _, a := range aliases {
+		if strings.Contains(strings.ToLower(a), lower) {
+			return true
+		}
+	}
+	return false
+}
+
 // filterNodeRows returns rows whose Name or IP contains search (case-insensitive).
 // Returns all rows when search is empty.
 func filterNodeRows(rows []model.NodeRow, search string) []model.NodeRow {
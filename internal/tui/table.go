@@ -1,9 +1,14 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	ltable "github.com/charmbracelet/lipgloss/table"
 	"github.com/mattn/go-runewidth"
 )
 
@@ -107,7 +112,7 @@ func (t tableModel) Update(msg tea.Msg) (tableModel, tea.Cmd) {
 			t.cursor++
 			return t, nil
 		default:
-			// Digit keys 1-9 → set sort column.
+			// Digit keys 1-9, 0 → set sort column.
 			col := digitToCol(msg.String())
 			if col >= 0 && col < len(t.columns) {
 				if col == t.sortCol {
@@ -127,12 +132,15 @@ func (t tableModel) Update(msg tea.Msg) (tableModel, tea.Cmd) {
 	return t, nil
 }
 
-// digitToCol converts a "1"–"9" key string to a 0-indexed column number.
-// Returns -1 for any other string.
+// digitToCol converts a "1"–"9" key string to a 0-indexed column number,
+// and "0" to the tenth column. Returns -1 for any other string.
 func digitToCol(s string) int {
 	if len(s) == 1 && s[0] >= '1' && s[0] <= '9' {
 		return int(s[0]-'1')
 	}
+	if s == "0" {
+		return 9
+	}
 	return -1
 }
 
@@ -166,6 +174,38 @@ func currentPageIndices(allIndices []int, page, pageSize int) []int {
 	return allIndices[start:end]
 }
 
+// searchState reports whether the search input is open and the filter that
+// is applied.
+func (t tableModel) searchState() (searching bool, search string) {
+	return t.searching, t.search
+}
+
+// screenTable is a table model shown as a full-screen view.
+type screenTable[T any] interface {
+	Update(msg tea.Msg) (T, tea.Cmd)
+	searchState() (searching bool, search string)
+}
+
+// updateScreenTable handles a key in a full-screen table view. Keys go to the
+// table while its search input is open, and esc clears an applied filter
+// before it closes the view. Esc or the view's own key (toggle) calls close,
+// r calls reload, and all other keys drive the table.
+func updateScreenTable[T screenTable[T]](table *T, msg tea.KeyMsg, toggle key.Binding, close func(), reload func() tea.Cmd) tea.Cmd {
+	var cmd tea.Cmd
+	searching, search := (*table).searchState()
+	switch {
+	case searching, key.Matches(msg, keys.Escape) && search != "":
+		*table, cmd = (*table).Update(msg)
+	case key.Matches(msg, keys.Escape), key.Matches(msg, toggle):
+		close()
+	case key.Matches(msg, keys.Refresh):
+		cmd = reload()
+	default:
+		*table, cmd = (*table).Update(msg)
+	}
+	return cmd
+}
+
 // clampPage ensures the page index stays within valid bounds given the total
 // number of rows and the configured pageSize.
 func (t *tableModel) clampPage(totalRows int) {
@@ -283,3 +323,133 @@ func columnWidths(available int, defs []columnDef) []int {
 	}
 	return result
 }
+
+// screenTableOverhead is the number of lines a full-screen table spends on
+// chrome: the title line, column header row, header separator and the
+// detail line below the rows.
+const screenTableOverhead = 4
+
+// fitHeight sizes the page so the table and its chrome fill availH lines,
+// then clamps page and cursor for totalRows rows.
+func (t *tableModel) fitHeight(availH, totalRows int) {
+	t.pageSize = availH - screenTableOverhead
+	if t.pageSize < 1 {
+		t.pageSize = 1
+	}
+	t.clampPage(totalRows)
+	t.clampCursor(t.currentPageRowCount(totalRows))
+}
+
+// cursorIndex returns the index into the display rows of the row under the
+// cursor, or -1 when the table is empty.
+func (t *tableModel) cursorIndex(totalRows int) int {
+	if totalRows == 0 {
+		return -1
+	}
+	start := t.page * t.pageSize
+	if start >= totalRows {
+		start = 0
+	}
+	idx := start + t.cursor
+	if idx >= totalRows {
+		return -1
+	}
+	return idx
+}
+
+// renderTitle renders a table title line with search/sort/page hints, in
+// the same format as the dashboard tables. extra is appended to the hints.
+func (t *tableModel) renderTitle(title string, totalRows int, extra string) string {
+	pageInfo := fmt.Sprintf("Page %d/%d", t.page+1, pageCount(totalRows, t.pageSize))
+
+	var right string
+	switch {
+	case t.searching:
+		right = "Search: " + t.input.View()
+	case t.search != "":
+		right = fmt.Sprintf("filter=%q  %s", t.search, pageInfo)
+	default:
+		right = fmt.Sprintf("[/: search]  [1-9: sort]  [←→: page]%s  %s", extra, pageInfo)
+	}
+	return StyleDim.Render(title + "  " + right)
+}
+
+// renderPage renders the current page of a table with totalRows display rows
+// in the dashboard table style. cells returns the formatted cell values for
+// display row i; fg returns the foreground color for a cell. The cursor row
+// is highlighted and alternating rows are shaded. The first column is
+// truncated to its allotted width so long names never wrap.
+func (t *tableModel) renderPage(width, totalRows int, empty string, cells func(i int) []string, fg func(i, col int) lipgloss.TerminalColor) string {
+	allIdx := make([]int, totalRows)
+	for i := range allIdx {
+		allIdx[i] = i
+	}
+	pageIdx := currentPageIndices(allIdx, t.page, t.pageSize)
+	if len(pageIdx) == 0 {
+		return StyleDim.Render("  " + empty)
+	}
+
+	var colWidths []int
+	if width > 0 {
+		colWidths = columnWidths(width, t.columns)
+	}
+
+	headers := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		h := c.Title
+		if i == t.sortCol {
+			if t.sortDesc {
+				h += "↓"
+			} else {
+				h += "↑"
+			}
+		}
+		if len(colWidths) == len(t.columns) && runewidth.StringWidth(h) < colWidths[i] {
+			h += strings.Repeat(" ", colWidths[i]-runewidth.StringWidth(h))
+		}
+		headers[i] = h
+	}
+
+	sortCol := t.sortCol
+	focused := t.focused
+	cursor := t.cursor
+	tbl := ltable.New().
+		Headers(headers...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == ltable.HeaderRow {
+				if col == sortCol {
+					return lipgloss.NewStyle().Bold(true).Foreground(colorBlue)
+				}
+				return lipgloss.NewStyle().Bold(true).Foreground(colorGray)
+			}
+			base := lipgloss.NewStyle()
+			if focused && row == cursor {
+				base = base.Background(colorSelectedBg)
+			} else if row%2 == 0 {
+				base = base.Background(colorAlt)
+			}
+			if row >= 0 && row < len(pageIdx) {
+				return base.Foreground(fg(pageIdx[row], col))
+			}
+			return base.Foreground(colorWhite)
+		}).
+		BorderStyle(lipgloss.NewStyle().Foreground(colorGray)).
+		BorderTop(false).
+		BorderBottom(false).
+		BorderLeft(false).
+		BorderRight(false).
+		BorderHeader(true).
+		BorderColumn(false)
+	if width > 0 {
+		tbl = tbl.Width(width)
+	}
+
+	for _, idx := range pageIdx {
+		row := cells(idx)
+		if len(colWidths) > 0 && len(row) > 0 {
+			row[0] = truncateName(row[0], colWidths[0])
+		}
+		tbl = tbl.Row(row...)
+	}
+	return tbl.String()
+}
@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// TasksTableModel is a sortable, paginated, searchable table of running
+// top-level tasks from _tasks.
+type TasksTableModel struct {
+	tableModel
+	allRows     []model.TaskRow // unfiltered source data
+	displayRows []model.TaskRow // after filter + sort applied
+}
+
+// NewTasksTable returns a TasksTableModel with a 6-column layout and
+// default sort by running time (col 2) descending, longest first.
+func NewTasksTable() TasksTableModel {
+	cols := []columnDef{
+		{Title: "Action", Width: 28, SortDesc: false},
+		{Title: "Node", Width: 14, SortDesc: false},
+		{Title: "Running", Width: 9, SortDesc: true},
+		{Title: "Description", Width: 40, SortDesc: false},
+		{Title: "Cancel", Width: 7, SortDesc: true},
+		{Title: "Children", Width: 8, SortDesc: true},
+	}
+	m := TasksTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 2
+	m.sortDesc = true
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *TasksTableModel) SetData(rows []model.TaskRow) {
+	m.allRows = rows
+	m.displayRows = sortTaskRows(filterTaskRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m TasksTableModel) Update(msg tea.Msg) (TasksTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortTaskRows(filterTaskRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// cursorRow returns the task under the cursor, or false when the table is empty.
+func (m *TasksTableModel) cursorRow() (model.TaskRow, bool) {
+	idx := m.cursorIndex(len(m.displayRows))
+	if idx < 0 {
+		return model.TaskRow{}, false
+	}
+	return m.displayRows[idx], true
+}
+
+// sortTaskRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Action, 1=Node, 2=RunningTime, 3=Description, 4=Cancellable, 5=Children
+//
+// col -1 means no sort (preserve order). Ties are broken by TaskID ascending.
+func sortTaskRows(rows []model.TaskRow, col int, desc bool) []model.TaskRow {
+	out := make([]model.TaskRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Action), strings.ToLower(b.Action))
+		case 1:
+			cmp = strings.Compare(strings.ToLower(a.Node), strings.ToLower(b.Node))
+		case 2:
+			cmp = compareInt64(int64(a.RunningTime), int64(b.RunningTime))
+		case 3:
+			cmp = strings.Compare(strings.ToLower(a.Description), strings.ToLower(b.Description))
+		case 4:
+			cmp = compareInt64(boolToInt64(a.Cancellable), boolToInt64(b.Cancellable))
+		case 5:
+			cmp = compareInt64(int64(a.Children), int64(b.Children))
+		}
+		if cmp == 0 {
+			return a.TaskID < b.TaskID
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterTaskRows returns rows whose action, node, description or task ID
+// contains search (case-insensitive). Returns all rows when search is empty.
+func filterTaskRows(rows []model.TaskRow, search string) []model.TaskRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Action), lower) ||
+			strings.Contains(strings.ToLower(r.Node), lower) ||
+			strings.Contains(strings.ToLower(r.Description), lower) ||
+			strings.Contains(strings.ToLower(r.TaskID), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// compareInt64 returns -1, 0 or 1 as a is less than, equal to, or greater than b.
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// boolToInt64 maps false to 0 and true to 1 for sorting.
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// taskCellValue formats a TaskRow field for a given column index.
+func taskCellValue(r model.TaskRow, col int) string {
+	switch col {
+	case 0:
+		return sanitize(r.Action)
+	case 1:
+		return sanitize(r.Node)
+	case 2:
+		return format.FormatAge(r.RunningTime)
+	case 3:
+		return sanitize(r.Description)
+	case 4:
+		switch {
+		case r.Cancelled:
+			return "cancelled"
+		case r.Cancellable:
+			return "yes"
+		default:
+			return "no"
+		}
+	case 5:
+		return strconv.Itoa(r.Children)
+	default:
+		return ""
+	}
+}
+
+// tasksLoadCmd fetches the running task list and returns a TasksLoadedMsg.
+// nonce is embedded in the message so the App can discard stale responses.
+func tasksLoadCmd(c client.ESClient, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		tasks, err := c.GetTasks(ctx)
+		return TasksLoadedMsg{Tasks: tasks, Err: err, Nonce: nonce}
+	}
+}
+
+// taskCancelCmd issues POST _tasks/<id>/_cancel and returns a
+// TaskCancelResultMsg with the outcome.
+func taskCancelCmd(c client.ESClient, taskID string, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := c.CancelTask(ctx, taskID)
+		return TaskCancelResultMsg{TaskID: taskID, Err: err, Nonce: nonce}
+	}
+}
+
+// renderTasksTitle renders the title bar for the running tasks screen.
+func renderTasksTitle(width int) string {
+	return renderTitleBar("Running Tasks", "[t/esc: back  r: reload  c: cancel task]", width)
+}
+
+// fitTasksTable sizes the tasks table page to the screen height.
+func (app *App) fitTasksTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderTasksTitle(width))
+	app.tasksTable.fitHeight(availH, len(app.tasksTable.displayRows))
+}
+
+// renderTasks renders the running tasks screen: title bar, table title,
+// the current page of tasks and a detail line for the cursor row.
+func renderTasks(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderTasksTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.tasksTable
+	var body string
+	switch {
+	case app.tasksLoading && m.allRows == nil:
+		body = "\n  " + StyleDim.Render("Loading tasks...")
+	case app.tasksErr != "" && m.allRows == nil:
+		body = "\n  " + StyleError.Render("Failed to load tasks: "+app.tasksErr)
+	default:
+		title := fmt.Sprintf("%d task(s)", len(m.displayRows))
+		if app.tasksLoading {
+			title += "  (reloading...)"
+		}
+		tbl := m.renderPage(width, len(m.displayRows), "(no running tasks)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = taskCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch col {
+				case 2:
+					if r.RunningTime >= time.Minute {
+						return colorYellow
+					}
+					return colorCyan
+				case 4:
+					if r.Cancelled {
+						return colorGray
+					}
+					if r.Cancellable {
+						return colorGreen
+					}
+					return colorGray
+				case 1:
+					return colorBlue
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if r, ok := m.cursorRow(); ok {
+			detail := sanitize(r.TaskID) + "  " + sanitize(r.Description)
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
+
+// renderTaskCancelConfirm renders a full-screen confirmation dialog for
+// cancelling the selected task, following the delete confirmation layout.
+func renderTaskCancelConfirm(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderTitleBar("Cancel Task Confirmation", "[y: confirm  n/esc: cancel]", width)
+	availH := screenAvailHeight(app, titleBar)
+
+	t := app.pendingCancelTask
+	lines := []string{
+		"",
+		"  " + StyleRed.Bold(true).Render("WARNING: Work already done by the task is not rolled back."),
+		"",
+		"  The following task will be cancelled:",
+		"",
+		"    Task:     " + sanitize(t.TaskID),
+		"    Action:   " + sanitize(t.Action),
+		"    Node:     " + sanitize(t.Node),
+		"    Running:  " + format.FormatAge(t.RunningTime),
+		"    " + truncateName("Desc:     "+sanitize(t.Description), width-4),
+	}
+	if t.Children > 0 {
+		lines = append(lines, "", fmt.Sprintf("  %d child task(s) will be cancelled with it.", t.Children))
+	}
+	footer := []string{
+		"",
+		"  " + StyleYellow.Render("Press y to confirm, n or esc to cancel."),
+	}
+
+	// The confirmation prompt always stays visible: trim details first.
+	if keep := availH - len(footer); len(lines) > keep {
+		if keep < 0 {
+			keep = 0
+		}
+		lines = lines[:keep]
+	}
+	lines = append(lines, footer...)
+	if len(lines) > availH {
+		lines = lines[len(lines)-availH:]
+	}
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleTaskRows() []model.TaskRow {
+	return []model.TaskRow{
+		{TaskID: "n1:1", Node: "es-1", Action: "indices:data/write/reindex", Description: "reindex from [logs] to [logs-v2]", RunningTime: 5 * time.Minute, Cancellable: true, Children: 4},
+		{TaskID: "n2:7", Node: "es-2", Action: "indices:data/read/search", Description: "indices[metrics-*]", RunningTime: 3 * time.Second, Cancellable: true},
+		{TaskID: "n1:9", Node: "es-1", Action: "cluster:monitor/tasks/lists", RunningTime: time.Millisecond},
+	}
+}
+
+// openTasksScreen puts app into tasks mode with rows loaded.
+func openTasksScreen(t *testing.T, app *App, rows []model.TaskRow) {
+	t.Helper()
+	newModel, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	require.NotNil(t, cmd, "t should return a tasks load command")
+	updated := newModel.(*App)
+	require.True(t, updated.tasksMode)
+	require.True(t, updated.tasksLoading)
+	updated.tasksLoading = false
+	updated.tasksTable.SetData(rows)
+}
+
+func TestSortTaskRows_RunningTimeDesc(t *testing.T) {
+	out := sortTaskRows(sampleTaskRows(), 2, true)
+	require.Len(t, out, 3)
+	assert.Equal(t, "n1:1", out[0].TaskID)
+	assert.Equal(t, "n2:7", out[1].TaskID)
+	assert.Equal(t, "n1:9", out[2].TaskID)
+}
+
+func TestSortTaskRows_ActionAscTieBreak(t *testing.T) {
+	rows := []model.TaskRow{
+		{TaskID: "b:2", Action: "x"},
+		{TaskID: "a:1", Action: "x"},
+		{TaskID: "c:3", Action: "a"},
+	}
+	out := sortTaskRows(rows, 0, false)
+	assert.Equal(t, []string{"c:3", "a:1", "b:2"}, []string{out[0].TaskID, out[1].TaskID, out[2].TaskID})
+}
+
+func TestFilterTaskRows(t *testing.T) {
+	rows := sampleTaskRows()
+	assert.Len(t, filterTaskRows(rows, ""), 3)
+	assert.Len(t, filterTaskRows(rows, "REINDEX"), 1)
+	assert.Len(t, filterTaskRows(rows, "es-1"), 2)
+	assert.Len(t, filterTaskRows(rows, "metrics-"), 1)
+	assert.Len(t, filterTaskRows(rows, "n2:7"), 1)
+	assert.Empty(t, filterTaskRows(rows, "nomatch"))
+}
+
+func TestTaskCellValue(t *testing.T) {
+	r := sampleTaskRows()[0]
+	assert.Equal(t, "5m00s", taskCellValue(r, 2))
+	assert.Equal(t, "yes", taskCellValue(r, 4))
+	assert.Equal(t, "4", taskCellValue(r, 5))
+	r.Cancelled = true
+	assert.Equal(t, "cancelled", taskCellValue(r, 4))
+	assert.Equal(t, "no", taskCellValue(sampleTaskRows()[2], 4))
+}
+
+func TestTasksLoadCmd(t *testing.T) {
+	mc := &tuiMockClient{
+		getTasksFn: func(context.Context) ([]client.TaskInfo, error) {
+			return []client.TaskInfo{{Node: "n1", ID: 1}}, nil
+		},
+	}
+	msg := tasksLoadCmd(mc, 3)()
+	result, ok := msg.(TasksLoadedMsg)
+	require.True(t, ok, "expected TasksLoadedMsg, got %T", msg)
+	assert.NoError(t, result.Err)
+	assert.Len(t, result.Tasks, 1)
+	assert.Equal(t, 3, result.Nonce)
+}
+
+func TestTaskCancelCmd(t *testing.T) {
+	var gotID string
+	mc := &tuiMockClient{
+		cancelTaskFn: func(_ context.Context, id string) error {
+			gotID = id
+			return nil
+		},
+	}
+	msg := taskCancelCmd(mc, "n1:1", 2)()
+	result, ok := msg.(TaskCancelResultMsg)
+	require.True(t, ok, "expected TaskCancelResultMsg, got %T", msg)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "n1:1", gotID)
+	assert.Equal(t, "n1:1", result.TaskID)
+}
+
+func TestApp_TasksLoadedMsg_PopulatesTable(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+
+	app.Update(TasksLoadedMsg{
+		Tasks: []client.TaskInfo{{Node: "n1", ID: 1, Action: "a", RunningTimeInNanos: 10}},
+		Nonce: app.tasksNonce,
+	})
+	assert.False(t, app.tasksLoading)
+	require.Len(t, app.tasksTable.displayRows, 1)
+	assert.Equal(t, "n1:1", app.tasksTable.displayRows[0].TaskID)
+
+	// Stale responses from a previous session are dropped.
+	app.Update(TasksLoadedMsg{Tasks: []client.TaskInfo{}, Nonce: app.tasksNonce - 1})
+	assert.Len(t, app.tasksTable.displayRows, 1)
+}
+
+func TestApp_TasksLoadedMsg_Error(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	app.Update(TasksLoadedMsg{Err: errors.New("forbidden"), Nonce: app.tasksNonce})
+	assert.Equal(t, "forbidden", app.tasksErr)
+	assert.Contains(t, renderTasks(app), "Failed to load tasks")
+}
+
+func TestApp_TasksScreen_CancelConfirmFlow(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openTasksScreen(t, app, sampleTaskRows())
+
+	// Default sort puts the 5m reindex first.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	assert.Nil(t, cmd)
+	require.True(t, app.tasksCancelConfirm, "c should open the cancel confirmation")
+	assert.Equal(t, "n1:1", app.pendingCancelTask.TaskID)
+
+	view := renderTaskCancelConfirm(app)
+	assert.Contains(t, view, "n1:1")
+	assert.Contains(t, view, "4 child task(s)")
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.False(t, app.tasksCancelConfirm)
+	require.NotNil(t, cmd, "y should return a cancel command")
+
+	// Result sets a status and reloads the list.
+	_, cmd = app.Update(TaskCancelResultMsg{TaskID: "n1:1", Nonce: app.tasksNonce})
+	assert.Equal(t, "Cancelled task n1:1", app.tasksStatus)
+	assert.False(t, app.tasksStatusErr)
+	assert.True(t, app.tasksLoading)
+	assert.NotNil(t, cmd)
+	assert.Contains(t, renderFooter(app), "Cancelled task n1:1")
+}
+
+func TestApp_TasksScreen_CancelConfirmNAborts(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openTasksScreen(t, app, sampleTaskRows())
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	require.True(t, app.tasksCancelConfirm)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	assert.False(t, app.tasksCancelConfirm)
+	assert.True(t, app.tasksMode, "n should return to the tasks list")
+	assert.Nil(t, cmd)
+}
+
+func TestApp_TasksScreen_CancelNotCancellable(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openTasksScreen(t, app, []model.TaskRow{sampleTaskRows()[2]})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	assert.False(t, app.tasksCancelConfirm)
+	assert.True(t, app.tasksStatusErr)
+	assert.Contains(t, app.tasksStatus, "not cancellable")
+}
+
+func TestApp_TasksScreen_CancelError(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	openTasksScreen(t, app, sampleTaskRows())
+	app.Update(TaskCancelResultMsg{TaskID: "n1:1", Err: errors.New("boom"), Nonce: app.tasksNonce})
+	assert.True(t, app.tasksStatusErr)
+	assert.True(t, strings.HasPrefix(app.tasksStatus, "Cancel failed"))
+}
+
+func TestRenderTasks_ShowsRows(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 30
+	openTasksScreen(t, app, sampleTaskRows())
+	app.fitTasksTable()
+	view := renderTasks(app)
+	assert.Contains(t, view, "Running Tasks")
+	assert.Contains(t, view, "indices:data/write/reindex")
+	assert.Contains(t, view, "3 task(s)")
+}
@@ -0,0 +1,432 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/engine"
+)
+
+// TemplatesTableModel is a sortable, paginated, searchable table of
+// composable, component and legacy templates.
+type TemplatesTableModel struct {
+	tableModel
+	allRows     []client.IndexTemplate // unfiltered source data
+	displayRows []client.IndexTemplate // after filter + sort applied
+	applied     map[string]bool        // "<kind>/<name>" of templates matching the scoped index → applied
+}
+
+// NewTemplatesTable returns a TemplatesTableModel with an 8-column layout and
+// default sort by name (col 0) ascending.
+func NewTemplatesTable() TemplatesTableModel {
+	cols := []columnDef{
+		{Title: "Template", Width: 24, SortDesc: false},
+		{Title: "Type", Width: 10, SortDesc: false},
+		{Title: "Patterns", Width: 24, SortDesc: false},
+		{Title: "Priority", Width: 8, SortDesc: true},
+		{Title: "Composed Of", Width: 24, SortDesc: false},
+		{Title: "Shards", Width: 6, SortDesc: true},
+		{Title: "Replicas", Width: 8, SortDesc: true},
+		{Title: "ILM Policy", Width: 16, SortDesc: false},
+	}
+	m := TemplatesTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 0
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *TemplatesTableModel) SetData(rows []client.IndexTemplate) {
+	m.allRows = rows
+	m.displayRows = sortTemplateRows(filterTemplateRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m TemplatesTableModel) Update(msg tea.Msg) (TemplatesTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortTemplateRows(filterTemplateRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// templateKey identifies a template across kinds, since a composable and a
+// legacy template may share a name.
+func templateKey(t client.IndexTemplate) string {
+	return t.Kind + "/" + t.Name
+}
+
+// sortTemplateRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Name, 1=Kind, 2=Patterns, 3=Priority, 4=ComposedOf, 5=Shards,
+//	6=Replicas, 7=LifecyclePolicy
+//
+// col -1 means no sort (preserve order). Ties are broken by name, then kind.
+func sortTemplateRows(rows []client.IndexTemplate, col int, desc bool) []client.IndexTemplate {
+	out := make([]client.IndexTemplate, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var cmp int
+		switch col {
+		case 0:
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case 1:
+			cmp = strings.Compare(a.Kind, b.Kind)
+		case 2:
+			cmp = strings.Compare(strings.Join(a.Patterns, ","), strings.Join(b.Patterns, ","))
+		case 3:
+			cmp = compareInt64(int64(a.Priority), int64(b.Priority))
+		case 4:
+			cmp = strings.Compare(strings.Join(a.ComposedOf, ","), strings.Join(b.ComposedOf, ","))
+		case 5:
+			cmp = compareInt64(settingInt(a.Settings.Shards), settingInt(b.Settings.Shards))
+		case 6:
+			cmp = compareInt64(settingInt(a.Settings.Replicas), settingInt(b.Settings.Replicas))
+		case 7:
+			cmp = strings.Compare(a.Settings.LifecyclePolicy, b.Settings.LifecyclePolicy)
+		}
+		if cmp == 0 {
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Kind < b.Kind
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// settingInt parses a numeric setting for sorting; unset or malformed values
+// sort as -1 (before 0 ascending).
+func settingInt(s string) int64 {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
+// filterTemplateRows returns templates whose name, patterns, component
+// templates or ILM policy contain search (case-insensitive). Returns all rows
+// when search is empty.
+func filterTemplateRows(rows []client.IndexTemplate, search string) []client.IndexTemplate {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Name), lower) ||
+			aliasMatches(r.Patterns, lower) ||
+			aliasMatches(r.ComposedOf, lower) ||
+			strings.Contains(strings.ToLower(r.Settings.LifecyclePolicy), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// templateCellValue formats a template field for a given column index.
+func templateCellValue(t client.IndexTemplate, col int) string {
+	orDash := func(s string) string {
+		if s == "" {
+			return "---"
+		}
+		return sanitize(s)
+	}
+	switch col {
+	case 0:
+		return sanitize(t.Name)
+	case 1:
+		if t.DataStream {
+			return t.Kind + " ds"
+		}
+		return t.Kind
+	case 2:
+		return orDash(strings.Join(t.Patterns, ","))
+	case 3:
+		if t.Kind == client.TemplateComponent {
+			return "---"
+		}
+		return strconv.Itoa(t.Priority)
+	case 4:
+		return orDash(strings.Join(t.ComposedOf, ","))
+	case 5:
+		return orDash(t.Settings.Shards)
+	case 6:
+		return orDash(t.Settings.Replicas)
+	case 7:
+		return orDash(t.Settings.LifecyclePolicy)
+	default:
+		return ""
+	}
+}
+
+// templatesLoadCmd fetches all templates and returns a TemplatesLoadedMsg.
+// nonce is embedded in the message so the App can discard stale responses.
+func templatesLoadCmd(c client.ESClient, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		templates, err := c.GetTemplates(ctx)
+		return TemplatesLoadedMsg{Templates: templates, Err: err, Nonce: nonce}
+	}
+}
+
+// simulateIndexLoadCmd resolves the template settings for index and returns
+// a SimulateIndexLoadedMsg.
+func simulateIndexLoadCmd(c client.ESClient, index string, nonce int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		sim, err := c.SimulateIndex(ctx, index)
+		return SimulateIndexLoadedMsg{Index: index, Result: sim, Err: err, Nonce: nonce}
+	}
+}
+
+// openTemplates switches to the template screen and starts loading it. When
+// the index table is focused, the index under the cursor is resolved against
+// the templates as well.
+func (app *App) openTemplates() tea.Cmd {
+	app.templatesNonce++
+	app.templatesMode = true
+	app.templatesLoading = true
+	app.templatesErr = ""
+	app.templatesIndex = ""
+	if app.activeTable == 0 {
+		app.templatesIndex = app.indexTable.cursorRowName()
+	}
+	app.templatesSim = nil
+	app.templatesSimErr = ""
+	app.templatesTable = NewTemplatesTable()
+	app.fitTemplatesTable()
+	return app.reloadTemplates()
+}
+
+// refreshTemplates reloads the template screen unless a load is already in
+// flight.
+func (app *App) refreshTemplates() tea.Cmd {
+	if app.templatesLoading {
+		return nil
+	}
+	app.templatesNonce++
+	app.templatesLoading = true
+	return app.reloadTemplates()
+}
+
+// reloadTemplates issues the template listing and, when the screen is scoped
+// to an index, the simulate request for it.
+func (app *App) reloadTemplates() tea.Cmd {
+	cmds := []tea.Cmd{templatesLoadCmd(app.client, app.templatesNonce)}
+	if app.templatesIndex != "" {
+		app.templatesSimLoading = true
+		cmds = append(cmds, simulateIndexLoadCmd(app.client, app.templatesIndex, app.templatesNonce))
+	}
+	return tea.Batch(cmds...)
+}
+
+// applyTemplates stores freshly loaded templates and marks those matching
+// the scoped index.
+func (app *App) applyTemplates(templates []client.IndexTemplate) {
+	app.templatesTable.applied = nil
+	if app.templatesIndex != "" {
+		app.templatesTable.applied = make(map[string]bool)
+		for _, m := range engine.MatchTemplates(app.templatesIndex, templates) {
+			app.templatesTable.applied[templateKey(m.Template)] = m.Applied
+		}
+	}
+	app.templatesTable.SetData(templates)
+	app.fitTemplatesTable()
+}
+
+// buildTemplateMatchLines returns the panel shown above the template table
+// when the screen is scoped to an index: the applied template, other matches
+// that lose on priority, and the settings resolved by _simulate_index.
+func buildTemplateMatchLines(app *App, width int) []string {
+	if app.templatesIndex == "" || app.templatesLoading || app.templatesErr != "" {
+		return nil
+	}
+	idx := sanitize(app.templatesIndex)
+	matches := engine.MatchTemplates(app.templatesIndex, app.templatesTable.allRows)
+	var applied, others []string
+	for _, m := range matches {
+		label := fmt.Sprintf("%s (%s, %s %d)", sanitize(m.Template.Name), m.Template.Kind, priorityLabel(m.Template), m.Template.Priority)
+		if m.Applied {
+			applied = append(applied, label)
+		} else {
+			others = append(others, label)
+		}
+	}
+	if app.templatesSim != nil {
+		seen := make(map[string]bool, len(matches))
+		for _, m := range matches {
+			seen[m.Template.Name] = true
+		}
+		for _, o := range app.templatesSim.Overlapping {
+			if !seen[o.Name] {
+				others = append(others, sanitize(o.Name))
+			}
+		}
+	}
+
+	lines := []string{""}
+	if len(applied) == 0 {
+		lines = append(lines, "  "+StyleYellow.Render(truncateName(fmt.Sprintf("Index %s: no template matches — created with cluster defaults", idx), width-2)))
+	} else {
+		lines = append(lines, "  "+StyleGreen.Render(truncateName(fmt.Sprintf("Index %s: applied %s", idx, strings.Join(applied, ", ")), width-2)))
+	}
+	if len(others) > 0 {
+		lines = append(lines, "  "+StyleYellow.Render(truncateName("Also matching, not applied: "+strings.Join(others, ", "), width-2)))
+	}
+	switch {
+	case app.templatesSimLoading:
+		lines = append(lines, "  "+StyleDim.Render("Resolving settings via _simulate_index..."))
+	case app.templatesSimErr != "":
+		lines = append(lines, "  "+StyleDim.Render(truncateName("Simulate failed: "+app.templatesSimErr, width-2)))
+	case app.templatesSim != nil:
+		s := app.templatesSim.Settings
+		orDefault := func(v string) string {
+			if v == "" {
+				return "default"
+			}
+			return sanitize(v)
+		}
+		policy := "none"
+		if s.LifecyclePolicy != "" {
+			policy = sanitize(s.LifecyclePolicy)
+		}
+		lines = append(lines, "  "+truncateName(fmt.Sprintf("Resolved for a new index: shards %s  replicas %s  ILM policy %s",
+			orDefault(s.Shards), orDefault(s.Replicas), policy), width-2))
+	}
+	return lines
+}
+
+// priorityLabel names the precedence field of a template kind.
+func priorityLabel(t client.IndexTemplate) string {
+	if t.Kind == client.TemplateLegacy {
+		return "order"
+	}
+	return "priority"
+}
+
+// renderTemplatesTitle renders the title bar for the template screen.
+func renderTemplatesTitle(width int) string {
+	return renderTitleBar("Index Templates", "[T/esc: back  r: reload]", width)
+}
+
+// fitTemplatesTable sizes the template table page to the screen height left
+// below the match panel.
+func (app *App) fitTemplatesTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderTemplatesTitle(width)) - len(buildTemplateMatchLines(app, width))
+	app.templatesTable.fitHeight(availH, len(app.templatesTable.displayRows))
+}
+
+// renderTemplates renders the template screen: title bar, the match panel
+// for the scoped index, and the current page of templates. Templates that
+// match the scoped index are green when applied and yellow otherwise.
+func renderTemplates(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderTemplatesTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.templatesTable
+	var body string
+	switch {
+	case app.templatesLoading:
+		body = "\n  " + StyleDim.Render("Loading templates...")
+	case app.templatesErr != "":
+		body = "\n  " + StyleError.Render("Failed to load templates: "+app.templatesErr)
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleDim.Render("No templates defined")
+	default:
+		var panel string
+		if lines := buildTemplateMatchLines(app, width); len(lines) > 0 {
+			panel = strings.Join(lines, "\n") + "\n"
+		}
+		counts := make(map[string]int)
+		for _, t := range m.allRows {
+			counts[t.Kind]++
+		}
+		title := fmt.Sprintf("%d composable, %d component, %d legacy",
+			counts[client.TemplateComposable], counts[client.TemplateComponent], counts[client.TemplateLegacy])
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching templates)",
+			func(i int) []string {
+				t := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = templateCellValue(t, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				if applied, ok := m.applied[templateKey(m.displayRows[i])]; ok && col == 0 {
+					if applied {
+						return colorGreen
+					}
+					return colorYellow
+				}
+				switch col {
+				case 1:
+					return colorBlue
+				case 5, 6:
+					return colorCyan
+				default:
+					return colorWhite
+				}
+			})
+		body = panel + m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			t := m.displayRows[idx]
+			detail := sanitize(t.Name)
+			if len(t.Patterns) > 0 {
+				detail += "  patterns: " + sanitize(strings.Join(t.Patterns, ", "))
+			}
+			if len(t.ComposedOf) > 0 {
+				detail += "  composed of: " + sanitize(strings.Join(t.ComposedOf, ", "))
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(detail, width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func sampleTemplates() []client.IndexTemplate {
+	return []client.IndexTemplate{
+		{Name: "logs", Kind: client.TemplateComposable, Patterns: []string{"logs-*"}, Priority: 200,
+			ComposedOf: []string{"logs-settings"}, Settings: client.TemplateSettings{Shards: "2", LifecyclePolicy: "logs"}},
+		{Name: "logs-old", Kind: client.TemplateComposable, Patterns: []string{"logs-*"}, Priority: 100},
+		{Name: "logs-settings", Kind: client.TemplateComponent, Settings: client.TemplateSettings{Replicas: "1"}},
+		{Name: "catchall", Kind: client.TemplateLegacy, Patterns: []string{"*"}},
+	}
+}
+
+func TestSortTemplateRows(t *testing.T) {
+	out := sortTemplateRows(sampleTemplates(), 3, true)
+	assert.Equal(t, "logs", out[0].Name)
+	out = sortTemplateRows(sampleTemplates(), 5, false)
+	assert.Equal(t, "logs", out[3].Name, "unset shard counts sort before explicit ones")
+}
+
+func TestFilterTemplateRows(t *testing.T) {
+	assert.Len(t, filterTemplateRows(sampleTemplates(), "logs-settings"), 2, "matches name and composed_of")
+	assert.Len(t, filterTemplateRows(sampleTemplates(), "*"), 3, "matches patterns; component templates have none")
+	assert.Len(t, filterTemplateRows(sampleTemplates(), ""), 4)
+}
+
+func TestTemplateCellValue(t *testing.T) {
+	rows := sampleTemplates()
+	assert.Equal(t, "200", templateCellValue(rows[0], 3))
+	assert.Equal(t, "logs-settings", templateCellValue(rows[0], 4))
+	assert.Equal(t, "---", templateCellValue(rows[0], 6))
+	assert.Equal(t, "logs", templateCellValue(rows[0], 7))
+	assert.Equal(t, "---", templateCellValue(rows[2], 3), "component templates have no priority")
+	assert.Equal(t, "1", templateCellValue(rows[2], 6))
+}
+
+func TestApp_TemplatesKey_ResolvesFocusedIndex(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	app.indexTable.SetData([]model.IndexRow{{Name: "logs-2024.01.01"}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	require.NotNil(t, cmd)
+	require.True(t, app.templatesMode)
+	assert.Equal(t, "logs-2024.01.01", app.templatesIndex)
+	assert.True(t, app.templatesSimLoading)
+
+	app.Update(TemplatesLoadedMsg{Templates: sampleTemplates(), Nonce: app.templatesNonce})
+	app.Update(SimulateIndexLoadedMsg{Index: "logs-2024.01.01", Nonce: app.templatesNonce,
+		Result: &client.SimulatedIndex{Settings: client.TemplateSettings{Shards: "2", Replicas: "1", LifecyclePolicy: "logs"}}})
+
+	assert.Equal(t, map[string]bool{"composable/logs": true, "composable/logs-old": false, "legacy/catchall": false}, app.templatesTable.applied)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "2 composable, 1 component, 1 legacy")
+	assert.Contains(t, out, "Index logs-2024.01.01: applied logs (composable, priority 200)")
+	assert.Contains(t, out, "Also matching, not applied: logs-old (composable, priority 100), catchall (legacy, order 0)")
+	assert.Contains(t, out, "shards 2  replicas 1  ILM policy logs")
+}
+
+func TestApp_Templates_ReloadSkipsWhileLoading(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	nonce := app.templatesNonce
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.Nil(t, cmd, "a load is already in flight")
+	assert.Equal(t, nonce, app.templatesNonce)
+
+	app.Update(TemplatesLoadedMsg{Templates: sampleTemplates(), Nonce: nonce})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.NotNil(t, cmd)
+	assert.True(t, app.templatesLoading)
+	assert.Equal(t, nonce+1, app.templatesNonce, "the response of the earlier load is now stale")
+}
+
+func TestApp_Templates_NodeFocusSkipsSimulate(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.activeTable = 1
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	assert.Empty(t, app.templatesIndex)
+	assert.False(t, app.templatesSimLoading)
+
+	app.Update(TemplatesLoadedMsg{Templates: sampleTemplates(), Nonce: app.templatesNonce})
+	assert.Nil(t, app.templatesTable.applied)
+	assert.NotContains(t, stripANSI(renderTemplates(app)), "applied")
+}
+
+func TestApp_Templates_StaleAndError(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.indexTable.SetData([]model.IndexRow{{Name: "logs-1"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+
+	app.Update(TemplatesLoadedMsg{Templates: sampleTemplates(), Nonce: app.templatesNonce - 1})
+	assert.Nil(t, app.templatesTable.allRows, "stale responses are dropped")
+
+	app.Update(SimulateIndexLoadedMsg{Index: "logs-1", Err: errors.New("forbidden"), Nonce: app.templatesNonce})
+	app.Update(TemplatesLoadedMsg{Templates: sampleTemplates(), Nonce: app.templatesNonce})
+	assert.Contains(t, stripANSI(renderTemplates(app)), "Simulate failed: forbidden")
+
+	app.Update(TemplatesLoadedMsg{Err: errors.New("timeout"), Nonce: app.templatesNonce})
+	assert.Contains(t, stripANSI(renderTemplates(app)), "Failed to load templates: timeout")
+}
@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
 
 // severity represents the alert level for a metric value.
 type severity int
@@ -37,16 +41,38 @@ func jvmSeverity(pct float64) severity {
 
 // storageSeverity returns Warning when storage > 80%, Critical when > 90%.
 func storageSeverity(pct float64) severity {
+	return storageSeverityAt(pct, 80, 90)
+}
+
+// storageSeverityAt returns Warning when storage > warn, Critical when
+// > crit. The overview passes the cluster's low and high disk watermarks.
+func storageSeverityAt(pct, warn, crit float64) severity {
 	switch {
-	case pct > 90:
+	case pct > crit:
 		return severityCritical
-	case pct > 80:
+	case pct > warn:
 		return severityWarning
 	default:
 		return severityNormal
 	}
 }
 
+// watermarkStyle colors a node's Disk% cell by the highest disk watermark
+// it exceeds: the usual column color below low, orange past low, red past
+// high and bold red past flood-stage.
+func watermarkStyle(base lipgloss.Style, level model.WatermarkLevel) lipgloss.Style {
+	switch level {
+	case model.WatermarkLow:
+		return base.Foreground(colorOrange)
+	case model.WatermarkHigh:
+		return base.Foreground(colorRed)
+	case model.WatermarkFloodStage:
+		return base.Foreground(colorRed).Bold(true)
+	default:
+		return base.Foreground(colorDiskYellow)
+	}
+}
+
 // searchLatSeverity returns Critical when search latency > 1000ms.
 func searchLatSeverity(ms float64) severity {
 	if ms > 1000 {
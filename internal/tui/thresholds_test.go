@@ -103,3 +103,21 @@ func TestThreshold_Latency(t *testing.T) {
 		}
 	}
 }
+
+func TestThreshold_StorageAtWatermarks(t *testing.T) {
+	cases := []struct {
+		pct  float64
+		want severity
+	}{
+		{85, severityNormal}, // boundary: at the low watermark is not past it
+		{85.5, severityWarning},
+		{90, severityWarning},
+		{90.5, severityCritical},
+	}
+	for _, tc := range cases {
+		got := storageSeverityAt(tc.pct, 85, 90)
+		if got != tc.want {
+			t.Errorf("storageSeverityAt(%v, 85, 90) = %v, want %v", tc.pct, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// TierTableModel is a sortable, paginated, searchable table of per-tier data
+// node aggregates, regrouped on every poll.
+type TierTableModel struct {
+	tableModel
+	allRows     []model.TierRow // unfiltered source data
+	displayRows []model.TierRow // after filter + sort applied
+}
+
+// NewTierTable returns a TierTableModel with an 8-column layout and default
+// sort by tier, hottest first (col 0) ascending.
+func NewTierTable() TierTableModel {
+	cols := []columnDef{
+		{Title: "Tier", Width: 10, SortDesc: false},
+		{Title: "Nodes", Width: 6, SortDesc: true},
+		{Title: "Capacity", Width: 10, SortDesc: true},
+		{Title: "Used%", Width: 7, SortDesc: true},
+		{Title: "Heap%", Width: 7, SortDesc: true},
+		{Title: "Shards", Width: 7, SortDesc: true},
+		{Title: "Idx/s", Width: 9, SortDesc: true},
+		{Title: "Srch/s", Width: 9, SortDesc: true},
+	}
+	m := TierTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 0
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *TierTableModel) SetData(rows []model.TierRow) {
+	m.allRows = rows
+	m.displayRows = sortTierRows(filterTierRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m TierTableModel) Update(msg tea.Msg) (TierTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortTierRows(filterTierRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortTierRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Tier (hot→cold order), 1=Nodes, 2=DiskTotalBytes, 3=UsedPercent,
+//	4=AvgHeapPercent, 5=Shards, 6=IndexingRate, 7=SearchRate
+//
+// col -1 means no sort (preserve order). Unknown values sort last; ties are
+// broken by tier order.
+func sortTierRows(rows []model.TierRow, col int, desc bool) []model.TierRow {
+	out := make([]model.TierRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var av, bv float64
+		switch col {
+		case 0:
+			av, bv = float64(engine.TierRank(a.Tier)), float64(engine.TierRank(b.Tier))
+		case 1:
+			av, bv = float64(a.Nodes), float64(b.Nodes)
+		case 2:
+			av, bv = float64(a.DiskTotalBytes), float64(b.DiskTotalBytes)
+			if a.DiskTotalBytes == 0 {
+				av = -1
+			}
+			if b.DiskTotalBytes == 0 {
+				bv = -1
+			}
+		case 3:
+			av, bv = a.UsedPercent, b.UsedPercent
+		case 4:
+			av, bv = a.AvgHeapPercent, b.AvgHeapPercent
+		case 5:
+			av, bv = float64(a.Shards), float64(b.Shards)
+		case 6:
+			av, bv = a.IndexingRate, b.IndexingRate
+		case 7:
+			av, bv = a.SearchRate, b.SearchRate
+		}
+		if aSentinel, bSentinel := av < 0, bv < 0; aSentinel != bSentinel {
+			return bSentinel // sentinel always last regardless of direction
+		}
+		cmp := compareFloat64(av, bv)
+		if cmp == 0 {
+			return engine.TierRank(a.Tier) < engine.TierRank(b.Tier)
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterTierRows returns rows whose tier contains search (case-insensitive).
+// Returns all rows when search is empty.
+func filterTierRows(rows []model.TierRow, search string) []model.TierRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(r.Tier, lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// tierCellValue formats a TierRow field for a given column index.
+func tierCellValue(r model.TierRow, col int) string {
+	switch col {
+	case 0:
+		return r.Tier
+	case 1:
+		return strconv.Itoa(r.Nodes)
+	case 2:
+		if r.DiskTotalBytes <= 0 {
+			return "---"
+		}
+		return format.FormatBytes(r.DiskTotalBytes)
+	case 3:
+		if r.UsedPercent < 0 {
+			return "---"
+		}
+		return format.FormatPercent(r.UsedPercent)
+	case 4:
+		if r.AvgHeapPercent < 0 {
+			return "---"
+		}
+		return format.FormatPercent(r.AvgHeapPercent)
+	case 5:
+		if r.Shards < 0 {
+			return "---"
+		}
+		return strconv.Itoa(r.Shards)
+	case 6:
+		return format.FormatRate(r.IndexingRate)
+	case 7:
+		return format.FormatRate(r.SearchRate)
+	default:
+		return ""
+	}
+}
+
+// tierColor returns the display color of a tier name.
+func tierColor(tier string) lipgloss.TerminalColor {
+	switch tier {
+	case "hot":
+		return colorRed
+	case "warm":
+		return colorOrange
+	case "cold":
+		return colorBlue
+	case "frozen":
+		return colorCyan
+	default:
+		return colorWhite
+	}
+}
+
+// refreshTiers regroups the latest node rows by data tier.
+func (app *App) refreshTiers() {
+	app.tierTable.SetData(engine.CalcTierRows(app.nodeRows))
+	app.fitTierTable()
+}
+
+// renderTiersTitle renders the title bar for the tier summary.
+func renderTiersTitle(width int) string {
+	return renderTitleBar("Data Tiers", "[w/esc: back  r: refresh]", width)
+}
+
+// fitTierTable sizes the tier table page to the screen height.
+func (app *App) fitTierTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderTiersTitle(width))
+	app.tierTable.fitHeight(availH, len(app.tierTable.displayRows))
+}
+
+// renderTiers renders the tier summary: title bar, the current page of
+// tiers, and the nodes in the tier under the cursor.
+func renderTiers(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderTiersTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.tierTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case len(m.allRows) == 0:
+		body = "\n  " + StyleDim.Render("No data nodes reported")
+	default:
+		warnPct, critPct := engine.StorageThresholds(app.current)
+		title := fmt.Sprintf("%d tier(s)", len(m.allRows))
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching tiers)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = tierCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch col {
+				case 0:
+					return tierColor(r.Tier)
+				case 3:
+					return severityFg(storageSeverityAt(r.UsedPercent, warnPct, critPct))
+				case 4:
+					return severityFg(jvmSeverity(r.AvgHeapPercent))
+				case 6:
+					return colorGreen
+				case 7:
+					return colorCyan
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			tier := m.displayRows[idx].Tier
+			var names []string
+			for _, n := range app.nodeRows {
+				if engine.NodeTier(n) == tier {
+					names = append(names, sanitize(n.Name))
+				}
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(tier+": "+strings.Join(names, ", "), width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
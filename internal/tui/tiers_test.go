@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestSortTierRows(t *testing.T) {
+	rows := []model.TierRow{
+		{Tier: "cold", UsedPercent: 40, DiskTotalBytes: 100},
+		{Tier: "hot", UsedPercent: -1},
+		{Tier: "warm", UsedPercent: 70, DiskTotalBytes: 50},
+	}
+	out := sortTierRows(rows, 0, false)
+	assert.Equal(t, []string{"hot", "warm", "cold"}, []string{out[0].Tier, out[1].Tier, out[2].Tier})
+	out = sortTierRows(rows, 3, true)
+	assert.Equal(t, []string{"warm", "cold", "hot"}, []string{out[0].Tier, out[1].Tier, out[2].Tier}, "unknown usage sorts last")
+	out = sortTierRows(rows, 2, false)
+	assert.Equal(t, "hot", out[2].Tier, "unknown capacity sorts last")
+	assert.Equal(t, "---", tierCellValue(model.TierRow{}, 2))
+	assert.Equal(t, "---", tierCellValue(model.TierRow{UsedPercent: -1}, 3))
+}
+
+func TestApp_Tiers_SkipsNodesWithoutTier(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	nodes := []model.NodeRow{
+		{Name: "h1", Roles: []string{"data_hot"}, Shards: 3, DiskTotalBytes: 1000, DiskAvailBytes: 400},
+		{Name: "c1", Roles: []string{"data_cold"}, Shards: 4, DiskTotalBytes: 1000, DiskAvailBytes: 900},
+		{Name: "m1", Roles: []string{"master"}, Shards: -1},
+	}
+	app.Update(SnapshotMsg{Snapshot: &model.Snapshot{}, NodeRows: nodes})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	require.True(t, app.tiersMode)
+	require.Len(t, app.tierTable.allRows, 2)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "2 tier(s)")
+	assert.Contains(t, out, "60.0%")
+	assert.Contains(t, out, "hot: h1")
+	assert.NotContains(t, out, "m1", "master-only nodes belong to no tier")
+}
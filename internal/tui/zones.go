@@ -0,0 +1,286 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jtsunne/epm-go/internal/engine"
+	"github.com/jtsunne/epm-go/internal/format"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+// ZoneTableModel is a sortable, paginated, searchable table of per-zone node
+// aggregates, regrouped on every poll and whenever the grouping attribute
+// changes.
+type ZoneTableModel struct {
+	tableModel
+	allRows     []model.ZoneRow // unfiltered source data
+	displayRows []model.ZoneRow // after filter + sort applied
+}
+
+// NewZoneTable returns a ZoneTableModel with a 7-column layout and default
+// sort by zone name (col 0) ascending.
+func NewZoneTable() ZoneTableModel {
+	cols := []columnDef{
+		{Title: "Zone", Width: 24, SortDesc: false},
+		{Title: "Nodes", Width: 6, SortDesc: true},
+		{Title: "CPU%", Width: 7, SortDesc: true},
+		{Title: "Heap%", Width: 7, SortDesc: true},
+		{Title: "Shards", Width: 7, SortDesc: true},
+		{Title: "Idx/s", Width: 9, SortDesc: true},
+		{Title: "Srch/s", Width: 9, SortDesc: true},
+	}
+	m := ZoneTableModel{
+		tableModel: newTableModel(cols),
+	}
+	m.sortCol = 0
+	m.focused = true
+	return m
+}
+
+// SetData applies the current search filter and sort to rows, storing the
+// result as displayRows ready for rendering.
+func (m *ZoneTableModel) SetData(rows []model.ZoneRow) {
+	m.allRows = rows
+	m.displayRows = sortZoneRows(filterZoneRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+}
+
+// Update handles keyboard events for sorting, pagination, and search,
+// re-applying filter/sort when the sort column, direction, or search term
+// changes.
+func (m ZoneTableModel) Update(msg tea.Msg) (ZoneTableModel, tea.Cmd) {
+	prevSort := m.sortCol
+	prevDesc := m.sortDesc
+	prevSearch := m.search
+
+	base, cmd := m.tableModel.Update(msg)
+	m.tableModel = base
+
+	if m.sortCol != prevSort || m.sortDesc != prevDesc || m.search != prevSearch {
+		m.displayRows = sortZoneRows(filterZoneRows(m.allRows, m.search), m.sortCol, m.sortDesc)
+	}
+	m.clampPage(len(m.displayRows))
+	m.clampCursor(m.currentPageRowCount(len(m.displayRows)))
+	return m, cmd
+}
+
+// sortZoneRows returns a sorted copy of rows.
+// Column mapping:
+//
+//	0=Zone, 1=Nodes, 2=AvgCPUPercent, 3=AvgHeapPercent, 4=Shards,
+//	5=IndexingRate, 6=SearchRate
+//
+// col -1 means no sort (preserve order). Unknown values sort last; ties are
+// broken by zone.
+func sortZoneRows(rows []model.ZoneRow, col int, desc bool) []model.ZoneRow {
+	out := make([]model.ZoneRow, len(rows))
+	copy(out, rows)
+	if col < 0 {
+		return out
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		var av, bv float64
+		switch col {
+		case 0:
+			cmp := strings.Compare(a.Zone, b.Zone)
+			if desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		case 1:
+			av, bv = float64(a.Nodes), float64(b.Nodes)
+		case 2:
+			av, bv = a.AvgCPUPercent, b.AvgCPUPercent
+		case 3:
+			av, bv = a.AvgHeapPercent, b.AvgHeapPercent
+		case 4:
+			av, bv = float64(a.Shards), float64(b.Shards)
+		case 5:
+			av, bv = a.IndexingRate, b.IndexingRate
+		case 6:
+			av, bv = a.SearchRate, b.SearchRate
+		}
+		if aSentinel, bSentinel := av < 0, bv < 0; aSentinel != bSentinel {
+			return bSentinel // sentinel always last regardless of direction
+		}
+		cmp := compareFloat64(av, bv)
+		if cmp == 0 {
+			return a.Zone < b.Zone
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return out
+}
+
+// filterZoneRows returns rows whose zone contains search (case-insensitive).
+// Returns all rows when search is empty.
+func filterZoneRows(rows []model.ZoneRow, search string) []model.ZoneRow {
+	if search == "" {
+		return rows
+	}
+	lower := strings.ToLower(search)
+	out := rows[:0:0]
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Zone), lower) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// zoneCellValue formats a ZoneRow field for a given column index.
+func zoneCellValue(r model.ZoneRow, col int) string {
+	switch col {
+	case 0:
+		if r.Zone == "" {
+			return "(none)"
+		}
+		return sanitize(r.Zone)
+	case 1:
+		return strconv.Itoa(r.Nodes)
+	case 2:
+		if r.AvgCPUPercent < 0 {
+			return "---"
+		}
+		return format.FormatPercent(r.AvgCPUPercent)
+	case 3:
+		if r.AvgHeapPercent < 0 {
+			return "---"
+		}
+		return format.FormatPercent(r.AvgHeapPercent)
+	case 4:
+		if r.Shards < 0 {
+			return "---"
+		}
+		return strconv.Itoa(r.Shards)
+	case 5:
+		return format.FormatRate(r.IndexingRate)
+	case 6:
+		return format.FormatRate(r.SearchRate)
+	default:
+		return ""
+	}
+}
+
+// refreshZones resolves the grouping attribute and regroups the latest node
+// rows by it.
+func (app *App) refreshZones() {
+	app.zoneAttr = engine.ZoneAttribute(app.nodeRows, app.recConfig.ZoneAttribute)
+	app.zoneTable.SetData(engine.CalcZoneRows(app.nodeRows, app.zoneAttr))
+	app.fitZoneTable()
+}
+
+// cycleZoneAttribute switches grouping to the next node attribute. The
+// choice also applies to the zone recommendations from the next poll on.
+func (app *App) cycleZoneAttribute() {
+	candidates := engine.ZoneAttributeCandidates(app.nodeRows)
+	if len(candidates) == 0 {
+		return
+	}
+	next := candidates[0]
+	for i, c := range candidates {
+		if c == app.zoneAttr {
+			next = candidates[(i+1)%len(candidates)]
+			break
+		}
+	}
+	app.recConfig.ZoneAttribute = next
+	app.refreshZones()
+}
+
+// renderZonesTitle renders the title bar for the zone summary.
+func renderZonesTitle(width int) string {
+	return renderTitleBar("Zones", "[z/esc: back  g: grouping attribute  r: refresh]", width)
+}
+
+// fitZoneTable sizes the zone table page to the screen height.
+func (app *App) fitZoneTable() {
+	width, _ := screenSize(app)
+	availH := screenAvailHeight(app, renderZonesTitle(width))
+	app.zoneTable.fitHeight(availH, len(app.zoneTable.displayRows))
+}
+
+// renderZones renders the zone summary: title bar, the current page of
+// zones, and the nodes in the zone under the cursor.
+func renderZones(app *App) string {
+	width, _ := screenSize(app)
+	titleBar := renderZonesTitle(width)
+	availH := screenAvailHeight(app, titleBar)
+
+	m := &app.zoneTable
+	var body string
+	switch {
+	case app.current == nil:
+		body = "\n  " + StyleDim.Render("Waiting for first poll...")
+	case app.current.NodeAttributes == nil:
+		body = "\n  " + StyleDim.Render("Node attributes unavailable (_cat/nodeattrs failed)")
+	case app.zoneAttr == "":
+		body = "\n  " + StyleDim.Render("No node attributes to group by. Set node.attr.zone (or rack) on the nodes, or pass --zone-attr.")
+	default:
+		title := fmt.Sprintf("%d zone(s) by node.attr.%s", len(m.allRows), sanitize(app.zoneAttr))
+		if n := len(engine.ZoneAttributeCandidates(app.nodeRows)); n > 1 {
+			title += fmt.Sprintf(" (%d attributes, g to switch)", n)
+		}
+		tbl := m.renderPage(width, len(m.displayRows), "(no matching zones)",
+			func(i int) []string {
+				r := m.displayRows[i]
+				cells := make([]string, len(m.columns))
+				for col := range m.columns {
+					cells[col] = zoneCellValue(r, col)
+				}
+				return cells
+			},
+			func(i, col int) lipgloss.TerminalColor {
+				r := m.displayRows[i]
+				switch col {
+				case 0:
+					if r.Zone == "" {
+						return colorGray
+					}
+					return colorWhite
+				case 2:
+					return severityFg(cpuSeverity(r.AvgCPUPercent))
+				case 3:
+					return severityFg(jvmSeverity(r.AvgHeapPercent))
+				case 5:
+					return colorGreen
+				case 6:
+					return colorCyan
+				default:
+					return colorWhite
+				}
+			})
+		body = m.renderTitle(title, len(m.displayRows), "") + "\n" + tbl
+		if idx := m.cursorIndex(len(m.displayRows)); idx >= 0 {
+			zone := m.displayRows[idx].Zone
+			var names []string
+			for _, n := range app.nodeRows {
+				if n.Attributes[app.zoneAttr] == zone {
+					names = append(names, sanitize(n.Name))
+				}
+			}
+			body += "\n" + StyleDim.Render("  "+truncateName(zoneCellValue(m.displayRows[idx], 0)+": "+strings.Join(names, ", "), width-2))
+		}
+	}
+
+	lines := strings.Split(body, "\n")
+	for len(lines) < availH {
+		lines = append(lines, "")
+	}
+	if len(lines) > availH {
+		lines = lines[:availH]
+	}
+	return titleBar + "\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jtsunne/epm-go/internal/client"
+	"github.com/jtsunne/epm-go/internal/model"
+)
+
+func TestSortZoneRows(t *testing.T) {
+	rows := []model.ZoneRow{
+		{Zone: "a", AvgCPUPercent: -1, Shards: 5},
+		{Zone: "b", AvgCPUPercent: 30, Shards: 9},
+		{Zone: "c", AvgCPUPercent: 10, Shards: 1},
+	}
+	out := sortZoneRows(rows, 2, true)
+	assert.Equal(t, []string{"b", "c", "a"}, []string{out[0].Zone, out[1].Zone, out[2].Zone}, "unknown CPU sorts last")
+	out = sortZoneRows(rows, 4, false)
+	assert.Equal(t, "c", out[0].Zone)
+	assert.Equal(t, "(none)", zoneCellValue(model.ZoneRow{}, 0))
+	assert.Equal(t, "---", zoneCellValue(model.ZoneRow{Shards: -1}, 4))
+}
+
+func TestApp_Zones_GroupAndCycle(t *testing.T) {
+	app := NewApp(&tuiMockClient{}, 10*time.Second)
+	app.width = 160
+	app.height = 40
+	nodes := []model.NodeRow{
+		{Name: "n1", Shards: 3, CPUPercent: 10, Attributes: map[string]string{"zone": "z1", "rack": "r1"}},
+		{Name: "n2", Shards: 4, CPUPercent: 30, Attributes: map[string]string{"zone": "z2", "rack": "r1"}},
+	}
+	app.Update(SnapshotMsg{Snapshot: &model.Snapshot{NodeAttributes: []client.NodeAttribute{}}, NodeRows: nodes})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	require.True(t, app.zonesMode)
+	assert.Equal(t, "zone", app.zoneAttr)
+	assert.Len(t, app.zoneTable.allRows, 2)
+	out := stripANSI(app.View())
+	assert.Contains(t, out, "2 zone(s) by node.attr.zone (2 attributes, g to switch)")
+	assert.Contains(t, out, "z1: n1")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	assert.Equal(t, "rack", app.zoneAttr)
+	assert.Equal(t, "rack", app.recConfig.ZoneAttribute, "recommendations follow the selected attribute")
+	require.Len(t, app.zoneTable.allRows, 1)
+	assert.Equal(t, 7, app.zoneTable.allRows[0].Shards)
+
+	// g is search text while the search input is open.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	assert.Equal(t, "rack", app.zoneAttr)
+	assert.True(t, app.zonesMode)
+}